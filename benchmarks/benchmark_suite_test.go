@@ -5,6 +5,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"os"
 	"testing"
 	"time"
 
@@ -17,7 +18,7 @@ import (
 )
 
 func BenchmarkFullWorkflow(b *testing.B) {
-	cfg := setupConfig()
+	cfg := setupConfig(b)
 	db := setupMySQLConnection(b, cfg)
 	defer db.Close()
 	defer cleanupMySQLTestData(b, db)
@@ -36,6 +37,8 @@ func BenchmarkFullWorkflow(b *testing.B) {
 		fileID := uuid.New().String()
 		workflows[i] = workflowData{
 			todo: entities.NewTodoItem(
+				benchmarkTenantID,
+				benchmarkOwnerID,
 				fmt.Sprintf("Full workflow benchmark todo %d", i),
 				time.Now().Add(24*time.Hour),
 				&fileID,
@@ -63,7 +66,7 @@ func BenchmarkFullWorkflow(b *testing.B) {
 			b.Fatalf("Failed to upload file: %v", err)
 		}
 
-		err = publisher.PublishTodoCreated(ctx, workflow.todo)
+		err = publisher.Publish(ctx, todoCreatedEvent(b, workflow.todo))
 		if err != nil {
 			b.Fatalf("Failed to publish message: %v", err)
 		}
@@ -71,7 +74,7 @@ func BenchmarkFullWorkflow(b *testing.B) {
 }
 
 func BenchmarkOperationComparison(b *testing.B) {
-	cfg := setupConfig()
+	cfg := setupConfig(b)
 	db := setupMySQLConnection(b, cfg)
 	defer db.Close()
 	defer cleanupMySQLTestData(b, db)
@@ -91,6 +94,8 @@ func BenchmarkOperationComparison(b *testing.B) {
 		todos := make([]*entities.TodoItem, b.N)
 		for i := 0; i < b.N; i++ {
 			todos[i] = entities.NewTodoItem(
+				benchmarkTenantID,
+				benchmarkOwnerID,
 				fmt.Sprintf("Comparison MySQL todo %d", i),
 				time.Now().Add(24*time.Hour),
 				nil,
@@ -127,6 +132,8 @@ func BenchmarkOperationComparison(b *testing.B) {
 		todos := make([]*entities.TodoItem, b.N)
 		for i := 0; i < b.N; i++ {
 			todos[i] = entities.NewTodoItem(
+				benchmarkTenantID,
+				benchmarkOwnerID,
 				fmt.Sprintf("Comparison Redis todo %d", i),
 				time.Now().Add(24*time.Hour),
 				nil,
@@ -135,7 +142,7 @@ func BenchmarkOperationComparison(b *testing.B) {
 
 		b.ResetTimer()
 		for i := 0; i < b.N; i++ {
-			err := publisher.PublishTodoCreated(ctx, todos[i])
+			err := publisher.Publish(ctx, todoCreatedEvent(b, todos[i]))
 			if err != nil {
 				b.Fatalf("Failed to publish message: %v", err)
 			}
@@ -144,7 +151,7 @@ func BenchmarkOperationComparison(b *testing.B) {
 }
 
 func BenchmarkErrorRecovery(b *testing.B) {
-	cfg := setupConfig()
+	cfg := setupConfig(b)
 	db := setupMySQLConnection(b, cfg)
 	defer db.Close()
 	defer cleanupMySQLTestData(b, db)
@@ -202,7 +209,7 @@ func BenchmarkErrorRecovery(b *testing.B) {
 }
 
 func BenchmarkCleanupAll(b *testing.B) {
-	cfg := setupConfig()
+	cfg := setupConfig(b)
 	db := setupMySQLConnection(b, cfg)
 	defer db.Close()
 
@@ -214,6 +221,8 @@ func BenchmarkCleanupAll(b *testing.B) {
 
 	for i := 0; i < 10; i++ {
 		todo := entities.NewTodoItem(
+			benchmarkTenantID,
+			benchmarkOwnerID,
 			fmt.Sprintf("Cleanup test todo %d", i),
 			time.Now().Add(24*time.Hour),
 			nil,
@@ -232,11 +241,13 @@ func BenchmarkCleanupAll(b *testing.B) {
 
 	for i := 0; i < 5; i++ {
 		todo := entities.NewTodoItem(
+			benchmarkTenantID,
+			benchmarkOwnerID,
 			fmt.Sprintf("Cleanup Redis test todo %d", i),
 			time.Now().Add(24*time.Hour),
 			nil,
 		)
-		_ = publisher.PublishTodoCreated(ctx, todo)
+		_ = publisher.Publish(ctx, todoCreatedEvent(b, todo))
 	}
 
 	b.ResetTimer()
@@ -254,8 +265,19 @@ type workflowData struct {
 	storagePath string
 }
 
-func setupConfig() *config.Config {
-	return config.Load()
+const (
+	benchmarkTenantID = "benchmark"
+	benchmarkOwnerID  = "benchmark-user"
+)
+
+func setupConfig(tb testing.TB) *config.Config {
+	tb.Helper()
+
+	cfg, err := config.Load(os.Getenv("CONFIG_FILE"))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return cfg
 }
 
 func setupTransactionManager(db *sql.DB) *repositories.MySQLTransactionManager {
@@ -17,7 +17,7 @@ import (
 )
 
 func BenchmarkMySQLTodoInsert(b *testing.B) {
-	cfg := config.Load()
+	cfg := setupConfig(b)
 	db := setupMySQLConnection(b, cfg)
 	defer db.Close()
 	defer cleanupMySQLTestData(b, db)
@@ -28,6 +28,8 @@ func BenchmarkMySQLTodoInsert(b *testing.B) {
 	todos := make([]*entities.TodoItem, b.N)
 	for i := 0; i < b.N; i++ {
 		todos[i] = entities.NewTodoItem(
+			benchmarkTenantID,
+			benchmarkOwnerID,
 			fmt.Sprintf("Benchmark todo item %d", i),
 			time.Now().Add(24*time.Hour),
 			nil,
@@ -47,7 +49,7 @@ func BenchmarkMySQLTodoInsert(b *testing.B) {
 }
 
 func BenchmarkMySQLTodoInsertWithFile(b *testing.B) {
-	cfg := config.Load()
+	cfg := setupConfig(b)
 	db := setupMySQLConnection(b, cfg)
 	defer db.Close()
 	defer cleanupMySQLTestData(b, db)
@@ -59,6 +61,8 @@ func BenchmarkMySQLTodoInsertWithFile(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		fileID := uuid.New().String()
 		todos[i] = entities.NewTodoItem(
+			benchmarkTenantID,
+			benchmarkOwnerID,
 			fmt.Sprintf("Benchmark todo with file %d", i),
 			time.Now().Add(24*time.Hour),
 			&fileID,
@@ -10,7 +10,6 @@ import (
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
 
-	"todo-service/internal/config"
 	"todo-service/internal/domain/entities"
 	"todo-service/internal/infrastructure/streams"
 )
@@ -23,6 +22,8 @@ func BenchmarkRedisStreamPublish(b *testing.B) {
 	todos := make([]*entities.TodoItem, b.N)
 	for i := 0; i < b.N; i++ {
 		todos[i] = entities.NewTodoItem(
+			benchmarkTenantID,
+			benchmarkOwnerID,
 			fmt.Sprintf("Benchmark todo for Redis %d", i),
 			time.Now().Add(24*time.Hour),
 			nil,
@@ -32,7 +33,7 @@ func BenchmarkRedisStreamPublish(b *testing.B) {
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		err := publisher.PublishTodoCreated(ctx, todos[i])
+		err := publisher.Publish(ctx, todoCreatedEvent(b, todos[i]))
 		if err != nil {
 			b.Fatalf("Failed to publish message: %v", err)
 		}
@@ -48,6 +49,8 @@ func BenchmarkRedisStreamPublishWithFiles(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		fileID := uuid.New().String()
 		todos[i] = entities.NewTodoItem(
+			benchmarkTenantID,
+			benchmarkOwnerID,
 			fmt.Sprintf("Benchmark todo with file for Redis %d", i),
 			time.Now().Add(24*time.Hour),
 			&fileID,
@@ -57,7 +60,7 @@ func BenchmarkRedisStreamPublishWithFiles(b *testing.B) {
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		err := publisher.PublishTodoCreated(ctx, todos[i])
+		err := publisher.Publish(ctx, todoCreatedEvent(b, todos[i]))
 		if err != nil {
 			b.Fatalf("Failed to publish message with file: %v", err)
 		}
@@ -76,6 +79,8 @@ func BenchmarkRedisStreamPublishConcurrent(b *testing.B) {
 			todos := make([]*entities.TodoItem, b.N)
 			for i := 0; i < b.N; i++ {
 				todos[i] = entities.NewTodoItem(
+					benchmarkTenantID,
+					benchmarkOwnerID,
 					fmt.Sprintf("Concurrent benchmark todo %d", i),
 					time.Now().Add(24*time.Hour),
 					nil,
@@ -94,7 +99,7 @@ func BenchmarkRedisStreamPublishConcurrent(b *testing.B) {
 					semaphore <- struct{}{}        // Acquire
 					defer func() { <-semaphore }() // Release
 
-					err := publisher.PublishTodoCreated(ctx, todos[index])
+					err := publisher.Publish(ctx, todoCreatedEvent(b, todos[index]))
 					if err != nil {
 						b.Errorf("Failed to publish message concurrently: %v", err)
 					}
@@ -125,6 +130,8 @@ func BenchmarkRedisStreamPublishWithTimeout(b *testing.B) {
 			todos := make([]*entities.TodoItem, b.N)
 			for i := 0; i < b.N; i++ {
 				todos[i] = entities.NewTodoItem(
+					benchmarkTenantID,
+					benchmarkOwnerID,
 					fmt.Sprintf("Timeout benchmark todo %d", i),
 					time.Now().Add(24*time.Hour),
 					nil,
@@ -136,7 +143,7 @@ func BenchmarkRedisStreamPublishWithTimeout(b *testing.B) {
 			for i := 0; i < b.N; i++ {
 				ctx, cancel := context.WithTimeout(context.Background(), timeout.timeout)
 
-				err := publisher.PublishTodoCreated(ctx, todos[i])
+				err := publisher.Publish(ctx, todoCreatedEvent(b, todos[i]))
 				cancel()
 
 				if err != nil {
@@ -148,7 +155,7 @@ func BenchmarkRedisStreamPublishWithTimeout(b *testing.B) {
 }
 
 func setupRedisStreamPublisher(b *testing.B) *streams.RedisStreamPublisher {
-	cfg := config.Load()
+	cfg := setupConfig(b)
 
 	client := redis.NewClient(&redis.Options{
 		Addr:     fmt.Sprintf("%s:%s", cfg.Redis.Host, cfg.Redis.Port),
@@ -170,8 +177,16 @@ func setupRedisStreamPublisher(b *testing.B) *streams.RedisStreamPublisher {
 	return streams.NewRedisStreamPublisher(client, streamName)
 }
 
+func todoCreatedEvent(b *testing.B, todo *entities.TodoItem) *entities.Event {
+	event, err := entities.NewTodoEvent(entities.EventTypeTodoCreated, todo)
+	if err != nil {
+		b.Fatalf("Failed to build todo event: %v", err)
+	}
+	return event
+}
+
 func cleanupRedisTestData(b *testing.B, publisher *streams.RedisStreamPublisher) {
-	cfg := config.Load()
+	cfg := setupConfig(b)
 
 	client := redis.NewClient(&redis.Options{
 		Addr:     fmt.Sprintf("%s:%s", cfg.Redis.Host, cfg.Redis.Port),
@@ -263,7 +278,7 @@ func cleanupRedisTestDataWithClient(b *testing.B, client *redis.Client) {
 		}
 	}
 
-	if isTestEnvironment() {
+	if isTestEnvironment(b) {
 		err := client.FlushDB(ctx).Err()
 		if err != nil {
 			b.Logf("Warning: Failed to flush Redis test DB: %v", err)
@@ -273,7 +288,7 @@ func cleanupRedisTestDataWithClient(b *testing.B, client *redis.Client) {
 	}
 }
 
-func isTestEnvironment() bool {
-	cfg := config.Load()
+func isTestEnvironment(b *testing.B) bool {
+	cfg := setupConfig(b)
 	return cfg.Redis.Host == "localhost" || cfg.Redis.Host == "127.0.0.1" || cfg.Redis.DB != 0
 }
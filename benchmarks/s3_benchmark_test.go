@@ -14,7 +14,6 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 
-	"todo-service/internal/config"
 	"todo-service/internal/infrastructure/storage"
 )
 
@@ -172,7 +171,7 @@ func BenchmarkS3FileUploadWithTimeout(b *testing.B) {
 }
 
 func setupS3Storage(b *testing.B) *storage.S3FileStorage {
-	cfg := config.Load()
+	cfg := setupConfig(b)
 
 	sess, err := session.NewSession(&aws.Config{
 		Region:           aws.String(cfg.AWS.Region),
@@ -195,7 +194,7 @@ func setupS3Storage(b *testing.B) *storage.S3FileStorage {
 }
 
 func cleanupS3TestData(b *testing.B, s3Storage *storage.S3FileStorage) {
-	cfg := config.Load()
+	cfg := setupConfig(b)
 
 	sess, err := session.NewSession(&aws.Config{
 		Region:           aws.String(cfg.AWS.Region),
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"todo-service/pkg/client"
+)
+
+func (c *cli) uploadCommand() *cobra.Command {
+	var (
+		attachTo       string
+		idempotencyKey string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "upload <path>",
+		Short: "Upload a file, optionally attaching it to a todo",
+		Long: "Upload a file (jpg, jpeg, png, gif, pdf, txt, doc or docx, up to 10 MiB)\n" +
+			"and print its ID. With --attach the file is attached to the todo.",
+		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+			return []string{"jpg", "jpeg", "png", "gif", "pdf", "txt", "doc", "docx"}, cobra.ShellCompDirectiveFilterFileExt
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			return c.withClient(cmd, func(ctx context.Context, api *client.Client) error {
+				uploaded, err := api.UploadFile(ctx, client.UploadFileRequest{
+					FileName:       filepath.Base(args[0]),
+					Data:           file,
+					IdempotencyKey: idempotencyKey,
+				})
+				if err != nil {
+					return err
+				}
+
+				if attachTo == "" {
+					return c.print(cmd.OutOrStdout(), uploaded, func(w *tabwriter.Writer) {
+						fmt.Fprintln(w, "FILE ID")
+						fmt.Fprintln(w, uploaded.FileID)
+					})
+				}
+
+				todo, err := attach(ctx, api, attachTo, uploaded.FileID)
+				if err != nil {
+					return fmt.Errorf("uploaded file %s but could not attach it: %w", uploaded.FileID, err)
+				}
+				return c.print(cmd.OutOrStdout(), todo, todoTable(todo))
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&attachTo, "attach", "", "ID of a todo to attach the file to")
+	cmd.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "make retries of this upload safe")
+	cmd.RegisterFlagCompletionFunc("attach", c.completeTodoIDs)
+	return cmd
+}
+
+func attach(ctx context.Context, api *client.Client, todoID, fileID string) (*client.Todo, error) {
+	todo, err := api.GetTodo(ctx, todoID)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.UpdateTodo(ctx, todoID, client.UpdateTodoRequest{
+		Description: todo.Description,
+		DueDate:     todo.DueDate,
+		FileID:      &fileID,
+		IfVersion:   &todo.Version,
+	})
+}
+
+func (c *cli) downloadCommand() *cobra.Command {
+	var outputPath string
+
+	cmd := &cobra.Command{
+		Use:   "download <file-id>",
+		Short: "Download an uploaded file",
+		Long: "Download an uploaded file into the current directory under its original\n" +
+			"name, or to --out; --out - writes it to stdout.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.withClient(cmd, func(ctx context.Context, api *client.Client) error {
+				if outputPath == "-" {
+					_, err := api.DownloadFile(ctx, args[0], cmd.OutOrStdout())
+					return err
+				}
+
+				// The name is only known from the response, so download to a
+				// temporary file next to the destination and rename it.
+				dir := "."
+				if outputPath != "" {
+					dir = filepath.Dir(outputPath)
+				}
+				tmp, err := os.CreateTemp(dir, ".godo-download-*")
+				if err != nil {
+					return err
+				}
+				defer os.Remove(tmp.Name())
+
+				info, err := api.DownloadFile(ctx, args[0], tmp)
+				if closeErr := tmp.Close(); err == nil {
+					err = closeErr
+				}
+				if err != nil {
+					return err
+				}
+
+				destination := outputPath
+				if destination == "" {
+					destination = filepath.Base(info.FileName)
+					if destination == "." || destination == string(filepath.Separator) {
+						destination = args[0]
+					}
+				}
+				if err := os.Rename(tmp.Name(), destination); err != nil {
+					return err
+				}
+
+				fmt.Fprintf(cmd.ErrOrStderr(), "Saved %s (%d bytes)\n", destination, info.Size)
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputPath, "out", "O", "", "where to write the file, - for stdout")
+	return cmd
+}
+
+func (c *cli) usageCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "usage",
+		Short: "Show storage used and what is left of your quota",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.withClient(cmd, func(ctx context.Context, api *client.Client) error {
+				usage, err := api.GetUsage(ctx)
+				if err != nil {
+					return err
+				}
+				return c.print(cmd.OutOrStdout(), usage, func(w *tabwriter.Writer) {
+					fmt.Fprintln(w, "\tUSED\tLIMIT\tREMAINING")
+					fmt.Fprintf(w, "bytes\t%d\t%s\t%s\n", usage.Bytes, limit(usage.MaxBytes), limit(usage.RemainingBytes))
+					fmt.Fprintf(w, "files\t%d\t%s\t%s\n", usage.Files, limit(usage.MaxFiles), limit(usage.RemainingFiles))
+				})
+			})
+		},
+	}
+}
+
+func limit(value *int64) string {
+	if value == nil {
+		return "unlimited"
+	}
+	return fmt.Sprint(*value)
+}
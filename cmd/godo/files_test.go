@@ -0,0 +1,104 @@
+package main
+
+import (
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveFile answers every request with content as an attachment named name.
+func serveFile(t *testing.T, name, content string) {
+	t.Helper()
+
+	t.Setenv("GODO_SERVER", newAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/files/file-1", r.URL.Path)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+		w.Write([]byte(content))
+	}))
+}
+
+// chdir switches to dir for the rest of the test.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+
+	previous, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { os.Chdir(previous) })
+}
+
+func TestDownload_SavesUnderOriginalName(t *testing.T) {
+	isolate(t)
+	serveFile(t, "notes.txt", "hello")
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	_, stderr, err := godo(t, "download", "file-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Saved notes.txt (5 bytes)\n", stderr)
+
+	content, err := os.ReadFile(filepath.Join(dir, "notes.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+
+	// The temporary file is gone.
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestDownload_KeepsServerNamesInTheCurrentDirectory(t *testing.T) {
+	isolate(t)
+	serveFile(t, "../../.bashrc", "echo pwned")
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	_, _, err := godo(t, "download", "file-1")
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, ".bashrc"))
+	require.NoError(t, err)
+	assert.Equal(t, "echo pwned", string(content))
+}
+
+func TestDownload_ToPathOrStdout(t *testing.T) {
+	isolate(t)
+	serveFile(t, "notes.txt", "hello")
+
+	destination := filepath.Join(t.TempDir(), "copy.txt")
+	_, stderr, err := godo(t, "download", "file-1", "--out", destination)
+	require.NoError(t, err)
+	assert.Contains(t, stderr, "Saved "+destination)
+	content, err := os.ReadFile(destination)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+
+	stdout, _, err := godo(t, "download", "file-1", "-O", "-")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", stdout)
+}
+
+func TestDownload_LeavesNothingBehindOnError(t *testing.T) {
+	isolate(t)
+	t.Setenv("GODO_SERVER", newAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"title":"File not found","status":404,"code":"file_not_found"}`))
+	}))
+	dir := t.TempDir()
+
+	_, _, err := godo(t, "download", "file-1", "--out", filepath.Join(dir, "notes.txt"))
+	assert.ErrorContains(t, err, "404")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,131 @@
+// Command godo is a command-line client for the todo service.
+package main
+
+import (
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"todo-service/pkg/client"
+)
+
+// cli holds the global flags and lazily builds the API client, so shell
+// completion can reach the API without running the command hooks.
+type cli struct {
+	configPath string
+	profile    string
+	server     string
+	token      string
+	apiKey     string
+	output     string
+	timeout    time.Duration
+
+	config *profileConfig
+	client *client.Client
+}
+
+func main() {
+	c := &cli{}
+	if err := c.rootCommand().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func (c *cli) rootCommand() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "godo",
+		Short: "Command-line client for the todo service",
+		Long: "Command-line client for the todo service.\n\n" +
+			"Servers and credentials are kept in profiles; see `godo profile --help`.\n" +
+			"Flags override the environment (GODO_SERVER, GODO_TOKEN, GODO_API_KEY,\n" +
+			"GODO_PROFILE), which overrides the active profile.",
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return validateOutput(c.output)
+		},
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&c.configPath, "config", "", "profile file (default $GODO_CONFIG or <user config dir>/godo/config.yaml)")
+	flags.StringVarP(&c.profile, "profile", "p", "", "profile to use instead of the current one")
+	flags.StringVar(&c.server, "server", "", "API base URL, e.g. http://localhost:8083")
+	flags.StringVar(&c.token, "token", "", "JWT bearer token")
+	flags.StringVar(&c.apiKey, "api-key", "", "API key, used instead of a token")
+	flags.StringVarP(&c.output, "output", "o", outputTable, "output format: table, json or yaml")
+	flags.DurationVar(&c.timeout, "timeout", 30*time.Second, "request timeout")
+
+	root.RegisterFlagCompletionFunc("output", fixedCompletion(outputTable, outputJSON, outputYAML))
+	root.RegisterFlagCompletionFunc("profile", c.completeProfiles)
+
+	root.AddCommand(
+		c.createCommand(),
+		c.listCommand(),
+		c.getCommand(),
+		c.updateCommand(),
+		c.setCompletedCommand(true),
+		c.setCompletedCommand(false),
+		c.deleteCommand(),
+		c.uploadCommand(),
+		c.downloadCommand(),
+		c.usageCommand(),
+		c.profileCommand(),
+	)
+
+	return root
+}
+
+// apiClient builds the client from flags, environment and profile.
+func (c *cli) apiClient() (*client.Client, error) {
+	if c.client != nil {
+		return c.client, nil
+	}
+
+	config, err := c.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := config.resolve(firstNonEmpty(c.profile, os.Getenv("GODO_PROFILE")))
+	if err != nil {
+		return nil, err
+	}
+
+	server := firstNonEmpty(c.server, os.Getenv("GODO_SERVER"), profile.Server, defaultServer)
+	options := []client.Option{
+		client.WithUserAgent("godo"),
+		client.WithHTTPClient(&http.Client{Timeout: c.timeout}),
+	}
+
+	// A token or key given explicitly replaces the profile's credentials
+	// entirely, so a stored API key never shadows a --token.
+	token, apiKey := firstNonEmpty(c.token, os.Getenv("GODO_TOKEN")), firstNonEmpty(c.apiKey, os.Getenv("GODO_API_KEY"))
+	if token == "" && apiKey == "" {
+		token, apiKey = profile.Token, profile.APIKey
+	}
+	switch {
+	case apiKey != "":
+		options = append(options, client.WithAPIKey(apiKey))
+	case token != "":
+		options = append(options, client.WithToken(token))
+	}
+
+	c.client, err = client.New(server, options...)
+	return c.client, err
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func fixedCompletion(values ...string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// isolate points godo at a fresh profile file and clears the environment
+// it reads, returning the profile file's path.
+func isolate(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("GODO_CONFIG", path)
+	for _, env := range []string{"GODO_SERVER", "GODO_TOKEN", "GODO_API_KEY", "GODO_PROFILE"} {
+		t.Setenv(env, "")
+	}
+	return path
+}
+
+// godo runs the CLI with args and returns what it wrote to stdout and
+// stderr.
+func godo(t *testing.T, args ...string) (string, string, error) {
+	t.Helper()
+
+	root := (&cli{}).rootCommand()
+	var stdout, stderr bytes.Buffer
+	root.SetOut(&stdout)
+	root.SetErr(&stderr)
+	root.SetArgs(args)
+
+	err := root.Execute()
+	return stdout.String(), stderr.String(), err
+}
+
+// newAPI serves handler as the todo service and returns its URL.
+func newAPI(t *testing.T, handler http.HandlerFunc) string {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func writeData(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+}
+
+func TestCredentials_Precedence(t *testing.T) {
+	var authorization string
+	server := newAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		writeData(w, map[string]interface{}{"bytes": 0, "files": 0})
+	})
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		args    []string
+		profile []string
+		want    string
+	}{
+		{name: "profile token", profile: []string{"--token", "profile-token"}, want: "Bearer profile-token"},
+		{name: "profile api key", profile: []string{"--api-key", "tdk_profile"}, want: "ApiKey tdk_profile"},
+		{name: "environment over profile", profile: []string{"--api-key", "tdk_profile"}, env: map[string]string{"GODO_TOKEN": "env-token"}, want: "Bearer env-token"},
+		{name: "flag over environment", env: map[string]string{"GODO_TOKEN": "env-token"}, args: []string{"--token", "flag-token"}, want: "Bearer flag-token"},
+		{name: "api key over token", args: []string{"--token", "flag-token", "--api-key", "tdk_flag"}, want: "ApiKey tdk_flag"},
+		{name: "none", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolate(t)
+			if tt.profile != nil {
+				_, _, err := godo(t, append([]string{"profile", "set", "local", "--server", server}, tt.profile...)...)
+				require.NoError(t, err)
+			} else {
+				t.Setenv("GODO_SERVER", server)
+			}
+			for env, value := range tt.env {
+				t.Setenv(env, value)
+			}
+
+			_, _, err := godo(t, append([]string{"usage"}, tt.args...)...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, authorization)
+		})
+	}
+}
+
+func TestServer_FlagOverridesEnvironment(t *testing.T) {
+	isolate(t)
+	var called bool
+	server := newAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		writeData(w, map[string]interface{}{"bytes": 0, "files": 0})
+	})
+	t.Setenv("GODO_SERVER", "http://127.0.0.1:1")
+
+	_, _, err := godo(t, "usage", "--server", server)
+	require.NoError(t, err)
+	assert.True(t, called)
+}
+
+func TestOutput_RejectsUnknownFormat(t *testing.T) {
+	isolate(t)
+
+	_, _, err := godo(t, "usage", "-o", "xml")
+	assert.ErrorContains(t, err, `unknown output format "xml"`)
+}
+
+func TestUsage_Formats(t *testing.T) {
+	isolate(t)
+	t.Setenv("GODO_SERVER", newAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/me/usage", r.URL.Path)
+		writeData(w, map[string]interface{}{"bytes": 1024, "files": 2, "max_bytes": 4096, "remaining_bytes": 3072})
+	}))
+
+	table, _, err := godo(t, "usage")
+	require.NoError(t, err)
+	assert.Contains(t, table, "bytes  1024  4096       3072")
+	assert.Contains(t, table, "files  2     unlimited  unlimited")
+
+	encoded, _, err := godo(t, "usage", "-o", "json")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"bytes":1024,"files":2,"max_bytes":4096,"max_files":null,"remaining_bytes":3072,"remaining_files":null}`, encoded)
+
+	yamlOut, _, err := godo(t, "usage", "-o", "yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "bytes: 1024\nfiles: 2\nmax_bytes: 4096\nmax_files: null\nremaining_bytes: 3072\nremaining_files: null\n", yamlOut)
+}
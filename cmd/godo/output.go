@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"todo-service/pkg/client"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+func validateOutput(format string) error {
+	switch format {
+	case outputTable, outputJSON, outputYAML:
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q: use table, json or yaml", format)
+	}
+}
+
+// print writes value in the selected format; table renders the table
+// format, all others are derived from the value's JSON encoding so field
+// names match the API.
+func (c *cli) print(w io.Writer, value interface{}, table func(w *tabwriter.Writer)) error {
+	switch c.output {
+	case outputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+
+	case outputYAML:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		// Decoding JSON into a node keeps the API's field order.
+		var node yaml.Node
+		if err := yaml.Unmarshal(encoded, &node); err != nil {
+			return err
+		}
+		blockStyle(&node)
+
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&node); err != nil {
+			return err
+		}
+		return encoder.Close()
+
+	default:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		table(tw)
+		return tw.Flush()
+	}
+}
+
+func blockStyle(node *yaml.Node) {
+	node.Style &^= yaml.FlowStyle | yaml.DoubleQuotedStyle
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+func todoTable(todos ...*client.Todo) func(w *tabwriter.Writer) {
+	return func(w *tabwriter.Writer) {
+		fmt.Fprintln(w, "ID\tDESCRIPTION\tDUE\tSTATUS\tFILE\tVERSION")
+		for _, todo := range todos {
+			file := "-"
+			if todo.FileID != nil {
+				file = *todo.FileID
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\n",
+				todo.ID, truncate(todo.Description, 60), formatTime(todo.DueDate), todoStatus(todo), file, todo.Version)
+		}
+	}
+}
+
+func todoStatus(todo *client.Todo) string {
+	switch {
+	case todo.Completed():
+		return "done " + formatTime(*todo.CompletedAt)
+	case todo.DueDate.Before(time.Now()):
+		return "overdue"
+	default:
+		return "open"
+	}
+}
+
+func formatTime(t time.Time) string {
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-1]) + "…"
+}
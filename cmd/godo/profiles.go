@@ -0,0 +1,244 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultServer = "http://localhost:8083"
+
+type profile struct {
+	Server string `yaml:"server"`
+	Token  string `yaml:"token,omitempty"`
+	APIKey string `yaml:"api_key,omitempty"`
+}
+
+// profileConfig is the profile file. It holds credentials, so it is only
+// ever written readable by the current user.
+type profileConfig struct {
+	Current  string              `yaml:"current,omitempty"`
+	Profiles map[string]*profile `yaml:"profiles"`
+
+	path string
+}
+
+func (c *cli) loadConfig() (*profileConfig, error) {
+	if c.config != nil {
+		return c.config, nil
+	}
+
+	path := firstNonEmpty(c.configPath, os.Getenv("GODO_CONFIG"))
+	if path == "" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return nil, fmt.Errorf("cannot locate the profile file, pass --config: %w", err)
+		}
+		path = filepath.Join(dir, "godo", "config.yaml")
+	}
+
+	config := &profileConfig{Profiles: map[string]*profile{}, path: path}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("invalid profile file %s: %w", path, err)
+	}
+	if config.Profiles == nil {
+		config.Profiles = map[string]*profile{}
+	}
+
+	c.config = config
+	return config, nil
+}
+
+// resolve returns the named profile, the current one when name is empty,
+// or an empty profile when none has been set up yet.
+func (pc *profileConfig) resolve(name string) (*profile, error) {
+	if name == "" {
+		name = pc.Current
+	}
+	if name == "" {
+		return &profile{}, nil
+	}
+
+	p, ok := pc.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile %q does not exist; create it with `godo profile set %s --server <url>`", name, name)
+	}
+	return p, nil
+}
+
+func (pc *profileConfig) save() error {
+	if err := os.MkdirAll(filepath.Dir(pc.path), 0o700); err != nil {
+		return err
+	}
+
+	var data bytes.Buffer
+	encoder := yaml.NewEncoder(&data)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(pc); err != nil {
+		return err
+	}
+	return os.WriteFile(pc.path, data.Bytes(), 0o600)
+}
+
+func (pc *profileConfig) names() []string {
+	names := make([]string, 0, len(pc.Profiles))
+	for name := range pc.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (c *cli) profileCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Manage servers and stored credentials",
+	}
+
+	var (
+		set profile
+		use bool
+	)
+	setCmd := &cobra.Command{
+		Use:   "set <name>",
+		Short: "Create or change a profile",
+		Example: "  godo profile set local --server http://localhost:8083 --token \"$(make -s token)\" --use\n" +
+			"  godo profile set prod --server https://todo.example.com --api-key tdk_…",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := c.loadConfig()
+			if err != nil {
+				return err
+			}
+
+			p, ok := config.Profiles[args[0]]
+			if !ok {
+				p = &profile{Server: defaultServer}
+				config.Profiles[args[0]] = p
+			}
+			flags := cmd.Flags()
+			if flags.Changed("server") {
+				p.Server = set.Server
+			}
+			if flags.Changed("token") {
+				p.Token, p.APIKey = set.Token, ""
+			}
+			if flags.Changed("api-key") {
+				p.APIKey, p.Token = set.APIKey, ""
+			}
+			if use || config.Current == "" {
+				config.Current = args[0]
+			}
+
+			return config.save()
+		},
+	}
+	// These shadow the global flags: here they are stored, not used.
+	setCmd.Flags().StringVar(&set.Server, "server", "", "API base URL")
+	setCmd.Flags().StringVar(&set.Token, "token", "", "JWT bearer token to store")
+	setCmd.Flags().StringVar(&set.APIKey, "api-key", "", "API key to store instead of a token")
+	setCmd.Flags().BoolVar(&use, "use", false, "make this the current profile")
+	setCmd.MarkFlagsMutuallyExclusive("token", "api-key")
+
+	cmd.AddCommand(
+		setCmd,
+		&cobra.Command{
+			Use:               "use <name>",
+			Short:             "Switch the current profile",
+			Args:              cobra.ExactArgs(1),
+			ValidArgsFunction: c.completeProfiles,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				config, err := c.loadConfig()
+				if err != nil {
+					return err
+				}
+				if _, err := config.resolve(args[0]); err != nil {
+					return err
+				}
+				config.Current = args[0]
+				return config.save()
+			},
+		},
+		&cobra.Command{
+			Use:               "delete <name>",
+			Short:             "Remove a profile and its credentials",
+			Args:              cobra.ExactArgs(1),
+			ValidArgsFunction: c.completeProfiles,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				config, err := c.loadConfig()
+				if err != nil {
+					return err
+				}
+				if _, err := config.resolve(args[0]); err != nil {
+					return err
+				}
+				delete(config.Profiles, args[0])
+				if config.Current == args[0] {
+					config.Current = ""
+				}
+				return config.save()
+			},
+		},
+		&cobra.Command{
+			Use:   "list",
+			Short: "List profiles; credentials are masked",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				config, err := c.loadConfig()
+				if err != nil {
+					return err
+				}
+
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "CURRENT\tNAME\tSERVER\tAUTH")
+				for _, name := range config.names() {
+					p := config.Profiles[name]
+					current := ""
+					if name == config.Current {
+						current = "*"
+					}
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", current, name, p.Server, describeAuth(p))
+				}
+				return w.Flush()
+			},
+		},
+	)
+
+	return cmd
+}
+
+func describeAuth(p *profile) string {
+	switch {
+	case p.APIKey != "":
+		return "api key " + mask(p.APIKey)
+	case p.Token != "":
+		return "token " + mask(p.Token)
+	default:
+		return "none"
+	}
+}
+
+func mask(secret string) string {
+	if len(secret) <= 8 {
+		return "****"
+	}
+	return secret[:4] + "…" + secret[len(secret)-4:]
+}
+
+func (c *cli) completeProfiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	config, err := c.loadConfig()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return config.names(), cobra.ShellCompDirectiveNoFileComp
+}
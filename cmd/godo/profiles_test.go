@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfiles(t *testing.T) {
+	path := isolate(t)
+
+	// The first profile becomes the current one.
+	_, _, err := godo(t, "profile", "set", "local", "--token", "local-development-token")
+	require.NoError(t, err)
+	_, _, err = godo(t, "profile", "set", "prod", "--server", "https://todo.example.com", "--api-key", "tdk_0123456789")
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	out, _, err := godo(t, "profile", "list")
+	require.NoError(t, err)
+	assert.Equal(t, ""+
+		"CURRENT  NAME   SERVER                    AUTH\n"+
+		"*        local  http://localhost:8083     token loca…oken\n"+
+		"         prod   https://todo.example.com  api key tdk_…6789\n", out)
+
+	// Storing a key drops the token, so only one credential is kept.
+	_, _, err = godo(t, "profile", "set", "local", "--api-key", "short", "--use")
+	require.NoError(t, err)
+	_, _, err = godo(t, "profile", "use", "prod")
+	require.NoError(t, err)
+
+	out, _, err = godo(t, "profile", "list")
+	require.NoError(t, err)
+	assert.Contains(t, out, "         local  http://localhost:8083     api key ****\n")
+	assert.Contains(t, out, "*        prod")
+
+	_, _, err = godo(t, "profile", "use", "staging")
+	assert.ErrorContains(t, err, `profile "staging" does not exist`)
+
+	_, _, err = godo(t, "profile", "delete", "prod")
+	require.NoError(t, err)
+	out, _, err = godo(t, "profile", "list")
+	require.NoError(t, err)
+	assert.NotContains(t, out, "prod")
+	assert.NotContains(t, out, "\n*", "no profile is current")
+
+	_, _, err = godo(t, "profile", "set", "local", "--token", "a", "--api-key", "b")
+	assert.Error(t, err)
+}
+
+func TestProfiles_UnknownProfileFailsRequests(t *testing.T) {
+	isolate(t)
+	t.Setenv("GODO_PROFILE", "missing")
+
+	_, _, err := godo(t, "usage")
+	assert.ErrorContains(t, err, `profile "missing" does not exist`)
+}
+
+func TestProfiles_RejectsInvalidFile(t *testing.T) {
+	path := isolate(t)
+	require.NoError(t, os.WriteFile(path, []byte("profiles: [oops"), 0o600))
+
+	_, _, err := godo(t, "profile", "list")
+	assert.ErrorContains(t, err, "invalid profile file")
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"todo-service/pkg/client"
+)
+
+const dueHelp = "due date: RFC 3339, YYYY-MM-DD, \"YYYY-MM-DD HH:MM\" (local time) or relative like +2h or +3d"
+
+func (c *cli) createCommand() *cobra.Command {
+	var (
+		due            string
+		fileID         string
+		idempotencyKey string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "create <description>",
+		Short:   "Create a todo",
+		Example: "  godo create \"Renew passport\" --due 2030-03-01\n  godo create \"Call back\" --due +2h",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dueDate, err := parseDue(due, time.Now())
+			if err != nil {
+				return err
+			}
+
+			req := client.CreateTodoRequest{Description: args[0], DueDate: dueDate, IdempotencyKey: idempotencyKey}
+			if fileID != "" {
+				req.FileID = &fileID
+			}
+
+			return c.withClient(cmd, func(ctx context.Context, api *client.Client) error {
+				todo, err := api.CreateTodo(ctx, req)
+				if err != nil {
+					return err
+				}
+				return c.print(cmd.OutOrStdout(), todo, todoTable(todo))
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&due, "due", "", dueHelp)
+	cmd.Flags().StringVar(&fileID, "file-id", "", "attach an uploaded file")
+	cmd.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "make retries of this create safe")
+	cmd.MarkFlagRequired("due")
+	return cmd
+}
+
+func (c *cli) listCommand() *cobra.Command {
+	var (
+		options client.ListTodosOptions
+		all     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List your todos and those shared with you",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.withClient(cmd, func(ctx context.Context, api *client.Client) error {
+				todos := []*client.Todo{}
+				for {
+					page, err := api.ListTodos(ctx, options)
+					if err != nil {
+						return err
+					}
+					todos = append(todos, page...)
+
+					if !all || len(page) == 0 {
+						break
+					}
+					options.Offset += len(page)
+				}
+				return c.print(cmd.OutOrStdout(), todos, todoTable(todos...))
+			})
+		},
+	}
+
+	cmd.Flags().IntVar(&options.Limit, "limit", 0, "page size (server default 50, max 200)")
+	cmd.Flags().IntVar(&options.Offset, "offset", 0, "number of todos to skip")
+	cmd.Flags().BoolVar(&all, "all", false, "fetch every page")
+	return cmd
+}
+
+func (c *cli) getCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:               "get <id>",
+		Short:             "Show a todo",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: c.completeTodoIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.withClient(cmd, func(ctx context.Context, api *client.Client) error {
+				todo, err := api.GetTodo(ctx, args[0])
+				if err != nil {
+					return err
+				}
+				return c.print(cmd.OutOrStdout(), todo, todoTable(todo))
+			})
+		},
+	}
+}
+
+func (c *cli) updateCommand() *cobra.Command {
+	var (
+		description string
+		due         string
+		fileID      string
+		detach      bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update <id>",
+		Short: "Change a todo's description, due date or attachment",
+		Long: "Change a todo's description, due date or attachment. Fields that are not\n" +
+			"given keep their value. The write fails if someone else changed the todo\n" +
+			"since it was read.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: c.completeTodoIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			if !flags.Changed("description") && !flags.Changed("due") && !flags.Changed("file-id") && !detach {
+				return fmt.Errorf("nothing to update: pass --description, --due, --file-id or --detach")
+			}
+
+			return c.withClient(cmd, func(ctx context.Context, api *client.Client) error {
+				current, err := api.GetTodo(ctx, args[0])
+				if err != nil {
+					return err
+				}
+
+				req := client.UpdateTodoRequest{
+					Description: current.Description,
+					DueDate:     current.DueDate,
+					FileID:      current.FileID,
+					IfVersion:   &current.Version,
+				}
+				if flags.Changed("description") {
+					req.Description = description
+				}
+				if flags.Changed("due") {
+					if req.DueDate, err = parseDue(due, time.Now()); err != nil {
+						return err
+					}
+				}
+				if flags.Changed("file-id") {
+					req.FileID = &fileID
+				}
+				if detach {
+					req.FileID = nil
+				}
+
+				todo, err := api.UpdateTodo(ctx, args[0], req)
+				if client.IsVersionMismatch(err) {
+					return fmt.Errorf("todo %s was changed by someone else while updating; run the command again", args[0])
+				}
+				if err != nil {
+					return err
+				}
+				return c.print(cmd.OutOrStdout(), todo, todoTable(todo))
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&description, "description", "d", "", "new description")
+	cmd.Flags().StringVar(&due, "due", "", dueHelp)
+	cmd.Flags().StringVar(&fileID, "file-id", "", "attach an uploaded file")
+	cmd.Flags().BoolVar(&detach, "detach", false, "remove the attached file")
+	cmd.MarkFlagsMutuallyExclusive("file-id", "detach")
+	return cmd
+}
+
+// setCompletedCommand builds `complete` or `reopen`.
+func (c *cli) setCompletedCommand(completed bool) *cobra.Command {
+	use, short, aliases := "reopen <id>...", "Mark todos as open again", []string(nil)
+	if completed {
+		use, short, aliases = "complete <id>...", "Mark todos as completed", []string{"done"}
+	}
+
+	return &cobra.Command{
+		Use:               use,
+		Aliases:           aliases,
+		Short:             short,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: c.completeTodoIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.withClient(cmd, func(ctx context.Context, api *client.Client) error {
+				todos := make([]*client.Todo, 0, len(args))
+				for _, id := range args {
+					var (
+						todo *client.Todo
+						err  error
+					)
+					if completed {
+						todo, err = api.CompleteTodo(ctx, id, nil)
+					} else {
+						todo, err = api.ReopenTodo(ctx, id, nil)
+					}
+					if err != nil {
+						return fmt.Errorf("%s: %w", id, err)
+					}
+					todos = append(todos, todo)
+				}
+				return c.print(cmd.OutOrStdout(), todos, todoTable(todos...))
+			})
+		},
+	}
+}
+
+func (c *cli) deleteCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:               "delete <id>...",
+		Aliases:           []string{"rm"},
+		Short:             "Delete todos",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: c.completeTodoIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.withClient(cmd, func(ctx context.Context, api *client.Client) error {
+				for _, id := range args {
+					if err := api.DeleteTodo(ctx, id, nil); err != nil {
+						return fmt.Errorf("%s: %w", id, err)
+					}
+					fmt.Fprintf(cmd.ErrOrStderr(), "Deleted %s\n", id)
+				}
+				return nil
+			})
+		},
+	}
+}
+
+func (c *cli) withClient(cmd *cobra.Command, run func(ctx context.Context, api *client.Client) error) error {
+	api, err := c.apiClient()
+	if err != nil {
+		return err
+	}
+	return run(cmd.Context(), api)
+}
+
+// completeTodoIDs offers the caller's todos, with their descriptions, as
+// completions for ID arguments.
+func (c *cli) completeTodoIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	api, err := c.apiClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	todos, err := api.ListTodos(ctx, client.ListTodosOptions{Limit: 200})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	completions := make([]string, 0, len(todos))
+	for _, todo := range todos {
+		if strings.HasPrefix(todo.ID, toComplete) {
+			completions = append(completions, todo.ID+"\t"+truncate(todo.Description, 40))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// parseDue accepts absolute dates and times, or an offset from now with
+// Go duration units plus d for days.
+func parseDue(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if offset, ok := strings.CutPrefix(value, "+"); ok {
+		if days, ok := strings.CutSuffix(offset, "d"); ok {
+			n, err := strconv.Atoi(days)
+			if err == nil {
+				return now.AddDate(0, 0, n), nil
+			}
+		}
+		if duration, err := time.ParseDuration(offset); err == nil {
+			return now.Add(duration), nil
+		}
+		return time.Time{}, fmt.Errorf("invalid relative due date %q: use e.g. +90m, +2h or +3d", value)
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid due date %q: %s", value, dueHelp)
+}
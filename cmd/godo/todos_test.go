@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDue(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	now := time.Date(2030, 3, 1, 12, 0, 0, 0, berlin)
+
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"2030-03-05T09:30:00Z", time.Date(2030, 3, 5, 9, 30, 0, 0, time.UTC)},
+		{"2030-03-05", time.Date(2030, 3, 5, 0, 0, 0, 0, berlin)},
+		{"2030-03-05 17:45", time.Date(2030, 3, 5, 17, 45, 0, 0, berlin)},
+		{"2030-03-05T17:45", time.Date(2030, 3, 5, 17, 45, 0, 0, berlin)},
+		{" +90m ", now.Add(90 * time.Minute)},
+		{"+2h", now.Add(2 * time.Hour)},
+		{"+3d", now.AddDate(0, 0, 3)},
+	}
+	for _, tt := range tests {
+		got, err := parseDue(tt.value, now)
+		require.NoError(t, err, tt.value)
+		assert.True(t, tt.want.Equal(got), "%s: got %s, want %s", tt.value, got, tt.want)
+	}
+
+	for _, value := range []string{"", "tomorrow", "+3w", "05.03.2030"} {
+		_, err := parseDue(value, now)
+		assert.Error(t, err, value)
+	}
+}
+
+// todoJSON is a todo as the API returns it.
+func todoJSON(id string, completed bool, version int) map[string]interface{} {
+	todo := map[string]interface{}{
+		"id":           id,
+		"description":  "Renew passport",
+		"due_date":     "2030-03-01T09:00:00Z",
+		"completed_at": nil,
+		"version":      version,
+	}
+	if completed {
+		todo["completed_at"] = "2030-02-28T10:00:00Z"
+	}
+	return todo
+}
+
+func TestSetCompleted_PostsEachTodo(t *testing.T) {
+	isolate(t)
+	var paths []string
+	t.Setenv("GODO_SERVER", newAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Empty(t, r.Header.Get("If-Match"))
+		paths = append(paths, r.URL.Path)
+
+		parts := strings.Split(r.URL.Path, "/")
+		writeData(w, todoJSON(parts[4], parts[5] == "complete", 2))
+	}))
+
+	out, _, err := godo(t, "complete", "todo-1", "todo-2", "-o", "json")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/api/v1/todo/todo-1/complete", "/api/v1/todo/todo-2/complete"}, paths)
+
+	var todos []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(out), &todos))
+	require.Len(t, todos, 2)
+	assert.Equal(t, "2030-02-28T10:00:00Z", todos[1]["completed_at"])
+
+	paths = nil
+	out, _, err = godo(t, "reopen", "todo-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/api/v1/todo/todo-1/reopen"}, paths)
+	assert.Contains(t, out, "todo-1")
+	assert.NotContains(t, out, "done")
+
+	// done is an alias of complete.
+	paths = nil
+	_, _, err = godo(t, "done", "todo-3")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/api/v1/todo/todo-3/complete"}, paths)
+}
+
+func TestSetCompleted_StopsAtFirstFailure(t *testing.T) {
+	isolate(t)
+	var calls int
+	t.Setenv("GODO_SERVER", newAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"title":"Todo not found","status":404,"code":"todo_not_found"}`))
+	}))
+
+	_, _, err := godo(t, "complete", "missing", "todo-2")
+	assert.ErrorContains(t, err, "missing: 404")
+	assert.Equal(t, 1, calls)
+}
+
+func TestUpdate_SendsReadVersion(t *testing.T) {
+	isolate(t)
+	t.Setenv("GODO_SERVER", newAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeData(w, todoJSON("todo-1", false, 7))
+		case http.MethodPut:
+			assert.Equal(t, `"7"`, r.Header.Get("If-Match"))
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "Renew passport and ID", body["description"])
+			assert.Equal(t, "2030-03-01T09:00:00Z", body["due_date"])
+
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusPreconditionFailed)
+			w.Write([]byte(`{"title":"Version mismatch","status":412,"code":"version_mismatch"}`))
+		}
+	}))
+
+	_, _, err := godo(t, "update", "todo-1", "-d", "Renew passport and ID")
+	assert.ErrorContains(t, err, "was changed by someone else")
+
+	_, _, err = godo(t, "update", "todo-1")
+	assert.ErrorContains(t, err, "nothing to update")
+}
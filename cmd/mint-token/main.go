@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"todo-service/internal/config"
+	"todo-service/internal/infrastructure/auth"
+)
+
+// mint-token prints an HS256 token accepted by a locally running service,
+// using the same configuration file and AUTH_* environment variables as the server.
+func main() {
+	subject := flag.String("sub", "local-user", "token subject (owner ID)")
+	tenant := flag.String("tenant", "", "tenant ID (defaults to AUTH_DEFAULT_TENANT on the server)")
+	ttl := flag.Duration("ttl", time.Hour, "token lifetime")
+	scopes := flag.String("scope", "", "space-separated scopes")
+	configPath := flag.String("config", os.Getenv("CONFIG_FILE"), "YAML or TOML config file shared with the server")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if cfg.Auth.JWTSecret == "" {
+		fmt.Fprintln(os.Stderr, "AUTH_JWT_SECRET must be set")
+		os.Exit(1)
+	}
+
+	minter := auth.NewHS256Minter([]byte(cfg.Auth.JWTSecret), cfg.Auth.Issuer, cfg.Auth.Audience)
+	token, err := minter.Mint(*subject, *tenant, *ttl, strings.Fields(*scopes)...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to mint token:", err)
+		os.Exit(1)
+	}
+
+	fmt.Println(token)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"todo-service/internal/app"
+)
+
+func (c *cli) cleanupCommand() *cobra.Command {
+	var (
+		tenants []string
+		force   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "cleanup --tenant <tenant> [--tenant <tenant>...]",
+		Short: "Delete all data of test tenants from MySQL, S3 and Redis",
+		Long: "Delete every todo, file, webhook, API key, stream event, idempotency key and\n" +
+			"rate limit counter of the given tenants. Other tenants are not touched.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(tenants) == 0 {
+				return fmt.Errorf("at least one --tenant is required")
+			}
+			for _, tenant := range tenants {
+				if tenant == "" {
+					return fmt.Errorf("--tenant must not be empty")
+				}
+				if tenant == c.cfg.Auth.DefaultTenant && !force {
+					return fmt.Errorf("%q is the default tenant that real users land in; pass --force to delete it anyway", tenant)
+				}
+			}
+
+			application, err := app.New(c.cfg, c.logger)
+			if err != nil {
+				return err
+			}
+			defer application.Shutdown(context.Background())
+
+			for _, tenant := range tenants {
+				report, err := application.Cleanup(cmd.Context(), tenant)
+				if err != nil {
+					return fmt.Errorf("failed to clean up tenant %q: %w", tenant, err)
+				}
+				printCleanupReport(cmd, report)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&tenants, "tenant", nil, "tenant to delete; repeat for several")
+	cmd.Flags().BoolVar(&force, "force", false, "allow deleting the default tenant")
+	return cmd
+}
+
+func printCleanupReport(cmd *cobra.Command, report *app.CleanupReport) {
+	tables := make([]string, 0, len(report.Rows))
+	for table := range report.Rows {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	rows := make([]string, 0, len(tables))
+	for _, table := range tables {
+		rows = append(rows, fmt.Sprintf("%s=%d", table, report.Rows[table]))
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Cleaned up tenant %q\n  rows:    %s\n  objects: %d\n  events:  %d\n  redis:   %d idempotency keys, %d rate limit keys\n",
+		report.TenantID, strings.Join(rows, " "), report.Objects, report.Events, report.IdempotencyKeys, report.RateLimitKeys)
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"todo-service/internal/app"
+)
+
+func (c *cli) doctorCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Check connectivity to MySQL, Redis and S3 and explain failures",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+			failed := 0
+
+			for _, diagnosis := range app.Doctor(cmd.Context(), c.cfg) {
+				if diagnosis.OK() {
+					fmt.Fprintf(out, "✓ %-10s %s\n", diagnosis.Name, diagnosis.Detail)
+					continue
+				}
+
+				failed++
+				fmt.Fprintf(out, "✗ %-10s %s\n    error: %v\n", diagnosis.Name, diagnosis.Detail, diagnosis.Err)
+				if diagnosis.Hint != "" {
+					fmt.Fprintf(out, "    hint:  %s\n", diagnosis.Hint)
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d check(s) failed", failed)
+			}
+			return nil
+		},
+	}
+}
@@ -1,51 +1,86 @@
 package main
 
 import (
-	"context"
+	"fmt"
 	"os"
-	"os/signal"
-	"syscall"
-	"time"
 
+	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
-	"todo-service/internal/app"
 	"todo-service/internal/config"
 )
 
-func main() {
-	logger := initLogger()
-	defer logger.Sync()
+// cli holds what every subcommand needs once the root command has loaded
+// the configuration.
+type cli struct {
+	configPath  string
+	printConfig bool
+	cfg         *config.Config
+	logger      *zap.Logger
+}
 
-	cfg := config.Load()
-	logger.Info("Configuration loaded", zap.String("port", cfg.App.Port))
+func main() {
+	c := &cli{}
+	root := c.rootCommand()
 
-	application, err := app.New(cfg, logger)
+	err := root.Execute()
+	if c.logger != nil {
+		c.logger.Sync()
+	}
 	if err != nil {
-		logger.Fatal("Failed to initialize application", zap.Error(err))
+		os.Exit(1)
 	}
+}
 
-	go func() {
-		if err := application.Start(); err != nil {
-			logger.Fatal("Server failed to start", zap.Error(err))
-		}
-	}()
+func (c *cli) rootCommand() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "todo-service",
+		Short: "Todo service API, background workers and operational tasks",
+		Long: "Todo service API, background workers and operational tasks.\n\n" +
+			"Without a subcommand the API server and workers are started, as with `serve`.",
+		Args:              cobra.NoArgs,
+		SilenceUsage:      true,
+		PersistentPreRunE: c.load,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.serve(true)
+		},
+	}
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	root.PersistentFlags().StringVar(&c.configPath, "config", os.Getenv("CONFIG_FILE"), "YAML or TOML config file; environment variables override it")
+	root.PersistentFlags().BoolVar(&c.printConfig, "print-config", false, "print the effective configuration with secrets redacted and exit")
 
-	logger.Info("Shutdown signal received")
+	root.AddCommand(
+		c.serveCommand(),
+		c.workerCommand(),
+		c.migrateCommand(),
+		c.seedCommand(),
+		c.cleanupCommand(),
+		c.doctorCommand(),
+	)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	return root
+}
+
+func (c *cli) load(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load(c.configPath)
+	if err != nil {
+		return err
+	}
 
-	if err := application.Shutdown(ctx); err != nil {
-		logger.Fatal("Server forced to shutdown", zap.Error(err))
+	if c.printConfig {
+		if err := cfg.WriteRedacted(os.Stdout); err != nil {
+			return err
+		}
+		os.Exit(0)
 	}
 
-	logger.Info("Server exited")
+	c.cfg = cfg
+	c.logger = initLogger()
+	zap.ReplaceGlobals(c.logger)
+
+	c.logger.Debug("Configuration loaded", zap.String("command", cmd.Name()), zap.String("file", c.configPath))
+	return nil
 }
 
 func initLogger() *zap.Logger {
@@ -63,7 +98,7 @@ func initLogger() *zap.Logger {
 
 	logger, err := config.Build()
 	if err != nil {
-		panic("Failed to initialize logger: " + err.Error())
+		panic(fmt.Sprintf("Failed to initialize logger: %v", err))
 	}
 
 	return logger
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/app"
+)
+
+// run executes the CLI with args against the default configuration and
+// returns what it printed.
+func run(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("AUTH_JWT_SECRET", "test-secret")
+
+	root := (&cli{}).rootCommand()
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(io.Discard)
+	root.SetArgs(args)
+
+	err := root.Execute()
+	return out.String(), err
+}
+
+func TestRootCommand_Subcommands(t *testing.T) {
+	root := (&cli{}).rootCommand()
+
+	var names []string
+	for _, cmd := range root.Commands() {
+		names = append(names, cmd.Name())
+	}
+	assert.Subset(t, names, []string{"serve", "worker", "migrate", "seed", "cleanup", "doctor"})
+
+	migrate, _, err := root.Find([]string{"migrate"})
+	require.NoError(t, err)
+	var steps []string
+	for _, cmd := range migrate.Commands() {
+		steps = append(steps, cmd.Name())
+	}
+	assert.ElementsMatch(t, []string{"up", "down", "to", "baseline", "status"}, steps)
+}
+
+func TestRootCommand_RejectsInvalidConfiguration(t *testing.T) {
+	t.Setenv("REDIS_DB", "one")
+
+	_, err := run(t, "doctor")
+	assert.ErrorContains(t, err, `REDIS_DB: "one" is not an integer`)
+}
+
+// The cases below fail before connecting to anything.
+
+func TestCleanupCommand_ValidatesTenants(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"no tenant", []string{"cleanup"}, "at least one --tenant is required"},
+		{"empty tenant", []string{"cleanup", "--tenant", ""}, "--tenant must not be empty"},
+		{"default tenant", []string{"cleanup", "--tenant", "test-1", "--tenant", "default"}, `"default" is the default tenant`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := run(t, tt.args...)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestSeedCommand_ValidatesCounts(t *testing.T) {
+	for _, args := range [][]string{{"--users", "0"}, {"--todos", "-1"}, {"--files", "-1"}} {
+		_, err := run(t, append([]string{"seed"}, args...)...)
+		assert.ErrorContains(t, err, "--users must be positive and --todos and --files non-negative", args)
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	version, err := parseVersion("12")
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), version)
+
+	for _, value := range []string{"-1", "v12", ""} {
+		_, err := parseVersion(value)
+		assert.ErrorContains(t, err, "version must be a non-negative integer", value)
+	}
+}
+
+func TestPrintCleanupReport(t *testing.T) {
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	printCleanupReport(cmd, &app.CleanupReport{
+		TenantID:        "test-1",
+		Rows:            map[string]int64{"todos": 3, "files": 1, "api_keys": 0},
+		Objects:         1,
+		Events:          4,
+		IdempotencyKeys: 2,
+		RateLimitKeys:   1,
+	})
+
+	assert.Equal(t, "Cleaned up tenant \"test-1\"\n"+
+		"  rows:    api_keys=0 files=1 todos=3\n"+
+		"  objects: 1\n"+
+		"  events:  4\n"+
+		"  redis:   2 idempotency keys, 1 rate limit keys\n", out.String())
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"todo-service/internal/app"
+	"todo-service/internal/infrastructure/migrations"
+)
+
+func (c *cli) migrateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply, roll back or inspect database migrations",
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "up",
+			Short: "Apply all pending migrations",
+			Args:  cobra.NoArgs,
+			RunE: c.withMigrator(func(ctx context.Context, migrator *migrations.Migrator, args []string) error {
+				return migrator.Up(ctx)
+			}),
+		},
+		&cobra.Command{
+			Use:   "down [steps]",
+			Short: "Roll back the latest migrations, one by default",
+			Args:  cobra.MaximumNArgs(1),
+			RunE: c.withMigrator(func(ctx context.Context, migrator *migrations.Migrator, args []string) error {
+				steps := 1
+				if len(args) == 1 {
+					var err error
+					if steps, err = strconv.Atoi(args[0]); err != nil || steps < 1 {
+						return fmt.Errorf("steps must be a positive integer, got %q", args[0])
+					}
+				}
+				return migrator.Down(ctx, steps)
+			}),
+		},
+		&cobra.Command{
+			Use:   "to <version>",
+			Short: "Migrate up or down to the given version",
+			Args:  cobra.ExactArgs(1),
+			RunE: c.withMigrator(func(ctx context.Context, migrator *migrations.Migrator, args []string) error {
+				version, err := parseVersion(args[0])
+				if err != nil {
+					return err
+				}
+				return migrator.To(ctx, version)
+			}),
+		},
+		&cobra.Command{
+			Use:   "baseline <version>",
+			Short: "Record migrations up to version as applied without running them",
+			Args:  cobra.ExactArgs(1),
+			RunE: c.withMigrator(func(ctx context.Context, migrator *migrations.Migrator, args []string) error {
+				version, err := parseVersion(args[0])
+				if err != nil {
+					return err
+				}
+				return migrator.Baseline(ctx, version)
+			}),
+		},
+		&cobra.Command{
+			Use:   "status",
+			Short: "List migrations and when they were applied",
+			Args:  cobra.NoArgs,
+			RunE: c.withMigrator(func(ctx context.Context, migrator *migrations.Migrator, args []string) error {
+				statuses, err := migrator.Status(ctx)
+				if err != nil {
+					return err
+				}
+				return printMigrationStatus(statuses)
+			}),
+		},
+	)
+
+	return cmd
+}
+
+func (c *cli) withMigrator(run func(ctx context.Context, migrator *migrations.Migrator, args []string) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		db, err := app.OpenMySQL(c.cfg, c.logger)
+		if err != nil {
+			return fmt.Errorf("failed to connect to MySQL: %w", err)
+		}
+		defer db.Close()
+
+		migrator, err := migrations.NewMigrator(db, c.logger)
+		if err != nil {
+			return err
+		}
+
+		return run(cmd.Context(), migrator, args)
+	}
+}
+
+func parseVersion(value string) (int64, error) {
+	version, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("version must be a non-negative integer, got %q", value)
+	}
+	return version, nil
+}
+
+func printMigrationStatus(statuses []migrations.Status) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.UTC().Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%03d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"todo-service/internal/app"
+)
+
+func (c *cli) seedCommand() *cobra.Command {
+	options := app.SeedOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Generate realistic fake todos and files for a tenant",
+		Long: "Generate realistic fake todos, attached files and shares for a tenant.\n" +
+			"Data is created through the normal use cases, so events and webhooks fire.\n" +
+			"Remove it again with `cleanup --tenant <tenant>`.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.Users < 1 || options.TodosPerUser < 0 || options.FilesPerUser < 0 {
+				return fmt.Errorf("--users must be positive and --todos and --files non-negative")
+			}
+
+			application, err := app.New(c.cfg, c.logger)
+			if err != nil {
+				return err
+			}
+			defer application.Shutdown(context.Background())
+
+			report, err := application.Seed(cmd.Context(), options)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Seeded tenant %q: %d users, %d todos, %d files, %d shares\n",
+				options.TenantID, len(report.Users), report.Todos, report.Files, report.Shares)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&options.TenantID, "tenant", "demo", "tenant to create the data in")
+	cmd.Flags().IntVar(&options.Users, "users", 5, "number of users")
+	cmd.Flags().IntVar(&options.TodosPerUser, "todos", 20, "todos per user")
+	cmd.Flags().IntVar(&options.FilesPerUser, "files", 3, "files per user, each attached to one of their todos")
+	cmd.Flags().Int64Var(&options.Seed, "seed", 1, "random seed; the same seed generates the same data")
+	return cmd
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"todo-service/internal/app"
+)
+
+func (c *cli) serveCommand() *cobra.Command {
+	var workers bool
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run the HTTP API",
+		Long: "Run the HTTP API. Background workers run in the same process unless\n" +
+			"--workers=false, in which case run them separately with `worker`.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.serve(workers)
+		},
+	}
+
+	cmd.Flags().BoolVar(&workers, "workers", true, "also run the background workers in this process")
+	return cmd
+}
+
+func (c *cli) serve(workers bool) error {
+	c.logger.Info("Configuration loaded", zap.String("port", c.cfg.App.Port), zap.String("file", c.configPath))
+
+	application, err := app.New(c.cfg, c.logger)
+	if err != nil {
+		return err
+	}
+
+	if workers {
+		application.StartWorkers()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	served := make(chan error, 1)
+	go func() {
+		served <- application.Serve()
+	}()
+
+	select {
+	case err := <-served:
+		if err != nil {
+			application.Shutdown(context.Background())
+			return err
+		}
+	case <-ctx.Done():
+		c.logger.Info("Shutdown signal received")
+	}
+
+	return c.shutdown(application)
+}
+
+func (c *cli) shutdown(application *app.App) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.App.ShutdownTimeout)
+	defer cancel()
+
+	if err := application.Shutdown(ctx); err != nil {
+		return errors.Join(errors.New("forced shutdown"), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"todo-service/internal/app"
+)
+
+func (c *cli) workerCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "worker",
+		Short: "Run only the background jobs and stream consumers",
+		Long: "Run only the background jobs and stream consumers, such as webhook\n" +
+			"delivery, without serving HTTP. Pair it with `serve --workers=false`.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			application, err := app.New(c.cfg, c.logger)
+			if err != nil {
+				return err
+			}
+
+			if !application.StartWorkers() {
+				application.Shutdown(context.Background())
+				return fmt.Errorf("the %s stream backend has nothing for a worker to consume", c.cfg.Stream.Backend)
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+			<-ctx.Done()
+
+			c.logger.Info("Shutdown signal received")
+			return c.shutdown(application)
+		},
+	}
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -14,35 +18,88 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
 	_ "github.com/go-sql-driver/mysql"
+	"github.com/nats-io/nats.go"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
 
 	"todo-service/internal/config"
+	"todo-service/internal/domain/entities"
 	"todo-service/internal/domain/ports"
+	"todo-service/internal/health"
+	"todo-service/internal/infrastructure/auth"
+	"todo-service/internal/infrastructure/idempotency"
+	"todo-service/internal/infrastructure/metrics"
+	"todo-service/internal/infrastructure/migrations"
+	"todo-service/internal/infrastructure/ratelimit"
 	"todo-service/internal/infrastructure/repositories"
 	"todo-service/internal/infrastructure/storage"
 	"todo-service/internal/infrastructure/streams"
+	"todo-service/internal/infrastructure/tracing"
+	"todo-service/internal/infrastructure/webhooks"
+	"todo-service/internal/interfaces/authn"
+	"todo-service/internal/interfaces/graphql/resolvers"
+	"todo-service/internal/interfaces/grpc/services"
 	"todo-service/internal/interfaces/http/handlers"
+	"todo-service/internal/interfaces/http/middleware"
+	"todo-service/internal/interfaces/http/openapi"
+	"todo-service/internal/logging"
 	"todo-service/internal/usecases"
 )
 
 type Dependencies struct {
-	TodoRepo        ports.TodoRepository
-	TxManager       ports.TransactionManager
-	StreamPublisher ports.StreamPublisher
-	FileStorage     ports.FileStorage
-	TodoUseCase     *usecases.TodoUseCase
-	FileUseCase     *usecases.FileUseCase
-	TodoHandler     *handlers.TodoHandler
-	FileHandler     *handlers.FileHandler
-	DB              *sql.DB
-	RedisClient     *redis.Client
-	Logger          *zap.Logger
+	TodoRepo         ports.TodoRepository
+	TxManager        ports.TransactionManager
+	StreamPublisher  ports.StreamPublisher
+	FileStorage      ports.FileStorage
+	FileRepo         ports.FileRepository
+	StorageUsageRepo ports.StorageUsageRepository
+	WebhookRepo      ports.WebhookRepository
+	APIKeyRepo       ports.APIKeyRepository
+	WebhookConsumer  ports.StreamConsumer
+	EventReader      ports.EventReader
+	IdempotencyStore ports.IdempotencyStore
+	TodoUseCase      *usecases.TodoUseCase
+	FileUseCase      *usecases.FileUseCase
+	WebhookUseCase   *usecases.WebhookUseCase
+	APIKeyUseCase    *usecases.APIKeyUseCase
+	EventFeed        *usecases.EventFeedUseCase
+	SyncUseCase      *usecases.SyncUseCase
+	TodoHandler      *handlers.TodoHandler
+	FileHandler      *handlers.FileHandler
+	WebhookHandler   *handlers.WebhookHandler
+	APIKeyHandler    *handlers.APIKeyHandler
+	EventsHandler    *handlers.LiveEventsHandler
+	SyncHandler      *handlers.SyncHandler
+	HealthHandler    *handlers.HealthHandler
+	DocsHandler      *handlers.DocsHandler
+	GraphQLHandler   *handlers.GraphQLHandler
+	TodoService      *services.TodoService
+	Authenticator    *authn.Authenticator
+	ValidateRequests gin.HandlerFunc
+	Idempotency      gin.HandlerFunc
+	RateLimitByIP    gin.HandlerFunc
+	RateLimit        gin.HandlerFunc
+	AccessLog        gin.HandlerFunc
+	Auth             gin.HandlerFunc
+	EventsAuth       gin.HandlerFunc
+	Metrics          *metrics.Metrics
+	ShutdownTracing  func(context.Context) error
+	DB               *sql.DB
+	RedisClient      *redis.Client
+	NATSConn         *nats.Conn
+	Logger           *zap.Logger
 }
 
 type App struct {
-	deps   *Dependencies
-	server *http.Server
-	logger *zap.Logger
+	cfg         *config.Config
+	deps        *Dependencies
+	server      *http.Server
+	grpcServer  *grpc.Server
+	grpcHealth  *grpchealth.Server
+	logger      *zap.Logger
+	stopWorkers context.CancelFunc
+	workers     sync.WaitGroup
 }
 
 func New(cfg *config.Config, logger *zap.Logger) (*App, error) {
@@ -52,40 +109,97 @@ func New(cfg *config.Config, logger *zap.Logger) (*App, error) {
 	}
 
 	router := setupRoutes(deps)
+	if err := router.SetTrustedProxies(cfg.App.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
 
 	server := &http.Server{
 		Addr:         ":" + cfg.App.Port,
 		Handler:      router,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+		ReadTimeout:  cfg.App.ReadTimeout,
+		WriteTimeout: cfg.App.WriteTimeout,
+		IdleTimeout:  cfg.App.IdleTimeout,
 	}
 
-	return &App{
+	app := &App{
+		cfg:    cfg,
 		deps:   deps,
 		server: server,
 		logger: logger,
-	}, nil
+	}
+	if cfg.App.GRPCPort != "" {
+		app.grpcServer, app.grpcHealth = setupGRPCServer(deps)
+	}
+
+	return app, nil
 }
 
-func (a *App) Start() error {
-	a.logger.Info("Starting server", zap.String("addr", a.server.Addr))
+// Serve runs the HTTP server, and the gRPC server when it is enabled, until
+// Shutdown is called or either of them fails.
+func (a *App) Serve() error {
+	served := make(chan error, 2)
+
+	if a.grpcServer != nil {
+		listener, err := net.Listen("tcp", ":"+a.cfg.App.GRPCPort)
+		if err != nil {
+			return fmt.Errorf("failed to start gRPC server: %w", err)
+		}
 
-	if err := a.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		return fmt.Errorf("failed to start server: %w", err)
+		a.logger.Info("Starting gRPC server", zap.String("addr", listener.Addr().String()))
+		a.grpcHealth.Resume()
+		go func() {
+			if err := a.grpcServer.Serve(listener); err != nil {
+				served <- fmt.Errorf("failed to serve gRPC: %w", err)
+				return
+			}
+			served <- nil
+		}()
 	}
 
-	return nil
+	a.logger.Info("Starting server", zap.String("addr", a.server.Addr))
+	a.deps.HealthHandler.SetReady(true)
+
+	go func() {
+		if err := a.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			served <- fmt.Errorf("failed to start server: %w", err)
+			return
+		}
+		served <- nil
+	}()
+
+	return <-served
 }
 
 func (a *App) Shutdown(ctx context.Context) error {
 	a.logger.Info("Shutting down server...")
+	a.deps.HealthHandler.SetReady(false)
+	if a.grpcHealth != nil {
+		a.grpcHealth.Shutdown()
+	}
+
+	if delay := a.cfg.App.ShutdownDelay; delay > 0 {
+		a.logger.Info("Waiting for load balancers to observe readiness change", zap.Duration("delay", delay))
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+		}
+	}
 
 	if err := a.server.Shutdown(ctx); err != nil {
 		a.logger.Error("Server shutdown error", zap.Error(err))
 		return err
 	}
 
+	a.shutdownGRPC(ctx)
+
+	a.shutdownWorkers(ctx)
+
+	if a.deps.ShutdownTracing != nil {
+		if err := a.deps.ShutdownTracing(ctx); err != nil {
+			a.logger.Error("Trace exporter shutdown error", zap.Error(err))
+		}
+	}
+
 	if a.deps.DB != nil {
 		if err := a.deps.DB.Close(); err != nil {
 			a.logger.Error("Database close error", zap.Error(err))
@@ -98,55 +212,432 @@ func (a *App) Shutdown(ctx context.Context) error {
 		}
 	}
 
+	if a.deps.NATSConn != nil {
+		if err := a.deps.NATSConn.Drain(); err != nil {
+			a.logger.Error("NATS drain error", zap.Error(err))
+		}
+	}
+
 	a.logger.Info("Server shutdown complete")
 	return nil
 }
 
+// StartWorkers starts the background stream consumers; Shutdown stops them.
+// It returns false when the stream backend has nothing to consume.
+func (a *App) StartWorkers() bool {
+	ctx, cancel := context.WithCancel(context.Background())
+	a.stopWorkers = cancel
+
+	if a.deps.WebhookConsumer == nil {
+		a.logger.Warn("Webhook delivery is not supported by this stream backend")
+		return false
+	}
+
+	a.workers.Add(2)
+	go func() {
+		defer a.workers.Done()
+
+		a.logger.Info("Starting webhook retry worker")
+		a.deps.WebhookUseCase.RunRetries(logging.WithLogger(ctx, a.logger), a.cfg.Webhooks.RetryInterval)
+	}()
+	go func() {
+		defer a.workers.Done()
+
+		a.logger.Info("Starting webhook delivery worker")
+		if err := a.deps.WebhookConsumer.Consume(ctx, a.deps.WebhookUseCase.HandleEvent); err != nil {
+			a.logger.Error("Webhook delivery worker stopped", zap.Error(err))
+		}
+	}()
+
+	return true
+}
+
+// shutdownGRPC lets in-flight calls finish and cancels those still running
+// when ctx expires.
+func (a *App) shutdownGRPC(ctx context.Context) {
+	if a.grpcServer == nil {
+		return
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		a.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		a.logger.Warn("Timed out waiting for gRPC calls to finish")
+		a.grpcServer.Stop()
+	}
+}
+
+func (a *App) shutdownWorkers(ctx context.Context) {
+	if a.stopWorkers == nil {
+		return
+	}
+	a.stopWorkers()
+
+	done := make(chan struct{})
+	go func() {
+		a.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		a.logger.Warn("Timed out waiting for background workers to stop")
+	}
+}
+
 func initDependencies(cfg *config.Config, logger *zap.Logger) (*Dependencies, error) {
-	db, err := initMySQL(cfg, logger)
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing.Exporter, cfg.Tracing.ServiceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize tracing: %w", err)
+	}
+
+	db, err := OpenMySQL(cfg, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize MySQL: %w", err)
 	}
 
-	redisClient, err := initRedis(cfg, logger)
+	if cfg.DB.AutoMigrate {
+		migrator, err := migrations.NewMigrator(db, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load migrations: %w", err)
+		}
+		if err := migrator.Up(context.Background()); err != nil {
+			return nil, fmt.Errorf("failed to migrate database: %w", err)
+		}
+	}
+
+	redisClient, err := OpenRedis(cfg, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize Redis: %w", err)
 	}
 
-	awsSession, err := initAWS(cfg, logger)
+	appMetrics := metrics.New()
+	appMetrics.RegisterDB(db, cfg.DB.Name)
+	appMetrics.InstrumentRedis(redisClient)
+
+	awsSession, err := NewAWSSession(cfg, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize AWS: %w", err)
 	}
 
-	txManager := repositories.NewMySQLTransactionManager(db)
-	streamPublisher := streams.NewRedisStreamPublisher(redisClient, "todo-events")
+	bus, err := initEventBus(cfg, redisClient, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize %s event stream: %w", cfg.Stream.Backend, err)
+	}
 
-	fileStorage, err := storage.NewS3FileStorage(awsSession, cfg.AWS.S3Bucket)
+	txManager := repositories.NewMySQLTransactionManager(db)
+	webhookRepo := repositories.NewMySQLWebhookRepository(db)
+	fileRepo := repositories.NewMySQLFileRepository(db)
+	storageUsageRepo := repositories.NewMySQLStorageUsageRepository(db)
+	apiKeyRepo := repositories.NewMySQLAPIKeyRepository(db)
+	webhookSender := webhooks.NewHTTPWebhookSender(cfg.Webhooks.Timeout, cfg.Webhooks.AllowPrivateNetworks)
+	idempotencyStore := idempotency.NewRedisIdempotencyStore(redisClient)
+
+	s3Storage, err := storage.NewS3FileStorage(awsSession, cfg.AWS.S3Bucket)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize S3 file storage: %w", err)
 	}
+	fileStorage := appMetrics.InstrumentFileStorage(s3Storage)
 
-	todoUseCase := usecases.NewTodoUseCase(txManager, streamPublisher)
-	fileUseCase := usecases.NewFileUseCase(fileStorage)
+	todoRules := entities.TodoRules{
+		MaxDescriptionLength: cfg.Todos.MaxDescriptionLength,
+		AllowPastDueDates:    cfg.Todos.AllowPastDueDates,
+		DueDateHorizon:       cfg.Todos.DueDateHorizon,
+	}
+	todoUseCase := usecases.NewTodoUseCase(txManager, bus.publisher, fileRepo, todoRules, appMetrics)
+	syncUseCase := usecases.NewSyncUseCase(txManager, bus.publisher, fileRepo, todoRules, appMetrics)
+	fileUseCase := usecases.NewFileUseCase(fileStorage, fileRepo, storageUsageRepo, entities.StorageQuota{
+		MaxBytes: cfg.Storage.QuotaBytes,
+		MaxFiles: cfg.Storage.QuotaFiles,
+	}, appMetrics)
+	apiKeyUseCase := usecases.NewAPIKeyUseCase(apiKeyRepo)
+	webhookUseCase := usecases.NewWebhookUseCase(webhookRepo, webhookSender, usecases.WebhookRetryPolicy{
+		MaxAttempts:    cfg.Webhooks.MaxAttempts,
+		InitialBackoff: cfg.Webhooks.InitialBackoff,
+		MaxBackoff:     cfg.Webhooks.MaxBackoff,
+		DisableAfter:   cfg.Webhooks.DisableAfter,
+	})
+	var eventFeed *usecases.EventFeedUseCase
+	if bus.reader != nil {
+		eventFeed = usecases.NewEventFeedUseCase(bus.reader)
+	}
 
-	todoHandler := handlers.NewTodoHandler(todoUseCase)
+	todoHandler := handlers.NewTodoHandler(todoUseCase, handlers.TodoHandlerOptions{
+		RequireIfMatch: cfg.App.RequireIfMatch,
+	})
 	fileHandler := handlers.NewFileHandler(fileUseCase)
+	webhookHandler := handlers.NewWebhookHandler(webhookUseCase)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyUseCase)
+	syncHandler := handlers.NewSyncHandler(syncUseCase)
+	todoService := services.NewTodoService(todoUseCase, fileUseCase, services.TodoServiceOptions{
+		RequireExpectedVersion: cfg.App.RequireIfMatch,
+	})
+	graphQLSchema, err := resolvers.NewSchema(todoUseCase, fileUseCase, eventFeed, resolvers.Options{
+		RequireExpectedVersion: cfg.App.RequireIfMatch,
+	})
+	if err != nil {
+		return nil, err
+	}
+	graphQLHandler := handlers.NewGraphQLHandler(graphQLSchema, handlers.GraphQLOptions{
+		MaxStreams:        cfg.Events.MaxConnections,
+		HeartbeatInterval: cfg.Events.HeartbeatInterval,
+	})
+	var eventsHandler *handlers.LiveEventsHandler
+	if eventFeed != nil {
+		eventsHandler = handlers.NewLiveEventsHandler(eventFeed, handlers.LiveEventsOptions{
+			MaxConnections:    cfg.Events.MaxConnections,
+			HeartbeatInterval: cfg.Events.HeartbeatInterval,
+			AllowedOrigins:    cfg.Events.AllowedOrigins,
+		})
+	}
+
+	tokenVerifier, err := initTokenVerifier(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize authentication: %w", err)
+	}
+	verifiers := map[string]ports.TokenVerifier{
+		middleware.SchemeBearer: tokenVerifier,
+		middleware.SchemeAPIKey: apiKeyUseCase,
+	}
+
+	spec, err := openapi.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI document: %w", err)
+	}
+	validateRequests := middleware.ValidateRequests(spec)
+	if !cfg.App.ValidateRequests {
+		validateRequests = func(c *gin.Context) { c.Next() }
+	}
+
+	idempotencyMiddleware := middleware.Idempotency(idempotencyStore, middleware.IdempotencyOptions{
+		TTL:       cfg.Idempotency.TTL,
+		LockTTL:   cfg.Idempotency.LockTTL,
+		BodyLimit: middleware.OperationBodyLimit(spec),
+	})
+
+	rateLimitByIP, rateLimitMiddleware, err := initRateLimit(cfg, redisClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize rate limiting: %w", err)
+	}
+
+	healthHandler := handlers.NewHealthHandler(health.NewChecker(health.Options{
+		Timeout:  cfg.Health.CheckTimeout,
+		CacheTTL: cfg.Health.CacheTTL,
+		Logger:   logger,
+	}, healthChecks(db, redisClient, s3Storage, bus.natsConn)...))
+
+	accessLogMiddleware := middleware.AccessLog(middleware.AccessLogOptions{
+		SkipPaths:    cfg.Log.AccessLogSkipList,
+		Headers:      cfg.Log.HTTPHeaders,
+		Bodies:       cfg.Log.HTTPBodies,
+		MaxBodyBytes: cfg.Log.HTTPBodyLimit,
+	})
 
 	return &Dependencies{
-		TxManager:       txManager,
-		StreamPublisher: streamPublisher,
-		FileStorage:     fileStorage,
-		TodoUseCase:     todoUseCase,
-		FileUseCase:     fileUseCase,
-		TodoHandler:     todoHandler,
-		FileHandler:     fileHandler,
-		DB:              db,
-		RedisClient:     redisClient,
-		Logger:          logger,
+		TxManager:        txManager,
+		StreamPublisher:  bus.publisher,
+		FileStorage:      fileStorage,
+		FileRepo:         fileRepo,
+		StorageUsageRepo: storageUsageRepo,
+		WebhookRepo:      webhookRepo,
+		APIKeyRepo:       apiKeyRepo,
+		WebhookConsumer:  bus.consumer,
+		EventReader:      bus.reader,
+		IdempotencyStore: idempotencyStore,
+		TodoUseCase:      todoUseCase,
+		FileUseCase:      fileUseCase,
+		WebhookUseCase:   webhookUseCase,
+		APIKeyUseCase:    apiKeyUseCase,
+		EventFeed:        eventFeed,
+		SyncUseCase:      syncUseCase,
+		TodoHandler:      todoHandler,
+		FileHandler:      fileHandler,
+		WebhookHandler:   webhookHandler,
+		APIKeyHandler:    apiKeyHandler,
+		EventsHandler:    eventsHandler,
+		SyncHandler:      syncHandler,
+		HealthHandler:    healthHandler,
+		DocsHandler:      handlers.NewDocsHandler(spec),
+		GraphQLHandler:   graphQLHandler,
+		TodoService:      todoService,
+		Authenticator:    authn.NewAuthenticator(verifiers),
+		ValidateRequests: validateRequests,
+		Idempotency:      idempotencyMiddleware,
+		RateLimitByIP:    rateLimitByIP,
+		RateLimit:        rateLimitMiddleware,
+		AccessLog:        accessLogMiddleware,
+		Auth:             middleware.Authenticate(verifiers, middleware.AuthOptions{}),
+		EventsAuth:       middleware.Authenticate(verifiers, middleware.AuthOptions{AllowQueryToken: true}),
+		Metrics:          appMetrics,
+		ShutdownTracing:  shutdownTracing,
+		DB:               db,
+		RedisClient:      redisClient,
+		NATSConn:         bus.natsConn,
+		Logger:           logger,
 	}, nil
 }
 
-func initMySQL(cfg *config.Config, logger *zap.Logger) (*sql.DB, error) {
+// healthChecks lists the dependencies probed by /health and /ready. Without
+// S3 only uploads fail, so it merely degrades the service.
+func healthChecks(db *sql.DB, redisClient *redis.Client, s3Storage *storage.S3FileStorage, natsConn *nats.Conn) []health.Check {
+	checks := []health.Check{
+		{Name: "mysql", Critical: true, Probe: db.PingContext},
+		{Name: "redis", Critical: true, Probe: func(ctx context.Context) error {
+			return redisClient.Ping(ctx).Err()
+		}},
+		{Name: "s3", Probe: s3Storage.Ping},
+	}
+
+	if natsConn != nil {
+		checks = append(checks, health.Check{Name: "nats", Critical: true, Probe: func(ctx context.Context) error {
+			if status := natsConn.Status(); status != nats.CONNECTED {
+				return fmt.Errorf("connection is %s", status)
+			}
+			return nil
+		}})
+	}
+
+	return checks
+}
+
+type eventBus struct {
+	publisher ports.StreamPublisher
+	consumer  ports.StreamConsumer
+	reader    ports.EventReader
+	natsConn  *nats.Conn
+}
+
+func initEventBus(cfg *config.Config, redisClient *redis.Client, logger *zap.Logger) (*eventBus, error) {
+	switch cfg.Stream.Backend {
+	case "redis":
+		return &eventBus{
+			publisher: streams.NewRedisStreamPublisher(redisClient, cfg.Stream.Name),
+			consumer:  streams.NewRedisStreamConsumer(redisClient, cfg.Stream.Name, cfg.Webhooks.ConsumerGroup, consumerName(), logger),
+			reader:    streams.NewRedisStreamReader(redisClient, cfg.Stream.Name),
+		}, nil
+
+	case "memory":
+		bus := streams.NewMemoryStreamPublisher(cfg.Stream.MemoryCapacity)
+		logger.Warn("Using in-memory event stream; events are lost on restart and not shared between instances")
+		return &eventBus{publisher: bus, consumer: bus, reader: bus}, nil
+
+	case "nats":
+		conn, err := nats.Connect(cfg.Stream.NATSURL, nats.Name("todo-service"))
+		if err != nil {
+			return nil, err
+		}
+
+		publisher, err := streams.NewNATSStreamPublisher(conn, cfg.Stream.NATSStream, cfg.Stream.Name)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		consumer, err := streams.NewNATSStreamConsumer(conn, cfg.Stream.NATSStream, cfg.Webhooks.ConsumerGroup, logger)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		reader, err := streams.NewNATSStreamReader(conn, cfg.Stream.NATSStream)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+
+		logger.Info("NATS JetStream connection established",
+			natsURLField(cfg.Stream.NATSURL),
+			zap.String("stream", cfg.Stream.NATSStream))
+
+		return &eventBus{publisher: publisher, consumer: consumer, reader: reader, natsConn: conn}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown stream backend %q", cfg.Stream.Backend)
+	}
+}
+
+// natsURLField logs the NATS servers without their userinfo, which holds
+// the user and password or the token.
+func natsURLField(raw string) zap.Field {
+	servers := strings.Split(raw, ",")
+	for i, server := range servers {
+		parsed, err := url.Parse(strings.TrimSpace(server))
+		if err != nil {
+			servers[i] = "[invalid]"
+			continue
+		}
+		parsed.User = nil
+		servers[i] = parsed.String()
+	}
+	return zap.String("url", strings.Join(servers, ","))
+}
+
+func initTokenVerifier(cfg *config.Config) (*auth.JWTVerifier, error) {
+	options := auth.JWTVerifierOptions{
+		HMACSecret: []byte(cfg.Auth.JWTSecret),
+		Issuer:     cfg.Auth.Issuer,
+		Audience:   cfg.Auth.Audience,
+		Leeway:     cfg.Auth.ClockSkew,
+
+		DefaultTenant: cfg.Auth.DefaultTenant,
+	}
+
+	var err error
+	switch {
+	case cfg.Auth.JWKSURL != "":
+		client := &http.Client{Timeout: 10 * time.Second}
+		options.KeySet, err = auth.NewJWKSURLKeySet(cfg.Auth.JWKSURL, client, cfg.Auth.JWKSRefreshInterval)
+	case cfg.Auth.JWKSFile != "":
+		options.KeySet, err = auth.NewJWKSFileKeySet(cfg.Auth.JWKSFile)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return auth.NewJWTVerifier(options)
+}
+
+// initRateLimit returns the per-IP limiter that runs before authentication
+// and the per-caller one that runs after it. Both share one limiter.
+func initRateLimit(cfg *config.Config, redisClient *redis.Client) (byIP, perCaller gin.HandlerFunc, err error) {
+	if !cfg.RateLimit.Enabled {
+		passThrough := func(c *gin.Context) { c.Next() }
+		return passThrough, passThrough, nil
+	}
+
+	var perIP entities.RateLimit
+	if cfg.RateLimit.PerIP != "" {
+		if perIP, err = entities.ParseRateLimit(cfg.RateLimit.PerIP); err != nil {
+			return nil, nil, fmt.Errorf("per IP: %w", err)
+		}
+	}
+
+	options := middleware.RateLimitOptions{Routes: make(map[string]entities.RateLimit)}
+	if options.Default, err = entities.ParseRateLimit(cfg.RateLimit.Default); err != nil {
+		return nil, nil, err
+	}
+	for route, value := range cfg.RateLimit.Routes {
+		if options.Routes[route], err = entities.ParseRateLimit(value); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", route, err)
+		}
+	}
+
+	limiter := ratelimit.NewFallbackRateLimiter(
+		ratelimit.NewRedisRateLimiter(redisClient),
+		ratelimit.NewMemoryRateLimiter(),
+		cfg.RateLimit.FallbackCooldown,
+	)
+
+	return middleware.RateLimitByIP(limiter, perIP), middleware.RateLimit(limiter, options), nil
+}
+
+// OpenMySQL connects to the configured database and verifies the connection.
+func OpenMySQL(cfg *config.Config, logger *zap.Logger) (*sql.DB, error) {
 	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
 		cfg.DB.User, cfg.DB.Password, cfg.DB.Host, cfg.DB.Port, cfg.DB.Name)
 
@@ -155,9 +646,9 @@ func initMySQL(cfg *config.Config, logger *zap.Logger) (*sql.DB, error) {
 		return nil, err
 	}
 
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(25)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	db.SetMaxOpenConns(cfg.DB.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.DB.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.DB.ConnMaxLifetime)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -174,11 +665,14 @@ func initMySQL(cfg *config.Config, logger *zap.Logger) (*sql.DB, error) {
 	return db, nil
 }
 
-func initRedis(cfg *config.Config, logger *zap.Logger) (*redis.Client, error) {
+// OpenRedis connects to the configured Redis database and verifies the connection.
+func OpenRedis(cfg *config.Config, logger *zap.Logger) (*redis.Client, error) {
 	client := redis.NewClient(&redis.Options{
-		Addr:     fmt.Sprintf("%s:%s", cfg.Redis.Host, cfg.Redis.Port),
-		Password: cfg.Redis.Password,
-		DB:       cfg.Redis.DB,
+		Addr:         fmt.Sprintf("%s:%s", cfg.Redis.Host, cfg.Redis.Port),
+		Password:     cfg.Redis.Password,
+		DB:           cfg.Redis.DB,
+		PoolSize:     cfg.Redis.PoolSize,
+		MinIdleConns: cfg.Redis.MinIdleConns,
 	})
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -196,7 +690,9 @@ func initRedis(cfg *config.Config, logger *zap.Logger) (*redis.Client, error) {
 	return client, nil
 }
 
-func initAWS(cfg *config.Config, logger *zap.Logger) (*session.Session, error) {
+// NewAWSSession configures an AWS session, pointing at the local endpoint
+// when one is set.
+func NewAWSSession(cfg *config.Config, logger *zap.Logger) (*session.Session, error) {
 	awsConfig := &aws.Config{
 		Region: aws.String(cfg.AWS.Region),
 	}
@@ -217,10 +713,12 @@ func initAWS(cfg *config.Config, logger *zap.Logger) (*session.Session, error) {
 	return sess, nil
 }
 
-type HealthStatus struct {
-	Status    string            `json:"status"`
-	Timestamp int64             `json:"timestamp"`
-	Services  map[string]string `json:"services"`
+func consumerName() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		return "todo-service"
+	}
+	return hostname
 }
 
 func setupRoutes(deps *Dependencies) *gin.Engine {
@@ -228,53 +726,71 @@ func setupRoutes(deps *Dependencies) *gin.Engine {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	router := gin.Default()
+	router := gin.New()
+	router.Use(
+		middleware.RequestID(deps.Logger),
+		deps.AccessLog,
+		middleware.Metrics(deps.Metrics),
+		middleware.Tracing(),
+		middleware.Recovery(),
+	)
 
-	router.GET("/health", func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
-		defer cancel()
+	router.GET("/metrics", gin.WrapH(deps.Metrics.Handler()))
 
-		services := make(map[string]string)
-		overallStatus := "healthy"
+	router.GET("/live", deps.HealthHandler.Live)
+	router.GET("/ready", deps.HealthHandler.Ready)
+	router.GET("/health", deps.HealthHandler.Health)
 
-		if err := deps.DB.PingContext(ctx); err != nil {
-			services["mysql"] = "unhealthy: " + err.Error()
-			overallStatus = "unhealthy"
-		} else {
-			services["mysql"] = "healthy"
-		}
+	router.GET("/openapi.json", deps.DocsHandler.OpenAPI)
+	router.GET("/docs", deps.DocsHandler.SwaggerUI)
 
-		if err := deps.RedisClient.Ping(ctx).Err(); err != nil {
-			services["redis"] = "unhealthy: " + err.Error()
-			overallStatus = "unhealthy"
-		} else {
-			services["redis"] = "healthy"
-		}
+	router.POST("/graphql", deps.RateLimitByIP, deps.Auth, deps.RateLimit, deps.ValidateRequests, deps.GraphQLHandler.Serve)
 
-		status := HealthStatus{
-			Status:    overallStatus,
-			Timestamp: time.Now().Unix(),
-			Services:  services,
-		}
+	v1 := router.Group("/api/v1")
 
-		if overallStatus == "healthy" {
-			c.JSON(http.StatusOK, status)
-		} else {
-			c.JSON(http.StatusServiceUnavailable, status)
-		}
-	})
+	readTodos := middleware.RequireScope(entities.ScopeTodosRead)
+	writeTodos := middleware.RequireScope(entities.ScopeTodosWrite)
+	readFiles := middleware.RequireScope(entities.ScopeFilesRead)
+	writeFiles := middleware.RequireScope(entities.ScopeFilesWrite)
+	readWebhooks := middleware.RequireScope(entities.ScopeWebhooksRead)
+	writeWebhooks := middleware.RequireScope(entities.ScopeWebhooksWrite)
 
-	router.GET("/ready", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status":    "ready",
-			"timestamp": time.Now().Unix(),
-		})
-	})
+	if deps.EventsHandler != nil {
+		v1.GET("/todo/events", deps.RateLimitByIP, deps.EventsAuth, deps.RateLimit, deps.ValidateRequests, readTodos, deps.EventsHandler.StreamSSE)
+		v1.GET("/todo/events/ws", deps.RateLimitByIP, deps.EventsAuth, deps.RateLimit, deps.ValidateRequests, readTodos, deps.EventsHandler.StreamWebSocket)
+	}
 
-	v1 := router.Group("/api/v1")
+	api := v1.Group("", deps.RateLimitByIP, deps.Auth, deps.RateLimit, deps.ValidateRequests)
 	{
-		v1.POST("/todo", deps.TodoHandler.CreateTodo)
-		v1.POST("/upload", deps.FileHandler.UploadFile)
+		api.POST("/todo", writeTodos, deps.Idempotency, deps.TodoHandler.CreateTodo)
+		api.GET("/todo", readTodos, deps.TodoHandler.ListTodos)
+		api.GET("/todo/:id", readTodos, deps.TodoHandler.GetTodo)
+		api.PUT("/todo/:id", writeTodos, deps.TodoHandler.UpdateTodo)
+		api.DELETE("/todo/:id", writeTodos, deps.TodoHandler.DeleteTodo)
+		api.POST("/todo/:id/complete", writeTodos, deps.TodoHandler.CompleteTodo)
+		api.POST("/todo/:id/reopen", writeTodos, deps.TodoHandler.ReopenTodo)
+		api.GET("/todo/:id/shares", readTodos, deps.TodoHandler.ListShares)
+		api.POST("/todo/:id/shares", writeTodos, deps.TodoHandler.ShareTodo)
+		api.DELETE("/todo/:id/shares/:user_id", writeTodos, deps.TodoHandler.UnshareTodo)
+		api.POST("/upload", writeFiles, deps.Idempotency, deps.FileHandler.UploadFile)
+		api.GET("/files/:id", readFiles, deps.FileHandler.DownloadFile)
+		api.DELETE("/files/:id", writeFiles, deps.FileHandler.DeleteFile)
+		api.GET("/me/usage", readFiles, deps.FileHandler.GetUsage)
+
+		api.GET("/sync", readTodos, deps.SyncHandler.Pull)
+		api.POST("/sync", writeTodos, deps.SyncHandler.Push)
+
+		api.POST("/webhooks", writeWebhooks, deps.WebhookHandler.CreateWebhook)
+		api.GET("/webhooks", readWebhooks, deps.WebhookHandler.ListWebhooks)
+		api.GET("/webhooks/:id", readWebhooks, deps.WebhookHandler.GetWebhook)
+		api.DELETE("/webhooks/:id", writeWebhooks, deps.WebhookHandler.DeleteWebhook)
+		api.POST("/webhooks/:id/enable", writeWebhooks, deps.WebhookHandler.EnableWebhook)
+		api.GET("/webhooks/:id/deliveries", readWebhooks, deps.WebhookHandler.ListDeliveries)
+
+		keys := api.Group("/api-keys", middleware.RequireAuthMethod(entities.AuthMethodJWT))
+		keys.POST("", deps.APIKeyHandler.CreateAPIKey)
+		keys.GET("", deps.APIKeyHandler.ListAPIKeys)
+		keys.DELETE("/:id", deps.APIKeyHandler.RevokeAPIKey)
 	}
 
 	return router
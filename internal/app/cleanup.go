@@ -0,0 +1,61 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"todo-service/internal/infrastructure/idempotency"
+	"todo-service/internal/infrastructure/ratelimit"
+	"todo-service/internal/infrastructure/repositories"
+	"todo-service/internal/infrastructure/streams"
+)
+
+type CleanupReport struct {
+	TenantID        string
+	Rows            map[string]int64
+	Objects         int
+	Events          int64
+	IdempotencyKeys int64
+	RateLimitKeys   int64
+}
+
+// Cleanup deletes everything stored for a tenant: uploaded objects first,
+// while their paths are still known, then database rows, then Redis state.
+func (a *App) Cleanup(ctx context.Context, tenantID string) (*CleanupReport, error) {
+	report := &CleanupReport{TenantID: tenantID}
+	cleaner := repositories.NewMySQLTenantCleaner(a.deps.DB)
+
+	paths, err := cleaner.StoragePaths(ctx, tenantID)
+	if err != nil {
+		return report, err
+	}
+	for _, path := range paths {
+		if err := a.deps.FileStorage.DeleteFile(ctx, path); err != nil {
+			return report, err
+		}
+		report.Objects++
+	}
+
+	if report.Rows, err = cleaner.DeleteTenant(ctx, tenantID); err != nil {
+		return report, err
+	}
+
+	if a.cfg.Stream.Backend == "redis" {
+		publisher := streams.NewRedisStreamPublisher(a.deps.RedisClient, a.cfg.Stream.Name)
+		if report.Events, err = publisher.DeleteTenantEvents(ctx, tenantID); err != nil {
+			return report, fmt.Errorf("failed to delete stream events: %w", err)
+		}
+	}
+
+	if report.IdempotencyKeys, err = idempotency.NewRedisIdempotencyStore(a.deps.RedisClient).DeleteTenant(ctx, tenantID); err != nil {
+		return report, fmt.Errorf("failed to delete idempotency keys: %w", err)
+	}
+	if report.RateLimitKeys, err = ratelimit.NewRedisRateLimiter(a.deps.RedisClient).DeleteTenant(ctx, tenantID); err != nil {
+		return report, fmt.Errorf("failed to delete rate limit state: %w", err)
+	}
+
+	a.logger.Info("Cleaned up tenant", zap.String("tenant_id", tenantID), zap.Int("objects", report.Objects), zap.Any("rows", report.Rows))
+	return report, nil
+}
@@ -0,0 +1,122 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"todo-service/internal/config"
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+	"todo-service/internal/domain/ports/mocks"
+	"todo-service/internal/infrastructure/idempotency"
+	"todo-service/internal/infrastructure/migrations"
+	"todo-service/internal/infrastructure/ratelimit"
+	"todo-service/internal/infrastructure/repositories"
+	"todo-service/internal/infrastructure/streams"
+)
+
+// TestCleanup_KeepsOtherTenants runs against MYSQL_TEST_DSN, like the
+// repository tests, and an in-memory Redis.
+func TestCleanup_KeepsOtherTenants(t *testing.T) {
+	dsn := os.Getenv("MYSQL_TEST_DSN")
+	if dsn == "" {
+		t.Skip("MYSQL_TEST_DSN is not set")
+	}
+	ctx := context.Background()
+
+	db, err := sql.Open("mysql", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	migrator, err := migrations.NewMigrator(db, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, migrator.Up(ctx))
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	suffix := uuid.NewString()[:8]
+	doomed, kept := "test-"+suffix+"-a", "test-"+suffix+"-b"
+	t.Cleanup(func() {
+		_, err := repositories.NewMySQLTenantCleaner(db).DeleteTenant(context.Background(), kept)
+		assert.NoError(t, err)
+	})
+
+	txManager := repositories.NewMySQLTransactionManager(db)
+	fileRepo := repositories.NewMySQLFileRepository(db)
+	publisher := streams.NewRedisStreamPublisher(client, "todo-events")
+	todos := make(map[string]*entities.TodoItem)
+	files := make(map[string]*entities.File)
+
+	for _, tenantID := range []string{doomed, kept} {
+		todo := entities.NewTodoItem(tenantID, contractUserID, "Todo of "+tenantID, time.Now().Add(time.Hour), nil)
+		require.NoError(t, txManager.DoInTx(ctx, func(repo ports.TodoRepository) error {
+			return repo.Create(ctx, todo)
+		}))
+		todos[tenantID] = todo
+
+		file := entities.NewFile(tenantID, contractUserID, "notes.txt", "text/plain", 5)
+		require.NoError(t, fileRepo.Create(ctx, file))
+		files[tenantID] = file
+
+		event, err := entities.NewTodoEvent(entities.EventTypeTodoCreated, todo)
+		require.NoError(t, err)
+		require.NoError(t, publisher.Publish(ctx, event))
+
+		_, err = idempotency.NewRedisIdempotencyStore(client).Begin(ctx, entities.NewIdempotencyRecord(tenantID+"/"+contractUserID+":POST /todo:k", "fp"), time.Minute)
+		require.NoError(t, err)
+		_, err = ratelimit.NewRedisRateLimiter(client).Allow(ctx, "user:"+tenantID+"/"+contractUserID, entities.RateLimit{Limit: 10, Period: time.Minute})
+		require.NoError(t, err)
+	}
+
+	// Only the cleaned-up tenant's object is deleted from storage.
+	fileStorage := mocks.NewMockFileStorage(t)
+	fileStorage.EXPECT().DeleteFile(mock.Anything, files[doomed].StoragePath).Return(nil).Once()
+
+	application := &App{
+		cfg:    &config.Config{Stream: config.StreamConfig{Backend: "redis", Name: "todo-events"}},
+		deps:   &Dependencies{DB: db, RedisClient: client, FileStorage: fileStorage},
+		logger: zap.NewNop(),
+	}
+
+	report, err := application.Cleanup(ctx, doomed)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Objects)
+	assert.Equal(t, int64(1), report.Rows["todos"])
+	assert.Equal(t, int64(1), report.Rows["files"])
+	assert.Equal(t, int64(1), report.Events)
+	assert.Equal(t, int64(1), report.IdempotencyKeys)
+	assert.Equal(t, int64(1), report.RateLimitKeys)
+
+	require.NoError(t, txManager.DoInTx(ctx, func(repo ports.TodoRepository) error {
+		_, err := repo.GetByID(ctx, doomed, todos[doomed].ID)
+		assert.ErrorIs(t, err, entities.ErrTodoNotFound)
+		_, err = repo.GetByID(ctx, kept, todos[kept].ID)
+		assert.NoError(t, err)
+		return nil
+	}))
+	_, err = fileRepo.GetByID(ctx, kept, contractUserID, files[kept].ID)
+	assert.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{
+		"idempotency:" + kept + "/" + contractUserID + ":POST /todo:k",
+		"ratelimit:user:" + kept + "/" + contractUserID,
+		"todo-events",
+	}, server.Keys())
+
+	entries, err := client.XRange(ctx, "todo-events", "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, kept, entries[0].Values["tenant_id"])
+}
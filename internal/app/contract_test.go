@@ -0,0 +1,499 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+	"todo-service/internal/domain/ports/mocks"
+	"todo-service/internal/health"
+	"todo-service/internal/infrastructure/metrics"
+	"todo-service/internal/interfaces/graphql/resolvers"
+	"todo-service/internal/interfaces/http/handlers"
+	"todo-service/internal/interfaces/http/middleware"
+	"todo-service/internal/interfaces/http/openapi"
+	"todo-service/internal/usecases"
+)
+
+const (
+	contractTenantID = "acme"
+	contractUserID   = "user-1"
+)
+
+type contractMocks struct {
+	txManager   *mocks.MockTransactionManager
+	publisher   *mocks.MockStreamPublisher
+	fileStorage *mocks.MockFileStorage
+	fileRepo    *mocks.MockFileRepository
+	usageRepo   *mocks.MockStorageUsageRepository
+	webhookRepo *mocks.MockWebhookRepository
+	apiKeyRepo  *mocks.MockAPIKeyRepository
+	eventReader *mocks.MockEventReader
+}
+
+// newContractRouter wires the real routes and handlers on top of mocked
+// ports, with authentication replaced by a fixed JWT principal.
+func newContractRouter(t *testing.T, spec *openapi.Spec) (*gin.Engine, *contractMocks) {
+	t.Helper()
+
+	deps, m := newContractDependencies(t, spec)
+	return setupRoutes(deps), m
+}
+
+// newContractDependencies returns what newContractRouter routes, for tests
+// that swap some of it before calling setupRoutes.
+func newContractDependencies(t *testing.T, spec *openapi.Spec) (*Dependencies, *contractMocks) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	m := &contractMocks{
+		txManager:   mocks.NewMockTransactionManager(t),
+		publisher:   mocks.NewMockStreamPublisher(t),
+		fileStorage: mocks.NewMockFileStorage(t),
+		fileRepo:    mocks.NewMockFileRepository(t),
+		usageRepo:   mocks.NewMockStorageUsageRepository(t),
+		webhookRepo: mocks.NewMockWebhookRepository(t),
+		apiKeyRepo:  mocks.NewMockAPIKeyRepository(t),
+		eventReader: mocks.NewMockEventReader(t),
+	}
+
+	authenticate := func(c *gin.Context) {
+		principal := &entities.Principal{ID: contractUserID, TenantID: contractTenantID, Method: entities.AuthMethodJWT}
+		c.Request = c.Request.WithContext(entities.ContextWithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+	passThrough := func(c *gin.Context) { c.Next() }
+
+	todoUseCase := usecases.NewTodoUseCase(m.txManager, m.publisher, m.fileRepo, entities.DefaultTodoRules(), nil)
+	fileUseCase := usecases.NewFileUseCase(m.fileStorage, m.fileRepo, m.usageRepo, entities.StorageQuota{MaxBytes: 1 << 20}, nil)
+	eventFeed := usecases.NewEventFeedUseCase(m.eventReader)
+	graphQLSchema, err := resolvers.NewSchema(todoUseCase, fileUseCase, eventFeed, resolvers.Options{})
+	require.NoError(t, err)
+	healthHandler := handlers.NewHealthHandler(health.NewChecker(health.Options{Timeout: time.Second}))
+	healthHandler.SetReady(true)
+
+	deps := &Dependencies{
+		TodoHandler:      handlers.NewTodoHandler(todoUseCase, handlers.TodoHandlerOptions{}),
+		FileHandler:      handlers.NewFileHandler(fileUseCase),
+		WebhookHandler:   handlers.NewWebhookHandler(usecases.NewWebhookUseCase(m.webhookRepo, nil, usecases.WebhookRetryPolicy{})),
+		APIKeyHandler:    handlers.NewAPIKeyHandler(usecases.NewAPIKeyUseCase(m.apiKeyRepo)),
+		SyncHandler:      handlers.NewSyncHandler(usecases.NewSyncUseCase(m.txManager, m.publisher, m.fileRepo, entities.DefaultTodoRules(), nil)),
+		EventsHandler:    handlers.NewLiveEventsHandler(eventFeed, handlers.LiveEventsOptions{}),
+		GraphQLHandler:   handlers.NewGraphQLHandler(graphQLSchema, handlers.GraphQLOptions{MaxStreams: 1, HeartbeatInterval: time.Minute}),
+		HealthHandler:    healthHandler,
+		DocsHandler:      handlers.NewDocsHandler(spec),
+		ValidateRequests: middleware.ValidateRequests(spec),
+		Idempotency:      passThrough,
+		RateLimitByIP:    passThrough,
+		RateLimit:        passThrough,
+		AccessLog:        passThrough,
+		Auth:             authenticate,
+		EventsAuth:       authenticate,
+		Metrics:          metrics.New(),
+		Logger:           zap.NewNop(),
+	}
+
+	return deps, m
+}
+
+func expectTx(t *testing.T, txManager *mocks.MockTransactionManager, setup func(repo *mocks.MockTodoRepository)) {
+	txManager.EXPECT().DoInTx(mock.Anything, mock.AnythingOfType("func(ports.TodoRepository) error")).
+		RunAndReturn(func(ctx context.Context, fn func(repo ports.TodoRepository) error) error {
+			repo := mocks.NewMockTodoRepository(t)
+			setup(repo)
+			return fn(repo)
+		}).Once()
+}
+
+func contractTodo() *entities.TodoItem {
+	fileID := "2d3a4b5c-0000-4000-8000-000000000001"
+	return entities.NewTodoItem(contractTenantID, contractUserID, "Write the contract test", time.Date(2030, 1, 2, 15, 0, 0, 0, time.UTC), &fileID)
+}
+
+func uploadBody(t *testing.T) (io.Reader, string) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	part, err := writer.CreateFormFile("file", "notes.txt")
+	require.NoError(t, err)
+	part.Write([]byte("hello world"))
+	require.NoError(t, writer.Close())
+	return &buf, writer.FormDataContentType()
+}
+
+// TestRoutesMatchSpec fails when a route is added without documenting it or
+// an operation is documented that no route serves.
+func TestRoutesMatchSpec(t *testing.T) {
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+	router, _ := newContractRouter(t, spec)
+
+	served := make(map[string]bool)
+	for _, route := range router.Routes() {
+		key := route.Method + " " + openapi.PathFromRoute(route.Path)
+		served[key] = true
+		assert.NotNil(t, spec.Operation(route.Method, openapi.PathFromRoute(route.Path)), "route %s is not documented", key)
+	}
+
+	for _, operation := range spec.Operations() {
+		key := operation.Method + " " + operation.Path
+		assert.True(t, served[key], "documented operation %s (%s) has no route", key, operation.ID)
+	}
+}
+
+// TestResponsesMatchSpec runs representative requests through the real
+// handlers and checks every response against the document.
+func TestResponsesMatchSpec(t *testing.T) {
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+
+	todo := contractTodo()
+	todoPath := "/api/v1/todo/" + todo.ID.String()
+	file := entities.NewFile(contractTenantID, contractUserID, "notes.txt", "text/plain", 11)
+
+	tests := []struct {
+		name      string
+		method    string
+		target    string
+		operation string
+		body      func(t *testing.T) (io.Reader, string)
+		setup     func(t *testing.T, m *contractMocks)
+		status    int
+	}{
+		{
+			name:      "create todo",
+			method:    http.MethodPost,
+			target:    "/api/v1/todo",
+			operation: "/api/v1/todo",
+			body: func(t *testing.T) (io.Reader, string) {
+				return strings.NewReader(`{"description":"Write the contract test","due_date":"2030-01-02T15:00:00Z"}`), "application/json"
+			},
+			setup: func(t *testing.T, m *contractMocks) {
+				expectTx(t, m.txManager, func(repo *mocks.MockTodoRepository) {
+					repo.EXPECT().Create(mock.Anything, mock.Anything).Return(nil)
+				})
+				m.publisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil)
+			},
+			status: http.StatusCreated,
+		},
+		{
+			name:      "create todo with invalid body",
+			method:    http.MethodPost,
+			target:    "/api/v1/todo",
+			operation: "/api/v1/todo",
+			body: func(t *testing.T) (io.Reader, string) {
+				return strings.NewReader(`{"description":"","due_date":"soon"}`), "application/json"
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "create todo breaking the domain rules",
+			method:    http.MethodPost,
+			target:    "/api/v1/todo",
+			operation: "/api/v1/todo",
+			body: func(t *testing.T) (io.Reader, string) {
+				return strings.NewReader(`{"description":"  ","due_date":"1970-01-01T00:00:00+01:00"}`), "application/json"
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "create todo while the database is down",
+			method:    http.MethodPost,
+			target:    "/api/v1/todo",
+			operation: "/api/v1/todo",
+			body: func(t *testing.T) (io.Reader, string) {
+				return strings.NewReader(`{"description":"Write the contract test","due_date":"2030-01-02T15:00:00Z"}`), "application/json"
+			},
+			setup: func(t *testing.T, m *contractMocks) {
+				m.txManager.EXPECT().DoInTx(mock.Anything, mock.Anything).Return(assert.AnError)
+			},
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:      "create todo as form",
+			method:    http.MethodPost,
+			target:    "/api/v1/todo",
+			operation: "/api/v1/todo",
+			body: func(t *testing.T) (io.Reader, string) {
+				return strings.NewReader(`description=x`), "application/x-www-form-urlencoded"
+			},
+			status: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:      "create todo too large",
+			method:    http.MethodPost,
+			target:    "/api/v1/todo",
+			operation: "/api/v1/todo",
+			body: func(t *testing.T) (io.Reader, string) {
+				return strings.NewReader(`{"description":"` + strings.Repeat("a", openapi.DefaultMaxBodyBytes) + `"}`), "application/json"
+			},
+			status: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:      "list todos",
+			method:    http.MethodGet,
+			target:    "/api/v1/todo?limit=10",
+			operation: "/api/v1/todo",
+			setup: func(t *testing.T, m *contractMocks) {
+				expectTx(t, m.txManager, func(repo *mocks.MockTodoRepository) {
+					repo.EXPECT().List(mock.Anything, contractTenantID, contractUserID, 10, 0).Return([]*entities.TodoItem{todo}, nil)
+				})
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "get todo",
+			method:    http.MethodGet,
+			target:    todoPath,
+			operation: "/api/v1/todo/{id}",
+			setup: func(t *testing.T, m *contractMocks) {
+				expectTx(t, m.txManager, func(repo *mocks.MockTodoRepository) {
+					repo.EXPECT().GetByID(mock.Anything, contractTenantID, todo.ID).Return(todo, nil)
+				})
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "get missing todo",
+			method:    http.MethodGet,
+			target:    todoPath,
+			operation: "/api/v1/todo/{id}",
+			setup: func(t *testing.T, m *contractMocks) {
+				expectTx(t, m.txManager, func(repo *mocks.MockTodoRepository) {
+					repo.EXPECT().GetByID(mock.Anything, contractTenantID, todo.ID).Return(nil, entities.ErrTodoNotFound)
+				})
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:      "complete todo",
+			method:    http.MethodPost,
+			target:    todoPath + "/complete",
+			operation: "/api/v1/todo/{id}/complete",
+			setup: func(t *testing.T, m *contractMocks) {
+				open := contractTodo()
+				expectTx(t, m.txManager, func(repo *mocks.MockTodoRepository) {
+					repo.EXPECT().GetByIDForUpdate(mock.Anything, contractTenantID, todo.ID).Return(open, nil)
+					repo.EXPECT().Update(mock.Anything, open).Return(nil)
+					repo.EXPECT().ListGrants(mock.Anything, contractTenantID, open.ID).Return(nil, nil)
+				})
+				m.publisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "list shares",
+			method:    http.MethodGet,
+			target:    todoPath + "/shares",
+			operation: "/api/v1/todo/{id}/shares",
+			setup: func(t *testing.T, m *contractMocks) {
+				grant, err := entities.NewTodoGrant(todo, "user-2", entities.RoleViewer, contractUserID)
+				require.NoError(t, err)
+				expectTx(t, m.txManager, func(repo *mocks.MockTodoRepository) {
+					repo.EXPECT().GetByID(mock.Anything, contractTenantID, todo.ID).Return(todo, nil)
+					repo.EXPECT().ListGrants(mock.Anything, contractTenantID, todo.ID).Return([]*entities.TodoGrant{grant}, nil)
+				})
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "upload file",
+			method:    http.MethodPost,
+			target:    "/api/v1/upload",
+			operation: "/api/v1/upload",
+			body:      uploadBody,
+			setup: func(t *testing.T, m *contractMocks) {
+				m.usageRepo.EXPECT().Reserve(mock.Anything, contractTenantID, contractUserID, int64(11), mock.Anything).Return(nil)
+				m.fileStorage.EXPECT().UploadFile(mock.Anything, mock.Anything, mock.Anything, mock.Anything, int64(11)).Return(nil)
+				m.fileRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(nil)
+			},
+			status: http.StatusCreated,
+		},
+		{
+			name:      "upload file while storage is down",
+			method:    http.MethodPost,
+			target:    "/api/v1/upload",
+			operation: "/api/v1/upload",
+			body:      uploadBody,
+			setup: func(t *testing.T, m *contractMocks) {
+				m.usageRepo.EXPECT().Reserve(mock.Anything, contractTenantID, contractUserID, int64(11), mock.Anything).Return(nil)
+				m.fileStorage.EXPECT().UploadFile(mock.Anything, mock.Anything, mock.Anything, mock.Anything, int64(11)).Return(assert.AnError)
+				m.usageRepo.EXPECT().Release(mock.Anything, contractTenantID, contractUserID, int64(11)).Return(nil)
+			},
+			status: http.StatusServiceUnavailable,
+		},
+		{
+			name:      "download file",
+			method:    http.MethodGet,
+			target:    "/api/v1/files/" + file.ID.String(),
+			operation: "/api/v1/files/{id}",
+			setup: func(t *testing.T, m *contractMocks) {
+				m.fileRepo.EXPECT().GetByID(mock.Anything, contractTenantID, contractUserID, file.ID).Return(file, nil)
+				m.fileStorage.EXPECT().DownloadFile(mock.Anything, file.StoragePath).Return(io.NopCloser(strings.NewReader("hello world")), nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "storage usage",
+			method:    http.MethodGet,
+			target:    "/api/v1/me/usage",
+			operation: "/api/v1/me/usage",
+			setup: func(t *testing.T, m *contractMocks) {
+				m.usageRepo.EXPECT().Get(mock.Anything, contractTenantID, contractUserID).
+					Return(&entities.StorageUsage{TenantID: contractTenantID, OwnerID: contractUserID, Bytes: 11, Files: 1}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "pull changes",
+			method:    http.MethodGet,
+			target:    "/api/v1/sync",
+			operation: "/api/v1/sync",
+			setup: func(t *testing.T, m *contractMocks) {
+				expectTx(t, m.txManager, func(repo *mocks.MockTodoRepository) {
+					repo.EXPECT().ListChanges(mock.Anything, contractTenantID, contractUserID, int64(0), mock.Anything).Return([]*entities.TodoItem{todo}, nil)
+				})
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "push too many changes",
+			method:    http.MethodPost,
+			target:    "/api/v1/sync",
+			operation: "/api/v1/sync",
+			body: func(t *testing.T) (io.Reader, string) {
+				change := `{"op":"delete","id":"` + todo.ID.String() + `"}`
+				return strings.NewReader(`{"changes":[` + strings.TrimSuffix(strings.Repeat(change+",", 101), ",") + `]}`), "application/json"
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "list webhooks",
+			method:    http.MethodGet,
+			target:    "/api/v1/webhooks",
+			operation: "/api/v1/webhooks",
+			setup: func(t *testing.T, m *contractMocks) {
+				m.webhookRepo.EXPECT().List(mock.Anything, contractTenantID, contractUserID).Return(nil, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "webhook deliveries with invalid id",
+			method:    http.MethodGet,
+			target:    "/api/v1/webhooks/not-a-uuid/deliveries",
+			operation: "/api/v1/webhooks/{id}/deliveries",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "list api keys",
+			method:    http.MethodGet,
+			target:    "/api/v1/api-keys",
+			operation: "/api/v1/api-keys",
+			setup: func(t *testing.T, m *contractMocks) {
+				m.apiKeyRepo.EXPECT().List(mock.Anything, contractTenantID, contractUserID).Return(nil, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "graphql query",
+			method:    http.MethodPost,
+			target:    "/graphql",
+			operation: "/graphql",
+			body: func(t *testing.T) (io.Reader, string) {
+				return strings.NewReader(`{"query":"{ todo(id: \"` + todo.ID.String() + `\") { id description } }"}`), "application/json"
+			},
+			setup: func(t *testing.T, m *contractMocks) {
+				expectTx(t, m.txManager, func(repo *mocks.MockTodoRepository) {
+					repo.EXPECT().GetByID(mock.Anything, contractTenantID, todo.ID).Return(nil, entities.ErrPermissionDenied)
+				})
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "graphql without query",
+			method:    http.MethodPost,
+			target:    "/graphql",
+			operation: "/graphql",
+			body: func(t *testing.T) (io.Reader, string) {
+				return strings.NewReader(`{"variables":{}}`), "application/json"
+			},
+			status: http.StatusBadRequest,
+		},
+		{name: "liveness", method: http.MethodGet, target: "/live", operation: "/live", status: http.StatusOK},
+		{name: "health", method: http.MethodGet, target: "/health", operation: "/health", status: http.StatusOK},
+		{name: "document", method: http.MethodGet, target: "/openapi.json", operation: "/openapi.json", status: http.StatusOK},
+		{name: "swagger ui", method: http.MethodGet, target: "/docs", operation: "/docs", status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, m := newContractRouter(t, spec)
+			if tt.setup != nil {
+				tt.setup(t, m)
+			}
+
+			var body io.Reader
+			var contentType string
+			if tt.body != nil {
+				body, contentType = tt.body(t)
+			}
+			req := httptest.NewRequest(tt.method, tt.target, body)
+			if contentType != "" {
+				req.Header.Set("Content-Type", contentType)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			require.Equal(t, tt.status, recorder.Code, recorder.Body.String())
+			assert.NotContains(t, recorder.Body.String(), assert.AnError.Error())
+
+			operation := spec.Operation(tt.method, tt.operation)
+			require.NotNil(t, operation)
+			assert.NoError(t, spec.ValidateResponse(operation, recorder.Code, recorder.Header(), recorder.Body.Bytes()))
+		})
+	}
+}
+
+// The schema check and the domain rules must name the same fields alike, so
+// clients can map a violation to an input whichever of them found it.
+func TestViolationsNameFieldsAlike(t *testing.T) {
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+	router, _ := newContractRouter(t, spec)
+
+	fields := func(body string) []string {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/todo", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusBadRequest, recorder.Code, recorder.Body.String())
+
+		var problem struct {
+			Errors []entities.FieldViolation `json:"errors"`
+		}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+		var fields []string
+		for _, violation := range problem.Errors {
+			fields = append(fields, violation.Field)
+		}
+		return fields
+	}
+
+	want := []string{"description", "due_date"}
+	assert.ElementsMatch(t, want, fields(`{"description":"","due_date":"soon"}`), "schema check")
+	assert.ElementsMatch(t, want, fields(`{"description":"  ","due_date":"1970-01-01T00:00:00Z"}`), "domain rules")
+}
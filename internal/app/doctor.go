@@ -0,0 +1,164 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"go.uber.org/zap"
+
+	"todo-service/internal/config"
+	"todo-service/internal/infrastructure/migrations"
+)
+
+// Diagnosis is the outcome of one doctor check. Hint tells the operator what
+// to change when the check fails.
+type Diagnosis struct {
+	Name   string
+	Detail string
+	Err    error
+	Hint   string
+}
+
+func (d Diagnosis) OK() bool {
+	return d.Err == nil
+}
+
+// Doctor checks connectivity to MySQL, Redis and S3 and whether the schema
+// is up to date. It does not need a working App, so it can explain why New
+// fails.
+func Doctor(ctx context.Context, cfg *config.Config) []Diagnosis {
+	logger := zap.NewNop()
+	var results []Diagnosis
+
+	mysqlCheck := Diagnosis{Name: "mysql", Detail: fmt.Sprintf("%s@%s:%s/%s", cfg.DB.User, cfg.DB.Host, cfg.DB.Port, cfg.DB.Name)}
+	db, err := OpenMySQL(cfg, logger)
+	if err != nil {
+		mysqlCheck.Err, mysqlCheck.Hint = err, mysqlHint(err)
+	}
+	results = append(results, mysqlCheck)
+
+	if db != nil {
+		results = append(results, diagnoseMigrations(ctx, db))
+		db.Close()
+	}
+
+	redisCheck := Diagnosis{Name: "redis", Detail: fmt.Sprintf("%s:%s/%d", cfg.Redis.Host, cfg.Redis.Port, cfg.Redis.DB)}
+	redisClient, err := OpenRedis(cfg, logger)
+	if err != nil {
+		redisCheck.Err, redisCheck.Hint = err, redisHint(err)
+	} else {
+		redisClient.Close()
+	}
+	results = append(results, redisCheck)
+
+	return append(results, diagnoseS3(ctx, cfg, logger))
+}
+
+func diagnoseMigrations(ctx context.Context, db *sql.DB) Diagnosis {
+	check := Diagnosis{Name: "migrations"}
+
+	migrator, err := migrations.NewMigrator(db, zap.NewNop())
+	if err != nil {
+		check.Err = err
+		return check
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		check.Err, check.Hint = err, "check that the MySQL user may create and read the schema_migrations table"
+		return check
+	}
+
+	var pending []string
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending = append(pending, fmt.Sprintf("%03d_%s", status.Version, status.Name))
+		}
+	}
+	check.Detail = fmt.Sprintf("latest version %d", migrator.Latest())
+	if len(pending) > 0 {
+		check.Err = fmt.Errorf("%d pending: %s", len(pending), strings.Join(pending, ", "))
+		check.Hint = "run `migrate up`, or `migrate baseline <version>` if the schema was created by the old init scripts"
+	}
+	return check
+}
+
+func diagnoseS3(ctx context.Context, cfg *config.Config, logger *zap.Logger) Diagnosis {
+	check := Diagnosis{Name: "s3", Detail: fmt.Sprintf("s3://%s (%s)", cfg.AWS.S3Bucket, cfg.AWS.Region)}
+	if cfg.AWS.Endpoint != "" {
+		check.Detail += " via " + cfg.AWS.Endpoint
+	}
+
+	sess, err := NewAWSSession(cfg, logger)
+	if err != nil {
+		check.Err, check.Hint = err, "check AWS_REGION and AWS_ENDPOINT_URL"
+		return check
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err = s3.New(sess).HeadBucketWithContext(ctx, &s3.HeadBucketInput{Bucket: aws.String(cfg.AWS.S3Bucket)})
+	if err != nil {
+		check.Err, check.Hint = err, s3Hint(err)
+	}
+	return check
+}
+
+func mysqlHint(err error) string {
+	message := err.Error()
+	switch {
+	case strings.Contains(message, "Error 1045"):
+		return "access denied: check DB_USER and DB_PASSWORD (or DB_PASSWORD_FILE)"
+	case strings.Contains(message, "Error 1049"):
+		return "the database does not exist: create it or fix DB_NAME"
+	case strings.Contains(message, "connection refused"):
+		return "nothing is listening: start MySQL (docker-compose up -d mysql) or fix DB_HOST and DB_PORT"
+	case strings.Contains(message, "no such host"):
+		return "DB_HOST does not resolve: use localhost outside Docker Compose"
+	case errors.Is(err, context.DeadlineExceeded) || strings.Contains(message, "i/o timeout"):
+		return "the connection timed out: check DB_HOST, DB_PORT and firewall rules"
+	default:
+		return "check the DB_* settings"
+	}
+}
+
+func redisHint(err error) string {
+	message := err.Error()
+	switch {
+	case strings.Contains(message, "NOAUTH"), strings.Contains(message, "WRONGPASS"), strings.Contains(message, "invalid password"):
+		return "authentication failed: check REDIS_PASSWORD (or REDIS_PASSWORD_FILE)"
+	case strings.Contains(message, "DB index is out of range"):
+		return "REDIS_DB is higher than the server's configured number of databases"
+	case strings.Contains(message, "connection refused"):
+		return "nothing is listening: start Redis (docker-compose up -d redis) or fix REDIS_HOST and REDIS_PORT"
+	case strings.Contains(message, "no such host"):
+		return "REDIS_HOST does not resolve: use localhost outside Docker Compose"
+	default:
+		return "check the REDIS_* settings"
+	}
+}
+
+func s3Hint(err error) string {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		switch awsErr.Code() {
+		case "NotFound", s3.ErrCodeNoSuchBucket:
+			return "the bucket does not exist: create it or fix S3_BUCKET (the server creates it on start)"
+		case "Forbidden", "AccessDenied", "InvalidAccessKeyId", "SignatureDoesNotMatch":
+			return "access denied: check the AWS credentials and the bucket policy"
+		case "NoCredentialProviders":
+			return "no AWS credentials found: set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY or an instance role"
+		case "RequestError", "RequestCanceled":
+			return "S3 is unreachable: check AWS_ENDPOINT_URL (LocalStack runs on http://localhost:4566)"
+		}
+	}
+	return "check AWS_ENDPOINT_URL, AWS_REGION and S3_BUCKET"
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/config"
+)
+
+// closedPort returns a local port nothing listens on.
+func closedPort(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	require.NoError(t, listener.Close())
+	return port
+}
+
+func TestDoctor_ExplainsFailures(t *testing.T) {
+	redisServer := miniredis.RunT(t)
+	s3Server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(s3Server.Close)
+
+	cfg := &config.Config{
+		DB:    config.DatabaseConfig{Host: "127.0.0.1", Port: closedPort(t), User: "todo", Name: "todos", MaxOpenConns: 1},
+		Redis: config.RedisConfig{Host: redisServer.Host(), Port: redisServer.Port()},
+		AWS:   config.AWSConfig{Region: "us-east-1", S3Bucket: "todo-files", Endpoint: s3Server.URL},
+	}
+
+	diagnoses := Doctor(context.Background(), cfg)
+
+	// Without a database connection the migrations cannot be checked.
+	names := make([]string, len(diagnoses))
+	for i, diagnosis := range diagnoses {
+		names[i] = diagnosis.Name
+	}
+	require.Equal(t, []string{"mysql", "redis", "s3"}, names)
+
+	mysqlCheck, redisCheck, s3Check := diagnoses[0], diagnoses[1], diagnoses[2]
+	assert.False(t, mysqlCheck.OK())
+	assert.Contains(t, mysqlCheck.Hint, "nothing is listening")
+	assert.Equal(t, "todo@127.0.0.1:"+cfg.DB.Port+"/todos", mysqlCheck.Detail)
+
+	assert.True(t, redisCheck.OK(), "%v", redisCheck.Err)
+
+	assert.False(t, s3Check.OK())
+	assert.Contains(t, s3Check.Hint, "the bucket does not exist")
+	assert.Equal(t, "s3://todo-files (us-east-1) via "+s3Server.URL, s3Check.Detail)
+}
+
+func TestDoctorHints(t *testing.T) {
+	tests := []struct {
+		name string
+		hint string
+		want string
+	}{
+		{"mysql access denied", mysqlHint(errors.New("Error 1045 (28000): Access denied for user 'todo'@'%'")), "check DB_USER and DB_PASSWORD"},
+		{"mysql unknown database", mysqlHint(errors.New("Error 1049 (42000): Unknown database 'todos'")), "the database does not exist"},
+		{"mysql unknown host", mysqlHint(errors.New("dial tcp: lookup mysql: no such host")), "DB_HOST does not resolve"},
+		{"mysql timeout", mysqlHint(context.DeadlineExceeded), "the connection timed out"},
+		{"mysql other", mysqlHint(errors.New("bad connection")), "check the DB_* settings"},
+		{"redis wrong password", redisHint(errors.New("WRONGPASS invalid username-password pair")), "check REDIS_PASSWORD"},
+		{"redis db out of range", redisHint(errors.New("ERR DB index is out of range")), "REDIS_DB is higher"},
+		{"redis refused", redisHint(errors.New("dial tcp 127.0.0.1:6379: connect: connection refused")), "nothing is listening"},
+		{"s3 other", s3Hint(errors.New("boom")), "check AWS_ENDPOINT_URL, AWS_REGION and S3_BUCKET"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Contains(t, tt.hint, tt.want)
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+	"todo-service/internal/domain/ports/mocks"
+	"todo-service/internal/interfaces/graphql/resolvers"
+	"todo-service/internal/interfaces/http/openapi"
+	"todo-service/internal/usecases"
+)
+
+type graphQLResult struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string            `json:"message"`
+		Extensions map[string]string `json:"extensions"`
+	} `json:"errors"`
+}
+
+func newGraphQLRouter(t *testing.T) (*gin.Engine, *contractMocks) {
+	t.Helper()
+
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+	return newContractRouter(t, spec)
+}
+
+func postGraphQL(t *testing.T, router *gin.Engine, query string, variables map[string]interface{}) graphQLResult {
+	t.Helper()
+
+	body, err := json.Marshal(resolvers.Request{Query: query, Variables: variables})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var result graphQLResult
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+	return result
+}
+
+func TestGraphQL_BehindAuthAndRateLimits(t *testing.T) {
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+
+	for _, guard := range []string{"RateLimitByIP", "Auth", "RateLimit"} {
+		t.Run(guard, func(t *testing.T) {
+			deps, _ := newContractDependencies(t, spec)
+			reject := func(c *gin.Context) { c.AbortWithStatus(http.StatusTeapot) }
+			switch guard {
+			case "RateLimitByIP":
+				deps.RateLimitByIP = reject
+			case "Auth":
+				deps.Auth = reject
+			case "RateLimit":
+				deps.RateLimit = reject
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"{ todoCounts { total } }"}`))
+			req.Header.Set("Content-Type", "application/json")
+			recorder := httptest.NewRecorder()
+			setupRoutes(deps).ServeHTTP(recorder, req)
+			assert.Equal(t, http.StatusTeapot, recorder.Code)
+		})
+	}
+}
+
+func TestGraphQL_TodosLoadsAttachmentsInOneBatch(t *testing.T) {
+	router, m := newGraphQLRouter(t)
+
+	var todos []*entities.TodoItem
+	var files []*entities.File
+	for i := 0; i < 3; i++ {
+		file := entities.NewFile(contractTenantID, "user-2", "notes.txt", "text/plain", 11)
+		fileID := file.ID.String()
+		todos = append(todos, entities.NewTodoItem(contractTenantID, contractUserID, "Todo", time.Now().Add(time.Hour), &fileID))
+		files = append(files, file)
+	}
+	after, err := entities.DecodeTodoCursor(entities.NewTodoCursor(todos[0]).Encode())
+	require.NoError(t, err)
+
+	// Both root fields run concurrently, each in its own transaction.
+	repo := mocks.NewMockTodoRepository(t)
+	repo.EXPECT().Search(mock.Anything, contractTenantID, contractUserID, entities.TodoFilter{Status: entities.TodoStatusOpen, Search: "odo"}, &after, 3, mock.Anything).
+		Return(todos, nil).Once()
+	repo.EXPECT().CountByStatus(mock.Anything, contractTenantID, contractUserID, mock.Anything).
+		Return(&entities.TodoCounts{Total: 5, Open: 3, Completed: 2, Overdue: 1}, nil).Once()
+	m.txManager.EXPECT().DoInTx(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(repo ports.TodoRepository) error) error {
+			return fn(repo)
+		}).Twice()
+
+	m.fileRepo.EXPECT().ListByIDs(mock.Anything, contractTenantID, contractUserID, mock.MatchedBy(func(ids []uuid.UUID) bool {
+		return assert.ElementsMatch(t, []uuid.UUID{files[0].ID, files[1].ID}, ids)
+	})).Return(files[:2], nil).Once()
+
+	result := postGraphQL(t, router, `query($after: String) {
+		todos(first: 2, after: $after, filter: {status: OPEN, search: "odo"}) {
+			edges { cursor node { id attachment { id fileName size } } }
+			pageInfo { hasNextPage endCursor }
+		}
+		todoCounts { total open completed overdue }
+	}`, map[string]interface{}{"after": after.Encode()})
+	require.Empty(t, result.Errors)
+
+	var data struct {
+		Todos struct {
+			Edges []struct {
+				Cursor string
+				Node   struct {
+					ID         string
+					Attachment *struct {
+						ID       string
+						FileName string
+						Size     int
+					}
+				}
+			}
+			PageInfo struct {
+				HasNextPage bool
+				EndCursor   string
+			}
+		}
+		TodoCounts entities.TodoCounts
+	}
+	require.NoError(t, json.Unmarshal(result.Data, &data))
+
+	require.Len(t, data.Todos.Edges, 2)
+	for i, edge := range data.Todos.Edges {
+		assert.Equal(t, todos[i].ID.String(), edge.Node.ID)
+		require.NotNil(t, edge.Node.Attachment)
+		assert.Equal(t, files[i].ID.String(), edge.Node.Attachment.ID)
+		assert.Equal(t, 11, edge.Node.Attachment.Size)
+	}
+	assert.True(t, data.Todos.PageInfo.HasNextPage)
+	assert.Equal(t, data.Todos.Edges[1].Cursor, data.Todos.PageInfo.EndCursor)
+
+	cursor, err := entities.DecodeTodoCursor(data.Todos.PageInfo.EndCursor)
+	require.NoError(t, err)
+	assert.Equal(t, todos[1].ID, cursor.ID)
+	assert.Equal(t, entities.TodoCounts{Total: 5, Open: 3, Completed: 2, Overdue: 1}, data.TodoCounts)
+}
+
+func TestGraphQL_Errors(t *testing.T) {
+	todo := contractTodo()
+	todo.Version = 3
+
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		setup     func(t *testing.T, m *contractMocks)
+		wantCode  string
+	}{
+		{
+			name:     "invalid id",
+			query:    `{ todo(id: "42") { id } }`,
+			wantCode: resolvers.CodeBadUserInput,
+		},
+		{
+			name:     "invalid cursor",
+			query:    `{ todos(after: "not a cursor") { pageInfo { hasNextPage } } }`,
+			wantCode: resolvers.CodeBadUserInput,
+		},
+		{
+			name:  "version mismatch",
+			query: `mutation($id: ID!) { completeTodo(id: $id, expectedVersion: 2) { version } }`,
+			setup: func(t *testing.T, m *contractMocks) {
+				expectTx(t, m.txManager, func(repo *mocks.MockTodoRepository) {
+					repo.EXPECT().GetByIDForUpdate(mock.Anything, contractTenantID, todo.ID).Return(todo, nil)
+				})
+			},
+			wantCode: resolvers.CodeVersionMismatch,
+		},
+		{
+			name:  "internal error",
+			query: `mutation($id: ID!) { deleteTodo(id: $id) }`,
+			setup: func(t *testing.T, m *contractMocks) {
+				m.txManager.EXPECT().DoInTx(mock.Anything, mock.Anything).Return(assert.AnError)
+			},
+			wantCode: resolvers.CodeInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, m := newGraphQLRouter(t)
+			if tt.setup != nil {
+				tt.setup(t, m)
+			}
+
+			result := postGraphQL(t, router, tt.query, map[string]interface{}{"id": todo.ID.String()})
+			require.Len(t, result.Errors, 1)
+			assert.Equal(t, tt.wantCode, result.Errors[0].Extensions["code"])
+			assert.NotContains(t, result.Errors[0].Message, assert.AnError.Error())
+		})
+	}
+}
+
+func TestGraphQL_DueDateNeedsAnOffset(t *testing.T) {
+	router, _ := newGraphQLRouter(t)
+
+	// No transaction is expected: the input is rejected before the use case.
+	result := postGraphQL(t, router, `mutation { createTodo(input: {description: "Buy milk", dueDate: "2030-01-02T15:00:00"}) { id } }`, nil)
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0].Message, `cannot parse "" as "Z07:00"`)
+}
+
+func TestGraphQL_RequiresScopes(t *testing.T) {
+	todoUseCase := usecases.NewTodoUseCase(mocks.NewMockTransactionManager(t), mocks.NewMockStreamPublisher(t), nil, entities.DefaultTodoRules(), nil)
+	schema, err := resolvers.NewSchema(todoUseCase, nil, nil, resolvers.Options{})
+	require.NoError(t, err)
+
+	ctx := entities.ContextWithPrincipal(context.Background(), &entities.Principal{
+		ID:       contractUserID,
+		TenantID: contractTenantID,
+		Method:   entities.AuthMethodAPIKey,
+		Scopes:   []string{entities.ScopeFilesRead},
+	})
+
+	response := schema.Exec(ctx, resolvers.Request{Query: `mutation { deleteTodo(id: "` + uuid.NewString() + `") }`})
+	require.Len(t, response.Errors, 1)
+	assert.Equal(t, resolvers.CodeForbidden, response.Errors[0].Extensions["code"])
+
+	response = schema.Exec(context.Background(), resolvers.Request{Query: `{ todoCounts { total } }`})
+	require.Len(t, response.Errors, 1)
+	assert.Equal(t, resolvers.CodeUnauthenticated, response.Errors[0].Extensions["code"])
+}
+
+func TestGraphQL_AttachmentRequiresFilesRead(t *testing.T) {
+	todo := contractTodo()
+	txManager := mocks.NewMockTransactionManager(t)
+	repo := mocks.NewMockTodoRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, contractTenantID, todo.ID).Return(todo, nil)
+	txManager.EXPECT().DoInTx(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(repo ports.TodoRepository) error) error {
+			return fn(repo)
+		})
+
+	todoUseCase := usecases.NewTodoUseCase(txManager, mocks.NewMockStreamPublisher(t), nil, entities.DefaultTodoRules(), nil)
+	fileUseCase := usecases.NewFileUseCase(mocks.NewMockFileStorage(t), mocks.NewMockFileRepository(t), mocks.NewMockStorageUsageRepository(t), entities.StorageQuota{}, nil)
+	schema, err := resolvers.NewSchema(todoUseCase, fileUseCase, nil, resolvers.Options{})
+	require.NoError(t, err)
+
+	// A todos:read key sees the todo but not its file, and no file is loaded.
+	ctx := entities.ContextWithPrincipal(context.Background(), &entities.Principal{
+		ID:       contractUserID,
+		TenantID: contractTenantID,
+		Method:   entities.AuthMethodAPIKey,
+		Scopes:   []string{entities.ScopeTodosRead},
+	})
+	response := schema.Exec(ctx, resolvers.Request{Query: `{ todo(id: "` + todo.ID.String() + `") { id attachment { fileName } } }`})
+	require.Len(t, response.Errors, 1)
+	assert.Equal(t, resolvers.CodeForbidden, response.Errors[0].Extensions["code"])
+	assert.JSONEq(t, `{"todo": {"id": "`+todo.ID.String()+`", "attachment": null}}`, string(response.Data))
+}
+
+func TestGraphQL_SubscriptionOverSSE(t *testing.T) {
+	router, m := newGraphQLRouter(t)
+	todo := contractTodo()
+	created, err := entities.NewTodoEvent(entities.EventTypeTodoCreated, todo)
+	require.NoError(t, err)
+	created.ID = "2-0"
+
+	m.eventReader.EXPECT().LastID(mock.Anything).Return("1-0", nil)
+	m.eventReader.EXPECT().Read(mock.Anything, "1-0", mock.Anything, mock.Anything).Return([]*entities.Event{created}, nil).Once()
+	m.eventReader.EXPECT().Read(mock.Anything, "2-0", mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, afterID string, count int64, block time.Duration) ([]*entities.Event, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}).Maybe()
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/graphql",
+		strings.NewReader(`{"query":"subscription { todoEvents(types: [\"todo.*\"]) { id type todo { description } } }"}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		if line == "event: next\n" {
+			break
+		}
+	}
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+
+	var result graphQLResult
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &result))
+	assert.Empty(t, result.Errors)
+	assert.JSONEq(t, `{"todoEvents":{"id":"2-0","type":"todo.created","todo":{"description":"Write the contract test"}}}`, string(result.Data))
+}
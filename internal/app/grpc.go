@@ -0,0 +1,65 @@
+package app
+
+import (
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alphapb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/interfaces/grpc/interceptors"
+	todov1 "todo-service/pkg/api/todo/v1"
+)
+
+// setupGRPCServer registers the todo.v1 service along with the standard
+// health and reflection services, which need no credentials. Health reports
+// NOT_SERVING until the server starts.
+func setupGRPCServer(deps *Dependencies) (*grpc.Server, *grpchealth.Server) {
+	auth := grpcAuthOptions()
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			interceptors.UnaryLogging(deps.Logger),
+			interceptors.UnaryRecovery(),
+			interceptors.UnaryAuth(deps.Authenticator, auth),
+		),
+		grpc.ChainStreamInterceptor(
+			interceptors.StreamLogging(deps.Logger),
+			interceptors.StreamRecovery(),
+			interceptors.StreamAuth(deps.Authenticator, auth),
+		),
+	)
+
+	todov1.RegisterTodoServiceServer(server, deps.TodoService)
+
+	health := grpchealth.NewServer()
+	health.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	health.SetServingStatus(todov1.TodoService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(server, health)
+
+	reflection.Register(server)
+
+	return server, health
+}
+
+// grpcAuthOptions lists the scope every todo.v1 method needs; the auth
+// interceptor refuses methods missing here.
+func grpcAuthOptions() interceptors.AuthOptions {
+	return interceptors.AuthOptions{
+		Scopes: map[string]string{
+			todov1.TodoService_CreateTodo_FullMethodName: entities.ScopeTodosWrite,
+			todov1.TodoService_GetTodo_FullMethodName:    entities.ScopeTodosRead,
+			todov1.TodoService_ListTodos_FullMethodName:  entities.ScopeTodosRead,
+			todov1.TodoService_UpdateTodo_FullMethodName: entities.ScopeTodosWrite,
+			todov1.TodoService_DeleteTodo_FullMethodName: entities.ScopeTodosWrite,
+			todov1.TodoService_UploadFile_FullMethodName: entities.ScopeFilesWrite,
+		},
+		Public: []string{
+			healthpb.Health_ServiceDesc.ServiceName,
+			reflectionpb.ServerReflection_ServiceDesc.ServiceName,
+			reflectionv1alphapb.ServerReflection_ServiceDesc.ServiceName,
+		},
+	}
+}
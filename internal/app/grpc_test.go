@@ -0,0 +1,310 @@
+package app
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+	"todo-service/internal/domain/ports/mocks"
+	"todo-service/internal/interfaces/authn"
+	"todo-service/internal/interfaces/grpc/services"
+	"todo-service/internal/usecases"
+	todov1 "todo-service/pkg/api/todo/v1"
+)
+
+type tokenVerifierFunc func(ctx context.Context, token string) (*entities.Principal, error)
+
+func (f tokenVerifierFunc) Verify(ctx context.Context, token string) (*entities.Principal, error) {
+	return f(ctx, token)
+}
+
+// newGRPCTestConn serves the real gRPC server over an in-memory listener on
+// top of mocked ports. The bearer token "user" is a full-rights user and
+// "reader" may only read todos.
+func newGRPCTestConn(t *testing.T) (*grpc.ClientConn, *contractMocks) {
+	t.Helper()
+
+	m := &contractMocks{
+		txManager:   mocks.NewMockTransactionManager(t),
+		publisher:   mocks.NewMockStreamPublisher(t),
+		fileStorage: mocks.NewMockFileStorage(t),
+		fileRepo:    mocks.NewMockFileRepository(t),
+		usageRepo:   mocks.NewMockStorageUsageRepository(t),
+	}
+
+	verifier := tokenVerifierFunc(func(ctx context.Context, token string) (*entities.Principal, error) {
+		switch token {
+		case "user":
+			return &entities.Principal{ID: contractUserID, TenantID: contractTenantID, Method: entities.AuthMethodJWT}, nil
+		case "reader":
+			return &entities.Principal{ID: contractUserID, TenantID: contractTenantID, Method: entities.AuthMethodAPIKey, Scopes: []string{entities.ScopeTodosRead}}, nil
+		}
+		return nil, entities.ErrInvalidCredentials
+	})
+
+	todoUseCase := usecases.NewTodoUseCase(m.txManager, m.publisher, m.fileRepo, entities.DefaultTodoRules(), nil)
+	fileUseCase := usecases.NewFileUseCase(m.fileStorage, m.fileRepo, m.usageRepo, entities.StorageQuota{}, nil)
+	server, health := setupGRPCServer(&Dependencies{
+		TodoService:   services.NewTodoService(todoUseCase, fileUseCase, services.TodoServiceOptions{}),
+		Authenticator: authn.NewAuthenticator(map[string]ports.TokenVerifier{authn.SchemeBearer: verifier}),
+		Logger:        zap.NewNop(),
+	})
+	health.Resume()
+
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn, m
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestGRPC_CreateAndGetTodo(t *testing.T) {
+	conn, m := newGRPCTestConn(t)
+	client := todov1.NewTodoServiceClient(conn)
+	todo := contractTodo()
+
+	expectTx(t, m.txManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(created *entities.TodoItem) bool {
+			return created.Description == todo.Description && created.DueDate.Equal(todo.DueDate)
+		})).Return(nil)
+	})
+	m.publisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil)
+	m.fileRepo.EXPECT().GetByID(mock.Anything, contractTenantID, contractUserID, uuid.MustParse(*todo.FileID)).
+		Return(entities.NewFile(contractTenantID, contractUserID, "notes.txt", "text/plain", 5), nil)
+
+	created, err := client.CreateTodo(withToken("user"), &todov1.CreateTodoRequest{
+		Description: todo.Description,
+		DueDate:     timestamppb.New(todo.DueDate),
+		FileId:      todo.FileID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, todo.Description, created.Todo.Description)
+	assert.Equal(t, *todo.FileID, created.Todo.GetFileId())
+	assert.Equal(t, int32(1), created.Todo.Version)
+	assert.Nil(t, created.Todo.CompletedAt)
+
+	expectTx(t, m.txManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().GetByID(mock.Anything, contractTenantID, todo.ID).Return(todo, nil)
+	})
+
+	got, err := client.GetTodo(withToken("reader"), &todov1.GetTodoRequest{Id: todo.ID.String()})
+	require.NoError(t, err)
+	assert.Equal(t, todo.ID.String(), got.Todo.Id)
+	assert.Equal(t, contractUserID, got.Todo.OwnerId)
+}
+
+func TestGRPC_Errors(t *testing.T) {
+	conn, m := newGRPCTestConn(t)
+	client := todov1.NewTodoServiceClient(conn)
+	todo := contractTodo()
+	todo.Version = 3
+
+	tests := []struct {
+		name     string
+		setup    func()
+		call     func() error
+		wantCode codes.Code
+	}{
+		{
+			name: "missing credentials",
+			call: func() error {
+				_, err := client.ListTodos(context.Background(), &todov1.ListTodosRequest{})
+				return err
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "invalid credentials",
+			call: func() error {
+				_, err := client.ListTodos(withToken("nope"), &todov1.ListTodosRequest{})
+				return err
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "missing scope",
+			call: func() error {
+				_, err := client.DeleteTodo(withToken("reader"), &todov1.DeleteTodoRequest{Id: todo.ID.String()})
+				return err
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "invalid id",
+			call: func() error {
+				_, err := client.GetTodo(withToken("user"), &todov1.GetTodoRequest{Id: "42"})
+				return err
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "not found",
+			setup: func() {
+				expectTx(t, m.txManager, func(repo *mocks.MockTodoRepository) {
+					repo.EXPECT().GetByID(mock.Anything, contractTenantID, todo.ID).Return(nil, entities.ErrTodoNotFound)
+				})
+			},
+			call: func() error {
+				_, err := client.GetTodo(withToken("user"), &todov1.GetTodoRequest{Id: todo.ID.String()})
+				return err
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "version mismatch",
+			setup: func() {
+				expectTx(t, m.txManager, func(repo *mocks.MockTodoRepository) {
+					repo.EXPECT().GetByIDForUpdate(mock.Anything, contractTenantID, todo.ID).Return(todo, nil)
+				})
+			},
+			call: func() error {
+				expected := int32(2)
+				_, err := client.DeleteTodo(withToken("user"), &todov1.DeleteTodoRequest{Id: todo.ID.String(), ExpectedVersion: &expected})
+				return err
+			},
+			wantCode: codes.Aborted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup()
+			}
+			assert.Equal(t, tt.wantCode, status.Code(tt.call()))
+		})
+	}
+}
+
+func TestGRPC_UploadFile(t *testing.T) {
+	conn, m := newGRPCTestConn(t)
+	client := todov1.NewTodoServiceClient(conn)
+
+	upload := func(size int64, chunks ...string) (*todov1.UploadFileResponse, error) {
+		stream, err := client.UploadFile(withToken("user"))
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&todov1.UploadFileRequest{Data: &todov1.UploadFileRequest_Info{
+			Info: &todov1.FileInfo{FileName: "notes.txt", ContentType: "text/plain", Size: size},
+		}}))
+		for _, chunk := range chunks {
+			require.NoError(t, stream.Send(&todov1.UploadFileRequest{Data: &todov1.UploadFileRequest_Chunk{Chunk: []byte(chunk)}}))
+		}
+		return stream.CloseAndRecv()
+	}
+	readAll := func(ctx context.Context, path, contentType string, data io.Reader, size int64) error {
+		_, err := io.ReadAll(data)
+		return err
+	}
+
+	m.usageRepo.EXPECT().Reserve(mock.Anything, contractTenantID, contractUserID, int64(11), mock.Anything).Return(nil).Once()
+	m.fileStorage.EXPECT().UploadFile(mock.Anything, mock.Anything, "text/plain", mock.Anything, int64(11)).
+		RunAndReturn(func(ctx context.Context, path, contentType string, data io.Reader, size int64) error {
+			contents, err := io.ReadAll(data)
+			assert.Equal(t, "hello world", string(contents))
+			return err
+		}).Once()
+	m.fileRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(nil).Once()
+
+	response, err := upload(11, "hello ", "world")
+	require.NoError(t, err)
+	assert.NotEmpty(t, response.FileId)
+
+	m.usageRepo.EXPECT().Reserve(mock.Anything, contractTenantID, contractUserID, int64(20), mock.Anything).Return(nil).Once()
+	m.fileStorage.EXPECT().UploadFile(mock.Anything, mock.Anything, "text/plain", mock.Anything, int64(20)).RunAndReturn(readAll).Once()
+	m.usageRepo.EXPECT().Release(mock.Anything, contractTenantID, contractUserID, int64(20)).Return(nil).Once()
+
+	_, err = upload(20, "hello ", "world")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "received 11 of the declared 20 bytes")
+
+	stream, err := client.UploadFile(withToken("user"))
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&todov1.UploadFileRequest{Data: &todov1.UploadFileRequest_Chunk{Chunk: []byte("hi")}}))
+	_, err = stream.CloseAndRecv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPC_UploadFileRejectsUnsafeNames(t *testing.T) {
+	conn, _ := newGRPCTestConn(t)
+	client := todov1.NewTodoServiceClient(conn)
+
+	// Nothing may be reserved or stored, which the mocks enforce.
+	for _, name := range []string{"../../other/files/x/notes.txt", `..\notes.txt`, "notes\r\n.txt"} {
+		stream, err := client.UploadFile(withToken("user"))
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&todov1.UploadFileRequest{Data: &todov1.UploadFileRequest_Info{
+			Info: &todov1.FileInfo{FileName: name, ContentType: "text/plain", Size: 5},
+		}}))
+		require.NoError(t, stream.Send(&todov1.UploadFileRequest{Data: &todov1.UploadFileRequest_Chunk{Chunk: []byte("hello")}}))
+
+		_, err = stream.CloseAndRecv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), name)
+	}
+}
+
+func TestGRPC_EveryMethodHasAScope(t *testing.T) {
+	scopes := grpcAuthOptions().Scopes
+	desc := todov1.TodoService_ServiceDesc
+
+	for _, method := range desc.Methods {
+		assert.Contains(t, scopes, "/"+desc.ServiceName+"/"+method.MethodName)
+	}
+	for _, stream := range desc.Streams {
+		assert.Contains(t, scopes, "/"+desc.ServiceName+"/"+stream.StreamName)
+	}
+}
+
+func TestGRPC_HealthAndReflectionArePublic(t *testing.T) {
+	conn, _ := newGRPCTestConn(t)
+
+	health, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{
+		Service: todov1.TodoService_ServiceDesc.ServiceName,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, health.Status)
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	response, err := stream.Recv()
+	require.NoError(t, err)
+
+	var names []string
+	for _, service := range response.GetListServicesResponse().GetService() {
+		names = append(names, service.Name)
+	}
+	assert.Contains(t, names, todov1.TodoService_ServiceDesc.ServiceName)
+}
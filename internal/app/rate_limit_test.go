@@ -0,0 +1,56 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+	"todo-service/internal/infrastructure/ratelimit"
+	"todo-service/internal/interfaces/http/middleware"
+	"todo-service/internal/interfaces/http/openapi"
+)
+
+func TestRoutes_RateLimitAnonymousCallersByIP(t *testing.T) {
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+
+	deps, _ := newContractDependencies(t, spec)
+	deps.Auth = middleware.Authenticate(map[string]ports.TokenVerifier{}, middleware.AuthOptions{})
+	deps.RateLimitByIP = middleware.RateLimitByIP(ratelimit.NewMemoryRateLimiter(), entities.RateLimit{Limit: 2, Period: time.Minute})
+
+	router := setupRoutes(deps)
+	require.NoError(t, router.SetTrustedProxies(nil))
+
+	get := func(remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/todo", nil)
+		req.RemoteAddr = remoteAddr
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	for i := 0; i < 2; i++ {
+		recorder := get("203.0.113.7:40000", "")
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.Equal(t, "2", recorder.Header().Get("RateLimit-Limit"))
+	}
+
+	recorder := get("203.0.113.7:40001", "")
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.NotEmpty(t, recorder.Header().Get("Retry-After"))
+	assert.Contains(t, recorder.Body.String(), "rate_limited")
+
+	// Forwarding headers from untrusted peers do not buy a fresh budget.
+	assert.Equal(t, http.StatusTooManyRequests, get("203.0.113.7:40002", "198.51.100.1").Code)
+
+	assert.Equal(t, http.StatusUnauthorized, get("203.0.113.8:40000", "").Code)
+}
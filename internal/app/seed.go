@@ -0,0 +1,145 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/usecases"
+)
+
+type SeedOptions struct {
+	TenantID     string
+	Users        int
+	TodosPerUser int
+	FilesPerUser int
+	// Seed makes the generated data reproducible.
+	Seed int64
+}
+
+type SeedReport struct {
+	Users  []string
+	Todos  int
+	Files  int
+	Shares int
+}
+
+var (
+	seedVerbs    = []string{"Review", "Draft", "Update", "Schedule", "Prepare", "Fix", "Book", "Follow up on", "Clean up", "Plan", "Send", "Renew"}
+	seedSubjects = []string{"quarterly report", "team offsite", "onboarding checklist", "dentist appointment", "insurance paperwork", "release notes", "budget spreadsheet", "flaky login test", "conference talk", "car service", "invoice #4821", "garden irrigation", "design review", "passport application"}
+	seedContexts = []string{"", "", "", " before Friday", " with Sam", " for the Berlin client", " (blocked on legal)", " after standup", " for Q3", " — see attached notes"}
+	seedNotes    = []string{"Agenda", "Meeting notes", "Checklist", "Requirements", "Receipts", "Draft outline"}
+)
+
+// Seed creates realistic todos, attached notes and shares for fake users of
+// a tenant. It goes through the use cases, so events, webhooks and quotas
+// behave exactly as they would for API traffic.
+func (a *App) Seed(ctx context.Context, options SeedOptions) (*SeedReport, error) {
+	random := rand.New(rand.NewSource(options.Seed))
+	report := &SeedReport{}
+
+	for i := 1; i <= options.Users; i++ {
+		report.Users = append(report.Users, fmt.Sprintf("seed-user-%d", i))
+	}
+
+	for _, userID := range report.Users {
+		userCtx := entities.ContextWithPrincipal(ctx, &entities.Principal{
+			ID:       userID,
+			TenantID: options.TenantID,
+			Method:   entities.AuthMethodJWT,
+		})
+
+		var fileIDs []string
+		for i := 0; i < options.FilesPerUser; i++ {
+			fileID, err := a.seedFile(userCtx, random)
+			if err != nil {
+				return report, fmt.Errorf("failed to seed file for %s: %w", userID, err)
+			}
+			fileIDs = append(fileIDs, fileID)
+			report.Files++
+		}
+
+		for i := 0; i < options.TodosPerUser; i++ {
+			req := usecases.CreateTodoRequest{
+				Description: seedDescription(random),
+				DueDate:     seedDueDate(random),
+			}
+			if i < len(fileIDs) {
+				req.FileID = &fileIDs[i]
+			}
+
+			todo, err := a.deps.TodoUseCase.CreateTodo(userCtx, req)
+			if err != nil {
+				return report, fmt.Errorf("failed to seed todo for %s: %w", userID, err)
+			}
+			report.Todos++
+
+			if len(report.Users) > 1 && random.Intn(5) == 0 {
+				if err := a.seedShare(userCtx, random, todo, userID, report.Users); err != nil {
+					return report, fmt.Errorf("failed to share seeded todo: %w", err)
+				}
+				report.Shares++
+			}
+		}
+
+		a.logger.Info("Seeded user", zap.String("tenant_id", options.TenantID), zap.String("user_id", userID))
+	}
+
+	return report, nil
+}
+
+func (a *App) seedFile(ctx context.Context, random *rand.Rand) (string, error) {
+	title := seedNotes[random.Intn(len(seedNotes))]
+
+	var content bytes.Buffer
+	fmt.Fprintf(&content, "%s\n%s\n\n", title, strings.Repeat("=", len(title)))
+	for i := 0; i < 3+random.Intn(5); i++ {
+		fmt.Fprintf(&content, "- %s\n", seedDescription(random))
+	}
+
+	response, err := a.deps.FileUseCase.UploadFile(ctx, usecases.UploadFileRequest{
+		FileName:    strings.ToLower(strings.ReplaceAll(title, " ", "-")) + ".txt",
+		ContentType: "text/plain",
+		Data:        &content,
+		Size:        int64(content.Len()),
+	})
+	if err != nil {
+		return "", err
+	}
+	return response.FileID, nil
+}
+
+func (a *App) seedShare(ctx context.Context, random *rand.Rand, todo *entities.TodoItem, ownerID string, users []string) error {
+	userID := users[random.Intn(len(users))]
+	for userID == ownerID {
+		userID = users[random.Intn(len(users))]
+	}
+
+	role := entities.RoleViewer
+	if random.Intn(2) == 0 {
+		role = entities.RoleEditor
+	}
+
+	_, err := a.deps.TodoUseCase.ShareTodo(ctx, todo.ID, usecases.ShareTodoRequest{UserID: userID, Role: role})
+	return err
+}
+
+func seedDescription(random *rand.Rand) string {
+	return seedVerbs[random.Intn(len(seedVerbs))] + " " +
+		seedSubjects[random.Intn(len(seedSubjects))] +
+		seedContexts[random.Intn(len(seedContexts))]
+}
+
+// seedDueDate spreads due dates from tomorrow to a month out, during working
+// hours. The todo rules reject due dates in the past, so nothing is overdue
+// until time passes.
+func seedDueDate(random *rand.Rand) time.Time {
+	day := time.Now().Truncate(24*time.Hour).AddDate(0, 0, 1+random.Intn(31))
+	return day.Add(time.Duration(9+random.Intn(9))*time.Hour + time.Duration(random.Intn(4)*15)*time.Minute)
+}
@@ -0,0 +1,156 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+	"todo-service/internal/domain/ports/mocks"
+	"todo-service/internal/usecases"
+)
+
+// seeded records what Seed stored through the mocked ports.
+type seeded struct {
+	todos  []*entities.TodoItem
+	files  []*entities.File
+	grants []*entities.TodoGrant
+}
+
+func newSeedApp(t *testing.T) (*App, *seeded) {
+	t.Helper()
+	stored := &seeded{}
+
+	find := func(ctx context.Context, tenantID string, id uuid.UUID) (*entities.TodoItem, error) {
+		for _, todo := range stored.todos {
+			if todo.TenantID == tenantID && todo.ID == id {
+				return todo, nil
+			}
+		}
+		return nil, entities.ErrTodoNotFound
+	}
+
+	txManager := mocks.NewMockTransactionManager(t)
+	txManager.EXPECT().DoInTx(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(repo ports.TodoRepository) error) error {
+			repo := mocks.NewMockTodoRepository(t)
+			repo.EXPECT().Create(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, todo *entities.TodoItem) error {
+				stored.todos = append(stored.todos, todo)
+				return nil
+			}).Maybe()
+			repo.EXPECT().GetByIDForUpdate(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(find).Maybe()
+			repo.EXPECT().GetGrant(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, entities.ErrTodoGrantNotFound).Maybe()
+			repo.EXPECT().SaveGrant(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, grant *entities.TodoGrant) error {
+				stored.grants = append(stored.grants, grant)
+				return nil
+			}).Maybe()
+			repo.EXPECT().ListGrants(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+			return fn(repo)
+		})
+	publisher := mocks.NewMockStreamPublisher(t)
+	publisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil)
+
+	fileStorage := mocks.NewMockFileStorage(t)
+	fileStorage.EXPECT().UploadFile(mock.Anything, mock.Anything, "text/plain", mock.Anything, mock.Anything).Return(nil).Maybe()
+	fileRepo := mocks.NewMockFileRepository(t)
+	fileRepo.EXPECT().Create(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, file *entities.File) error {
+		stored.files = append(stored.files, file)
+		return nil
+	}).Maybe()
+	fileRepo.EXPECT().GetByID(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, tenantID, ownerID string, id uuid.UUID) (*entities.File, error) {
+			for _, file := range stored.files {
+				if file.TenantID == tenantID && file.OwnerID == ownerID && file.ID == id {
+					return file, nil
+				}
+			}
+			return nil, entities.ErrFileNotFound
+		}).Maybe()
+	usageRepo := mocks.NewMockStorageUsageRepository(t)
+	usageRepo.EXPECT().Reserve(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	return &App{
+		deps: &Dependencies{
+			TodoUseCase: usecases.NewTodoUseCase(txManager, publisher, fileRepo, entities.DefaultTodoRules(), nil),
+			FileUseCase: usecases.NewFileUseCase(fileStorage, fileRepo, usageRepo, entities.StorageQuota{}, nil),
+		},
+		logger: zap.NewNop(),
+	}, stored
+}
+
+func TestSeed_CreatesDataForEachUser(t *testing.T) {
+	application, stored := newSeedApp(t)
+
+	report, err := application.Seed(context.Background(), SeedOptions{TenantID: "demo", Users: 3, TodosPerUser: 10, FilesPerUser: 2, Seed: 7})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"seed-user-1", "seed-user-2", "seed-user-3"}, report.Users)
+	assert.Equal(t, 30, report.Todos)
+	assert.Equal(t, 6, report.Files)
+	assert.Equal(t, len(stored.grants), report.Shares)
+	require.Len(t, stored.todos, 30)
+	require.Len(t, stored.files, 6)
+
+	files := make(map[string]*entities.File)
+	for _, file := range stored.files {
+		files[file.ID.String()] = file
+	}
+
+	attached := 0
+	for _, todo := range stored.todos {
+		assert.Equal(t, "demo", todo.TenantID)
+		assert.Contains(t, report.Users, todo.OwnerID)
+		assert.NotEmpty(t, todo.Description)
+		if todo.FileID != nil {
+			attached++
+			require.Contains(t, files, *todo.FileID)
+			assert.Equal(t, todo.OwnerID, files[*todo.FileID].OwnerID, "todos only attach their owner's files")
+		}
+	}
+	assert.Equal(t, 6, attached)
+
+	// The use cases reject due dates in the past, so none are generated.
+	for _, todo := range stored.todos {
+		assert.True(t, todo.DueDate.After(time.Now()), "due %s", todo.DueDate)
+	}
+
+	for _, grant := range stored.grants {
+		assert.Contains(t, report.Users, grant.UserID)
+		assert.NotEqual(t, testOwnerOf(t, stored, grant), grant.UserID, "todos are never shared with their owner")
+	}
+}
+
+func TestSeed_IsReproducible(t *testing.T) {
+	options := SeedOptions{TenantID: "demo", Users: 2, TodosPerUser: 5, Seed: 42}
+	descriptions := func() []string {
+		application, stored := newSeedApp(t)
+		_, err := application.Seed(context.Background(), options)
+		require.NoError(t, err)
+
+		var result []string
+		for _, todo := range stored.todos {
+			result = append(result, todo.Description)
+		}
+		return result
+	}
+
+	assert.Equal(t, descriptions(), descriptions())
+}
+
+func testOwnerOf(t *testing.T, stored *seeded, grant *entities.TodoGrant) string {
+	t.Helper()
+	for _, todo := range stored.todos {
+		if todo.ID == grant.TodoID {
+			return todo.OwnerID
+		}
+	}
+	t.Fatalf("grant for unknown todo %s", grant.TodoID)
+	return ""
+}
@@ -1,27 +1,62 @@
 package config
 
 import (
-	"os"
-	"strconv"
+	"time"
 )
 
 type Config struct {
-	App   AppConfig
-	DB    DatabaseConfig
-	Redis RedisConfig
-	AWS   AWSConfig
+	App         AppConfig
+	DB          DatabaseConfig
+	Redis       RedisConfig
+	AWS         AWSConfig
+	Stream      StreamConfig
+	Webhooks    WebhookConfig
+	Events      EventsConfig
+	Idempotency IdempotencyConfig
+	Auth        AuthConfig
+	RateLimit   RateLimitConfig
+	Storage     StorageConfig
+	Todos       TodoConfig
+	Tracing     TracingConfig
+	Log         LogConfig
+	Health      HealthConfig
+
+	// settings records every resolved value in load order for WriteRedacted.
+	settings []setting
 }
 
 type AppConfig struct {
 	Port string
+	// GRPCPort serves the gRPC API; empty disables it.
+	GRPCPort       string
+	RequireIfMatch bool
+	// ValidateRequests rejects requests that do not match the OpenAPI
+	// document before they reach a handler.
+	ValidateRequests bool
+	ReadTimeout      time.Duration
+	WriteTimeout     time.Duration
+	IdleTimeout      time.Duration
+	ShutdownTimeout  time.Duration
+	// ShutdownDelay keeps serving while readiness fails so load balancers
+	// can stop routing traffic before connections are drained.
+	ShutdownDelay time.Duration
+	// TrustedProxies lists the IPs and CIDRs whose X-Forwarded-For and
+	// X-Real-IP headers are believed; with none, the client IP is the peer
+	// address of the connection.
+	TrustedProxies []string
 }
 
 type DatabaseConfig struct {
-	Host     string
-	Port     string
-	User     string
-	Password string
-	Name     string
+	Host            string
+	Port            string
+	User            string
+	Password        string
+	Name            string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	// AutoMigrate applies pending migrations before the server starts.
+	AutoMigrate bool
 }
 
 type RedisConfig struct {
@@ -29,6 +64,9 @@ type RedisConfig struct {
 	Port     string
 	DB       int
 	Password string
+	// PoolSize of zero keeps the client default of ten connections per CPU.
+	PoolSize     int
+	MinIdleConns int
 }
 
 type AWSConfig struct {
@@ -37,44 +75,211 @@ type AWSConfig struct {
 	S3Bucket string
 }
 
-func Load() *Config {
-	return &Config{
+type StreamConfig struct {
+	Backend        string
+	Name           string
+	NATSURL        string
+	NATSStream     string
+	MemoryCapacity int
+}
+
+type EventsConfig struct {
+	MaxConnections    int
+	HeartbeatInterval time.Duration
+	AllowedOrigins    []string
+}
+
+type AuthConfig struct {
+	JWTSecret           string
+	JWKSFile            string
+	JWKSURL             string
+	JWKSRefreshInterval time.Duration
+	Issuer              string
+	Audience            string
+	ClockSkew           time.Duration
+	DefaultTenant       string
+}
+
+type RateLimitConfig struct {
+	Enabled bool
+	// PerIP limits every API request per client IP before authentication;
+	// empty disables it.
+	PerIP            string
+	Default          string
+	Routes           map[string]string
+	FallbackCooldown time.Duration
+}
+
+// StorageConfig holds per-user upload quotas; zero means unlimited.
+type StorageConfig struct {
+	QuotaBytes int64
+	QuotaFiles int64
+}
+
+// TodoConfig holds the limits new and changed todos must stay within.
+type TodoConfig struct {
+	MaxDescriptionLength int
+	AllowPastDueDates    bool
+	DueDateHorizon       time.Duration
+}
+
+type LogConfig struct {
+	HTTPHeaders       bool
+	HTTPBodies        bool
+	HTTPBodyLimit     int
+	AccessLogSkipList []string
+}
+
+type HealthConfig struct {
+	CheckTimeout time.Duration
+	CacheTTL     time.Duration
+}
+
+type TracingConfig struct {
+	Exporter    string
+	ServiceName string
+}
+
+type IdempotencyConfig struct {
+	TTL     time.Duration
+	LockTTL time.Duration
+}
+
+type WebhookConfig struct {
+	ConsumerGroup  string
+	Timeout        time.Duration
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	DisableAfter   int
+	// RetryInterval is how often the worker looks for due retries.
+	RetryInterval time.Duration
+	// AllowPrivateNetworks lets webhooks target loopback and private
+	// addresses; only for local development.
+	AllowPrivateNetworks bool
+}
+
+// Load builds the configuration from defaults, then the optional YAML or
+// TOML file at path, then environment variables. Every malformed or invalid
+// setting is reported at once in a *ValidationError.
+func Load(path string) (*Config, error) {
+	l, err := newLoader(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
 		App: AppConfig{
-			Port: getEnv("PORT", "8083"),
+			Port:             l.string("app.port", "PORT", "8083"),
+			GRPCPort:         l.string("app.grpc_port", "GRPC_PORT", "9090"),
+			RequireIfMatch:   l.bool("app.require_if_match", "REQUIRE_IF_MATCH", false),
+			ValidateRequests: l.bool("app.validate_requests", "VALIDATE_REQUESTS", true),
+			ReadTimeout:      l.duration("app.read_timeout", "SERVER_READ_TIMEOUT", 15*time.Second),
+			WriteTimeout:     l.duration("app.write_timeout", "SERVER_WRITE_TIMEOUT", 15*time.Second),
+			IdleTimeout:      l.duration("app.idle_timeout", "SERVER_IDLE_TIMEOUT", 60*time.Second),
+			ShutdownTimeout:  l.duration("app.shutdown_timeout", "SHUTDOWN_TIMEOUT", 30*time.Second),
+			ShutdownDelay:    l.duration("app.shutdown_delay", "SHUTDOWN_DELAY", 0),
+			TrustedProxies:   l.list("app.trusted_proxies", "TRUSTED_PROXIES", ""),
 		},
 		DB: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "3306"),
-			User:     getEnv("DB_USER", "todo_user"),
-			Password: getEnv("DB_PASSWORD", "todo_password"),
-			Name:     getEnv("DB_NAME", "todo_db"),
+			Host:            l.string("db.host", "DB_HOST", "localhost"),
+			Port:            l.string("db.port", "DB_PORT", "3306"),
+			User:            l.string("db.user", "DB_USER", "todo_user"),
+			Password:        l.secret("db.password", "DB_PASSWORD", ""),
+			Name:            l.string("db.name", "DB_NAME", "todo_db"),
+			MaxOpenConns:    l.int("db.max_open_conns", "DB_MAX_OPEN_CONNS", 25),
+			MaxIdleConns:    l.int("db.max_idle_conns", "DB_MAX_IDLE_CONNS", 25),
+			ConnMaxLifetime: l.duration("db.conn_max_lifetime", "DB_CONN_MAX_LIFETIME", 5*time.Minute),
+			AutoMigrate:     l.bool("db.auto_migrate", "DB_AUTO_MIGRATE", false),
 		},
 		Redis: RedisConfig{
-			Host:     getEnv("REDIS_HOST", "localhost"),
-			Port:     getEnv("REDIS_PORT", "6379"),
-			DB:       getIntEnv("REDIS_DB", 0),
-			Password: getEnv("REDIS_PASSWORD", ""),
+			Host:         l.string("redis.host", "REDIS_HOST", "localhost"),
+			Port:         l.string("redis.port", "REDIS_PORT", "6379"),
+			DB:           l.int("redis.db", "REDIS_DB", 0),
+			Password:     l.secret("redis.password", "REDIS_PASSWORD", ""),
+			PoolSize:     l.int("redis.pool_size", "REDIS_POOL_SIZE", 0),
+			MinIdleConns: l.int("redis.min_idle_conns", "REDIS_MIN_IDLE_CONNS", 0),
 		},
 		AWS: AWSConfig{
-			Endpoint: getEnv("AWS_ENDPOINT_URL", "http://localhost:4566"),
-			Region:   getEnv("AWS_REGION", "us-east-1"),
-			S3Bucket: getEnv("S3_BUCKET", "todo-bucket"),
+			Endpoint: l.string("aws.endpoint", "AWS_ENDPOINT_URL", "http://localhost:4566"),
+			Region:   l.string("aws.region", "AWS_REGION", "us-east-1"),
+			S3Bucket: l.string("aws.s3_bucket", "S3_BUCKET", "todo-bucket"),
+		},
+		Stream: StreamConfig{
+			Backend:        l.string("stream.backend", "STREAM_BACKEND", "redis"),
+			Name:           l.string("stream.name", "STREAM_NAME", "todo-events"),
+			NATSURL:        l.secret("stream.nats_url", "NATS_URL", "nats://localhost:4222"),
+			NATSStream:     l.string("stream.nats_stream", "NATS_STREAM", "TODO_EVENTS"),
+			MemoryCapacity: l.int("stream.memory_capacity", "STREAM_MEMORY_CAPACITY", 10000),
+		},
+		Webhooks: WebhookConfig{
+			ConsumerGroup:        l.string("webhooks.consumer_group", "WEBHOOK_CONSUMER_GROUP", "webhooks"),
+			Timeout:              l.duration("webhooks.timeout", "WEBHOOK_TIMEOUT", 10*time.Second),
+			MaxAttempts:          l.int("webhooks.max_attempts", "WEBHOOK_MAX_ATTEMPTS", 5),
+			InitialBackoff:       l.duration("webhooks.initial_backoff", "WEBHOOK_INITIAL_BACKOFF", time.Second),
+			MaxBackoff:           l.duration("webhooks.max_backoff", "WEBHOOK_MAX_BACKOFF", time.Minute),
+			DisableAfter:         l.int("webhooks.disable_after", "WEBHOOK_DISABLE_AFTER", 10),
+			RetryInterval:        l.duration("webhooks.retry_interval", "WEBHOOK_RETRY_INTERVAL", time.Second),
+			AllowPrivateNetworks: l.bool("webhooks.allow_private_networks", "WEBHOOK_ALLOW_PRIVATE_NETWORKS", false),
+		},
+		Events: EventsConfig{
+			MaxConnections:    l.int("events.max_connections", "EVENTS_MAX_CONNECTIONS", 500),
+			HeartbeatInterval: l.duration("events.heartbeat_interval", "EVENTS_HEARTBEAT_INTERVAL", 15*time.Second),
+			AllowedOrigins:    l.list("events.allowed_origins", "EVENTS_ALLOWED_ORIGINS", ""),
+		},
+		Auth: AuthConfig{
+			JWTSecret:           l.secret("auth.jwt_secret", "AUTH_JWT_SECRET", ""),
+			JWKSFile:            l.string("auth.jwks_file", "AUTH_JWKS_FILE", ""),
+			JWKSURL:             l.string("auth.jwks_url", "AUTH_JWKS_URL", ""),
+			JWKSRefreshInterval: l.duration("auth.jwks_refresh_interval", "AUTH_JWKS_REFRESH_INTERVAL", 15*time.Minute),
+			Issuer:              l.string("auth.issuer", "AUTH_ISSUER", ""),
+			Audience:            l.string("auth.audience", "AUTH_AUDIENCE", ""),
+			ClockSkew:           l.duration("auth.clock_skew", "AUTH_CLOCK_SKEW", 30*time.Second),
+			DefaultTenant:       l.string("auth.default_tenant", "AUTH_DEFAULT_TENANT", "default"),
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:     l.duration("idempotency.ttl", "IDEMPOTENCY_TTL", 24*time.Hour),
+			LockTTL: l.duration("idempotency.lock_ttl", "IDEMPOTENCY_LOCK_TTL", time.Minute),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:          l.bool("rate_limit.enabled", "RATE_LIMIT_ENABLED", true),
+			PerIP:            l.string("rate_limit.per_ip", "RATE_LIMIT_PER_IP", "1200/1m"),
+			Default:          l.string("rate_limit.default", "RATE_LIMIT_DEFAULT", "600/1m"),
+			Routes:           l.stringMap("rate_limit.routes", "RATE_LIMIT_ROUTES", "POST /api/v1/upload=30/1m"),
+			FallbackCooldown: l.duration("rate_limit.fallback_cooldown", "RATE_LIMIT_FALLBACK_COOLDOWN", 10*time.Second),
+		},
+		Storage: StorageConfig{
+			QuotaBytes: l.int64("storage.quota_bytes", "STORAGE_QUOTA_BYTES", 1<<30),
+			QuotaFiles: l.int64("storage.quota_files", "STORAGE_QUOTA_FILES", 1000),
+		},
+		Todos: TodoConfig{
+			MaxDescriptionLength: l.int("todos.max_description_length", "TODO_MAX_DESCRIPTION_LENGTH", 1000),
+			AllowPastDueDates:    l.bool("todos.allow_past_due_dates", "TODO_ALLOW_PAST_DUE_DATES", false),
+			DueDateHorizon:       l.duration("todos.due_date_horizon", "TODO_DUE_DATE_HORIZON", 87600*time.Hour),
+		},
+		Tracing: TracingConfig{
+			Exporter:    l.string("tracing.exporter", "TRACING_EXPORTER", "none"),
+			ServiceName: l.string("tracing.service_name", "OTEL_SERVICE_NAME", "todo-service"),
+		},
+		Log: LogConfig{
+			HTTPHeaders:       l.bool("log.http_headers", "LOG_HTTP_HEADERS", false),
+			HTTPBodies:        l.bool("log.http_bodies", "LOG_HTTP_BODIES", false),
+			HTTPBodyLimit:     l.int("log.http_body_limit", "LOG_HTTP_BODY_LIMIT", 4096),
+			AccessLogSkipList: l.list("log.skip_paths", "LOG_SKIP_PATHS", "/live,/health,/ready,/metrics"),
+		},
+		Health: HealthConfig{
+			CheckTimeout: l.duration("health.check_timeout", "HEALTH_CHECK_TIMEOUT", 2*time.Second),
+			CacheTTL:     l.duration("health.cache_ttl", "HEALTH_CACHE_TTL", 2*time.Second),
 		},
 	}
-}
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
+	l.checkUnknownKeys()
+	cfg.settings = l.settings
 
-func getIntEnv(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		if intValue, err := strconv.Atoi(value); err == nil {
-			return intValue
-		}
+	problems := append(l.problems, cfg.validate()...)
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
 	}
-	return defaultValue
+
+	return cfg, nil
 }
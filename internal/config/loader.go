@@ -0,0 +1,298 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// setting is one resolved value, keyed by its dotted config file path.
+type setting struct {
+	key    string
+	value  any
+	secret bool
+}
+
+// loader resolves each setting from, in increasing priority, its default,
+// the config file and the environment. Malformed values are collected as
+// problems instead of silently falling back to the default.
+type loader struct {
+	file     map[string]any
+	used     map[string]bool
+	problems []string
+	settings []setting
+}
+
+func newLoader(path string) (*loader, error) {
+	l := &loader{used: make(map[string]bool)}
+	if path == "" {
+		return l, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &l.file)
+	case ".toml":
+		err = toml.Unmarshal(data, &l.file)
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q (use .yaml, .yml or .toml)", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return l, nil
+}
+
+// lookup returns the raw value for a setting and the name it was given
+// under, so problems point at the variable or file key to fix.
+func (l *loader) lookup(key, env string) (any, string, bool) {
+	l.used[key] = true
+
+	if value := os.Getenv(env); value != "" {
+		return value, env, true
+	}
+	if value, ok := l.fileValue(key); ok {
+		return value, key, true
+	}
+	return nil, "", false
+}
+
+func (l *loader) fileValue(key string) (any, bool) {
+	var node any = l.file
+	for _, part := range strings.Split(key, ".") {
+		section, ok := node.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if node, ok = section[part]; !ok {
+			return nil, false
+		}
+	}
+	// An empty YAML value means "use the default", same as an unset variable.
+	return node, node != nil
+}
+
+func (l *loader) scalar(key, env string) (string, string, bool) {
+	raw, source, ok := l.lookup(key, env)
+	if !ok {
+		return "", "", false
+	}
+
+	switch raw.(type) {
+	case map[string]any, []any:
+		l.problemf("%s: must be a single value", source)
+		return "", "", false
+	}
+	return fmt.Sprint(raw), source, true
+}
+
+func (l *loader) record(key string, value any, secret bool) {
+	l.settings = append(l.settings, setting{key: key, value: value, secret: secret})
+}
+
+func (l *loader) problemf(format string, args ...any) {
+	l.problems = append(l.problems, fmt.Sprintf(format, args...))
+}
+
+func (l *loader) string(key, env, defaultValue string) string {
+	value := defaultValue
+	if raw, _, ok := l.scalar(key, env); ok {
+		value = raw
+	}
+	l.record(key, value, false)
+	return value
+}
+
+// secret is like string but can also be read from the file named by
+// <ENV>_FILE or <key>_file, and is redacted by WriteRedacted. The
+// environment still takes priority over the config file as a whole.
+func (l *loader) secret(key, env, defaultValue string) string {
+	pathKey, pathEnv := key+"_file", env+"_FILE"
+	l.used[key] = true
+	l.used[pathKey] = true
+
+	var value string
+	if os.Getenv(env) != "" || os.Getenv(pathEnv) != "" {
+		value = l.readSecret(os.Getenv(env), env, os.Getenv(pathEnv), pathEnv)
+	} else {
+		value = l.readSecret(l.fileString(key), key, l.fileString(pathKey), pathKey)
+	}
+	if value == "" {
+		value = defaultValue
+	}
+
+	l.record(key, value, true)
+	return value
+}
+
+func (l *loader) readSecret(value, name, path, pathName string) string {
+	if path == "" {
+		return value
+	}
+	if value != "" {
+		l.problemf("%s and %s are mutually exclusive", name, pathName)
+		return value
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		l.problemf("%s: %v", pathName, err)
+		return ""
+	}
+	return strings.TrimRight(string(data), "\r\n")
+}
+
+func (l *loader) fileString(key string) string {
+	value, ok := l.fileValue(key)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+func (l *loader) int(key, env string, defaultValue int) int {
+	return int(l.integer(key, env, int64(defaultValue), strconv.IntSize))
+}
+
+func (l *loader) int64(key, env string, defaultValue int64) int64 {
+	return l.integer(key, env, defaultValue, 64)
+}
+
+func (l *loader) integer(key, env string, defaultValue int64, bitSize int) int64 {
+	value := defaultValue
+	if raw, source, ok := l.scalar(key, env); ok {
+		parsed, err := strconv.ParseInt(raw, 10, bitSize)
+		if err != nil {
+			l.problemf("%s: %q is not an integer", source, raw)
+		} else {
+			value = parsed
+		}
+	}
+	l.record(key, value, false)
+	return value
+}
+
+func (l *loader) bool(key, env string, defaultValue bool) bool {
+	value := defaultValue
+	if raw, source, ok := l.scalar(key, env); ok {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			l.problemf("%s: %q is not a boolean", source, raw)
+		} else {
+			value = parsed
+		}
+	}
+	l.record(key, value, false)
+	return value
+}
+
+func (l *loader) duration(key, env string, defaultValue time.Duration) time.Duration {
+	value := defaultValue
+	if raw, source, ok := l.scalar(key, env); ok {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			l.problemf("%s: %q is not a duration (e.g. 500ms, 10s, 5m)", source, raw)
+		} else {
+			value = parsed
+		}
+	}
+	l.record(key, value, false)
+	return value
+}
+
+// list reads a YAML/TOML array from the file or a comma-separated string.
+func (l *loader) list(key, env, defaultValue string) []string {
+	raw, source, ok := l.lookup(key, env)
+	if !ok {
+		raw = defaultValue
+	}
+
+	var values []string
+	switch raw := raw.(type) {
+	case []any:
+		for _, item := range raw {
+			values = append(values, fmt.Sprint(item))
+		}
+	case map[string]any:
+		l.problemf("%s: must be a list", source)
+	default:
+		for _, value := range strings.Split(fmt.Sprint(raw), ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+
+	l.record(key, values, false)
+	return values
+}
+
+// stringMap reads a YAML/TOML table from the file or "key=value" pairs
+// separated by semicolons.
+func (l *loader) stringMap(key, env, defaultValue string) map[string]string {
+	raw, source, ok := l.lookup(key, env)
+	if !ok {
+		raw = defaultValue
+	}
+
+	values := make(map[string]string)
+	switch raw := raw.(type) {
+	case map[string]any:
+		for name, value := range raw {
+			values[name] = fmt.Sprint(value)
+		}
+	case []any:
+		l.problemf("%s: must be a table of key/value pairs", source)
+	default:
+		for _, pair := range strings.Split(fmt.Sprint(raw), ";") {
+			name, value, ok := strings.Cut(pair, "=")
+			if name = strings.TrimSpace(name); ok && name != "" {
+				values[name] = strings.TrimSpace(value)
+			} else if strings.TrimSpace(pair) != "" {
+				l.problemf("%s: %q is not a key=value pair", source, pair)
+			}
+		}
+	}
+
+	l.record(key, values, false)
+	return values
+}
+
+// checkUnknownKeys reports config file entries no setting asked for, which
+// are almost always typos.
+func (l *loader) checkUnknownKeys() {
+	var unknown []string
+	var walk func(prefix string, node map[string]any)
+	walk = func(prefix string, node map[string]any) {
+		for name, value := range node {
+			key := prefix + name
+			if l.used[key] {
+				continue
+			}
+			if section, ok := value.(map[string]any); ok {
+				walk(key+".", section)
+				continue
+			}
+			unknown = append(unknown, key)
+		}
+	}
+	walk("", l.file)
+
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		l.problemf("%s: unknown setting", key)
+	}
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "[REDACTED]"
+
+// WriteRedacted writes the effective configuration as YAML in the config
+// file layout, with secrets replaced. The output can be used as a config file.
+func (c *Config) WriteRedacted(w io.Writer) error {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+
+	for _, s := range c.settings {
+		value := s.value
+		if s.secret && value != "" {
+			value = redacted
+		}
+
+		var node yaml.Node
+		if err := node.Encode(value); err != nil {
+			return err
+		}
+
+		parts := strings.Split(s.key, ".")
+		section := root
+		for _, part := range parts[:len(parts)-1] {
+			section = childMapping(section, part)
+		}
+		section.Content = append(section.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: parts[len(parts)-1]}, &node)
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func childMapping(parent *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(parent.Content); i += 2 {
+		if parent.Content[i].Value == key {
+			return parent.Content[i+1]
+		}
+	}
+
+	child := &yaml.Node{Kind: yaml.MappingNode}
+	parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, child)
+	return child
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxDescriptionLength is the most characters todos.description holds: a
+// TEXT column stores 65535 bytes, and a utf8mb4 character takes up to four.
+const maxDescriptionLength = 65535 / 4
+
+// ValidationError lists every problem found while loading the configuration.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+type validator struct {
+	problems []string
+}
+
+func (v *validator) check(ok bool, format string, args ...any) {
+	if !ok {
+		v.problems = append(v.problems, fmt.Sprintf(format, args...))
+	}
+}
+
+func (v *validator) required(value, name string) {
+	v.check(value != "", "%s must be set", name)
+}
+
+func (v *validator) positive(value time.Duration, name string) {
+	v.check(value > 0, "%s must be positive, got %s", name, value)
+}
+
+func (v *validator) port(value, name string) {
+	port, err := strconv.Atoi(value)
+	v.check(err == nil && port > 0 && port <= 65535, "%s must be a port number, got %q", name, value)
+}
+
+func (v *validator) oneOf(value, name string, allowed ...string) {
+	for _, candidate := range allowed {
+		if value == candidate {
+			return
+		}
+	}
+	v.check(false, "%s must be one of %s, got %q", name, strings.Join(allowed, ", "), value)
+}
+
+func (c *Config) validate() []string {
+	v := &validator{}
+
+	v.port(c.App.Port, "app.port (PORT)")
+	if c.App.GRPCPort != "" {
+		v.port(c.App.GRPCPort, "app.grpc_port (GRPC_PORT)")
+		v.check(c.App.GRPCPort != c.App.Port, "app.grpc_port (GRPC_PORT) must differ from app.port (PORT)")
+	}
+	v.positive(c.App.ReadTimeout, "app.read_timeout (SERVER_READ_TIMEOUT)")
+	v.positive(c.App.WriteTimeout, "app.write_timeout (SERVER_WRITE_TIMEOUT)")
+	v.positive(c.App.IdleTimeout, "app.idle_timeout (SERVER_IDLE_TIMEOUT)")
+	v.positive(c.App.ShutdownTimeout, "app.shutdown_timeout (SHUTDOWN_TIMEOUT)")
+	v.check(c.App.ShutdownDelay >= 0 && c.App.ShutdownDelay < c.App.ShutdownTimeout,
+		"app.shutdown_delay (SHUTDOWN_DELAY) must be between 0 and app.shutdown_timeout, got %s", c.App.ShutdownDelay)
+	for _, proxy := range c.App.TrustedProxies {
+		_, _, cidrErr := net.ParseCIDR(proxy)
+		v.check(cidrErr == nil || net.ParseIP(proxy) != nil,
+			"app.trusted_proxies (TRUSTED_PROXIES) must list IPs or CIDRs, got %q", proxy)
+	}
+
+	v.required(c.DB.Host, "db.host (DB_HOST)")
+	v.port(c.DB.Port, "db.port (DB_PORT)")
+	v.required(c.DB.User, "db.user (DB_USER)")
+	v.required(c.DB.Name, "db.name (DB_NAME)")
+	v.check(c.DB.MaxOpenConns > 0, "db.max_open_conns (DB_MAX_OPEN_CONNS) must be positive, got %d", c.DB.MaxOpenConns)
+	v.check(c.DB.MaxIdleConns >= 0 && c.DB.MaxIdleConns <= c.DB.MaxOpenConns,
+		"db.max_idle_conns (DB_MAX_IDLE_CONNS) must be between 0 and db.max_open_conns, got %d", c.DB.MaxIdleConns)
+	v.positive(c.DB.ConnMaxLifetime, "db.conn_max_lifetime (DB_CONN_MAX_LIFETIME)")
+
+	v.required(c.Redis.Host, "redis.host (REDIS_HOST)")
+	v.port(c.Redis.Port, "redis.port (REDIS_PORT)")
+	v.check(c.Redis.DB >= 0, "redis.db (REDIS_DB) must not be negative, got %d", c.Redis.DB)
+	v.check(c.Redis.PoolSize >= 0, "redis.pool_size (REDIS_POOL_SIZE) must not be negative, got %d", c.Redis.PoolSize)
+	v.check(c.Redis.MinIdleConns >= 0, "redis.min_idle_conns (REDIS_MIN_IDLE_CONNS) must not be negative, got %d", c.Redis.MinIdleConns)
+
+	v.required(c.AWS.Region, "aws.region (AWS_REGION)")
+	v.required(c.AWS.S3Bucket, "aws.s3_bucket (S3_BUCKET)")
+
+	v.oneOf(c.Stream.Backend, "stream.backend (STREAM_BACKEND)", "redis", "memory", "nats")
+	v.required(c.Stream.Name, "stream.name (STREAM_NAME)")
+	v.check(c.Stream.MemoryCapacity > 0, "stream.memory_capacity (STREAM_MEMORY_CAPACITY) must be positive, got %d", c.Stream.MemoryCapacity)
+
+	v.required(c.Webhooks.ConsumerGroup, "webhooks.consumer_group (WEBHOOK_CONSUMER_GROUP)")
+	v.check(c.Stream.Backend != "nats" || !strings.ContainsAny(c.Webhooks.ConsumerGroup, ".*>/\\ \t\r\n"),
+		"webhooks.consumer_group (WEBHOOK_CONSUMER_GROUP) must not contain whitespace, '.', '*', '>' or path separators with the nats stream backend, got %q", c.Webhooks.ConsumerGroup)
+	v.positive(c.Webhooks.Timeout, "webhooks.timeout (WEBHOOK_TIMEOUT)")
+	v.check(c.Webhooks.MaxAttempts > 0, "webhooks.max_attempts (WEBHOOK_MAX_ATTEMPTS) must be positive, got %d", c.Webhooks.MaxAttempts)
+	v.positive(c.Webhooks.InitialBackoff, "webhooks.initial_backoff (WEBHOOK_INITIAL_BACKOFF)")
+	v.check(c.Webhooks.MaxBackoff >= c.Webhooks.InitialBackoff,
+		"webhooks.max_backoff (WEBHOOK_MAX_BACKOFF) must not be less than webhooks.initial_backoff, got %s", c.Webhooks.MaxBackoff)
+	v.check(c.Webhooks.DisableAfter >= 0, "webhooks.disable_after (WEBHOOK_DISABLE_AFTER) must not be negative, got %d", c.Webhooks.DisableAfter)
+	v.positive(c.Webhooks.RetryInterval, "webhooks.retry_interval (WEBHOOK_RETRY_INTERVAL)")
+
+	v.check(c.Events.MaxConnections > 0, "events.max_connections (EVENTS_MAX_CONNECTIONS) must be positive, got %d", c.Events.MaxConnections)
+	v.positive(c.Events.HeartbeatInterval, "events.heartbeat_interval (EVENTS_HEARTBEAT_INTERVAL)")
+
+	v.check(c.Auth.JWTSecret != "" || c.Auth.JWKSFile != "" || c.Auth.JWKSURL != "",
+		"one of auth.jwt_secret (AUTH_JWT_SECRET), auth.jwks_file (AUTH_JWKS_FILE) or auth.jwks_url (AUTH_JWKS_URL) must be set")
+	v.check(c.Auth.JWKSFile == "" || c.Auth.JWKSURL == "",
+		"auth.jwks_file (AUTH_JWKS_FILE) and auth.jwks_url (AUTH_JWKS_URL) are mutually exclusive")
+	v.positive(c.Auth.JWKSRefreshInterval, "auth.jwks_refresh_interval (AUTH_JWKS_REFRESH_INTERVAL)")
+	v.check(c.Auth.ClockSkew >= 0, "auth.clock_skew (AUTH_CLOCK_SKEW) must not be negative, got %s", c.Auth.ClockSkew)
+	v.required(c.Auth.DefaultTenant, "auth.default_tenant (AUTH_DEFAULT_TENANT)")
+
+	v.positive(c.Idempotency.TTL, "idempotency.ttl (IDEMPOTENCY_TTL)")
+	v.positive(c.Idempotency.LockTTL, "idempotency.lock_ttl (IDEMPOTENCY_LOCK_TTL)")
+
+	v.positive(c.RateLimit.FallbackCooldown, "rate_limit.fallback_cooldown (RATE_LIMIT_FALLBACK_COOLDOWN)")
+
+	v.check(c.Storage.QuotaBytes >= 0, "storage.quota_bytes (STORAGE_QUOTA_BYTES) must not be negative, got %d", c.Storage.QuotaBytes)
+	v.check(c.Storage.QuotaFiles >= 0, "storage.quota_files (STORAGE_QUOTA_FILES) must not be negative, got %d", c.Storage.QuotaFiles)
+
+	v.check(c.Todos.MaxDescriptionLength > 0, "todos.max_description_length (TODO_MAX_DESCRIPTION_LENGTH) must be positive, got %d", c.Todos.MaxDescriptionLength)
+	v.check(c.Todos.MaxDescriptionLength <= maxDescriptionLength, "todos.max_description_length (TODO_MAX_DESCRIPTION_LENGTH) must be at most %d, the most the description column holds, got %d", maxDescriptionLength, c.Todos.MaxDescriptionLength)
+	v.positive(c.Todos.DueDateHorizon, "todos.due_date_horizon (TODO_DUE_DATE_HORIZON)")
+
+	v.oneOf(c.Tracing.Exporter, "tracing.exporter (TRACING_EXPORTER)", "none", "stdout", "otlp")
+	v.required(c.Tracing.ServiceName, "tracing.service_name (OTEL_SERVICE_NAME)")
+
+	v.check(c.Log.HTTPBodyLimit >= 0, "log.http_body_limit (LOG_HTTP_BODY_LIMIT) must not be negative, got %d", c.Log.HTTPBodyLimit)
+
+	v.positive(c.Health.CheckTimeout, "health.check_timeout (HEALTH_CHECK_TIMEOUT)")
+	v.check(c.Health.CacheTTL >= 0, "health.cache_ttl (HEALTH_CACHE_TTL) must not be negative, got %s", c.Health.CacheTTL)
+
+	return v.problems
+}
@@ -0,0 +1,123 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ScopeTodosRead     = "todos:read"
+	ScopeTodosWrite    = "todos:write"
+	ScopeFilesRead     = "files:read"
+	ScopeFilesWrite    = "files:write"
+	ScopeWebhooksRead  = "webhooks:read"
+	ScopeWebhooksWrite = "webhooks:write"
+
+	apiKeyPrefix       = "tdk_"
+	apiKeyDisplayChars = 8
+)
+
+var KnownScopes = []string{ScopeTodosRead, ScopeTodosWrite, ScopeFilesRead, ScopeFilesWrite, ScopeWebhooksRead, ScopeWebhooksWrite}
+
+var (
+	ErrAPIKeyNotFound    error = &NotFoundError{Resource: "api key"}
+	ErrInsufficientScope       = errors.New("insufficient scope")
+)
+
+// APIKey is a long-lived credential for non-interactive callers. Only a
+// SHA-256 hash of the key is stored; the plaintext is shown once, when the
+// key is issued.
+type APIKey struct {
+	ID         uuid.UUID  `json:"id"`
+	TenantID   string     `json:"tenant_id"`
+	OwnerID    string     `json:"owner_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	KeyHash    string     `json:"-"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// NewAPIKey generates a key for ownerID within tenantID and returns it together with the
+// plaintext key, which is not recoverable afterwards.
+func NewAPIKey(tenantID, ownerID, name string, scopes []string, expiresAt *time.Time) (*APIKey, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	plaintext := apiKeyPrefix + hex.EncodeToString(buf)
+
+	return &APIKey{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		OwnerID:   ownerID,
+		Name:      name,
+		Prefix:    plaintext[:len(apiKeyPrefix)+apiKeyDisplayChars],
+		KeyHash:   HashAPIKey(plaintext),
+		Scopes:    scopes,
+		CreatedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	}, plaintext, nil
+}
+
+func HashAPIKey(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+func LooksLikeAPIKey(plaintext string) bool {
+	return strings.HasPrefix(plaintext, apiKeyPrefix) && len(plaintext) == len(apiKeyPrefix)+64
+}
+
+// Validate returns a *ValidationError listing every problem with the key.
+func (k *APIKey) Validate() error {
+	errs := &ValidationError{}
+	if strings.TrimSpace(k.Name) == "" {
+		errs.Add("name", "required", "is required")
+	}
+	if len(k.Scopes) == 0 {
+		errs.Add("scopes", "required", "at least one scope is required")
+	}
+	for _, scope := range k.Scopes {
+		if !isKnownScope(scope) {
+			errs.Add("scopes", "unknown_scope", fmt.Sprintf("unknown scope %q", scope))
+		}
+	}
+	if k.ExpiresAt != nil && !k.ExpiresAt.After(time.Now()) {
+		errs.Add("expires_at", "not_in_future", "must be in the future")
+	}
+	return errs.Err()
+}
+
+func (k *APIKey) IsUsable(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+func (k *APIKey) Principal() *Principal {
+	return &Principal{
+		ID:       k.OwnerID,
+		TenantID: k.TenantID,
+		Method:   AuthMethodAPIKey,
+		Scopes:   k.Scopes,
+		APIKeyID: k.ID.String(),
+	}
+}
+
+func isKnownScope(scope string) bool {
+	for _, known := range KnownScopes {
+		if scope == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package entities
+
+import (
+	"strings"
+)
+
+// FieldViolation is one problem with one input. Field is the input's name as
+// the client sent it: a body property's JSON path such as "description" or
+// "changes[1].op", a parameter or header such as "id" or "If-Match", or
+// "body" for the body as a whole. Code is a stable, machine-readable reason
+// such as "required".
+type FieldViolation struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidationError reports every problem found in an input at once, so a
+// client can fix them all before retrying.
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+func NewValidationError(violations ...FieldViolation) *ValidationError {
+	return &ValidationError{Violations: violations}
+}
+
+// Add records a violation; use Err to return the error only when there was
+// at least one.
+func (e *ValidationError) Add(field, code, message string) {
+	e.Violations = append(e.Violations, FieldViolation{Field: field, Code: code, Message: message})
+}
+
+// Err returns e, or nil when no violation was recorded.
+func (e *ValidationError) Err() error {
+	if len(e.Violations) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.Field + ": " + violation.Message
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// NotFoundError means the resource does not exist or is not visible to the
+// caller; the two are deliberately indistinguishable.
+type NotFoundError struct {
+	Resource string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Resource + " not found"
+}
+
+// Code is the stable error code the APIs report, e.g. "todo_not_found".
+func (e *NotFoundError) Code() string {
+	return strings.ReplaceAll(e.Resource, " ", "_") + "_not_found"
+}
+
+// ConflictError means the request cannot be applied to the resource in its
+// current state.
+type ConflictError struct {
+	Code    string
+	Message string
+}
+
+func (e *ConflictError) Error() string {
+	return e.Message
+}
+
+// UnavailableError means a dependency the operation needs failed; the
+// request may succeed if retried later.
+type UnavailableError struct {
+	Dependency string
+	Err        error
+}
+
+func NewUnavailableError(dependency string, err error) *UnavailableError {
+	return &UnavailableError{Dependency: dependency, Err: err}
+}
+
+func (e *UnavailableError) Error() string {
+	return e.Dependency + " unavailable: " + e.Err.Error()
+}
+
+func (e *UnavailableError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,135 @@
+package entities
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	EventTypeTodoCreated  = "todo.created"
+	EventTypeTodoUpdated  = "todo.updated"
+	EventTypeTodoDeleted  = "todo.deleted"
+	EventTypeTodoShared   = "todo.shared"
+	EventTypeTodoUnshared = "todo.unshared"
+)
+
+var eventIDPattern = regexp.MustCompile(`^\d+(-\d+)?$`)
+
+type Event struct {
+	ID         string          `json:"id,omitempty"`
+	Type       string          `json:"type"`
+	TodoID     string          `json:"todo_id,omitempty"`
+	TenantID   string          `json:"tenant_id,omitempty"`
+	OwnerID    string          `json:"owner_id,omitempty"`
+	GranteeIDs []string        `json:"grantee_ids,omitempty"`
+	Data       json.RawMessage `json:"data,omitempty"`
+	Timestamp  int64           `json:"timestamp"`
+}
+
+func NewEvent(eventType, todoID string, payload interface{}) (*Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s event payload: %w", eventType, err)
+	}
+
+	return &Event{
+		Type:      eventType,
+		TodoID:    todoID,
+		Data:      data,
+		Timestamp: time.Now().Unix(),
+	}, nil
+}
+
+// NewTodoEvent describes a change to todo. grants are the todo's current
+// grants, whose users may see the event as well as the owner.
+func NewTodoEvent(eventType string, todo *TodoItem, grants ...*TodoGrant) (*Event, error) {
+	event, err := NewEvent(eventType, todo.ID.String(), todo)
+	if err != nil {
+		return nil, err
+	}
+
+	event.TenantID = todo.TenantID
+	event.OwnerID = todo.OwnerID
+	event.addGrantees(grants)
+	return event, nil
+}
+
+// NewTodoGrantEvent describes a change to who can access todo. The event
+// belongs to the todo's owner, like every other event about the todo, and
+// is visible to the users of grants and to the user grant was made for,
+// even once it has been revoked.
+func NewTodoGrantEvent(eventType string, todo *TodoItem, grant *TodoGrant, grants ...*TodoGrant) (*Event, error) {
+	event, err := NewEvent(eventType, todo.ID.String(), grant)
+	if err != nil {
+		return nil, err
+	}
+
+	event.TenantID = todo.TenantID
+	event.OwnerID = todo.OwnerID
+	event.addGrantees(append([]*TodoGrant{grant}, grants...))
+	return event, nil
+}
+
+// VisibleTo reports whether userID may see the event: the todo's owner and
+// the users it was shared with may, with the same rule reads follow.
+func (e *Event) VisibleTo(userID string) bool {
+	if e.OwnerID == userID {
+		return true
+	}
+	for _, granteeID := range e.GranteeIDs {
+		if granteeID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Event) addGrantees(grants []*TodoGrant) {
+	for _, grant := range grants {
+		if !e.VisibleTo(grant.UserID) {
+			e.GranteeIDs = append(e.GranteeIDs, grant.UserID)
+		}
+	}
+}
+
+func IsValidEventID(id string) bool {
+	return eventIDPattern.MatchString(id)
+}
+
+// CompareEventIDs orders two valid event IDs the way the stream does,
+// returning -1, 0 or +1.
+func CompareEventIDs(a, b string) int {
+	aTime, aSequence := splitEventID(a)
+	bTime, bSequence := splitEventID(b)
+	if c := cmp.Compare(aTime, bTime); c != 0 {
+		return c
+	}
+	return cmp.Compare(aSequence, bSequence)
+}
+
+func splitEventID(id string) (uint64, uint64) {
+	timePart, sequencePart, _ := strings.Cut(id, "-")
+	timestamp, _ := strconv.ParseUint(timePart, 10, 64)
+	sequence, _ := strconv.ParseUint(sequencePart, 10, 64)
+	return timestamp, sequence
+}
+
+// MatchesEventType reports whether eventType is selected by any of the
+// patterns. "*" selects everything and "todo.*" selects every type with the
+// "todo." prefix.
+func MatchesEventType(patterns []string, eventType string) bool {
+	for _, pattern := range patterns {
+		switch {
+		case pattern == "*" || pattern == eventType:
+			return true
+		case strings.HasSuffix(pattern, ".*") && strings.HasPrefix(eventType, strings.TrimSuffix(pattern, "*")):
+			return true
+		}
+	}
+	return false
+}
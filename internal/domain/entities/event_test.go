@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareEventIDs(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1700000000000-0", b: "1700000000000-0", want: 0},
+		{a: "1700000000000-1", b: "1700000000000-0", want: 1},
+		{a: "1700000000000-9", b: "1700000000000-10", want: -1},
+		{a: "999-5", b: "1000-0", want: -1},
+		{a: "42", b: "42-0", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.want, CompareEventIDs(tt.a, tt.b))
+		})
+	}
+}
+
+func TestEvent_VisibleTo(t *testing.T) {
+	todo := NewTodoItem("acme", "user-1", "Buy milk", Now().AddDate(0, 0, 1), nil)
+	grant, err := NewTodoGrant(todo, "user-2", RoleViewer, "user-1")
+	require.NoError(t, err)
+
+	event, err := NewTodoEvent(EventTypeTodoUpdated, todo, grant)
+	require.NoError(t, err)
+
+	assert.True(t, event.VisibleTo("user-1"))
+	assert.True(t, event.VisibleTo("user-2"))
+	assert.False(t, event.VisibleTo("user-3"))
+}
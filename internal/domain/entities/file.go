@@ -1,10 +1,12 @@
 package entities
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 )
@@ -13,6 +15,8 @@ const (
 	MaxFileSize = 10 * 1024 * 1024
 )
 
+var ErrFileNotFound error = &NotFoundError{Resource: "file"}
+
 var allowedExtensions = map[string]bool{
 	".jpg":  true,
 	".jpeg": true,
@@ -26,6 +30,8 @@ var allowedExtensions = map[string]bool{
 
 type File struct {
 	ID          uuid.UUID `json:"id"`
+	TenantID    string    `json:"tenant_id"`
+	OwnerID     string    `json:"owner_id"`
 	FileName    string    `json:"file_name"`
 	ContentType string    `json:"content_type"`
 	Size        int64     `json:"size"`
@@ -34,45 +40,93 @@ type File struct {
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
-func NewFile(fileName, contentType string, size int64) *File {
+func NewFile(tenantID, ownerID, fileName, contentType string, size int64) *File {
 	now := time.Now()
 	id := uuid.New()
 	return &File{
 		ID:          id,
+		TenantID:    tenantID,
+		OwnerID:     ownerID,
 		FileName:    fileName,
 		ContentType: contentType,
 		Size:        size,
-		StoragePath: generateStoragePath(id.String(), fileName),
+		StoragePath: generateStoragePath(tenantID, id.String(), fileName),
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
 }
 
 func (f *File) IsValid() bool {
-	return f.FileName != "" && f.Size > 0 && f.ID != uuid.Nil
+	return f.FileName != "" && f.Size > 0 && f.ID != uuid.Nil && f.TenantID != "" && f.OwnerID != ""
+}
+
+// File rejection reasons, used as a bounded metric label.
+const (
+	FileRejectedTooLarge   = "too_large"
+	FileRejectedEmpty      = "empty"
+	FileRejectedType       = "type_not_allowed"
+	FileRejectedNoName     = "missing_name"
+	FileRejectedName       = "invalid_name"
+	FileRejectedQuota      = "quota_exceeded"
+	FileRejectedOtherError = "other"
+)
+
+// FileValidationError is returned by ValidateFile; Reason is one of the
+// FileRejected* constants.
+type FileValidationError struct {
+	Reason  string
+	Message string
+}
+
+func (e *FileValidationError) Error() string {
+	return e.Message
+}
+
+// FileRejectionReason classifies an upload error for metrics.
+func FileRejectionReason(err error) string {
+	var validationErr *FileValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return validationErr.Reason
+	case errors.Is(err, ErrStorageQuotaExceeded):
+		return FileRejectedQuota
+	default:
+		return FileRejectedOtherError
+	}
 }
 
 func ValidateFile(fileName string, size int64) error {
 	if size > MaxFileSize {
-		return fmt.Errorf("file size exceeds maximum allowed size of %d bytes", MaxFileSize)
+		return &FileValidationError{FileRejectedTooLarge, fmt.Sprintf("file size exceeds maximum allowed size of %d bytes", MaxFileSize)}
 	}
 
 	if size <= 0 {
-		return fmt.Errorf("file size must be greater than 0")
+		return &FileValidationError{FileRejectedEmpty, "file size must be greater than 0"}
 	}
 
-	ext := strings.ToLower(filepath.Ext(fileName))
-	if !allowedExtensions[ext] {
-		return fmt.Errorf("file type %s is not allowed", ext)
+	// The name becomes part of the storage key, so it must not be able to
+	// step out of the file's own prefix.
+	if strings.ContainsAny(fileName, `/\`) || strings.Contains(fileName, "..") || strings.IndexFunc(fileName, unicode.IsControl) >= 0 {
+		return &FileValidationError{FileRejectedName, `file name must not contain path separators, ".." or control characters`}
 	}
 
-	if fileName == "" {
-		return fmt.Errorf("file name cannot be empty")
+	// A name that is only an extension, such as ".pdf", has nothing to show
+	// the user either.
+	ext := filepath.Ext(fileName)
+	if strings.TrimSpace(strings.TrimSuffix(fileName, ext)) == "" {
+		return &FileValidationError{FileRejectedNoName, "file name cannot be empty"}
+	}
+
+	ext = strings.ToLower(ext)
+	if !allowedExtensions[ext] {
+		return &FileValidationError{FileRejectedType, fmt.Sprintf("file type %s is not allowed", ext)}
 	}
 
 	return nil
 }
 
-func generateStoragePath(fileID, fileName string) string {
-	return "files/" + fileID + "/" + fileName
+// generateStoragePath keeps every tenant's objects under its own prefix so
+// bucket policies and lifecycle rules can be applied per tenant.
+func generateStoragePath(tenantID, fileID, fileName string) string {
+	return "tenants/" + tenantID + "/files/" + fileID + "/" + fileName
 }
@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFile(t *testing.T) {
+	tests := []struct {
+		name       string
+		fileName   string
+		size       int64
+		wantReason string
+	}{
+		{name: "valid", fileName: "Q3 report.pdf", size: 10},
+		{name: "too large", fileName: "notes.txt", size: MaxFileSize + 1, wantReason: FileRejectedTooLarge},
+		{name: "empty", fileName: "notes.txt", size: 0, wantReason: FileRejectedEmpty},
+		{name: "disallowed type", fileName: "setup.exe", size: 10, wantReason: FileRejectedType},
+		{name: "no extension", fileName: "notes", size: 10, wantReason: FileRejectedType},
+		{name: "no name", fileName: "", size: 10, wantReason: FileRejectedNoName},
+		{name: "only an extension", fileName: ".pdf", size: 10, wantReason: FileRejectedNoName},
+		{name: "blank before the extension", fileName: "  .pdf", size: 10, wantReason: FileRejectedNoName},
+		{name: "parent directory", fileName: "../notes.txt", size: 10, wantReason: FileRejectedName},
+		{name: "other tenant", fileName: "tenants/other/files/x/notes.txt", size: 10, wantReason: FileRejectedName},
+		{name: "backslash", fileName: `..\notes.txt`, size: 10, wantReason: FileRejectedName},
+		{name: "dot dot", fileName: "notes..txt", size: 10, wantReason: FileRejectedName},
+		{name: "newline", fileName: "notes\n.txt", size: 10, wantReason: FileRejectedName},
+		{name: "nul", fileName: "notes.txt\x00.exe", size: 10, wantReason: FileRejectedName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFile(tt.fileName, tt.size)
+			if tt.wantReason == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, tt.wantReason, FileRejectionReason(err))
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrIdempotencyKeyInUse    error = &ConflictError{Code: "request_in_progress", Message: "a request with this idempotency key is still in progress"}
+	ErrIdempotencyKeyMismatch error = &ConflictError{Code: "idempotency_key_reused", Message: "idempotency key was already used with a different request"}
+
+	// ErrIdempotencyLockLost is returned when a request finishes after its
+	// claim on the key expired and another request took it over.
+	ErrIdempotencyLockLost = errors.New("idempotency key is no longer held by this request")
+)
+
+const (
+	IdempotencyStatusInFlight  = "in_flight"
+	IdempotencyStatusCompleted = "completed"
+)
+
+// IdempotencyRecord is what is remembered about a request carrying an
+// Idempotency-Key: the fingerprint of the original request and, once it has
+// finished, the response to replay for retries. Token identifies the request
+// holding the in-flight claim, so a request whose claim has expired cannot
+// overwrite or release the claim of the one that replaced it.
+type IdempotencyRecord struct {
+	Key         string      `json:"key"`
+	Token       string      `json:"token"`
+	Fingerprint string      `json:"fingerprint"`
+	Status      string      `json:"status"`
+	StatusCode  int         `json:"status_code,omitempty"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        []byte      `json:"body,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+func NewIdempotencyRecord(key, fingerprint string) *IdempotencyRecord {
+	return &IdempotencyRecord{
+		Key:         key,
+		Token:       uuid.NewString(),
+		Fingerprint: fingerprint,
+		Status:      IdempotencyStatusInFlight,
+		CreatedAt:   time.Now(),
+	}
+}
+
+func (r *IdempotencyRecord) IsCompleted() bool {
+	return r.Status == IdempotencyStatusCompleted
+}
+
+func (r *IdempotencyRecord) Complete(statusCode int, header http.Header, body []byte) {
+	r.Status = IdempotencyStatusCompleted
+	r.StatusCode = statusCode
+	r.Header = header
+	r.Body = body
+}
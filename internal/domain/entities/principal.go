@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"context"
+	"errors"
+	"regexp"
+)
+
+var (
+	ErrUnauthenticated    = errors.New("authentication required")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Tenant IDs end up in storage paths and stream fields, so they are limited
+// to a conservative character set.
+var tenantIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,63}$`)
+
+const (
+	AuthMethodJWT    = "jwt"
+	AuthMethodAPIKey = "api_key"
+)
+
+// Principal is the authenticated caller of a request. ID is the stable
+// subject identifier that owns todos, files and webhooks; TenantID is the
+// workspace all of them live in.
+type Principal struct {
+	ID       string   `json:"id"`
+	TenantID string   `json:"tenant_id"`
+	Method   string   `json:"method"`
+	Scopes   []string `json:"scopes,omitempty"`
+	APIKeyID string   `json:"api_key_id,omitempty"`
+}
+
+// HasScope reports whether the principal may perform operations guarded by
+// scope. User tokens without a scope claim act with the user's full rights;
+// API keys and scoped tokens are limited to what they list.
+func (p *Principal) HasScope(scope string) bool {
+	if p.Method == AuthMethodJWT && len(p.Scopes) == 0 {
+		return true
+	}
+	for _, granted := range p.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func IsValidTenantID(tenantID string) bool {
+	return tenantIDPattern.MatchString(tenantID)
+}
+
+type principalContextKey struct{}
+
+func ContextWithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(*Principal)
+	return principal, ok && principal != nil && principal.ID != "" && principal.TenantID != ""
+}
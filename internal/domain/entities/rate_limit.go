@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimit allows Limit requests per Period. Bursts of up to Limit
+// requests are allowed, after which requests are admitted evenly, one every
+// Period/Limit.
+type RateLimit struct {
+	Limit  int
+	Period time.Duration
+}
+
+// ParseRateLimit parses limits written as "<requests>/<period>", e.g.
+// "100/1m" or "5/1s".
+func ParseRateLimit(value string) (RateLimit, error) {
+	count, period, ok := strings.Cut(strings.TrimSpace(value), "/")
+	if !ok {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q: expected <requests>/<period>", value)
+	}
+
+	limit, err := strconv.Atoi(count)
+	if err != nil || limit <= 0 {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q: requests must be a positive integer", value)
+	}
+
+	duration, err := time.ParseDuration(period)
+	if err != nil || duration <= 0 {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q: period must be a positive duration", value)
+	}
+
+	return RateLimit{Limit: limit, Period: duration}, nil
+}
+
+// Interval is the steady-state spacing between admitted requests.
+func (l RateLimit) Interval() time.Duration {
+	return l.Period / time.Duration(l.Limit)
+}
+
+func (l RateLimit) String() string {
+	return strconv.Itoa(l.Limit) + "/" + l.Period.String()
+}
+
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration
+	RetryAfter time.Duration
+}
@@ -0,0 +1,82 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrStorageQuotaExceeded = errors.New("storage quota exceeded")
+
+// StorageQuota caps what a single user may keep in file storage. A zero
+// limit means unlimited.
+type StorageQuota struct {
+	MaxBytes int64
+	MaxFiles int64
+}
+
+// StorageUsage is the running total of a user's uploaded files.
+type StorageUsage struct {
+	TenantID  string    `json:"tenant_id"`
+	OwnerID   string    `json:"owner_id"`
+	Bytes     int64     `json:"bytes"`
+	Files     int64     `json:"files"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RemainingBytes returns how many more bytes fit in the quota, or -1 when
+// bytes are unlimited.
+func (u *StorageUsage) RemainingBytes(quota StorageQuota) int64 {
+	return remaining(quota.MaxBytes, u.Bytes)
+}
+
+// RemainingFiles returns how many more files fit in the quota, or -1 when
+// the file count is unlimited.
+func (u *StorageUsage) RemainingFiles(quota StorageQuota) int64 {
+	return remaining(quota.MaxFiles, u.Files)
+}
+
+// Fits reports whether one more file of size bytes stays within quota.
+func (u *StorageUsage) Fits(quota StorageQuota, size int64) bool {
+	if quota.MaxBytes > 0 && u.Bytes+size > quota.MaxBytes {
+		return false
+	}
+	return quota.MaxFiles <= 0 || u.Files+1 <= quota.MaxFiles
+}
+
+func remaining(limit, used int64) int64 {
+	if limit <= 0 {
+		return -1
+	}
+	if used >= limit {
+		return 0
+	}
+	return limit - used
+}
+
+// QuotaExceededError reports an upload rejected by the caller's storage
+// quota together with what is left of it. It matches
+// ErrStorageQuotaExceeded with errors.Is.
+type QuotaExceededError struct {
+	Quota     StorageQuota
+	Usage     StorageUsage
+	Requested int64
+}
+
+func (e *QuotaExceededError) Error() string {
+	if e.Quota.MaxFiles > 0 && e.Usage.Files >= e.Quota.MaxFiles {
+		return fmt.Sprintf("%s: file limit of %d reached", ErrStorageQuotaExceeded, e.Quota.MaxFiles)
+	}
+	return fmt.Sprintf("%s: %d bytes requested, %d of %d bytes remaining",
+		ErrStorageQuotaExceeded, e.Requested, e.Usage.RemainingBytes(e.Quota), e.Quota.MaxBytes)
+}
+
+func (e *QuotaExceededError) Unwrap() error {
+	return ErrStorageQuotaExceeded
+}
+
+// TooLarge reports whether the file could never fit, even with an empty
+// quota.
+func (e *QuotaExceededError) TooLarge() bool {
+	return e.Quota.MaxBytes > 0 && e.Requested > e.Quota.MaxBytes
+}
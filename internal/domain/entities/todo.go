@@ -1,32 +1,90 @@
 package entities
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrTodoNotFound        error = &NotFoundError{Resource: "todo"}
+	ErrTodoVersionMismatch error = &ConflictError{Code: "version_mismatch", Message: "todo version does not match"}
+	ErrTodoAlreadyExists   error = &ConflictError{Code: "todo_exists", Message: "todo already exists"}
+)
+
 type TodoItem struct {
-	ID          uuid.UUID `json:"id"`
-	Description string    `json:"description"`
-	DueDate     time.Time `json:"due_date"`
-	FileID      *string   `json:"file_id,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uuid.UUID  `json:"id"`
+	TenantID    string     `json:"tenant_id"`
+	OwnerID     string     `json:"owner_id"`
+	Description string     `json:"description"`
+	DueDate     time.Time  `json:"due_date"`
+	FileID      *string    `json:"file_id,omitempty"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	Version     int        `json:"version"`
+	ChangeSeq   int64      `json:"-"`
 }
 
-func NewTodoItem(description string, dueDate time.Time, fileID *string) *TodoItem {
-	now := time.Now()
+// NewTodoItem creates a todo; check it with TodoRules.Validate. The
+// description is stored without surrounding whitespace, and the due date in
+// UTC whatever zone the client sent it in.
+func NewTodoItem(tenantID, ownerID, description string, dueDate time.Time, fileID *string) *TodoItem {
+	now := Now()
 	return &TodoItem{
 		ID:          uuid.New(),
-		Description: description,
-		DueDate:     dueDate,
+		TenantID:    tenantID,
+		OwnerID:     ownerID,
+		Description: strings.TrimSpace(description),
+		DueDate:     dueDate.UTC(),
 		FileID:      fileID,
 		CreatedAt:   now,
 		UpdatedAt:   now,
+		Version:     1,
 	}
 }
 
-func (t *TodoItem) IsValid() bool {
-	return t.Description != "" && t.ID != uuid.Nil
+func (t *TodoItem) IsDeleted() bool {
+	return t.DeletedAt != nil
+}
+
+func (t *TodoItem) Update(description string, dueDate time.Time, fileID *string) {
+	t.Description = strings.TrimSpace(description)
+	t.DueDate = dueDate.UTC()
+	t.FileID = fileID
+	t.UpdatedAt = Now()
+}
+
+func (t *TodoItem) IsCompleted() bool {
+	return t.CompletedAt != nil
+}
+
+// SetCompleted completes or reopens the todo. Completing an already
+// completed todo keeps the original completion time.
+func (t *TodoItem) SetCompleted(completed bool) {
+	if completed == t.IsCompleted() {
+		return
+	}
+
+	now := Now()
+	if completed {
+		t.CompletedAt = &now
+	} else {
+		t.CompletedAt = nil
+	}
+	t.UpdatedAt = now
+}
+
+func (t *TodoItem) MarkDeleted() {
+	now := Now()
+	t.DeletedAt = &now
+	t.UpdatedAt = now
+}
+
+// Now returns the current time at the microsecond precision MySQL stores,
+// so timestamps handed to clients compare equal after a round trip.
+func Now() time.Time {
+	return time.Now().Truncate(time.Microsecond)
 }
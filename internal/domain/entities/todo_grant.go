@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RoleOwner  = "owner"
+	RoleEditor = "editor"
+	RoleViewer = "viewer"
+)
+
+var roleRank = map[string]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleOwner:  3,
+}
+
+var (
+	ErrTodoGrantNotFound error = &NotFoundError{Resource: "todo grant"}
+	ErrPermissionDenied        = errors.New("permission denied")
+	ErrInvalidRole             = errors.New("invalid role")
+)
+
+// TodoGrant gives a user other than the todo's owner access to it. Viewers
+// may read the todo, editors may also change it, and owners may additionally
+// delete and share it.
+type TodoGrant struct {
+	TodoID    uuid.UUID `json:"todo_id"`
+	TenantID  string    `json:"tenant_id"`
+	UserID    string    `json:"user_id"`
+	Role      string    `json:"role"`
+	GrantedBy string    `json:"granted_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewTodoGrant(todo *TodoItem, userID, role, grantedBy string) (*TodoGrant, error) {
+	if !IsValidRole(role) {
+		return nil, ErrInvalidRole
+	}
+
+	now := Now()
+	return &TodoGrant{
+		TodoID:    todo.ID,
+		TenantID:  todo.TenantID,
+		UserID:    userID,
+		Role:      role,
+		GrantedBy: grantedBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+func IsValidRole(role string) bool {
+	_, ok := roleRank[role]
+	return ok
+}
+
+// RoleAtLeast reports whether role carries at least the rights of required.
+// The empty role carries none.
+func RoleAtLeast(role, required string) bool {
+	return roleRank[role] > 0 && roleRank[role] >= roleRank[required]
+}
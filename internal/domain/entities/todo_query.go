@@ -0,0 +1,75 @@
+package entities
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+const (
+	TodoStatusOpen      = "open"
+	TodoStatusCompleted = "completed"
+	// TodoStatusOverdue selects open todos whose due date has passed.
+	TodoStatusOverdue = "overdue"
+)
+
+// TodoFilter narrows a todo listing. Zero fields match everything; Search
+// is a case-insensitive substring of the description.
+type TodoFilter struct {
+	Status        string
+	DueAfter      *time.Time
+	DueBefore     *time.Time
+	Search        string
+	HasAttachment *bool
+}
+
+// TodoCursor is a todo's position in listings, which are ordered newest
+// first and by ID among todos created at the same time.
+type TodoCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+func NewTodoCursor(todo *TodoItem) TodoCursor {
+	return TodoCursor{CreatedAt: todo.CreatedAt, ID: todo.ID}
+}
+
+// Encode returns the cursor as an opaque string for clients to send back.
+func (c TodoCursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()))
+}
+
+func DecodeTodoCursor(value string) (TodoCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return TodoCursor{}, ErrInvalidCursor
+	}
+
+	createdAt, id, found := strings.Cut(string(raw), "|")
+	if !found {
+		return TodoCursor{}, ErrInvalidCursor
+	}
+
+	var cursor TodoCursor
+	if cursor.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return TodoCursor{}, ErrInvalidCursor
+	}
+	if cursor.ID, err = uuid.Parse(id); err != nil {
+		return TodoCursor{}, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+// TodoCounts breaks down the todos a user can see by status. Overdue todos
+// are also counted as open.
+type TodoCounts struct {
+	Total     int `json:"total"`
+	Open      int `json:"open"`
+	Completed int `json:"completed"`
+	Overdue   int `json:"overdue"`
+}
@@ -0,0 +1,129 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultMaxDescriptionLength = 1000
+	DefaultDueDateHorizon       = 10 * 365 * 24 * time.Hour
+
+	// dueDateClockSkew lets a due date of "now" through even if the client's
+	// clock is slightly ahead of ours.
+	dueDateClockSkew = time.Minute
+)
+
+// TodoRules are the limits a todo must stay within. They are checked when a
+// todo is created or changed, never on stored todos.
+type TodoRules struct {
+	// MaxDescriptionLength is counted in characters. NewTodoItem and Update
+	// trim surrounding whitespace, so it bounds what is stored.
+	MaxDescriptionLength int
+	// AllowPastDueDates lets clients record todos that are already overdue,
+	// e.g. when importing them from elsewhere.
+	AllowPastDueDates bool
+	// DueDateHorizon is how far from now a due date may be: in the future,
+	// and in the past too when past due dates are allowed.
+	DueDateHorizon time.Duration
+}
+
+func DefaultTodoRules() TodoRules {
+	return TodoRules{
+		MaxDescriptionLength: DefaultMaxDescriptionLength,
+		DueDateHorizon:       DefaultDueDateHorizon,
+	}
+}
+
+// Validate checks a new todo, reporting every violation at once in a
+// *ValidationError.
+func (r TodoRules) Validate(todo *TodoItem, now time.Time) error {
+	return r.validate(todo, now, true)
+}
+
+// ValidateUpdate checks a todo changed by Update. A due date left as it was
+// is not held to the bounds, so an overdue todo can still be edited.
+func (r TodoRules) ValidateUpdate(todo *TodoItem, previousDueDate, now time.Time) error {
+	return r.validate(todo, now, !todo.DueDate.Equal(previousDueDate))
+}
+
+func (r TodoRules) validate(todo *TodoItem, now time.Time, checkDueDate bool) error {
+	errs := &ValidationError{}
+
+	r.validateDescription(errs, todo.Description)
+
+	switch {
+	case todo.DueDate.IsZero():
+		errs.Add("due_date", "required", "is required")
+	case checkDueDate:
+		r.validateDueDate(errs, todo.DueDate, now)
+	}
+
+	if todo.FileID != nil && !isCanonicalUUID(*todo.FileID) {
+		errs.Add("file_id", "invalid_uuid", "must be a UUID")
+	}
+
+	return errs.Err()
+}
+
+func (r TodoRules) validateDescription(errs *ValidationError, description string) {
+	if !utf8.ValidString(description) {
+		errs.Add("description", "invalid_utf8", "must be valid UTF-8")
+		return
+	}
+
+	switch length := utf8.RuneCountInString(description); {
+	case length == 0:
+		errs.Add("description", "required", "is required")
+		return
+	case length > r.MaxDescriptionLength:
+		errs.Add("description", "too_long", fmt.Sprintf("must be at most %d characters, got %d", r.MaxDescriptionLength, length))
+	}
+
+	if strings.IndexFunc(description, isDisallowedControl) >= 0 {
+		errs.Add("description", "control_character", "must not contain control characters other than tabs and line breaks")
+	}
+}
+
+// validateDueDate compares instants, so a due date given in any time zone
+// is judged the same as its UTC equivalent. That is enough because no API
+// accepts a time without a zone: REST rejects timestamps without a UTC
+// offset (missing_timezone), GraphQL's Time takes RFC 3339, which requires
+// one, or Unix times, and a gRPC Timestamp is an instant. The zone a client
+// sent is not kept; showing the due date in a zone is the client's business.
+func (r TodoRules) validateDueDate(errs *ValidationError, dueDate, now time.Time) {
+	earliest := now.Add(-dueDateClockSkew)
+	if r.AllowPastDueDates {
+		earliest = now.Add(-r.DueDateHorizon)
+	}
+	latest := now.Add(r.DueDateHorizon)
+
+	switch {
+	case dueDate.Before(earliest) && !r.AllowPastDueDates:
+		errs.Add("due_date", "in_past", "must not be in the past")
+	case dueDate.Before(earliest):
+		errs.Add("due_date", "too_early", "must not be before "+earliest.UTC().Format(time.RFC3339))
+	case dueDate.After(latest):
+		errs.Add("due_date", "too_late", "must not be after "+latest.UTC().Format(time.RFC3339))
+	}
+}
+
+func isDisallowedControl(r rune) bool {
+	return unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t'
+}
+
+// isCanonicalUUID accepts only the hyphenated form; uuid.Parse also takes
+// URNs, braces and bare hex, which would store the same file under
+// different IDs.
+func isCanonicalUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	_, err := uuid.Parse(s)
+	return err == nil
+}
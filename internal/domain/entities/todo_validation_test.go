@@ -0,0 +1,227 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodoRules_Validate(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	tomorrow := now.Add(24 * time.Hour)
+	fileID := "6f1c2b1e-8d3a-4c55-9a57-3b0e2f6d9c11"
+	pastAllowed := DefaultTodoRules()
+	pastAllowed.AllowPastDueDates = true
+
+	tests := []struct {
+		name        string
+		rules       TodoRules
+		description string
+		dueDate     time.Time
+		fileID      *string
+		want        []FieldViolation
+	}{
+		{
+			name:        "valid",
+			description: "Buy milk",
+			dueDate:     tomorrow,
+			fileID:      &fileID,
+		},
+		{
+			name:        "multi-line description",
+			description: "Groceries:\n\t- milk\r\n\t- eggs",
+			dueDate:     tomorrow,
+		},
+		{
+			name:        "empty description",
+			description: "",
+			dueDate:     tomorrow,
+			want:        []FieldViolation{{Field: "description", Code: "required", Message: "is required"}},
+		},
+		{
+			name:        "whitespace-only description",
+			description: " \t\n ",
+			dueDate:     tomorrow,
+			want:        []FieldViolation{{Field: "description", Code: "required", Message: "is required"}},
+		},
+		{
+			name:        "description at the limit after trimming",
+			description: "  " + strings.Repeat("é", DefaultMaxDescriptionLength) + "\n",
+			dueDate:     tomorrow,
+		},
+		{
+			name:        "description padded with whitespace",
+			description: "  Buy milk" + strings.Repeat(" ", 100*1024) + "\n",
+			dueDate:     tomorrow,
+		},
+		{
+			name:        "description too long",
+			description: strings.Repeat("a", 100*1024),
+			dueDate:     tomorrow,
+			want:        []FieldViolation{{Field: "description", Code: "too_long", Message: "must be at most 1000 characters, got 102400"}},
+		},
+		{
+			name:        "invalid UTF-8",
+			description: "Buy \xff milk",
+			dueDate:     tomorrow,
+			want:        []FieldViolation{{Field: "description", Code: "invalid_utf8", Message: "must be valid UTF-8"}},
+		},
+		{
+			name:        "control character",
+			description: "Buy\x00milk\x1b[31m",
+			dueDate:     tomorrow,
+			want:        []FieldViolation{{Field: "description", Code: "control_character", Message: "must not contain control characters other than tabs and line breaks"}},
+		},
+		{
+			name:        "missing due date",
+			description: "Buy milk",
+			want:        []FieldViolation{{Field: "due_date", Code: "required", Message: "is required"}},
+		},
+		{
+			name:        "due date in 1970",
+			description: "Buy milk",
+			dueDate:     time.Unix(0, 0),
+			want:        []FieldViolation{{Field: "due_date", Code: "in_past", Message: "must not be in the past"}},
+		},
+		{
+			name:        "due date within clock skew",
+			description: "Buy milk",
+			dueDate:     now.Add(-30 * time.Second),
+		},
+		{
+			name:        "due date too far ahead",
+			description: "Buy milk",
+			dueDate:     now.AddDate(20, 0, 0),
+			want:        []FieldViolation{{Field: "due_date", Code: "too_late", Message: "must not be after 2036-10-15T12:00:00Z"}},
+		},
+		{
+			name:        "due date compared across time zones",
+			description: "Buy milk",
+			// 13:30 in New York is 17:30 UTC, after now.
+			dueDate: time.Date(2026, 10, 18, 13, 30, 0, 0, time.FixedZone("EDT", -4*60*60)),
+		},
+		{
+			name:        "past due date allowed",
+			rules:       pastAllowed,
+			description: "Buy milk",
+			dueDate:     now.AddDate(-1, 0, 0),
+		},
+		{
+			name:        "past due date allowed but beyond the horizon",
+			rules:       pastAllowed,
+			description: "Buy milk",
+			dueDate:     time.Unix(0, 0),
+			want:        []FieldViolation{{Field: "due_date", Code: "too_early", Message: "must not be before 2016-10-20T12:00:00Z"}},
+		},
+		{
+			name:        "file ID not a UUID",
+			description: "Buy milk",
+			dueDate:     tomorrow,
+			fileID:      stringPtr("file-123"),
+			want:        []FieldViolation{{Field: "file_id", Code: "invalid_uuid", Message: "must be a UUID"}},
+		},
+		{
+			name:        "file ID as URN",
+			description: "Buy milk",
+			dueDate:     tomorrow,
+			fileID:      stringPtr("urn:uuid:" + fileID),
+			want:        []FieldViolation{{Field: "file_id", Code: "invalid_uuid", Message: "must be a UUID"}},
+		},
+		{
+			name:        "every violation at once",
+			description: "   ",
+			dueDate:     time.Unix(0, 0),
+			fileID:      stringPtr(""),
+			want: []FieldViolation{
+				{Field: "description", Code: "required", Message: "is required"},
+				{Field: "due_date", Code: "in_past", Message: "must not be in the past"},
+				{Field: "file_id", Code: "invalid_uuid", Message: "must be a UUID"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := tt.rules
+			if rules == (TodoRules{}) {
+				rules = DefaultTodoRules()
+			}
+			todo := NewTodoItem("tenant-1", "user-1", tt.description, tt.dueDate, tt.fileID)
+			// The length checked is the length stored.
+			assert.Equal(t, strings.TrimSpace(tt.description), todo.Description)
+
+			err := rules.Validate(todo, now)
+
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.want, validationErr.Violations)
+		})
+	}
+}
+
+func TestTodoRules_ValidateUpdate(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	overdue := now.Add(-48 * time.Hour)
+
+	tests := []struct {
+		name    string
+		dueDate time.Time
+		want    []FieldViolation
+	}{
+		{
+			name:    "overdue due date kept",
+			dueDate: overdue,
+		},
+		{
+			name:    "overdue due date kept in another time zone",
+			dueDate: overdue.In(time.FixedZone("CEST", 2*60*60)),
+		},
+		{
+			name:    "due date moved further into the past",
+			dueDate: overdue.Add(-time.Hour),
+			want:    []FieldViolation{{Field: "due_date", Code: "in_past", Message: "must not be in the past"}},
+		},
+		{
+			name:    "due date moved into the future",
+			dueDate: now.Add(time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todo := NewTodoItem("tenant-1", "user-1", "Buy milk", overdue, nil)
+			previousDueDate := todo.DueDate
+			todo.Update("Buy oat milk", tt.dueDate, nil)
+
+			err := DefaultTodoRules().ValidateUpdate(todo, previousDueDate, now)
+
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.want, validationErr.Violations)
+		})
+	}
+}
+
+func TestNewTodoItem_StoresDueDateInUTC(t *testing.T) {
+	dueDate := time.Date(2026, 10, 18, 9, 0, 0, 0, time.FixedZone("PDT", -7*60*60))
+
+	todo := NewTodoItem("tenant-1", "user-1", "Buy milk", dueDate, nil)
+
+	assert.Equal(t, time.UTC, todo.DueDate.Location())
+	assert.True(t, todo.DueDate.Equal(dueDate))
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,182 @@
+package entities
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWebhookNotFound error = &NotFoundError{Resource: "webhook"}
+	// ErrForbiddenWebhookDestination means a webhook URL leads to an
+	// address that is not on the public internet.
+	ErrForbiddenWebhookDestination = errors.New("webhook destination is not a public address")
+)
+
+// nonPublicPrefixes are the special-purpose ranges net/netip has no
+// predicate for.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+}
+
+type Webhook struct {
+	ID                  uuid.UUID  `json:"id"`
+	TenantID            string     `json:"tenant_id"`
+	OwnerID             string     `json:"owner_id"`
+	URL                 string     `json:"url"`
+	EventTypes          []string   `json:"event_types"`
+	Secret              string     `json:"-"`
+	Active              bool       `json:"active"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+type WebhookDelivery struct {
+	ID         uuid.UUID `json:"id"`
+	WebhookID  uuid.UUID `json:"webhook_id"`
+	EventID    string    `json:"event_id"`
+	EventType  string    `json:"event_type"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Success    bool      `json:"success"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+	// NextAttemptAt is when the failed attempt will be retried; nil once
+	// the retry has been claimed or when none is left.
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	// Event is kept with the attempt so the retry can resend it after the
+	// stream entry has been acknowledged.
+	Event *Event `json:"-"`
+}
+
+func NewWebhook(tenantID, ownerID, rawURL string, eventTypes []string, secret string) (*Webhook, error) {
+	if secret == "" {
+		generated, err := GenerateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	if len(eventTypes) == 0 {
+		eventTypes = []string{"*"}
+	}
+
+	now := time.Now()
+	return &Webhook{
+		ID:         uuid.New(),
+		TenantID:   tenantID,
+		OwnerID:    ownerID,
+		URL:        rawURL,
+		EventTypes: eventTypes,
+		Secret:     secret,
+		Active:     true,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
+
+func NewWebhookDelivery(webhookID uuid.UUID, event *Event, attempt int) *WebhookDelivery {
+	return &WebhookDelivery{
+		ID:        uuid.New(),
+		WebhookID: webhookID,
+		EventID:   event.ID,
+		EventType: event.Type,
+		Attempt:   attempt,
+		CreatedAt: time.Now(),
+		Event:     event,
+	}
+}
+
+// Validate returns a *ValidationError listing every problem with the
+// webhook.
+func (w *Webhook) Validate() error {
+	errs := &ValidationError{}
+	parsed, err := url.Parse(w.URL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		errs.Add("url", "invalid_url", "must be an absolute http or https URL")
+	}
+
+	for _, eventType := range w.EventTypes {
+		if strings.TrimSpace(eventType) == "" {
+			errs.Add("event_types", "empty_value", "cannot contain empty values")
+			break
+		}
+	}
+
+	if len(w.Secret) < 16 {
+		errs.Add("secret", "too_short", "must be at least 16 characters")
+	}
+
+	return errs.Err()
+}
+
+// Subscribes reports whether the webhook should receive event. Webhooks only
+// ever see events about their owner's todos in their own tenant.
+func (w *Webhook) Subscribes(event *Event) bool {
+	return w.TenantID == event.TenantID && w.OwnerID == event.OwnerID && MatchesEventType(w.EventTypes, event.Type)
+}
+
+func (w *Webhook) RecordSuccess() {
+	w.ConsecutiveFailures = 0
+	w.UpdatedAt = time.Now()
+}
+
+func (w *Webhook) RecordFailure(disableAfter int) {
+	now := time.Now()
+	w.ConsecutiveFailures++
+	w.UpdatedAt = now
+
+	if disableAfter > 0 && w.ConsecutiveFailures >= disableAfter {
+		w.Active = false
+		w.DisabledAt = &now
+	}
+}
+
+func (w *Webhook) Enable() {
+	w.Active = true
+	w.ConsecutiveFailures = 0
+	w.DisabledAt = nil
+	w.UpdatedAt = time.Now()
+}
+
+// IsPublicAddress reports whether webhooks may be delivered to addr. It
+// rules out loopback, private, link-local (including cloud metadata
+// endpoints such as 169.254.169.254) and other special-purpose addresses.
+func IsPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+func GenerateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
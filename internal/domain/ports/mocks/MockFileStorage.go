@@ -21,6 +21,102 @@ func (_m *MockFileStorage) EXPECT() *MockFileStorage_Expecter {
 	return &MockFileStorage_Expecter{mock: &_m.Mock}
 }
 
+func (_m *MockFileStorage) DeleteFile(ctx context.Context, storagePath string) error {
+	ret := _m.Called(ctx, storagePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, storagePath)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type MockFileStorage_DeleteFile_Call struct {
+	*mock.Call
+}
+
+func (_e *MockFileStorage_Expecter) DeleteFile(ctx interface{}, storagePath interface{}) *MockFileStorage_DeleteFile_Call {
+	return &MockFileStorage_DeleteFile_Call{Call: _e.mock.On("DeleteFile", ctx, storagePath)}
+}
+
+func (_c *MockFileStorage_DeleteFile_Call) Run(run func(ctx context.Context, storagePath string)) *MockFileStorage_DeleteFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockFileStorage_DeleteFile_Call) Return(_a0 error) *MockFileStorage_DeleteFile_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockFileStorage_DeleteFile_Call) RunAndReturn(run func(context.Context, string) error) *MockFileStorage_DeleteFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+func (_m *MockFileStorage) DownloadFile(ctx context.Context, storagePath string) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, storagePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DownloadFile")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (io.ReadCloser, error)); ok {
+		return rf(ctx, storagePath)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) io.ReadCloser); ok {
+		r0 = rf(ctx, storagePath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, storagePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type MockFileStorage_DownloadFile_Call struct {
+	*mock.Call
+}
+
+func (_e *MockFileStorage_Expecter) DownloadFile(ctx interface{}, storagePath interface{}) *MockFileStorage_DownloadFile_Call {
+	return &MockFileStorage_DownloadFile_Call{Call: _e.mock.On("DownloadFile", ctx, storagePath)}
+}
+
+func (_c *MockFileStorage_DownloadFile_Call) Run(run func(ctx context.Context, storagePath string)) *MockFileStorage_DownloadFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockFileStorage_DownloadFile_Call) Return(_a0 io.ReadCloser, _a1 error) *MockFileStorage_DownloadFile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFileStorage_DownloadFile_Call) RunAndReturn(run func(context.Context, string) (io.ReadCloser, error)) *MockFileStorage_DownloadFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 func (_m *MockFileStorage) UploadFile(ctx context.Context, storagePath string, contentType string, data io.Reader, size int64) error {
 	ret := _m.Called(ctx, storagePath, contentType, data, size)
 
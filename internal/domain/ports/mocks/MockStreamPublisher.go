@@ -21,16 +21,16 @@ func (_m *MockStreamPublisher) EXPECT() *MockStreamPublisher_Expecter {
 	return &MockStreamPublisher_Expecter{mock: &_m.Mock}
 }
 
-func (_m *MockStreamPublisher) PublishTodoCreated(ctx context.Context, todo *entities.TodoItem) error {
-	ret := _m.Called(ctx, todo)
+func (_m *MockStreamPublisher) Publish(ctx context.Context, event *entities.Event) error {
+	ret := _m.Called(ctx, event)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PublishTodoCreated")
+		panic("no return value specified for Publish")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *entities.TodoItem) error); ok {
-		r0 = rf(ctx, todo)
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.Event) error); ok {
+		r0 = rf(ctx, event)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -38,27 +38,27 @@ func (_m *MockStreamPublisher) PublishTodoCreated(ctx context.Context, todo *ent
 	return r0
 }
 
-type MockStreamPublisher_PublishTodoCreated_Call struct {
+type MockStreamPublisher_Publish_Call struct {
 	*mock.Call
 }
 
-func (_e *MockStreamPublisher_Expecter) PublishTodoCreated(ctx interface{}, todo interface{}) *MockStreamPublisher_PublishTodoCreated_Call {
-	return &MockStreamPublisher_PublishTodoCreated_Call{Call: _e.mock.On("PublishTodoCreated", ctx, todo)}
+func (_e *MockStreamPublisher_Expecter) Publish(ctx interface{}, event interface{}) *MockStreamPublisher_Publish_Call {
+	return &MockStreamPublisher_Publish_Call{Call: _e.mock.On("Publish", ctx, event)}
 }
 
-func (_c *MockStreamPublisher_PublishTodoCreated_Call) Run(run func(ctx context.Context, todo *entities.TodoItem)) *MockStreamPublisher_PublishTodoCreated_Call {
+func (_c *MockStreamPublisher_Publish_Call) Run(run func(ctx context.Context, event *entities.Event)) *MockStreamPublisher_Publish_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*entities.TodoItem))
+		run(args[0].(context.Context), args[1].(*entities.Event))
 	})
 	return _c
 }
 
-func (_c *MockStreamPublisher_PublishTodoCreated_Call) Return(_a0 error) *MockStreamPublisher_PublishTodoCreated_Call {
+func (_c *MockStreamPublisher_Publish_Call) Return(_a0 error) *MockStreamPublisher_Publish_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockStreamPublisher_PublishTodoCreated_Call) RunAndReturn(run func(context.Context, *entities.TodoItem) error) *MockStreamPublisher_PublishTodoCreated_Call {
+func (_c *MockStreamPublisher_Publish_Call) RunAndReturn(run func(context.Context, *entities.Event) error) *MockStreamPublisher_Publish_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -7,6 +7,10 @@ import (
 	entities "todo-service/internal/domain/entities"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
 )
 
 type MockTodoRepository struct {
@@ -21,6 +25,60 @@ func (_m *MockTodoRepository) EXPECT() *MockTodoRepository_Expecter {
 	return &MockTodoRepository_Expecter{mock: &_m.Mock}
 }
 
+func (_m *MockTodoRepository) CountByStatus(ctx context.Context, tenantID string, userID string, now time.Time) (*entities.TodoCounts, error) {
+	ret := _m.Called(ctx, tenantID, userID, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByStatus")
+	}
+
+	var r0 *entities.TodoCounts
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (*entities.TodoCounts, error)); ok {
+		return rf(ctx, tenantID, userID, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) *entities.TodoCounts); ok {
+		r0 = rf(ctx, tenantID, userID, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.TodoCounts)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, tenantID, userID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type MockTodoRepository_CountByStatus_Call struct {
+	*mock.Call
+}
+
+func (_e *MockTodoRepository_Expecter) CountByStatus(ctx interface{}, tenantID interface{}, userID interface{}, now interface{}) *MockTodoRepository_CountByStatus_Call {
+	return &MockTodoRepository_CountByStatus_Call{Call: _e.mock.On("CountByStatus", ctx, tenantID, userID, now)}
+}
+
+func (_c *MockTodoRepository_CountByStatus_Call) Run(run func(ctx context.Context, tenantID string, userID string, now time.Time)) *MockTodoRepository_CountByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_CountByStatus_Call) Return(_a0 *entities.TodoCounts, _a1 error) *MockTodoRepository_CountByStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_CountByStatus_Call) RunAndReturn(run func(context.Context, string, string, time.Time) (*entities.TodoCounts, error)) *MockTodoRepository_CountByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 func (_m *MockTodoRepository) Create(ctx context.Context, todo *entities.TodoItem) error {
 	ret := _m.Called(ctx, todo)
 
@@ -63,6 +121,552 @@ func (_c *MockTodoRepository_Create_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+func (_m *MockTodoRepository) Delete(ctx context.Context, todo *entities.TodoItem) error {
+	ret := _m.Called(ctx, todo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.TodoItem) error); ok {
+		r0 = rf(ctx, todo)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type MockTodoRepository_Delete_Call struct {
+	*mock.Call
+}
+
+func (_e *MockTodoRepository_Expecter) Delete(ctx interface{}, todo interface{}) *MockTodoRepository_Delete_Call {
+	return &MockTodoRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, todo)}
+}
+
+func (_c *MockTodoRepository_Delete_Call) Run(run func(ctx context.Context, todo *entities.TodoItem)) *MockTodoRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entities.TodoItem))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_Delete_Call) Return(_a0 error) *MockTodoRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTodoRepository_Delete_Call) RunAndReturn(run func(context.Context, *entities.TodoItem) error) *MockTodoRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+func (_m *MockTodoRepository) DeleteGrant(ctx context.Context, tenantID string, todoID uuid.UUID, userID string) error {
+	ret := _m.Called(ctx, tenantID, todoID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGrant")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, tenantID, todoID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type MockTodoRepository_DeleteGrant_Call struct {
+	*mock.Call
+}
+
+func (_e *MockTodoRepository_Expecter) DeleteGrant(ctx interface{}, tenantID interface{}, todoID interface{}, userID interface{}) *MockTodoRepository_DeleteGrant_Call {
+	return &MockTodoRepository_DeleteGrant_Call{Call: _e.mock.On("DeleteGrant", ctx, tenantID, todoID, userID)}
+}
+
+func (_c *MockTodoRepository_DeleteGrant_Call) Run(run func(ctx context.Context, tenantID string, todoID uuid.UUID, userID string)) *MockTodoRepository_DeleteGrant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_DeleteGrant_Call) Return(_a0 error) *MockTodoRepository_DeleteGrant_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTodoRepository_DeleteGrant_Call) RunAndReturn(run func(context.Context, string, uuid.UUID, string) error) *MockTodoRepository_DeleteGrant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+func (_m *MockTodoRepository) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entities.TodoItem, error) {
+	ret := _m.Called(ctx, tenantID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *entities.TodoItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (*entities.TodoItem, error)); ok {
+		return rf(ctx, tenantID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *entities.TodoItem); ok {
+		r0 = rf(ctx, tenantID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.TodoItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type MockTodoRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+func (_e *MockTodoRepository_Expecter) GetByID(ctx interface{}, tenantID interface{}, id interface{}) *MockTodoRepository_GetByID_Call {
+	return &MockTodoRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, tenantID, id)}
+}
+
+func (_c *MockTodoRepository_GetByID_Call) Run(run func(ctx context.Context, tenantID string, id uuid.UUID)) *MockTodoRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_GetByID_Call) Return(_a0 *entities.TodoItem, _a1 error) *MockTodoRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_GetByID_Call) RunAndReturn(run func(context.Context, string, uuid.UUID) (*entities.TodoItem, error)) *MockTodoRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+func (_m *MockTodoRepository) GetByIDForUpdate(ctx context.Context, tenantID string, id uuid.UUID) (*entities.TodoItem, error) {
+	ret := _m.Called(ctx, tenantID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDForUpdate")
+	}
+
+	var r0 *entities.TodoItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (*entities.TodoItem, error)); ok {
+		return rf(ctx, tenantID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *entities.TodoItem); ok {
+		r0 = rf(ctx, tenantID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.TodoItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type MockTodoRepository_GetByIDForUpdate_Call struct {
+	*mock.Call
+}
+
+func (_e *MockTodoRepository_Expecter) GetByIDForUpdate(ctx interface{}, tenantID interface{}, id interface{}) *MockTodoRepository_GetByIDForUpdate_Call {
+	return &MockTodoRepository_GetByIDForUpdate_Call{Call: _e.mock.On("GetByIDForUpdate", ctx, tenantID, id)}
+}
+
+func (_c *MockTodoRepository_GetByIDForUpdate_Call) Run(run func(ctx context.Context, tenantID string, id uuid.UUID)) *MockTodoRepository_GetByIDForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_GetByIDForUpdate_Call) Return(_a0 *entities.TodoItem, _a1 error) *MockTodoRepository_GetByIDForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_GetByIDForUpdate_Call) RunAndReturn(run func(context.Context, string, uuid.UUID) (*entities.TodoItem, error)) *MockTodoRepository_GetByIDForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+func (_m *MockTodoRepository) GetGrant(ctx context.Context, tenantID string, todoID uuid.UUID, userID string) (*entities.TodoGrant, error) {
+	ret := _m.Called(ctx, tenantID, todoID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGrant")
+	}
+
+	var r0 *entities.TodoGrant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, string) (*entities.TodoGrant, error)); ok {
+		return rf(ctx, tenantID, todoID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, string) *entities.TodoGrant); ok {
+		r0 = rf(ctx, tenantID, todoID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.TodoGrant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, tenantID, todoID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type MockTodoRepository_GetGrant_Call struct {
+	*mock.Call
+}
+
+func (_e *MockTodoRepository_Expecter) GetGrant(ctx interface{}, tenantID interface{}, todoID interface{}, userID interface{}) *MockTodoRepository_GetGrant_Call {
+	return &MockTodoRepository_GetGrant_Call{Call: _e.mock.On("GetGrant", ctx, tenantID, todoID, userID)}
+}
+
+func (_c *MockTodoRepository_GetGrant_Call) Run(run func(ctx context.Context, tenantID string, todoID uuid.UUID, userID string)) *MockTodoRepository_GetGrant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_GetGrant_Call) Return(_a0 *entities.TodoGrant, _a1 error) *MockTodoRepository_GetGrant_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_GetGrant_Call) RunAndReturn(run func(context.Context, string, uuid.UUID, string) (*entities.TodoGrant, error)) *MockTodoRepository_GetGrant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+func (_m *MockTodoRepository) List(ctx context.Context, tenantID string, userID string, limit int, offset int) ([]*entities.TodoItem, error) {
+	ret := _m.Called(ctx, tenantID, userID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.TodoItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, int) ([]*entities.TodoItem, error)); ok {
+		return rf(ctx, tenantID, userID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, int) []*entities.TodoItem); ok {
+		r0 = rf(ctx, tenantID, userID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.TodoItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int, int) error); ok {
+		r1 = rf(ctx, tenantID, userID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type MockTodoRepository_List_Call struct {
+	*mock.Call
+}
+
+func (_e *MockTodoRepository_Expecter) List(ctx interface{}, tenantID interface{}, userID interface{}, limit interface{}, offset interface{}) *MockTodoRepository_List_Call {
+	return &MockTodoRepository_List_Call{Call: _e.mock.On("List", ctx, tenantID, userID, limit, offset)}
+}
+
+func (_c *MockTodoRepository_List_Call) Run(run func(ctx context.Context, tenantID string, userID string, limit int, offset int)) *MockTodoRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_List_Call) Return(_a0 []*entities.TodoItem, _a1 error) *MockTodoRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_List_Call) RunAndReturn(run func(context.Context, string, string, int, int) ([]*entities.TodoItem, error)) *MockTodoRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+func (_m *MockTodoRepository) ListChanges(ctx context.Context, tenantID string, userID string, afterSeq int64, limit int) ([]*entities.TodoItem, error) {
+	ret := _m.Called(ctx, tenantID, userID, afterSeq, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListChanges")
+	}
+
+	var r0 []*entities.TodoItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, int) ([]*entities.TodoItem, error)); ok {
+		return rf(ctx, tenantID, userID, afterSeq, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, int) []*entities.TodoItem); ok {
+		r0 = rf(ctx, tenantID, userID, afterSeq, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.TodoItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64, int) error); ok {
+		r1 = rf(ctx, tenantID, userID, afterSeq, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type MockTodoRepository_ListChanges_Call struct {
+	*mock.Call
+}
+
+func (_e *MockTodoRepository_Expecter) ListChanges(ctx interface{}, tenantID interface{}, userID interface{}, afterSeq interface{}, limit interface{}) *MockTodoRepository_ListChanges_Call {
+	return &MockTodoRepository_ListChanges_Call{Call: _e.mock.On("ListChanges", ctx, tenantID, userID, afterSeq, limit)}
+}
+
+func (_c *MockTodoRepository_ListChanges_Call) Run(run func(ctx context.Context, tenantID string, userID string, afterSeq int64, limit int)) *MockTodoRepository_ListChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int64), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_ListChanges_Call) Return(_a0 []*entities.TodoItem, _a1 error) *MockTodoRepository_ListChanges_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_ListChanges_Call) RunAndReturn(run func(context.Context, string, string, int64, int) ([]*entities.TodoItem, error)) *MockTodoRepository_ListChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+func (_m *MockTodoRepository) ListGrants(ctx context.Context, tenantID string, todoID uuid.UUID) ([]*entities.TodoGrant, error) {
+	ret := _m.Called(ctx, tenantID, todoID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListGrants")
+	}
+
+	var r0 []*entities.TodoGrant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) ([]*entities.TodoGrant, error)); ok {
+		return rf(ctx, tenantID, todoID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) []*entities.TodoGrant); ok {
+		r0 = rf(ctx, tenantID, todoID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.TodoGrant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID, todoID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type MockTodoRepository_ListGrants_Call struct {
+	*mock.Call
+}
+
+func (_e *MockTodoRepository_Expecter) ListGrants(ctx interface{}, tenantID interface{}, todoID interface{}) *MockTodoRepository_ListGrants_Call {
+	return &MockTodoRepository_ListGrants_Call{Call: _e.mock.On("ListGrants", ctx, tenantID, todoID)}
+}
+
+func (_c *MockTodoRepository_ListGrants_Call) Run(run func(ctx context.Context, tenantID string, todoID uuid.UUID)) *MockTodoRepository_ListGrants_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_ListGrants_Call) Return(_a0 []*entities.TodoGrant, _a1 error) *MockTodoRepository_ListGrants_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_ListGrants_Call) RunAndReturn(run func(context.Context, string, uuid.UUID) ([]*entities.TodoGrant, error)) *MockTodoRepository_ListGrants_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+func (_m *MockTodoRepository) SaveGrant(ctx context.Context, grant *entities.TodoGrant) error {
+	ret := _m.Called(ctx, grant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveGrant")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.TodoGrant) error); ok {
+		r0 = rf(ctx, grant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type MockTodoRepository_SaveGrant_Call struct {
+	*mock.Call
+}
+
+func (_e *MockTodoRepository_Expecter) SaveGrant(ctx interface{}, grant interface{}) *MockTodoRepository_SaveGrant_Call {
+	return &MockTodoRepository_SaveGrant_Call{Call: _e.mock.On("SaveGrant", ctx, grant)}
+}
+
+func (_c *MockTodoRepository_SaveGrant_Call) Run(run func(ctx context.Context, grant *entities.TodoGrant)) *MockTodoRepository_SaveGrant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entities.TodoGrant))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_SaveGrant_Call) Return(_a0 error) *MockTodoRepository_SaveGrant_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTodoRepository_SaveGrant_Call) RunAndReturn(run func(context.Context, *entities.TodoGrant) error) *MockTodoRepository_SaveGrant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+func (_m *MockTodoRepository) Search(ctx context.Context, tenantID string, userID string, filter entities.TodoFilter, after *entities.TodoCursor, limit int, now time.Time) ([]*entities.TodoItem, error) {
+	ret := _m.Called(ctx, tenantID, userID, filter, after, limit, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []*entities.TodoItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, entities.TodoFilter, *entities.TodoCursor, int, time.Time) ([]*entities.TodoItem, error)); ok {
+		return rf(ctx, tenantID, userID, filter, after, limit, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, entities.TodoFilter, *entities.TodoCursor, int, time.Time) []*entities.TodoItem); ok {
+		r0 = rf(ctx, tenantID, userID, filter, after, limit, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.TodoItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, entities.TodoFilter, *entities.TodoCursor, int, time.Time) error); ok {
+		r1 = rf(ctx, tenantID, userID, filter, after, limit, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type MockTodoRepository_Search_Call struct {
+	*mock.Call
+}
+
+func (_e *MockTodoRepository_Expecter) Search(ctx interface{}, tenantID interface{}, userID interface{}, filter interface{}, after interface{}, limit interface{}, now interface{}) *MockTodoRepository_Search_Call {
+	return &MockTodoRepository_Search_Call{Call: _e.mock.On("Search", ctx, tenantID, userID, filter, after, limit, now)}
+}
+
+func (_c *MockTodoRepository_Search_Call) Run(run func(ctx context.Context, tenantID string, userID string, filter entities.TodoFilter, after *entities.TodoCursor, limit int, now time.Time)) *MockTodoRepository_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(entities.TodoFilter), args[4].(*entities.TodoCursor), args[5].(int), args[6].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_Search_Call) Return(_a0 []*entities.TodoItem, _a1 error) *MockTodoRepository_Search_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTodoRepository_Search_Call) RunAndReturn(run func(context.Context, string, string, entities.TodoFilter, *entities.TodoCursor, int, time.Time) ([]*entities.TodoItem, error)) *MockTodoRepository_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+func (_m *MockTodoRepository) Update(ctx context.Context, todo *entities.TodoItem) error {
+	ret := _m.Called(ctx, todo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.TodoItem) error); ok {
+		r0 = rf(ctx, todo)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type MockTodoRepository_Update_Call struct {
+	*mock.Call
+}
+
+func (_e *MockTodoRepository_Expecter) Update(ctx interface{}, todo interface{}) *MockTodoRepository_Update_Call {
+	return &MockTodoRepository_Update_Call{Call: _e.mock.On("Update", ctx, todo)}
+}
+
+func (_c *MockTodoRepository_Update_Call) Run(run func(ctx context.Context, todo *entities.TodoItem)) *MockTodoRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entities.TodoItem))
+	})
+	return _c
+}
+
+func (_c *MockTodoRepository_Update_Call) Return(_a0 error) *MockTodoRepository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTodoRepository_Update_Call) RunAndReturn(run func(context.Context, *entities.TodoItem) error) *MockTodoRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 func NewMockTodoRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
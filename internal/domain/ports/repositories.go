@@ -3,12 +3,32 @@ package ports
 import (
 	"context"
 	"io"
+	"time"
+
+	"github.com/google/uuid"
 
 	"todo-service/internal/domain/entities"
 )
 
+// TodoRepository loads todos by ID within a tenant regardless of who owns
+// them; callers decide access from the owner and the todo's grants. List,
+// Search, CountByStatus and ListChanges cover the todos userID owns or has
+// been granted; Search returns up to limit todos following after, or from the start when
+// after is nil. now decides which todos are overdue.
 type TodoRepository interface {
 	Create(ctx context.Context, todo *entities.TodoItem) error
+	GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entities.TodoItem, error)
+	GetByIDForUpdate(ctx context.Context, tenantID string, id uuid.UUID) (*entities.TodoItem, error)
+	List(ctx context.Context, tenantID, userID string, limit, offset int) ([]*entities.TodoItem, error)
+	Search(ctx context.Context, tenantID, userID string, filter entities.TodoFilter, after *entities.TodoCursor, limit int, now time.Time) ([]*entities.TodoItem, error)
+	CountByStatus(ctx context.Context, tenantID, userID string, now time.Time) (*entities.TodoCounts, error)
+	Update(ctx context.Context, todo *entities.TodoItem) error
+	Delete(ctx context.Context, todo *entities.TodoItem) error
+	ListChanges(ctx context.Context, tenantID, userID string, afterSeq int64, limit int) ([]*entities.TodoItem, error)
+	GetGrant(ctx context.Context, tenantID string, todoID uuid.UUID, userID string) (*entities.TodoGrant, error)
+	ListGrants(ctx context.Context, tenantID string, todoID uuid.UUID) ([]*entities.TodoGrant, error)
+	SaveGrant(ctx context.Context, grant *entities.TodoGrant) error
+	DeleteGrant(ctx context.Context, tenantID string, todoID uuid.UUID, userID string) error
 }
 
 type TransactionManager interface {
@@ -16,9 +36,104 @@ type TransactionManager interface {
 }
 
 type StreamPublisher interface {
-	PublishTodoCreated(ctx context.Context, todo *entities.TodoItem) error
+	Publish(ctx context.Context, event *entities.Event) error
+}
+
+type EventHandler func(ctx context.Context, event *entities.Event) error
+
+type StreamConsumer interface {
+	Consume(ctx context.Context, handler EventHandler) error
+}
+
+// EventReader reads the event stream. Read waits up to block for events
+// after afterID when there are none yet; a block of zero or less returns at
+// once.
+type EventReader interface {
+	LastID(ctx context.Context) (string, error)
+	Read(ctx context.Context, afterID string, count int64, block time.Duration) ([]*entities.Event, error)
 }
 
+// FileStorage stores file contents. DownloadFile fails with ErrFileNotFound
+// when nothing is stored at storagePath; callers must close the reader.
 type FileStorage interface {
 	UploadFile(ctx context.Context, storagePath, contentType string, data io.Reader, size int64) error
+	DownloadFile(ctx context.Context, storagePath string) (io.ReadCloser, error)
+	DeleteFile(ctx context.Context, storagePath string) error
+}
+
+// FileRepository scopes files to their owner, except ListByIDs, which also
+// finds files attached to todos userID can read so attachments of shared
+// todos can be shown. IDs of other files are left out of its result.
+type FileRepository interface {
+	Create(ctx context.Context, file *entities.File) error
+	GetByID(ctx context.Context, tenantID, ownerID string, id uuid.UUID) (*entities.File, error)
+	ListByIDs(ctx context.Context, tenantID, userID string, ids []uuid.UUID) ([]*entities.File, error)
+	Delete(ctx context.Context, tenantID, ownerID string, id uuid.UUID) error
+}
+
+// StorageUsageRepository keeps per-user storage totals. Reserve adds one file
+// of the given size only if the result stays within quota, failing with
+// ErrStorageQuotaExceeded otherwise; Release gives the space back.
+type StorageUsageRepository interface {
+	Get(ctx context.Context, tenantID, ownerID string) (*entities.StorageUsage, error)
+	Reserve(ctx context.Context, tenantID, ownerID string, size int64, quota entities.StorageQuota) error
+	Release(ctx context.Context, tenantID, ownerID string, size int64) error
+}
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *entities.Webhook) error
+	GetByID(ctx context.Context, tenantID, ownerID string, id uuid.UUID) (*entities.Webhook, error)
+	List(ctx context.Context, tenantID, ownerID string) ([]*entities.Webhook, error)
+	ListActive(ctx context.Context) ([]*entities.Webhook, error)
+	UpdateStatus(ctx context.Context, webhook *entities.Webhook) error
+	Delete(ctx context.Context, tenantID, ownerID string, id uuid.UUID) error
+	CreateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error
+	ListDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]*entities.WebhookDelivery, error)
+	// ListDueDeliveries returns failed attempts whose retry is due at now.
+	ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*entities.WebhookDelivery, error)
+	// ClaimDelivery takes a due retry for this worker; it reports false
+	// when another worker claimed it first.
+	ClaimDelivery(ctx context.Context, id uuid.UUID) (bool, error)
+}
+
+// IdempotencyStore remembers requests by Idempotency-Key. Begin either
+// claims the key for a new request (returning nil) or returns the completed
+// record to replay; it fails with ErrIdempotencyKeyInUse or
+// ErrIdempotencyKeyMismatch otherwise. Complete and Release only act while
+// the record's in-flight claim is still the one stored.
+type IdempotencyStore interface {
+	Begin(ctx context.Context, record *entities.IdempotencyRecord, lockTTL time.Duration) (*entities.IdempotencyRecord, error)
+	Complete(ctx context.Context, record *entities.IdempotencyRecord, ttl time.Duration) error
+	Release(ctx context.Context, record *entities.IdempotencyRecord) error
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entities.APIKey) error
+	GetByHash(ctx context.Context, keyHash string) (*entities.APIKey, error)
+	List(ctx context.Context, tenantID, ownerID string) ([]*entities.APIKey, error)
+	Revoke(ctx context.Context, tenantID, ownerID string, id uuid.UUID, revokedAt time.Time) error
+	TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+}
+
+// RateLimiter counts a request against the bucket identified by key.
+type RateLimiter interface {
+	Allow(ctx context.Context, key string, limit entities.RateLimit) (*entities.RateLimitResult, error)
+}
+
+// Metrics counts domain events for monitoring. Label values passed in must
+// come from a fixed set.
+type Metrics interface {
+	TodoCreated()
+	UploadRejected(reason string)
+}
+
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*entities.Principal, error)
+}
+
+type WebhookSender interface {
+	Send(ctx context.Context, webhook *entities.Webhook, event *entities.Event) (int, error)
+	// CheckDestination fails with entities.ErrForbiddenWebhookDestination
+	// when rawURL resolves to an address Send would refuse to dial.
+	CheckDestination(ctx context.Context, rawURL string) error
 }
@@ -0,0 +1,163 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type Status string
+
+const (
+	StatusHealthy   Status = "healthy"
+	StatusDegraded  Status = "degraded"
+	StatusUnhealthy Status = "unhealthy"
+)
+
+const (
+	checkUp   = "up"
+	checkDown = "down"
+)
+
+// Check probes a single dependency. A failing critical check makes the
+// service unhealthy; any other failure only degrades it.
+type Check struct {
+	Name     string
+	Critical bool
+	Probe    func(ctx context.Context) error
+}
+
+type CheckResult struct {
+	Status    string `json:"status"`
+	Critical  bool   `json:"critical"`
+	LatencyMS int64  `json:"latency_ms"`
+}
+
+type Report struct {
+	Status    Status                 `json:"status"`
+	Timestamp int64                  `json:"timestamp"`
+	Checks    map[string]CheckResult `json:"checks"`
+}
+
+type Options struct {
+	// Timeout bounds each check individually.
+	Timeout time.Duration
+	// CacheTTL is how long a report is reused before dependencies are probed again.
+	CacheTTL time.Duration
+	// Logger records why a check failed; reports are public and only carry its status.
+	Logger *zap.Logger
+}
+
+// Checker runs all checks in parallel and caches the combined report so
+// that frequent probes don't hammer the dependencies.
+type Checker struct {
+	checks  []Check
+	options Options
+	now     func() time.Time
+
+	mu        sync.Mutex
+	report    *Report
+	checkedAt time.Time
+}
+
+func NewChecker(options Options, checks ...Check) *Checker {
+	if options.Timeout <= 0 {
+		options.Timeout = 2 * time.Second
+	}
+	if options.Logger == nil {
+		options.Logger = zap.NewNop()
+	}
+	return &Checker{
+		checks:  checks,
+		options: options,
+		now:     time.Now,
+	}
+}
+
+// Check returns the cached report if it is still fresh, otherwise probes
+// every dependency. Concurrent callers share a single round of probes.
+func (c *Checker) Check(ctx context.Context) *Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.report != nil && c.now().Sub(c.checkedAt) < c.options.CacheTTL {
+		return c.report
+	}
+
+	// The report is shared, so one caller going away must not fail it for the rest.
+	c.report = c.run(context.WithoutCancel(ctx))
+	c.checkedAt = c.now()
+	return c.report
+}
+
+func (c *Checker) run(ctx context.Context) *Report {
+	results := make([]CheckResult, len(c.checks))
+
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.probe(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := &Report{
+		Status:    StatusHealthy,
+		Timestamp: c.now().Unix(),
+		Checks:    make(map[string]CheckResult, len(c.checks)),
+	}
+	for i, check := range c.checks {
+		result := results[i]
+		report.Checks[check.Name] = result
+
+		if result.Status == checkUp {
+			continue
+		}
+		if check.Critical {
+			report.Status = StatusUnhealthy
+		} else if report.Status == StatusHealthy {
+			report.Status = StatusDegraded
+		}
+	}
+
+	return report
+}
+
+func (c *Checker) probe(ctx context.Context, check Check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.options.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check.Probe(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		// Don't wait on probes that ignore their context.
+		err = fmt.Errorf("timed out after %s", c.options.Timeout)
+	}
+
+	result := CheckResult{
+		Status:    checkUp,
+		Critical:  check.Critical,
+		LatencyMS: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Status = checkDown
+		c.options.Logger.Warn("Health check failed",
+			zap.String("check", check.Name),
+			zap.Bool("critical", check.Critical),
+			zap.Error(err),
+		)
+	}
+	return result
+}
@@ -0,0 +1,107 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func up(ctx context.Context) error { return nil }
+
+func down(ctx context.Context) error { return errors.New("connection refused") }
+
+func TestChecker_Status(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []Check
+		want   Status
+	}{
+		{"all up", []Check{{Name: "mysql", Critical: true, Probe: up}, {Name: "s3", Probe: up}}, StatusHealthy},
+		{"non-critical down", []Check{{Name: "mysql", Critical: true, Probe: up}, {Name: "s3", Probe: down}}, StatusDegraded},
+		{"critical down", []Check{{Name: "mysql", Critical: true, Probe: down}, {Name: "s3", Probe: down}}, StatusUnhealthy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := NewChecker(Options{}, tt.checks...).Check(context.Background())
+			assert.Equal(t, tt.want, report.Status)
+			assert.Len(t, report.Checks, len(tt.checks))
+		})
+	}
+}
+
+func TestChecker_TimesOutEachCheckInParallel(t *testing.T) {
+	hang := func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}
+	core, logs := observer.New(zap.WarnLevel)
+	checker := NewChecker(Options{Timeout: 50 * time.Millisecond, Logger: zap.New(core)},
+		Check{Name: "redis", Critical: true, Probe: hang},
+		Check{Name: "s3", Probe: hang},
+		Check{Name: "mysql", Critical: true, Probe: up},
+	)
+
+	start := time.Now()
+	report := checker.Check(context.Background())
+
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, StatusUnhealthy, report.Status)
+	assert.Equal(t, "down", report.Checks["redis"].Status)
+	assert.Equal(t, "up", report.Checks["mysql"].Status)
+
+	failed := logs.FilterField(zap.String("check", "redis")).All()
+	require.Len(t, failed, 1)
+	assert.Contains(t, failed[0].ContextMap()["error"], "timed out")
+}
+
+func TestChecker_KeepsErrorsOutOfReport(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	checker := NewChecker(Options{Logger: zap.New(core)}, Check{Name: "mysql", Critical: true, Probe: down})
+
+	body, err := json.Marshal(checker.Check(context.Background()))
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(body), "connection refused")
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "connection refused", logs.All()[0].ContextMap()["error"])
+}
+
+func TestChecker_CachesReport(t *testing.T) {
+	var calls atomic.Int32
+	probe := func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	}
+
+	now := time.Now()
+	checker := NewChecker(Options{CacheTTL: 2 * time.Second}, Check{Name: "mysql", Critical: true, Probe: probe})
+	checker.now = func() time.Time { return now }
+
+	checker.Check(context.Background())
+	checker.Check(context.Background())
+	assert.Equal(t, int32(1), calls.Load())
+
+	now = now.Add(3 * time.Second)
+	checker.Check(context.Background())
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestChecker_IgnoresCallerCancellation(t *testing.T) {
+	checker := NewChecker(Options{}, Check{Name: "mysql", Critical: true, Probe: func(ctx context.Context) error {
+		return ctx.Err()
+	}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.Equal(t, StatusHealthy, checker.Check(ctx).Status)
+}
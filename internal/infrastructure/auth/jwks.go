@@ -0,0 +1,237 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	minJWKSRefreshInterval = time.Minute
+	jwksFetchTimeout       = 10 * time.Second
+)
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// JWKSKeySet holds the RSA signing keys published in a JSON Web Key Set.
+// Keys loaded from a URL are refreshed every maxAge and, at most once per
+// minute, whenever a token names a key ID that is not known yet, so key
+// rotation at the issuer is picked up without a restart. Concurrent callers
+// share one fetch, made without holding the lock, and a failed fetch is not
+// retried for a minute either; the previous keys keep being served meanwhile.
+type JWKSKeySet struct {
+	load   func(ctx context.Context) ([]byte, error)
+	maxAge time.Duration
+	fetch  singleflight.Group
+
+	mu          sync.RWMutex
+	keys        map[string]*rsa.PublicKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+func NewJWKSFileKeySet(path string) (*JWKSKeySet, error) {
+	keySet := &JWKSKeySet{
+		load: func(ctx context.Context) ([]byte, error) {
+			return os.ReadFile(path)
+		},
+	}
+
+	if err := keySet.refresh(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return keySet, nil
+}
+
+func NewJWKSURLKeySet(url string, client *http.Client, maxAge time.Duration) (*JWKSKeySet, error) {
+	keySet := &JWKSKeySet{
+		load: func(ctx context.Context) ([]byte, error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			resp, err := client.Do(req)
+			if err != nil {
+				return nil, err
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+			}
+
+			return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		},
+		maxAge: maxAge,
+	}
+
+	if err := keySet.refresh(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return keySet, nil
+}
+
+func (s *JWKSKeySet) Key(ctx context.Context, keyID string) (*rsa.PublicKey, error) {
+	if s.maxAge > 0 && s.expired() {
+		// A failed refresh keeps serving the previous keys.
+		_ = s.refresh(ctx)
+	}
+
+	if key, ok := s.lookup(keyID); ok {
+		return key, nil
+	}
+
+	if s.maxAge > 0 && s.mayRefetch() {
+		if err := s.refresh(ctx); err != nil {
+			return nil, err
+		}
+		if key, ok := s.lookup(keyID); ok {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown signing key %q", keyID)
+}
+
+// expired reports whether the keys are older than maxAge and no fetch has
+// been tried within the last minute.
+func (s *JWKSKeySet) expired() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return time.Since(s.fetchedAt) > s.maxAge && time.Since(s.attemptedAt) > minJWKSRefreshInterval
+}
+
+// mayRefetch reports whether an unknown key ID may trigger a fetch.
+func (s *JWKSKeySet) mayRefetch() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return time.Since(s.attemptedAt) > minJWKSRefreshInterval
+}
+
+func (s *JWKSKeySet) lookup(keyID string) (*rsa.PublicKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if keyID == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+
+	key, ok := s.keys[keyID]
+	return key, ok
+}
+
+// refresh fetches the key set, joining a fetch already in progress. The
+// fetch outlives a caller that gives up waiting, so it is bounded by its own
+// timeout rather than the caller's context.
+func (s *JWKSKeySet) refresh(ctx context.Context) error {
+	result := s.fetch.DoChan("jwks", func() (interface{}, error) {
+		s.mu.Lock()
+		s.attemptedAt = time.Now()
+		s.mu.Unlock()
+
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), jwksFetchTimeout)
+		defer cancel()
+
+		data, err := s.load(fetchCtx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load JWKS: %w", err)
+		}
+
+		keys, err := parseJWKS(data)
+		if err != nil {
+			return nil, err
+		}
+
+		s.mu.Lock()
+		s.keys = keys
+		s.fetchedAt = time.Now()
+		s.mu.Unlock()
+		return nil, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case res := <-result:
+		return res.Err
+	}
+}
+
+func parseJWKS(data []byte) (map[string]*rsa.PublicKey, error) {
+	var set jsonWebKeySet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, jwk := range set.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus for key %q: %w", jwk.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent for key %q: %w", jwk.Kid, err)
+		}
+
+		keys[jwk.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("JWKS contains no RSA signing keys")
+	}
+
+	return keys, nil
+}
+
+// EncodeJWKS publishes public keys in JWKS form, keyed by key ID.
+func EncodeJWKS(keys map[string]*rsa.PublicKey) ([]byte, error) {
+	var set jsonWebKeySet
+	for keyID, key := range keys {
+		set.Keys = append(set.Keys, jsonWebKey{
+			Kty: "RSA",
+			Kid: keyID,
+			Use: "sig",
+			Alg: "RS256",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+
+	return json.Marshal(set)
+}
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"todo-service/internal/domain/entities"
+)
+
+type JWTVerifierOptions struct {
+	HMACSecret []byte
+	KeySet     *JWKSKeySet
+	Issuer     string
+	Audience   string
+	Leeway     time.Duration
+
+	// DefaultTenant is assumed for tokens without a tenant_id claim. When
+	// empty, such tokens are rejected.
+	DefaultTenant string
+}
+
+// JWTVerifier accepts HS256 tokens signed with a shared secret and RS256
+// tokens signed by a key from a JWKS, whichever are configured. The token
+// subject becomes the principal ID and the tenant_id claim its tenant.
+type JWTVerifier struct {
+	options JWTVerifierOptions
+	parser  *jwt.Parser
+}
+
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Scope    string `json:"scope,omitempty"`
+	TenantID string `json:"tenant_id,omitempty"`
+}
+
+func NewJWTVerifier(options JWTVerifierOptions) (*JWTVerifier, error) {
+	var methods []string
+	if len(options.HMACSecret) > 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if options.KeySet != nil {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("no JWT signing secret or JWKS configured")
+	}
+
+	parserOptions := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(options.Leeway),
+	}
+	if options.Issuer != "" {
+		parserOptions = append(parserOptions, jwt.WithIssuer(options.Issuer))
+	}
+	if options.Audience != "" {
+		parserOptions = append(parserOptions, jwt.WithAudience(options.Audience))
+	}
+
+	return &JWTVerifier{
+		options: options,
+		parser:  jwt.NewParser(parserOptions...),
+	}, nil
+}
+
+func (v *JWTVerifier) Verify(ctx context.Context, token string) (*entities.Principal, error) {
+	var claims tokenClaims
+	_, err := v.parser.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		switch t.Method.(type) {
+		case *jwt.SigningMethodHMAC:
+			return v.options.HMACSecret, nil
+		case *jwt.SigningMethodRSA:
+			keyID, _ := t.Header["kid"].(string)
+			return v.options.KeySet.Key(ctx, keyID)
+		default:
+			return nil, fmt.Errorf("unexpected signing method %s", t.Method.Alg())
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", entities.ErrInvalidCredentials, err)
+	}
+
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: token has no subject", entities.ErrInvalidCredentials)
+	}
+
+	tenantID := claims.TenantID
+	if tenantID == "" {
+		tenantID = v.options.DefaultTenant
+	}
+	if tenantID == "" {
+		return nil, fmt.Errorf("%w: token has no tenant", entities.ErrInvalidCredentials)
+	}
+	if !entities.IsValidTenantID(tenantID) {
+		return nil, fmt.Errorf("%w: invalid tenant %q", entities.ErrInvalidCredentials, tenantID)
+	}
+
+	return &entities.Principal{
+		ID:       claims.Subject,
+		TenantID: tenantID,
+		Method:   entities.AuthMethodJWT,
+		Scopes:   strings.Fields(claims.Scope),
+	}, nil
+}
@@ -0,0 +1,270 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/domain/entities"
+)
+
+var testSecret = []byte("test-signing-secret-0123456789")
+
+func generateKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+func TestVerify_HS256(t *testing.T) {
+	verifier, err := NewJWTVerifier(JWTVerifierOptions{
+		HMACSecret: testSecret,
+		Issuer:     "https://issuer.test",
+		Audience:   "todo-service",
+	})
+	require.NoError(t, err)
+
+	token, err := NewHS256Minter(testSecret, "https://issuer.test", "todo-service").Mint("user-1", "acme", time.Minute, "todos:read")
+	require.NoError(t, err)
+
+	principal, err := verifier.Verify(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", principal.ID)
+	assert.Equal(t, "acme", principal.TenantID)
+	assert.Equal(t, entities.AuthMethodJWT, principal.Method)
+	assert.Equal(t, []string{"todos:read"}, principal.Scopes)
+}
+
+func TestVerify_RejectsInvalidTokens(t *testing.T) {
+	verifier, err := NewJWTVerifier(JWTVerifierOptions{
+		HMACSecret: testSecret,
+		Issuer:     "https://issuer.test",
+		Audience:   "todo-service",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		minter *TokenMinter
+		ttl    time.Duration
+	}{
+		{"wrong secret", NewHS256Minter([]byte("another-secret-0123456789"), "https://issuer.test", "todo-service"), time.Minute},
+		{"wrong issuer", NewHS256Minter(testSecret, "https://evil.test", "todo-service"), time.Minute},
+		{"wrong audience", NewHS256Minter(testSecret, "https://issuer.test", "other-service"), time.Minute},
+		{"expired", NewHS256Minter(testSecret, "https://issuer.test", "todo-service"), -time.Minute},
+		{"unconfigured algorithm", NewRS256Minter(generateKey(t), "k1", "https://issuer.test", "todo-service"), time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := tt.minter.Mint("user-1", "acme", tt.ttl)
+			require.NoError(t, err)
+
+			_, err = verifier.Verify(context.Background(), token)
+			assert.ErrorIs(t, err, entities.ErrInvalidCredentials)
+		})
+	}
+}
+
+func TestVerify_Tenant(t *testing.T) {
+	minter := NewHS256Minter(testSecret, "", "")
+
+	withDefault, err := NewJWTVerifier(JWTVerifierOptions{HMACSecret: testSecret, DefaultTenant: "default"})
+	require.NoError(t, err)
+	strict, err := NewJWTVerifier(JWTVerifierOptions{HMACSecret: testSecret})
+	require.NoError(t, err)
+
+	untenanted, err := minter.Mint("user-1", "", time.Minute)
+	require.NoError(t, err)
+
+	principal, err := withDefault.Verify(context.Background(), untenanted)
+	require.NoError(t, err)
+	assert.Equal(t, "default", principal.TenantID)
+
+	_, err = strict.Verify(context.Background(), untenanted)
+	assert.ErrorIs(t, err, entities.ErrInvalidCredentials)
+
+	malformed, err := minter.Mint("user-1", "../other", time.Minute)
+	require.NoError(t, err)
+
+	_, err = withDefault.Verify(context.Background(), malformed)
+	assert.ErrorIs(t, err, entities.ErrInvalidCredentials)
+}
+
+func TestVerify_RS256FromJWKSFile(t *testing.T) {
+	key := generateKey(t)
+	jwks, err := EncodeJWKS(map[string]*rsa.PublicKey{"k1": &key.PublicKey})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, jwks, 0o600))
+
+	keySet, err := NewJWKSFileKeySet(path)
+	require.NoError(t, err)
+
+	verifier, err := NewJWTVerifier(JWTVerifierOptions{KeySet: keySet})
+	require.NoError(t, err)
+
+	token, err := NewRS256Minter(key, "k1", "", "").Mint("user-2", "acme", time.Minute)
+	require.NoError(t, err)
+
+	principal, err := verifier.Verify(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-2", principal.ID)
+
+	hmacToken, err := NewHS256Minter(testSecret, "", "").Mint("user-2", "acme", time.Minute)
+	require.NoError(t, err)
+
+	_, err = verifier.Verify(context.Background(), hmacToken)
+	assert.ErrorIs(t, err, entities.ErrInvalidCredentials)
+}
+
+func TestVerify_RS256FromJWKSURLPicksUpRotatedKeys(t *testing.T) {
+	oldKey := generateKey(t)
+	newKey := generateKey(t)
+
+	var rotated atomic.Bool
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		keys := map[string]*rsa.PublicKey{"old": &oldKey.PublicKey}
+		if rotated.Load() {
+			keys["new"] = &newKey.PublicKey
+		}
+		jwks, _ := EncodeJWKS(keys)
+		w.Write(jwks)
+	}))
+	defer server.Close()
+
+	keySet, err := NewJWKSURLKeySet(server.URL, server.Client(), time.Hour)
+	require.NoError(t, err)
+
+	verifier, err := NewJWTVerifier(JWTVerifierOptions{KeySet: keySet})
+	require.NoError(t, err)
+
+	token, err := NewRS256Minter(newKey, "new", "", "").Mint("user-3", "acme", time.Minute)
+	require.NoError(t, err)
+
+	rotated.Store(true)
+
+	// Unknown key IDs only trigger a refetch once the minimum refresh
+	// interval has passed.
+	_, err = verifier.Verify(context.Background(), token)
+	assert.ErrorIs(t, err, entities.ErrInvalidCredentials)
+
+	keySet.attemptedAt = time.Now().Add(-2 * minJWKSRefreshInterval)
+
+	principal, err := verifier.Verify(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-3", principal.ID)
+	assert.Equal(t, int32(2), fetches.Load())
+}
+
+func TestJWKSKeySet_BacksOffAfterFailedFetch(t *testing.T) {
+	key := generateKey(t)
+
+	var failing atomic.Bool
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		if failing.Load() {
+			time.Sleep(50 * time.Millisecond)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		jwks, _ := EncodeJWKS(map[string]*rsa.PublicKey{"old": &key.PublicKey})
+		w.Write(jwks)
+	}))
+	defer server.Close()
+
+	keySet, err := NewJWKSURLKeySet(server.URL, server.Client(), time.Hour)
+	require.NoError(t, err)
+
+	failing.Store(true)
+	keySet.attemptedAt = time.Now().Add(-2 * minJWKSRefreshInterval)
+
+	// Concurrent lookups of an unknown key share one failed fetch.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := keySet.Key(context.Background(), "new")
+			assert.Error(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), fetches.Load())
+
+	// The failure counts as an attempt, so the issuer is not hammered.
+	_, err = keySet.Key(context.Background(), "new")
+	assert.ErrorContains(t, err, "unknown signing key")
+	assert.Equal(t, int32(2), fetches.Load())
+
+	// Expired keys keep being served while fetches fail.
+	keySet.fetchedAt = time.Now().Add(-2 * time.Hour)
+	found, err := keySet.Key(context.Background(), "old")
+	require.NoError(t, err)
+	assert.Equal(t, &key.PublicKey, found)
+	assert.Equal(t, int32(2), fetches.Load())
+
+	keySet.attemptedAt = time.Now().Add(-2 * minJWKSRefreshInterval)
+	found, err = keySet.Key(context.Background(), "old")
+	require.NoError(t, err)
+	assert.Equal(t, &key.PublicKey, found)
+	assert.Equal(t, int32(3), fetches.Load())
+}
+
+func TestJWKSKeySet_ServesKnownKeysDuringFetch(t *testing.T) {
+	key := generateKey(t)
+
+	requested := make(chan struct{}, 1)
+	release := make(chan struct{})
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fetches.Add(1) > 1 {
+			requested <- struct{}{}
+			<-release
+		}
+		jwks, _ := EncodeJWKS(map[string]*rsa.PublicKey{"old": &key.PublicKey})
+		w.Write(jwks)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	keySet, err := NewJWKSURLKeySet(server.URL, server.Client(), time.Hour)
+	require.NoError(t, err)
+	keySet.attemptedAt = time.Now().Add(-2 * minJWKSRefreshInterval)
+
+	go keySet.Key(context.Background(), "new")
+	<-requested
+
+	found, err := keySet.Key(context.Background(), "old")
+	require.NoError(t, err)
+	assert.Equal(t, &key.PublicKey, found)
+
+	// A caller that gives up stops waiting for the fetch.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	keySet.attemptedAt = time.Now().Add(-2 * minJWKSRefreshInterval)
+	_, err = keySet.Key(ctx, "new")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestNewJWTVerifier_RequiresKeys(t *testing.T) {
+	_, err := NewJWTVerifier(JWTVerifierOptions{})
+	assert.Error(t, err)
+}
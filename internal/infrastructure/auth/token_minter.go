@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// TokenMinter issues tokens the JWTVerifier accepts. It exists for tests
+// and local development; production tokens come from the identity provider.
+type TokenMinter struct {
+	method   jwt.SigningMethod
+	key      interface{}
+	keyID    string
+	issuer   string
+	audience string
+}
+
+func NewHS256Minter(secret []byte, issuer, audience string) *TokenMinter {
+	return &TokenMinter{
+		method:   jwt.SigningMethodHS256,
+		key:      secret,
+		issuer:   issuer,
+		audience: audience,
+	}
+}
+
+func NewRS256Minter(key *rsa.PrivateKey, keyID, issuer, audience string) *TokenMinter {
+	return &TokenMinter{
+		method:   jwt.SigningMethodRS256,
+		key:      key,
+		keyID:    keyID,
+		issuer:   issuer,
+		audience: audience,
+	}
+}
+
+// Mint issues a token for subject. An empty tenantID leaves the claim out,
+// so the verifier's default tenant applies.
+func (m *TokenMinter) Mint(subject, tenantID string, ttl time.Duration, scopes ...string) (string, error) {
+	now := time.Now()
+	claims := tokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			Issuer:    m.issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+		Scope:    strings.Join(scopes, " "),
+		TenantID: tenantID,
+	}
+	if m.audience != "" {
+		claims.Audience = jwt.ClaimStrings{m.audience}
+	}
+
+	token := jwt.NewWithClaims(m.method, claims)
+	if m.keyID != "" {
+		token.Header["kid"] = m.keyID
+	}
+
+	return token.SignedString(m.key)
+}
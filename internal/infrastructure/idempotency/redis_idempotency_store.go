@@ -0,0 +1,135 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/infrastructure/rediskeys"
+)
+
+const keyPrefix = "idempotency:"
+
+// claimedScript runs its command only while KEYS[1] still holds the
+// in-flight claim with token ARGV[1]; it returns 0 when the claim is gone.
+// Completing stores ARGV[2] for ARGV[3] milliseconds, releasing deletes it.
+var claimedScript = redis.NewScript(`
+local data = redis.call('GET', KEYS[1])
+if not data then
+	return 0
+end
+local record = cjson.decode(data)
+if record.status ~= 'in_flight' or record.token ~= ARGV[1] then
+	return 0
+end
+if ARGV[2] then
+	redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3])
+else
+	redis.call('DEL', KEYS[1])
+end
+return 1
+`)
+
+type RedisIdempotencyStore struct {
+	client *redis.Client
+}
+
+func NewRedisIdempotencyStore(client *redis.Client) *RedisIdempotencyStore {
+	return &RedisIdempotencyStore{
+		client: client,
+	}
+}
+
+func (s *RedisIdempotencyStore) Begin(ctx context.Context, record *entities.IdempotencyRecord, lockTTL time.Duration) (*entities.IdempotencyRecord, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal idempotency record: %w", err)
+	}
+
+	// The key can expire or be released between SETNX and GET, so retry a
+	// few times before giving up.
+	for attempt := 0; attempt < 3; attempt++ {
+		claimed, err := s.client.SetNX(ctx, keyPrefix+record.Key, data, lockTTL).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to claim idempotency key: %w", err)
+		}
+		if claimed {
+			return nil, nil
+		}
+
+		stored, err := s.get(ctx, record.Key)
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if stored.Fingerprint != record.Fingerprint {
+			return nil, entities.ErrIdempotencyKeyMismatch
+		}
+		if !stored.IsCompleted() {
+			return nil, entities.ErrIdempotencyKeyInUse
+		}
+		return stored, nil
+	}
+
+	return nil, entities.ErrIdempotencyKeyInUse
+}
+
+// Complete stores the response only while the request still holds its
+// in-flight claim; otherwise it returns ErrIdempotencyLockLost and leaves the
+// key to whichever request holds it now.
+func (s *RedisIdempotencyStore) Complete(ctx context.Context, record *entities.IdempotencyRecord, ttl time.Duration) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal idempotency record: %w", err)
+	}
+
+	stored, err := claimedScript.Run(ctx, s.client, []string{keyPrefix + record.Key}, record.Token, data, ttl.Milliseconds()).Int()
+	if err != nil {
+		return fmt.Errorf("failed to store idempotent response: %w", err)
+	}
+	if stored == 0 {
+		return entities.ErrIdempotencyLockLost
+	}
+
+	return nil
+}
+
+// Release drops the request's in-flight claim so the key can be retried. A
+// claim that has already expired or been taken over is left alone.
+func (s *RedisIdempotencyStore) Release(ctx context.Context, record *entities.IdempotencyRecord) error {
+	if err := claimedScript.Run(ctx, s.client, []string{keyPrefix + record.Key}, record.Token).Err(); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (s *RedisIdempotencyStore) get(ctx context.Context, key string) (*entities.IdempotencyRecord, error) {
+	data, err := s.client.Get(ctx, keyPrefix+key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to load idempotency record: %w", err)
+	}
+
+	var record entities.IdempotencyRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to decode idempotency record: %w", err)
+	}
+
+	return &record, nil
+}
+
+// DeleteTenant removes every stored response for a tenant's users.
+func (s *RedisIdempotencyStore) DeleteTenant(ctx context.Context, tenantID string) (int64, error) {
+	return rediskeys.DeleteByPrefix(ctx, s.client, keyPrefix+tenantID+"/")
+}
@@ -0,0 +1,156 @@
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/domain/entities"
+)
+
+func newTestStore(t *testing.T) (*RedisIdempotencyStore, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return NewRedisIdempotencyStore(client), server
+}
+
+func TestBegin_ClaimsNewKey(t *testing.T) {
+	store, _ := newTestStore(t)
+	ctx := context.Background()
+
+	stored, err := store.Begin(ctx, entities.NewIdempotencyRecord("key-1", "fp"), time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+
+	_, err = store.Begin(ctx, entities.NewIdempotencyRecord("key-1", "fp"), time.Minute)
+	assert.ErrorIs(t, err, entities.ErrIdempotencyKeyInUse)
+}
+
+func TestBegin_ReplaysCompletedResponse(t *testing.T) {
+	store, _ := newTestStore(t)
+	ctx := context.Background()
+
+	record := entities.NewIdempotencyRecord("key-1", "fp")
+	_, err := store.Begin(ctx, record, time.Minute)
+	require.NoError(t, err)
+
+	record.Complete(http.StatusCreated, http.Header{"Content-Type": []string{"application/json"}}, []byte(`{"data":{}}`))
+	require.NoError(t, store.Complete(ctx, record, time.Hour))
+
+	stored, err := store.Begin(ctx, entities.NewIdempotencyRecord("key-1", "fp"), time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, http.StatusCreated, stored.StatusCode)
+	assert.Equal(t, "application/json", stored.Header.Get("Content-Type"))
+	assert.Equal(t, `{"data":{}}`, string(stored.Body))
+}
+
+func TestBegin_RejectsDifferentFingerprint(t *testing.T) {
+	store, _ := newTestStore(t)
+	ctx := context.Background()
+
+	record := entities.NewIdempotencyRecord("key-1", "fp")
+	_, err := store.Begin(ctx, record, time.Minute)
+	require.NoError(t, err)
+	record.Complete(http.StatusCreated, nil, nil)
+	require.NoError(t, store.Complete(ctx, record, time.Hour))
+
+	_, err = store.Begin(ctx, entities.NewIdempotencyRecord("key-1", "other"), time.Minute)
+	assert.ErrorIs(t, err, entities.ErrIdempotencyKeyMismatch)
+}
+
+func TestRelease_AllowsRetry(t *testing.T) {
+	store, _ := newTestStore(t)
+	ctx := context.Background()
+
+	record := entities.NewIdempotencyRecord("key-1", "fp")
+	_, err := store.Begin(ctx, record, time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, store.Release(ctx, record))
+
+	stored, err := store.Begin(ctx, entities.NewIdempotencyRecord("key-1", "fp"), time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+}
+
+func TestBegin_LockExpires(t *testing.T) {
+	store, server := newTestStore(t)
+	ctx := context.Background()
+
+	_, err := store.Begin(ctx, entities.NewIdempotencyRecord("key-1", "fp"), time.Minute)
+	require.NoError(t, err)
+
+	server.FastForward(2 * time.Minute)
+
+	stored, err := store.Begin(ctx, entities.NewIdempotencyRecord("key-1", "fp"), time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+}
+
+func TestComplete_AfterClaimWasTakenOver(t *testing.T) {
+	store, server := newTestStore(t)
+	ctx := context.Background()
+
+	slow := entities.NewIdempotencyRecord("key-1", "fp")
+	_, err := store.Begin(ctx, slow, time.Minute)
+	require.NoError(t, err)
+
+	server.FastForward(2 * time.Minute)
+	retry := entities.NewIdempotencyRecord("key-1", "fp")
+	_, err = store.Begin(ctx, retry, time.Minute)
+	require.NoError(t, err)
+
+	slow.Complete(http.StatusCreated, nil, []byte(`{"data":{"id":"first"}}`))
+	assert.ErrorIs(t, store.Complete(ctx, slow, time.Hour), entities.ErrIdempotencyLockLost)
+	require.NoError(t, store.Release(ctx, slow))
+
+	// The retry still holds the key and stores its own response.
+	_, err = store.Begin(ctx, entities.NewIdempotencyRecord("key-1", "fp"), time.Minute)
+	assert.ErrorIs(t, err, entities.ErrIdempotencyKeyInUse)
+
+	retry.Complete(http.StatusCreated, nil, []byte(`{"data":{"id":"second"}}`))
+	require.NoError(t, store.Complete(ctx, retry, time.Hour))
+
+	stored, err := store.Begin(ctx, entities.NewIdempotencyRecord("key-1", "fp"), time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, `{"data":{"id":"second"}}`, string(stored.Body))
+	assert.Equal(t, time.Hour, server.TTL(keyPrefix+"key-1"))
+}
+
+func TestComplete_DoesNotOverwriteCompletedResponse(t *testing.T) {
+	store, _ := newTestStore(t)
+	ctx := context.Background()
+
+	record := entities.NewIdempotencyRecord("key-1", "fp")
+	_, err := store.Begin(ctx, record, time.Minute)
+	require.NoError(t, err)
+	record.Complete(http.StatusCreated, nil, []byte("first"))
+	require.NoError(t, store.Complete(ctx, record, time.Hour))
+
+	record.Complete(http.StatusOK, nil, []byte("second"))
+	assert.ErrorIs(t, store.Complete(ctx, record, time.Hour), entities.ErrIdempotencyLockLost)
+}
+
+func TestDeleteTenant_RemovesOnlyThatTenant(t *testing.T) {
+	store, server := newTestStore(t)
+	ctx := context.Background()
+
+	for _, key := range []string{"acme/user-1:POST /todo:a", "acme/user-2:POST /todo:b", "acme-corp/user-1:POST /todo:c"} {
+		_, err := store.Begin(ctx, entities.NewIdempotencyRecord(key, "fp"), time.Minute)
+		require.NoError(t, err)
+	}
+
+	deleted, err := store.DeleteTenant(ctx, "acme")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	assert.Equal(t, []string{keyPrefix + "acme-corp/user-1:POST /todo:c"}, server.Keys())
+}
@@ -0,0 +1,134 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "todo"
+
+const (
+	outcomeSuccess = "success"
+	outcomeError   = "error"
+)
+
+// Metrics owns the Prometheus registry served on /metrics. Every label is
+// drawn from a fixed set (route templates, command names, reasons), never
+// from IDs or raw paths.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	redisCommands *prometheus.HistogramVec
+
+	s3Duration   *prometheus.HistogramVec
+	s3UploadSize prometheus.Histogram
+
+	todosCreated    prometheus.Counter
+	uploadsRejected *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "HTTP requests by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by method and route template.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+
+		redisCommands: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "redis",
+			Name:      "command_duration_seconds",
+			Help:      "Redis command latency by command name and outcome.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"command", "outcome"}),
+
+		s3Duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "s3",
+			Name:      "operation_duration_seconds",
+			Help:      "S3 call latency by operation and outcome.",
+			Buckets:   []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		}, []string{"operation", "outcome"}),
+		s3UploadSize: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "s3",
+			Name:      "upload_size_bytes",
+			Help:      "Size of files uploaded to S3.",
+			Buckets:   prometheus.ExponentialBuckets(1024, 4, 8),
+		}),
+
+		todosCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "todos_created_total",
+			Help:      "Todos created through the API or delta sync.",
+		}),
+		uploadsRejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "file_uploads_rejected_total",
+			Help:      "Uploads rejected before storage, by reason.",
+		}, []string{"reason"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.redisCommands,
+		m.s3Duration,
+		m.s3UploadSize,
+		m.todosCreated,
+		m.uploadsRejected,
+	)
+
+	return m
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// RegisterDB exports the pool statistics of db as go_sql_* metrics.
+func (m *Metrics) RegisterDB(db *sql.DB, name string) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+func (m *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+func (m *Metrics) TodoCreated() {
+	m.todosCreated.Inc()
+}
+
+func (m *Metrics) UploadRejected(reason string) {
+	m.uploadsRejected.WithLabelValues(reason).Inc()
+}
+
+func outcome(err error) string {
+	if err != nil {
+		return outcomeError
+	}
+	return outcomeSuccess
+}
@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/interfaces/http/middleware"
+)
+
+func TestHTTPMetrics_UseRouteTemplates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := New()
+
+	router := gin.New()
+	router.Use(middleware.Metrics(m))
+	router.GET("/api/v1/todo/:id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	for _, path := range []string{"/api/v1/todo/1", "/api/v1/todo/2", "/does/not/exist"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.httpRequests.WithLabelValues("GET", "/api/v1/todo/:id", "204")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.httpRequests.WithLabelValues("GET", "unmatched", "404")))
+	assert.Equal(t, 2, testutil.CollectAndCount(m.httpRequests))
+}
+
+func TestRedisMetrics_TimeCommandsAndExportPoolStats(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	m := New()
+	m.InstrumentRedis(client)
+
+	ctx := context.Background()
+	require.NoError(t, client.XAdd(ctx, &redis.XAddArgs{Stream: "todo-events", Values: map[string]interface{}{"k": "v"}}).Err())
+	require.ErrorIs(t, client.Get(ctx, "missing").Err(), redis.Nil)
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `todo_redis_command_duration_seconds_count{command="xadd",outcome="success"} 1`)
+	assert.Contains(t, body, `todo_redis_command_duration_seconds_count{command="get",outcome="success"} 1`)
+	assert.Contains(t, body, "todo_redis_pool_connections ")
+}
+
+func TestDomainMetrics_CountRejectionsByReason(t *testing.T) {
+	m := New()
+
+	m.UploadRejected(entities.FileRejectionReason(entities.ValidateFile("setup.exe", 10)))
+	m.UploadRejected(entities.FileRejectionReason(entities.ValidateFile("big.pdf", entities.MaxFileSize+1)))
+	m.UploadRejected(entities.FileRejectionReason(entities.ValidateFile("other.exe", 10)))
+	m.TodoCreated()
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.uploadsRejected.WithLabelValues(entities.FileRejectedType)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.uploadsRejected.WithLabelValues(entities.FileRejectedTooLarge)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.todosCreated))
+}
+
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var lines []string
+	for _, line := range strings.Split(recorder.Body.String(), "\n") {
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
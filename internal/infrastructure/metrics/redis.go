@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type redisStartKey struct{}
+
+// InstrumentRedis times every command sent through client (XADD, the rate
+// limiter script, idempotency keys, …) and exports its connection pool
+// statistics.
+func (m *Metrics) InstrumentRedis(client *redis.Client) {
+	client.AddHook(&redisHook{durations: m.redisCommands})
+	m.registry.MustRegister(&redisPoolCollector{client: client})
+}
+
+type redisHook struct {
+	durations *prometheus.HistogramVec
+}
+
+func (h *redisHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	return context.WithValue(ctx, redisStartKey{}, time.Now()), nil
+}
+
+func (h *redisHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	h.observe(ctx, strings.ToLower(cmd.Name()), cmd.Err())
+	return nil
+}
+
+func (h *redisHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	return context.WithValue(ctx, redisStartKey{}, time.Now()), nil
+}
+
+func (h *redisHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	var err error
+	for _, cmd := range cmds {
+		if cmdErr := cmd.Err(); cmdErr != nil && !errors.Is(cmdErr, redis.Nil) {
+			err = cmdErr
+			break
+		}
+	}
+	h.observe(ctx, "pipeline", err)
+	return nil
+}
+
+func (h *redisHook) observe(ctx context.Context, command string, err error) {
+	start, ok := ctx.Value(redisStartKey{}).(time.Time)
+	if !ok {
+		return
+	}
+	// A missing key is an answer, not a failure.
+	if errors.Is(err, redis.Nil) {
+		err = nil
+	}
+	h.durations.WithLabelValues(command, outcome(err)).Observe(time.Since(start).Seconds())
+}
+
+var (
+	redisPoolHits = prometheus.NewDesc(namespace+"_redis_pool_hits_total",
+		"Times a free connection was found in the pool.", nil, nil)
+	redisPoolMisses = prometheus.NewDesc(namespace+"_redis_pool_misses_total",
+		"Times a free connection was not found in the pool.", nil, nil)
+	redisPoolTimeouts = prometheus.NewDesc(namespace+"_redis_pool_timeouts_total",
+		"Times a wait for a connection timed out.", nil, nil)
+	redisPoolStale = prometheus.NewDesc(namespace+"_redis_pool_stale_connections_total",
+		"Stale connections removed from the pool.", nil, nil)
+	redisPoolTotal = prometheus.NewDesc(namespace+"_redis_pool_connections",
+		"Connections currently in the pool.", nil, nil)
+	redisPoolIdle = prometheus.NewDesc(namespace+"_redis_pool_idle_connections",
+		"Idle connections currently in the pool.", nil, nil)
+)
+
+type redisPoolCollector struct {
+	client *redis.Client
+}
+
+func (c *redisPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- redisPoolHits
+	ch <- redisPoolMisses
+	ch <- redisPoolTimeouts
+	ch <- redisPoolStale
+	ch <- redisPoolTotal
+	ch <- redisPoolIdle
+}
+
+func (c *redisPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.client.PoolStats()
+	ch <- prometheus.MustNewConstMetric(redisPoolHits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(redisPoolMisses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(redisPoolTimeouts, prometheus.CounterValue, float64(stats.Timeouts))
+	ch <- prometheus.MustNewConstMetric(redisPoolStale, prometheus.CounterValue, float64(stats.StaleConns))
+	ch <- prometheus.MustNewConstMetric(redisPoolTotal, prometheus.GaugeValue, float64(stats.TotalConns))
+	ch <- prometheus.MustNewConstMetric(redisPoolIdle, prometheus.GaugeValue, float64(stats.IdleConns))
+}
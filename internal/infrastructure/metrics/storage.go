@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"todo-service/internal/domain/ports"
+)
+
+type instrumentedFileStorage struct {
+	next    ports.FileStorage
+	metrics *Metrics
+}
+
+// InstrumentFileStorage records the latency of every storage call and the
+// size of uploaded files.
+func (m *Metrics) InstrumentFileStorage(next ports.FileStorage) ports.FileStorage {
+	return &instrumentedFileStorage{next: next, metrics: m}
+}
+
+func (s *instrumentedFileStorage) UploadFile(ctx context.Context, storagePath, contentType string, data io.Reader, size int64) error {
+	start := time.Now()
+	err := s.next.UploadFile(ctx, storagePath, contentType, data, size)
+	s.metrics.s3Duration.WithLabelValues("upload", outcome(err)).Observe(time.Since(start).Seconds())
+	if err == nil {
+		s.metrics.s3UploadSize.Observe(float64(size))
+	}
+	return err
+}
+
+func (s *instrumentedFileStorage) DownloadFile(ctx context.Context, storagePath string) (io.ReadCloser, error) {
+	start := time.Now()
+	data, err := s.next.DownloadFile(ctx, storagePath)
+	s.metrics.s3Duration.WithLabelValues("download", outcome(err)).Observe(time.Since(start).Seconds())
+	return data, err
+}
+
+func (s *instrumentedFileStorage) DeleteFile(ctx context.Context, storagePath string) error {
+	start := time.Now()
+	err := s.next.DeleteFile(ctx, storagePath)
+	s.metrics.s3Duration.WithLabelValues("delete", outcome(err)).Observe(time.Since(start).Seconds())
+	return err
+}
@@ -0,0 +1,434 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+//go:embed sql/*.sql
+var embedded embed.FS
+
+const (
+	lockName           = "todo-service.schema_migrations"
+	defaultLockTimeout = time.Minute
+)
+
+var (
+	ErrLockTimeout      = errors.New("timed out waiting for the migration lock")
+	ErrUnknownVersion   = errors.New("unknown migration version")
+	ErrMissingMigration = errors.New("database has migrations this binary does not know about")
+)
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+}
+
+// Migrator applies the versioned SQL migrations embedded in the binary and
+// records them in schema_migrations. Runs hold a MySQL advisory lock so
+// replicas starting at the same time apply each migration once.
+type Migrator struct {
+	db          *sql.DB
+	migrations  []Migration
+	logger      *zap.Logger
+	lockTimeout time.Duration
+}
+
+func NewMigrator(db *sql.DB, logger *zap.Logger) (*Migrator, error) {
+	sub, err := fs.Sub(embedded, "sql")
+	if err != nil {
+		return nil, err
+	}
+	return NewMigratorFromFS(db, sub, logger)
+}
+
+func NewMigratorFromFS(db *sql.DB, fsys fs.FS, logger *zap.Logger) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		db:          db,
+		migrations:  migrations,
+		logger:      logger,
+		lockTimeout: defaultLockTimeout,
+	}, nil
+}
+
+// Load reads NNN_name.up.sql / NNN_name.down.sql pairs, ordered by version.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		data, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(data)
+		} else {
+			migration.Down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %03d_%s needs both an up and a down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Latest is the highest version known to this binary, or zero.
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.To(ctx, m.Latest())
+}
+
+// Down reverts the most recently applied steps migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		versions := sortedVersions(applied)
+		if steps > len(versions) {
+			steps = len(versions)
+		}
+
+		var target int64
+		if remaining := len(versions) - steps; remaining > 0 {
+			target = versions[remaining-1]
+		}
+		return m.migrate(ctx, conn, applied, target)
+	})
+}
+
+// To migrates up or down so that exactly the migrations up to and including
+// version are applied. Version zero reverts everything.
+func (m *Migrator) To(ctx context.Context, version int64) error {
+	if version != 0 && m.find(version) == nil {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		return m.migrate(ctx, conn, applied, version)
+	})
+}
+
+// Baseline records migrations up to version as applied without running
+// them, for databases created before schema_migrations existed.
+func (m *Migrator) Baseline(ctx context.Context, version int64) error {
+	if m.find(version) == nil {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version > version || applied[migration.Version] {
+				continue
+			}
+			if err := recordApplied(ctx, conn, migration); err != nil {
+				return err
+			}
+			m.logger.Info("Marked migration as applied", zap.Int64("version", migration.Version), zap.String("name", migration.Name))
+		}
+		return nil
+	})
+}
+
+// Status lists every known migration and when it was applied, plus any
+// applied version this binary doesn't know about.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := ensureTable(ctx, conn); err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.QueryContext(ctx, "SELECT version, name, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	appliedAt := make(map[int64]time.Time)
+	names := make(map[int64]string)
+	for rows.Next() {
+		var version int64
+		var name string
+		var at time.Time
+		if err := rows.Scan(&version, &name, &at); err != nil {
+			return nil, err
+		}
+		appliedAt[version] = at
+		names[version] = name
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var statuses []Status
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if at, ok := appliedAt[migration.Version]; ok {
+			status.AppliedAt = &at
+			delete(appliedAt, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for version, at := range appliedAt {
+		at := at
+		statuses = append(statuses, Status{Version: version, Name: names[version], AppliedAt: &at})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+
+	return statuses, nil
+}
+
+func (m *Migrator) migrate(ctx context.Context, conn *sql.Conn, applied map[int64]bool, target int64) error {
+	up, down, err := m.plan(applied, target)
+	if err != nil {
+		return err
+	}
+
+	if len(up) == 0 && len(down) == 0 {
+		m.logger.Info("Schema is up to date", zap.Int64("version", target))
+		return nil
+	}
+
+	for _, migration := range down {
+		m.logger.Info("Reverting migration", zap.Int64("version", migration.Version), zap.String("name", migration.Name))
+		if err := execScript(ctx, conn, migration.Down); err != nil {
+			return fmt.Errorf("revert %03d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if _, err := conn.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = ?", migration.Version); err != nil {
+			return err
+		}
+	}
+
+	for _, migration := range up {
+		m.logger.Info("Applying migration", zap.Int64("version", migration.Version), zap.String("name", migration.Name))
+		if err := execScript(ctx, conn, migration.Up); err != nil {
+			return fmt.Errorf("apply %03d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if err := recordApplied(ctx, conn, migration); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// plan returns the migrations to apply, ascending, and to revert,
+// descending, to end up at target.
+func (m *Migrator) plan(applied map[int64]bool, target int64) (up, down []Migration, err error) {
+	for version := range applied {
+		if version > target && m.find(version) == nil {
+			return nil, nil, fmt.Errorf("%w: version %d is applied", ErrMissingMigration, version)
+		}
+	}
+
+	for _, migration := range m.migrations {
+		if migration.Version <= target && !applied[migration.Version] {
+			up = append(up, migration)
+		}
+	}
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		if migration := m.migrations[i]; migration.Version > target && applied[migration.Version] {
+			down = append(down, migration)
+		}
+	}
+	return up, down, nil
+}
+
+func (m *Migrator) find(version int64) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+// withLock runs fn on a single connection holding the advisory lock. MySQL
+// releases GET_LOCK locks when their connection closes, so a crashed
+// migrator can't leave the lock held.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, int(m.lockTimeout.Seconds())).Scan(&acquired); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	if acquired.Int64 != 1 {
+		return ErrLockTimeout
+	}
+	defer func() {
+		if _, releaseErr := conn.ExecContext(context.WithoutCancel(ctx), "SELECT RELEASE_LOCK(?)", lockName); releaseErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to release migration lock: %w", releaseErr))
+		}
+	}()
+
+	if err := ensureTable(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+func ensureTable(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+	return nil
+}
+
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]bool, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]bool)
+	for rows.Next() {
+		var version int64
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+func recordApplied(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	_, err := conn.ExecContext(ctx, "INSERT INTO schema_migrations (version, name) VALUES (?, ?)", migration.Version, migration.Name)
+	return err
+}
+
+func sortedVersions(applied map[int64]bool) []int64 {
+	versions := make([]int64, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions
+}
+
+// execScript runs each statement of a migration on the same connection so
+// session variables carry over between statements. DDL commits implicitly
+// in MySQL, so a failing migration may be partially applied.
+func execScript(ctx context.Context, conn *sql.Conn, script string) error {
+	for _, statement := range splitStatements(script) {
+		if _, err := conn.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitStatements splits a script into statements terminated by a semicolon
+// at the end of a line, dropping "--" comment lines.
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+
+		current.WriteString(line)
+		current.WriteString("\n")
+
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSuffix(strings.TrimSpace(current.String()), ";"))
+			current.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+
+	return statements
+}
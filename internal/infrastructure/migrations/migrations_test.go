@@ -0,0 +1,96 @@
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func versions(migrations []Migration) []int64 {
+	var result []int64
+	for _, migration := range migrations {
+		result = append(result, migration.Version)
+	}
+	return result
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrator, err := NewMigrator(nil, zap.NewNop())
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(12), migrator.Latest())
+	for i, migration := range migrator.migrations {
+		assert.Equal(t, int64(i+1), migration.Version, "migrations must be numbered without gaps")
+		assert.NotEmpty(t, splitStatements(migration.Up), migration.Name)
+		assert.NotEmpty(t, splitStatements(migration.Down), migration.Name)
+	}
+}
+
+func TestLoad_RequiresDownMigration(t *testing.T) {
+	_, err := Load(fstest.MapFS{
+		"001_create_todos.up.sql":   {Data: []byte("CREATE TABLE todos (id INT);")},
+		"001_create_todos.down.sql": {Data: []byte("DROP TABLE todos;")},
+		"002_add_column.up.sql":     {Data: []byte("ALTER TABLE todos ADD COLUMN x INT;")},
+	})
+
+	assert.ErrorContains(t, err, "002_add_column needs both an up and a down file")
+}
+
+func TestPlan(t *testing.T) {
+	fsys := fstest.MapFS{}
+	for _, name := range []string{"001_a", "002_b", "003_c"} {
+		fsys[name+".up.sql"] = &fstest.MapFile{Data: []byte("SELECT 1;")}
+		fsys[name+".down.sql"] = &fstest.MapFile{Data: []byte("SELECT 1;")}
+	}
+	migrator, err := NewMigratorFromFS(nil, fsys, zap.NewNop())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		applied  map[int64]bool
+		target   int64
+		wantUp   []int64
+		wantDown []int64
+	}{
+		{"fresh database", map[int64]bool{}, 3, []int64{1, 2, 3}, nil},
+		{"pending", map[int64]bool{1: true}, 3, []int64{2, 3}, nil},
+		{"fills gaps", map[int64]bool{1: true, 3: true}, 3, []int64{2}, nil},
+		{"down to version", map[int64]bool{1: true, 2: true, 3: true}, 1, nil, []int64{3, 2}},
+		{"down to zero", map[int64]bool{1: true, 2: true}, 0, nil, []int64{2, 1}},
+		{"up to date", map[int64]bool{1: true, 2: true, 3: true}, 3, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			up, down, err := migrator.plan(tt.applied, tt.target)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantUp, versions(up))
+			assert.Equal(t, tt.wantDown, versions(down))
+		})
+	}
+
+	_, _, err = migrator.plan(map[int64]bool{1: true, 4: true}, 1)
+	assert.ErrorIs(t, err, ErrMissingMigration)
+}
+
+func TestSplitStatements(t *testing.T) {
+	statements := splitStatements(`-- Migration: Example
+-- Version: 001
+
+CREATE TABLE t (
+    id INT PRIMARY KEY -- inline comments stay
+);
+
+SET @seq := 0;
+UPDATE t SET id = (@seq := @seq + 1) ORDER BY id;
+INSERT INTO t (id) VALUES (1)
+    ON DUPLICATE KEY UPDATE id = VALUES(id)`)
+
+	require.Len(t, statements, 4)
+	assert.Equal(t, "CREATE TABLE t (\n    id INT PRIMARY KEY -- inline comments stay\n)", statements[0])
+	assert.Equal(t, "SET @seq := 0", statements[1])
+	assert.Equal(t, "INSERT INTO t (id) VALUES (1)\n    ON DUPLICATE KEY UPDATE id = VALUES(id)", statements[3])
+}
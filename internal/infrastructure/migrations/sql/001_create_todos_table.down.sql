@@ -0,0 +1,5 @@
+-- Migration: Create todos table
+-- Version: 001
+-- Description: Rollback; drops the todos table and all todo items
+
+DROP TABLE IF EXISTS todos;
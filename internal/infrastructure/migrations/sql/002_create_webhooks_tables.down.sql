@@ -0,0 +1,6 @@
+-- Migration: Create webhook tables
+-- Version: 002
+-- Description: Rollback; drops webhook subscriptions and their delivery log
+
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
@@ -0,0 +1,14 @@
+-- Migration: Add change tracking to todos
+-- Version: 003
+-- Description: Rollback; drops the change sequence and tombstones (deleted todos are purged)
+
+DROP TABLE IF EXISTS todo_change_sequence;
+
+DELETE FROM todos WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE todos
+    DROP INDEX idx_change_seq,
+    DROP COLUMN change_seq,
+    DROP COLUMN deleted_at,
+    MODIFY created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    MODIFY updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP;
@@ -0,0 +1,22 @@
+-- Migration: Add change tracking to todos
+-- Version: 003
+-- Description: Change sequence and tombstones for delta sync, microsecond timestamps
+
+ALTER TABLE todos
+    MODIFY created_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    MODIFY updated_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6),
+    ADD COLUMN deleted_at TIMESTAMP(6) NULL,
+    ADD COLUMN change_seq BIGINT NOT NULL DEFAULT 0,
+    ADD INDEX idx_change_seq (change_seq);
+
+-- Single-row counter. Writers bump it inside their transaction, so the row
+-- lock orders commits and a reader never sees sequence N+1 before N.
+CREATE TABLE IF NOT EXISTS todo_change_sequence (
+    id TINYINT PRIMARY KEY,
+    value BIGINT NOT NULL
+) ENGINE=InnoDB;
+
+SET @seq := 0;
+UPDATE todos SET change_seq = (@seq := @seq + 1) ORDER BY created_at, id;
+INSERT INTO todo_change_sequence (id, value) VALUES (1, @seq)
+    ON DUPLICATE KEY UPDATE value = GREATEST(value, VALUES(value));
@@ -0,0 +1,6 @@
+-- Migration: Add optimistic concurrency version to todos
+-- Version: 004
+-- Description: Rollback; drops the todo version column
+
+ALTER TABLE todos
+    DROP COLUMN version;
@@ -0,0 +1,6 @@
+-- Migration: Add optimistic concurrency version to todos
+-- Version: 004
+-- Description: Integer version incremented on every update, exposed as ETag
+
+ALTER TABLE todos
+    ADD COLUMN version INT NOT NULL DEFAULT 1;
@@ -0,0 +1,14 @@
+-- Migration: Add owners
+-- Version: 005
+-- Description: Rollback; drops the files table and owner columns
+
+DROP TABLE IF EXISTS files;
+
+ALTER TABLE webhooks
+    DROP INDEX idx_owner,
+    DROP COLUMN owner_id;
+
+ALTER TABLE todos
+    DROP INDEX idx_owner_created,
+    DROP INDEX idx_owner_change_seq,
+    DROP COLUMN owner_id;
@@ -0,0 +1,25 @@
+-- Migration: Add owners
+-- Version: 005
+-- Description: Scope todos and webhooks to the authenticated user and record uploaded files
+
+ALTER TABLE todos
+    ADD COLUMN owner_id VARCHAR(255) NOT NULL DEFAULT '' AFTER id,
+    ADD INDEX idx_owner_created (owner_id, deleted_at, created_at),
+    ADD INDEX idx_owner_change_seq (owner_id, change_seq);
+
+ALTER TABLE webhooks
+    ADD COLUMN owner_id VARCHAR(255) NOT NULL DEFAULT '' AFTER id,
+    ADD INDEX idx_owner (owner_id);
+
+CREATE TABLE IF NOT EXISTS files (
+    id VARCHAR(36) PRIMARY KEY,
+    owner_id VARCHAR(255) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    storage_path VARCHAR(1024) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    INDEX idx_owner_created (owner_id, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,5 @@
+-- Migration: Create API keys table
+-- Version: 006
+-- Description: Rollback; drops the API key table, revoking every key
+
+DROP TABLE IF EXISTS api_keys;
@@ -0,0 +1,25 @@
+-- Migration: Add tenants
+-- Version: 007
+-- Description: Rollback; drops tenant columns and restores per-owner indexes
+
+ALTER TABLE todos
+    DROP INDEX idx_tenant_owner_created,
+    DROP INDEX idx_tenant_owner_change_seq,
+    ADD INDEX idx_owner_created (owner_id, deleted_at, created_at),
+    ADD INDEX idx_owner_change_seq (owner_id, change_seq),
+    DROP COLUMN tenant_id;
+
+ALTER TABLE files
+    DROP INDEX idx_tenant_owner_created,
+    ADD INDEX idx_owner_created (owner_id, created_at),
+    DROP COLUMN tenant_id;
+
+ALTER TABLE webhooks
+    DROP INDEX idx_tenant_owner,
+    ADD INDEX idx_owner (owner_id),
+    DROP COLUMN tenant_id;
+
+ALTER TABLE api_keys
+    DROP INDEX idx_tenant_owner_created,
+    ADD INDEX idx_owner_created (owner_id, created_at),
+    DROP COLUMN tenant_id;
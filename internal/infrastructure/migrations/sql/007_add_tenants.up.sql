@@ -0,0 +1,25 @@
+-- Migration: Add tenants
+-- Version: 007
+-- Description: Scope todos, files, webhooks and API keys to a tenant workspace
+
+ALTER TABLE todos
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' AFTER id,
+    DROP INDEX idx_owner_created,
+    DROP INDEX idx_owner_change_seq,
+    ADD INDEX idx_tenant_owner_created (tenant_id, owner_id, deleted_at, created_at),
+    ADD INDEX idx_tenant_owner_change_seq (tenant_id, owner_id, change_seq);
+
+ALTER TABLE files
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' AFTER id,
+    DROP INDEX idx_owner_created,
+    ADD INDEX idx_tenant_owner_created (tenant_id, owner_id, created_at);
+
+ALTER TABLE webhooks
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' AFTER id,
+    DROP INDEX idx_owner,
+    ADD INDEX idx_tenant_owner (tenant_id, owner_id);
+
+ALTER TABLE api_keys
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' AFTER id,
+    DROP INDEX idx_owner_created,
+    ADD INDEX idx_tenant_owner_created (tenant_id, owner_id, created_at);
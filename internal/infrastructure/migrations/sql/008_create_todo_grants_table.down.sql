@@ -0,0 +1,5 @@
+-- Migration: Create todo grants table
+-- Version: 008
+-- Description: Rollback; drops the todo grants table, unsharing every todo
+
+DROP TABLE IF EXISTS todo_grants;
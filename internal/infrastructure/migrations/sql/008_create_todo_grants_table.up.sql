@@ -0,0 +1,17 @@
+-- Migration: Create todo grants table
+-- Version: 008
+-- Description: Share todos with other users of the same tenant as editor, viewer or co-owner
+
+CREATE TABLE IF NOT EXISTS todo_grants (
+    todo_id VARCHAR(36) NOT NULL,
+    tenant_id VARCHAR(64) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    role VARCHAR(16) NOT NULL,
+    granted_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+
+    PRIMARY KEY (todo_id, user_id),
+    INDEX idx_tenant_user (tenant_id, user_id, todo_id),
+    CONSTRAINT fk_todo_grants_todo FOREIGN KEY (todo_id) REFERENCES todos(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
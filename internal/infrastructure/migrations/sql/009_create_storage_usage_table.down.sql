@@ -0,0 +1,5 @@
+-- Migration: Create storage usage table
+-- Version: 009
+-- Description: Rollback; drops the storage usage table
+
+DROP TABLE IF EXISTS storage_usage;
@@ -0,0 +1,18 @@
+-- Migration: Create storage usage table
+-- Version: 009
+-- Description: Track bytes and file counts per user for storage quotas
+
+CREATE TABLE IF NOT EXISTS storage_usage (
+    tenant_id VARCHAR(64) NOT NULL,
+    owner_id VARCHAR(255) NOT NULL,
+    bytes_used BIGINT NOT NULL DEFAULT 0,
+    file_count BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP(6) NOT NULL,
+
+    PRIMARY KEY (tenant_id, owner_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT IGNORE INTO storage_usage (tenant_id, owner_id, bytes_used, file_count, updated_at)
+SELECT tenant_id, owner_id, SUM(size), COUNT(*), CURRENT_TIMESTAMP(6)
+FROM files
+GROUP BY tenant_id, owner_id;
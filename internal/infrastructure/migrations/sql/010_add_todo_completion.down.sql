@@ -0,0 +1,6 @@
+-- Migration: Add completion to todos
+-- Version: 010
+-- Description: Rollback; drops the todo completion column
+
+ALTER TABLE todos
+    DROP COLUMN completed_at;
@@ -0,0 +1,6 @@
+-- Migration: Add completion to todos
+-- Version: 010
+-- Description: Record when a todo was completed; NULL while it is open
+
+ALTER TABLE todos
+    ADD COLUMN completed_at TIMESTAMP(6) NULL DEFAULT NULL AFTER file_id;
@@ -0,0 +1,8 @@
+-- Migration: Add webhook retry schedule
+-- Version: 011
+-- Description: Rollback; drops the webhook retry schedule
+
+ALTER TABLE webhook_deliveries
+    DROP INDEX idx_next_attempt,
+    DROP COLUMN next_attempt_at,
+    DROP COLUMN payload;
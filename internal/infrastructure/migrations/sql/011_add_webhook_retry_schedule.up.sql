@@ -0,0 +1,8 @@
+-- Migration: Add webhook retry schedule
+-- Version: 011
+-- Description: Persist failed deliveries with their event so retries survive restarts and do not block the consumer
+
+ALTER TABLE webhook_deliveries
+    ADD COLUMN payload JSON NULL AFTER duration_ms,
+    ADD COLUMN next_attempt_at TIMESTAMP(3) NULL DEFAULT NULL AFTER payload,
+    ADD INDEX idx_next_attempt (next_attempt_at);
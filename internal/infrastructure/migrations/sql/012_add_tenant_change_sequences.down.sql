@@ -0,0 +1,14 @@
+-- Migration: Add tenant change sequences
+-- Version: 012
+-- Description: Rollback; returns to a single change sequence continuing from the highest per-tenant value
+
+CREATE TABLE IF NOT EXISTS todo_change_sequence (
+    id TINYINT PRIMARY KEY,
+    value BIGINT NOT NULL
+) ENGINE=InnoDB;
+
+INSERT INTO todo_change_sequence (id, value)
+    SELECT 1, COALESCE(MAX(value), 0) FROM todo_change_sequences
+    ON DUPLICATE KEY UPDATE value = GREATEST(todo_change_sequence.value, VALUES(value));
+
+DROP TABLE IF EXISTS todo_change_sequences;
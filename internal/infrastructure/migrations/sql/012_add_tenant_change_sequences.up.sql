@@ -0,0 +1,17 @@
+-- Migration: Add tenant change sequences
+-- Version: 012
+-- Description: One change sequence per tenant so writes in different tenants no longer serialize on a single counter row
+
+-- Sync only reads changes within a tenant, so sequence numbers need only be
+-- ordered per tenant. Each counter continues from the tenant's highest
+-- change_seq, keeping existing sync tokens valid.
+CREATE TABLE IF NOT EXISTS todo_change_sequences (
+    tenant_id VARCHAR(64) PRIMARY KEY,
+    value BIGINT NOT NULL
+) ENGINE=InnoDB;
+
+INSERT INTO todo_change_sequences (tenant_id, value)
+    SELECT tenant_id, MAX(change_seq) FROM todos GROUP BY tenant_id
+    ON DUPLICATE KEY UPDATE value = GREATEST(todo_change_sequences.value, VALUES(value));
+
+DROP TABLE IF EXISTS todo_change_sequence;
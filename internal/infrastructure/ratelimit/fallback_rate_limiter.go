@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+	"todo-service/internal/logging"
+)
+
+// FallbackRateLimiter uses primary and switches to fallback for cooldown
+// whenever primary fails, so an unreachable Redis neither rejects every
+// request nor adds a timeout to each one.
+type FallbackRateLimiter struct {
+	primary  ports.RateLimiter
+	fallback ports.RateLimiter
+	cooldown time.Duration
+
+	mu        sync.Mutex
+	downUntil time.Time
+}
+
+func NewFallbackRateLimiter(primary, fallback ports.RateLimiter, cooldown time.Duration) *FallbackRateLimiter {
+	return &FallbackRateLimiter{
+		primary:  primary,
+		fallback: fallback,
+		cooldown: cooldown,
+	}
+}
+
+func (l *FallbackRateLimiter) Allow(ctx context.Context, key string, limit entities.RateLimit) (*entities.RateLimitResult, error) {
+	if l.primaryDown() {
+		return l.fallback.Allow(ctx, key, limit)
+	}
+
+	result, err := l.primary.Allow(ctx, key, limit)
+	if err == nil {
+		return result, nil
+	}
+
+	logging.FromContext(ctx).Warn("Rate limiter unavailable, using local limits",
+		zap.Duration("cooldown", l.cooldown), zap.Error(err))
+	l.markDown()
+
+	return l.fallback.Allow(ctx, key, limit)
+}
+
+func (l *FallbackRateLimiter) primaryDown() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return time.Now().Before(l.downUntil)
+}
+
+func (l *FallbackRateLimiter) markDown() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.downUntil = time.Now().Add(l.cooldown)
+}
@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"todo-service/internal/domain/entities"
+)
+
+const sweepInterval = time.Minute
+
+// MemoryRateLimiter applies the same algorithm as RedisRateLimiter to
+// buckets held in process memory. Limits are per instance.
+type MemoryRateLimiter struct {
+	mu        sync.Mutex
+	tats      map[string]time.Time
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryRateLimiter() *MemoryRateLimiter {
+	return &MemoryRateLimiter{
+		tats: make(map[string]time.Time),
+		now:  time.Now,
+	}
+}
+
+func (l *MemoryRateLimiter) Allow(ctx context.Context, key string, limit entities.RateLimit) (*entities.RateLimitResult, error) {
+	interval := limit.Interval()
+	if interval <= 0 {
+		interval = time.Microsecond
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	tat, ok := l.tats[key]
+	if !ok || tat.Before(now) {
+		tat = now
+	}
+
+	newTAT := tat.Add(interval)
+	allowAt := newTAT.Add(-interval * time.Duration(limit.Limit))
+	if now.Before(allowAt) {
+		return &entities.RateLimitResult{
+			Limit:      limit.Limit,
+			ResetAfter: tat.Sub(now),
+			RetryAfter: allowAt.Sub(now),
+		}, nil
+	}
+
+	l.tats[key] = newTAT
+	return &entities.RateLimitResult{
+		Allowed:    true,
+		Limit:      limit.Limit,
+		Remaining:  int(now.Sub(allowAt) / interval),
+		ResetAfter: newTAT.Sub(now),
+	}, nil
+}
+
+// sweep drops buckets that have fully drained so idle clients do not
+// accumulate.
+func (l *MemoryRateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, tat := range l.tats {
+		if tat.Before(now) {
+			delete(l.tats, key)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/infrastructure/rediskeys"
+)
+
+const keyPrefix = "ratelimit:"
+
+// gcraScript implements the generic cell rate algorithm. KEYS[1] holds the
+// theoretical arrival time (TAT) of the next request in microseconds of
+// Redis server time, so every instance shares one clock. ARGV[1] is the
+// emission interval and ARGV[2] the burst size.
+var gcraScript = redis.NewScript(`
+local interval = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local clock = redis.call('TIME')
+local now = tonumber(clock[1]) * 1000000 + tonumber(clock[2])
+
+local tat = tonumber(redis.call('GET', KEYS[1]))
+if not tat or tat < now then
+	tat = now
+end
+
+local new_tat = tat + interval
+local allow_at = new_tat - interval * burst
+if now < allow_at then
+	return {0, 0, tat - now, allow_at - now}
+end
+
+local ttl = math.max(1, math.ceil((new_tat - now) / 1000))
+redis.call('SET', KEYS[1], string.format('%.0f', new_tat), 'PX', ttl)
+return {1, math.floor((now - allow_at) / interval), new_tat - now, 0}
+`)
+
+type RedisRateLimiter struct {
+	client *redis.Client
+}
+
+func NewRedisRateLimiter(client *redis.Client) *RedisRateLimiter {
+	return &RedisRateLimiter{
+		client: client,
+	}
+}
+
+func (l *RedisRateLimiter) Allow(ctx context.Context, key string, limit entities.RateLimit) (*entities.RateLimitResult, error) {
+	interval := limit.Interval().Microseconds()
+	if interval < 1 {
+		interval = 1
+	}
+
+	values, err := gcraScript.Run(ctx, l.client, []string{keyPrefix + key}, interval, limit.Limit).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate rate limit: %w", err)
+	}
+	if len(values) != 4 {
+		return nil, fmt.Errorf("unexpected rate limit script result %v", values)
+	}
+
+	return &entities.RateLimitResult{
+		Allowed:    values[0] == 1,
+		Limit:      limit.Limit,
+		Remaining:  int(values[1]),
+		ResetAfter: time.Duration(values[2]) * time.Microsecond,
+		RetryAfter: time.Duration(values[3]) * time.Microsecond,
+	}, nil
+}
+
+// DeleteTenant resets the limits of a tenant's users. Limits tracked per
+// API key or client IP are left alone.
+func (l *RedisRateLimiter) DeleteTenant(ctx context.Context, tenantID string) (int64, error) {
+	return rediskeys.DeleteByPrefix(ctx, l.client, keyPrefix+"user:"+tenantID+"/")
+}
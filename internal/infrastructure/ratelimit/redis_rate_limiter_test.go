@@ -0,0 +1,135 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/domain/entities"
+)
+
+func newTestLimiter(t *testing.T) *RedisRateLimiter {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return NewRedisRateLimiter(client)
+}
+
+func TestRedisRateLimiter_AllowsBurstThenLimits(t *testing.T) {
+	limiter := newTestLimiter(t)
+	ctx := context.Background()
+	limit := entities.RateLimit{Limit: 3, Period: time.Hour}
+
+	for want := 2; want >= 0; want-- {
+		result, err := limiter.Allow(ctx, "user-1", limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, want, result.Remaining)
+	}
+
+	result, err := limiter.Allow(ctx, "user-1", limit)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Zero(t, result.Remaining)
+	assert.InDelta(t, (20 * time.Minute).Seconds(), result.RetryAfter.Seconds(), 5)
+	assert.InDelta(t, time.Hour.Seconds(), result.ResetAfter.Seconds(), 5)
+
+	other, err := limiter.Allow(ctx, "user-2", limit)
+	require.NoError(t, err)
+	assert.True(t, other.Allowed)
+}
+
+func TestRedisRateLimiter_ReplenishesOverTime(t *testing.T) {
+	limiter := newTestLimiter(t)
+	ctx := context.Background()
+	limit := entities.RateLimit{Limit: 1, Period: 50 * time.Millisecond}
+
+	result, err := limiter.Allow(ctx, "user-1", limit)
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+
+	result, err = limiter.Allow(ctx, "user-1", limit)
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+
+	time.Sleep(result.RetryAfter + 5*time.Millisecond)
+
+	result, err = limiter.Allow(ctx, "user-1", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+}
+
+func TestMemoryRateLimiter_MatchesRedisBehaviour(t *testing.T) {
+	limiter := NewMemoryRateLimiter()
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+	limit := entities.RateLimit{Limit: 2, Period: time.Minute}
+
+	for want := 1; want >= 0; want-- {
+		result, err := limiter.Allow(context.Background(), "ip:10.0.0.1", limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, want, result.Remaining)
+	}
+
+	result, err := limiter.Allow(context.Background(), "ip:10.0.0.1", limit)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 30*time.Second, result.RetryAfter)
+
+	now = now.Add(30 * time.Second)
+	result, err = limiter.Allow(context.Background(), "ip:10.0.0.1", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+}
+
+type failingLimiter struct {
+	calls int
+}
+
+func (l *failingLimiter) Allow(ctx context.Context, key string, limit entities.RateLimit) (*entities.RateLimitResult, error) {
+	l.calls++
+	return nil, errors.New("connection refused")
+}
+
+func TestFallbackRateLimiter_UsesFallbackDuringCooldown(t *testing.T) {
+	primary := &failingLimiter{}
+	limiter := NewFallbackRateLimiter(primary, NewMemoryRateLimiter(), time.Minute)
+	limit := entities.RateLimit{Limit: 1, Period: time.Hour}
+
+	result, err := limiter.Allow(context.Background(), "user-1", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	result, err = limiter.Allow(context.Background(), "user-1", limit)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+
+	assert.Equal(t, 1, primary.calls)
+}
+
+func TestRedisRateLimiter_DeleteTenantKeepsOtherCallers(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	limiter := NewRedisRateLimiter(client)
+	ctx := context.Background()
+	limit := entities.RateLimit{Limit: 10, Period: time.Minute}
+
+	for _, subject := range []string{"user:acme/user-1", "user:acme/user-2", "user:acme-corp/user-1", "key:acme", "ip:10.0.0.1"} {
+		_, err := limiter.Allow(ctx, subject, limit)
+		require.NoError(t, err)
+	}
+
+	deleted, err := limiter.DeleteTenant(ctx, "acme")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	assert.ElementsMatch(t, []string{keyPrefix + "user:acme-corp/user-1", keyPrefix + "key:acme", keyPrefix + "ip:10.0.0.1"}, server.Keys())
+}
@@ -0,0 +1,31 @@
+// Package rediskeys holds helpers shared by the Redis-backed stores.
+package rediskeys
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// scanBatch is the COUNT hint passed to SCAN.
+const scanBatch = 500
+
+var globEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+
+// DeleteByPrefix deletes every key starting with prefix and returns how many
+// were removed. The prefix is matched literally, so a tenant ID containing
+// glob characters cannot widen the match. Keys are scanned rather than
+// listed with KEYS, so Redis is never blocked for long.
+func DeleteByPrefix(ctx context.Context, client redis.Cmdable, prefix string) (int64, error) {
+	var deleted int64
+	iter := client.Scan(ctx, 0, globEscaper.Replace(prefix)+"*", scanBatch).Iterator()
+	for iter.Next(ctx) {
+		n, err := client.Del(ctx, iter.Val()).Result()
+		if err != nil {
+			return deleted, err
+		}
+		deleted += n
+	}
+	return deleted, iter.Err()
+}
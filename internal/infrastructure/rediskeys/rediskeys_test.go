@@ -0,0 +1,31 @@
+package rediskeys
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteByPrefix_MatchesPrefixLiterally(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	for _, key := range []string{"state:a*c/1", "state:a*c/2", "state:abc/1", "state:a[b]c/1", "state:a*c-corp/1"} {
+		server.Set(key, "1")
+	}
+
+	deleted, err := DeleteByPrefix(context.Background(), client, "state:a*c/")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	assert.ElementsMatch(t, []string{"state:abc/1", "state:a[b]c/1", "state:a*c-corp/1"}, server.Keys())
+
+	deleted, err = DeleteByPrefix(context.Background(), client, "state:a[b]c/")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	assert.ElementsMatch(t, []string{"state:abc/1", "state:a*c-corp/1"}, server.Keys())
+}
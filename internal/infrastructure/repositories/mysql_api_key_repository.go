@@ -0,0 +1,160 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"todo-service/internal/domain/entities"
+)
+
+type MySQLAPIKeyRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLAPIKeyRepository(db *sql.DB) *MySQLAPIKeyRepository {
+	return &MySQLAPIKeyRepository{db: db}
+}
+
+const apiKeyColumns = `id, tenant_id, owner_id, name, prefix, key_hash, scopes, created_at, expires_at, last_used_at, revoked_at`
+
+func (r *MySQLAPIKeyRepository) Create(ctx context.Context, key *entities.APIKey) error {
+	query := `
+		INSERT INTO api_keys (` + apiKeyColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	scopes, err := json.Marshal(key.Scopes)
+	if err != nil {
+		return fmt.Errorf("failed to encode api key scopes: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		key.ID.String(),
+		key.TenantID,
+		key.OwnerID,
+		key.Name,
+		key.Prefix,
+		key.KeyHash,
+		string(scopes),
+		key.CreatedAt,
+		key.ExpiresAt,
+		key.LastUsedAt,
+		key.RevokedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MySQLAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*entities.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = ?`
+
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, keyHash))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entities.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return key, nil
+}
+
+func (r *MySQLAPIKeyRepository) List(ctx context.Context, tenantID, ownerID string) ([]*entities.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE tenant_id = ? AND owner_id = ? ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*entities.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (r *MySQLAPIKeyRepository) Revoke(ctx context.Context, tenantID, ownerID string, id uuid.UUID, revokedAt time.Time) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE api_keys SET revoked_at = COALESCE(revoked_at, ?) WHERE id = ? AND tenant_id = ? AND owner_id = ?`,
+		revokedAt, id.String(), tenantID, ownerID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	if affected == 0 {
+		return entities.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func (r *MySQLAPIKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE api_keys SET last_used_at = ? WHERE id = ?`, usedAt, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to record api key use: %w", err)
+	}
+
+	return nil
+}
+
+func scanAPIKey(row rowScanner) (*entities.APIKey, error) {
+	var (
+		key        entities.APIKey
+		id         string
+		scopes     []byte
+		expiresAt  sql.NullTime
+		lastUsedAt sql.NullTime
+		revokedAt  sql.NullTime
+	)
+
+	if err := row.Scan(&id, &key.TenantID, &key.OwnerID, &key.Name, &key.Prefix, &key.KeyHash, &scopes,
+		&key.CreatedAt, &expiresAt, &lastUsedAt, &revokedAt); err != nil {
+		return nil, err
+	}
+
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid api key id %q: %w", id, err)
+	}
+	key.ID = parsedID
+
+	if err := json.Unmarshal(scopes, &key.Scopes); err != nil {
+		return nil, fmt.Errorf("invalid api key scopes: %w", err)
+	}
+
+	if expiresAt.Valid {
+		key.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+
+	return &key, nil
+}
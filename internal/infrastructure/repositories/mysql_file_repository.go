@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"todo-service/internal/domain/entities"
+)
+
+type MySQLFileRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLFileRepository(db *sql.DB) *MySQLFileRepository {
+	return &MySQLFileRepository{db: db}
+}
+
+const fileColumns = `id, tenant_id, owner_id, file_name, content_type, size, storage_path, created_at, updated_at`
+
+func (r *MySQLFileRepository) Create(ctx context.Context, file *entities.File) error {
+	query := `
+		INSERT INTO files (` + fileColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		file.ID.String(),
+		file.TenantID,
+		file.OwnerID,
+		file.FileName,
+		file.ContentType,
+		file.Size,
+		file.StoragePath,
+		file.CreatedAt,
+		file.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MySQLFileRepository) GetByID(ctx context.Context, tenantID, ownerID string, id uuid.UUID) (*entities.File, error) {
+	query := `SELECT ` + fileColumns + ` FROM files WHERE id = ? AND tenant_id = ? AND owner_id = ?`
+
+	var (
+		file  entities.File
+		rawID string
+	)
+	err := r.db.QueryRowContext(ctx, query, id.String(), tenantID, ownerID).Scan(&rawID, &file.TenantID, &file.OwnerID, &file.FileName,
+		&file.ContentType, &file.Size, &file.StoragePath, &file.CreatedAt, &file.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entities.ErrFileNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+
+	file.ID, err = uuid.Parse(rawID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file id %q: %w", rawID, err)
+	}
+
+	return &file, nil
+}
+
+// ListByIDs returns the files among ids that userID owns or that are
+// attached to a todo userID owns or was granted, the same visibility rule
+// the todo queries use.
+func (r *MySQLFileRepository) ListByIDs(ctx context.Context, tenantID, userID string, ids []uuid.UUID) ([]*entities.File, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, 0, len(ids)+4)
+	args = append(args, tenantID)
+	for _, id := range ids {
+		args = append(args, id.String())
+	}
+	args = append(args, userID, userID, userID)
+	query := `
+		SELECT ` + fileColumns + `
+		FROM files f
+		WHERE f.tenant_id = ? AND f.id IN (?` + strings.Repeat(`, ?`, len(ids)-1) + `)
+			AND (f.owner_id = ? OR EXISTS (
+				SELECT 1
+				FROM todos t
+				LEFT JOIN todo_grants g
+					ON g.todo_id = t.id AND g.tenant_id = t.tenant_id AND g.user_id = ?
+				WHERE t.tenant_id = f.tenant_id AND t.file_id = f.id AND t.deleted_at IS NULL
+					AND (t.owner_id = ? OR g.user_id IS NOT NULL)
+			))
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+	defer rows.Close()
+
+	var files []*entities.File
+	for rows.Next() {
+		var (
+			file  entities.File
+			rawID string
+		)
+		if err := rows.Scan(&rawID, &file.TenantID, &file.OwnerID, &file.FileName,
+			&file.ContentType, &file.Size, &file.StoragePath, &file.CreatedAt, &file.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan file: %w", err)
+		}
+		if file.ID, err = uuid.Parse(rawID); err != nil {
+			return nil, fmt.Errorf("invalid file id %q: %w", rawID, err)
+		}
+		files = append(files, &file)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	return files, nil
+}
+
+func (r *MySQLFileRepository) Delete(ctx context.Context, tenantID, ownerID string, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM files WHERE id = ? AND tenant_id = ? AND owner_id = ?`, id.String(), tenantID, ownerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return entities.ErrFileNotFound
+	}
+
+	return nil
+}
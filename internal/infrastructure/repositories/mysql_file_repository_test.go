@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+)
+
+func TestMySQLFileRepository_ListByIDsOnlyVisibleFiles(t *testing.T) {
+	db := openTestDB(t)
+	tenantID := testTenants(t, db, 1)[0]
+	repo := NewMySQLFileRepository(db)
+	txManager := NewMySQLTransactionManager(db)
+	ctx := context.Background()
+
+	attached := entities.NewFile(tenantID, testUserID, "attached.txt", "text/plain", 5)
+	private := entities.NewFile(tenantID, testUserID, "private.txt", "text/plain", 5)
+	require.NoError(t, repo.Create(ctx, attached))
+	require.NoError(t, repo.Create(ctx, private))
+
+	fileID := attached.ID.String()
+	todo := entities.NewTodoItem(tenantID, testUserID, "Shared with an attachment", time.Now().Add(time.Hour), &fileID)
+	grant, err := entities.NewTodoGrant(todo, "user-2", entities.RoleViewer, testUserID)
+	require.NoError(t, err)
+	require.NoError(t, txManager.DoInTx(ctx, func(repo ports.TodoRepository) error {
+		if err := repo.Create(ctx, todo); err != nil {
+			return err
+		}
+		return repo.SaveGrant(ctx, grant)
+	}))
+
+	tests := []struct {
+		userID string
+		want   []uuid.UUID
+	}{
+		{userID: testUserID, want: []uuid.UUID{attached.ID, private.ID}},
+		{userID: "user-2", want: []uuid.UUID{attached.ID}},
+		{userID: "user-3"},
+	}
+
+	for _, tt := range tests {
+		files, err := repo.ListByIDs(ctx, tenantID, tt.userID, []uuid.UUID{attached.ID, private.ID})
+		require.NoError(t, err)
+
+		var got []uuid.UUID
+		for _, file := range files {
+			got = append(got, file.ID)
+		}
+		assert.ElementsMatch(t, tt.want, got, tt.userID)
+	}
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"todo-service/internal/domain/entities"
+)
+
+type MySQLStorageUsageRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLStorageUsageRepository(db *sql.DB) *MySQLStorageUsageRepository {
+	return &MySQLStorageUsageRepository{db: db}
+}
+
+// Get returns zero usage for users who have never uploaded a file.
+func (r *MySQLStorageUsageRepository) Get(ctx context.Context, tenantID, ownerID string) (*entities.StorageUsage, error) {
+	query := `SELECT bytes_used, file_count, updated_at FROM storage_usage WHERE tenant_id = ? AND owner_id = ?`
+
+	usage := entities.StorageUsage{TenantID: tenantID, OwnerID: ownerID}
+	err := r.db.QueryRowContext(ctx, query, tenantID, ownerID).Scan(&usage.Bytes, &usage.Files, &usage.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &usage, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage usage: %w", err)
+	}
+
+	return &usage, nil
+}
+
+// Reserve checks and updates the totals in a single UPDATE, so concurrent
+// uploads by the same user cannot together overshoot the quota.
+func (r *MySQLStorageUsageRepository) Reserve(ctx context.Context, tenantID, ownerID string, size int64, quota entities.StorageQuota) error {
+	now := time.Now()
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT IGNORE INTO storage_usage (tenant_id, owner_id, bytes_used, file_count, updated_at)
+		VALUES (?, ?, 0, 0, ?)
+	`, tenantID, ownerID, now)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage usage: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE storage_usage
+		SET bytes_used = bytes_used + ?, file_count = file_count + 1, updated_at = ?
+		WHERE tenant_id = ? AND owner_id = ?
+			AND (? = 0 OR bytes_used + ? <= ?)
+			AND (? = 0 OR file_count + 1 <= ?)
+	`, size, now, tenantID, ownerID,
+		quota.MaxBytes, size, quota.MaxBytes,
+		quota.MaxFiles, quota.MaxFiles,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to reserve storage: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to reserve storage: %w", err)
+	}
+	if affected == 0 {
+		return entities.ErrStorageQuotaExceeded
+	}
+
+	return nil
+}
+
+func (r *MySQLStorageUsageRepository) Release(ctx context.Context, tenantID, ownerID string, size int64) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE storage_usage
+		SET bytes_used = GREATEST(bytes_used - ?, 0), file_count = GREATEST(file_count - 1, 0), updated_at = ?
+		WHERE tenant_id = ? AND owner_id = ?
+	`, size, time.Now(), tenantID, ownerID)
+	if err != nil {
+		return fmt.Errorf("failed to release storage: %w", err)
+	}
+
+	return nil
+}
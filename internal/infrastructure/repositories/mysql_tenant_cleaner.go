@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// tenantTables lists every table holding tenant data, children before
+// parents so foreign keys never block a delete. The tenant's change
+// sequence is kept, so sync tokens issued before a cleanup never point
+// past changes made after it.
+var tenantTables = []string{
+	"todo_grants",
+	"todos",
+	"files",
+	"storage_usage",
+	"webhooks",
+	"api_keys",
+}
+
+// MySQLTenantCleaner removes all rows belonging to a tenant. It backs the
+// cleanup command and is not used while serving requests.
+type MySQLTenantCleaner struct {
+	db *sql.DB
+}
+
+func NewMySQLTenantCleaner(db *sql.DB) *MySQLTenantCleaner {
+	return &MySQLTenantCleaner{db: db}
+}
+
+// StoragePaths returns where the tenant's uploaded files are stored, so they
+// can be removed before their rows are.
+func (c *MySQLTenantCleaner) StoragePaths(ctx context.Context, tenantID string) ([]string, error) {
+	rows, err := c.db.QueryContext(ctx, `SELECT storage_path FROM files WHERE tenant_id = ?`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+	defer rows.Close()
+
+	var paths []string
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, fmt.Errorf("failed to scan file: %w", err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, rows.Err()
+}
+
+// DeleteTenant deletes the tenant's rows in one transaction and returns the
+// number removed per table. Webhook deliveries go with their webhooks.
+func (c *MySQLTenantCleaner) DeleteTenant(ctx context.Context, tenantID string) (map[string]int64, error) {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	deleted := make(map[string]int64, len(tenantTables))
+	for _, table := range tenantTables {
+		result, err := tx.ExecContext(ctx, "DELETE FROM "+table+" WHERE tenant_id = ?", tenantID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete from %s: %w", table, err)
+		}
+		if deleted[table], err = result.RowsAffected(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return deleted, nil
+}
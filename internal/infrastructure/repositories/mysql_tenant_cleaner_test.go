@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+)
+
+// seedTenant stores one row in every tenant table and returns the path of
+// the tenant's file.
+func seedTenant(t *testing.T, db *sql.DB, tenantID string) string {
+	t.Helper()
+	ctx := context.Background()
+	txManager := NewMySQLTransactionManager(db)
+
+	todo := createTodo(t, txManager, tenantID)
+	grant, err := entities.NewTodoGrant(todo, "user-2", entities.RoleViewer, testUserID)
+	require.NoError(t, err)
+	require.NoError(t, txManager.DoInTx(ctx, func(repo ports.TodoRepository) error {
+		return repo.SaveGrant(ctx, grant)
+	}))
+
+	file := entities.NewFile(tenantID, testUserID, "notes.txt", "text/plain", 5)
+	require.NoError(t, NewMySQLFileRepository(db).Create(ctx, file))
+	require.NoError(t, NewMySQLStorageUsageRepository(db).Reserve(ctx, tenantID, testUserID, file.Size, entities.StorageQuota{}))
+
+	webhook, err := entities.NewWebhook(tenantID, testUserID, "https://hooks.example.com/"+tenantID, nil, "")
+	require.NoError(t, err)
+	require.NoError(t, NewMySQLWebhookRepository(db).Create(ctx, webhook))
+
+	key, _, err := entities.NewAPIKey(tenantID, testUserID, "ci", []string{entities.ScopeTodosRead}, nil)
+	require.NoError(t, err)
+	require.NoError(t, NewMySQLAPIKeyRepository(db).Create(ctx, key))
+
+	return file.StoragePath
+}
+
+func TestMySQLTenantCleaner_DeletesOnlyThatTenant(t *testing.T) {
+	db := openTestDB(t)
+	tenants := testTenants(t, db, 2)
+	cleaner := NewMySQLTenantCleaner(db)
+	ctx := context.Background()
+
+	doomedPath := seedTenant(t, db, tenants[0])
+	keptPath := seedTenant(t, db, tenants[1])
+
+	paths, err := cleaner.StoragePaths(ctx, tenants[0])
+	require.NoError(t, err)
+	assert.Equal(t, []string{doomedPath}, paths)
+
+	deleted, err := cleaner.DeleteTenant(ctx, tenants[0])
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{
+		"todo_grants":   1,
+		"todos":         1,
+		"files":         1,
+		"storage_usage": 1,
+		"webhooks":      1,
+		"api_keys":      1,
+	}, deleted)
+
+	for _, table := range tenantTables {
+		var doomed, kept int
+		require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table+" WHERE tenant_id = ?", tenants[0]).Scan(&doomed))
+		require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table+" WHERE tenant_id = ?", tenants[1]).Scan(&kept))
+		assert.Zero(t, doomed, table)
+		assert.Equal(t, 1, kept, table)
+	}
+
+	paths, err = cleaner.StoragePaths(ctx, tenants[1])
+	require.NoError(t, err)
+	assert.Equal(t, []string{keptPath}, paths)
+}
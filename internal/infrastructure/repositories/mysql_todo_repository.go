@@ -3,14 +3,24 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 
 	"todo-service/internal/domain/entities"
 	"todo-service/internal/domain/ports"
+	"todo-service/internal/infrastructure/tracing"
 )
 
+var tracer = otel.Tracer("todo-service/internal/infrastructure/repositories")
+
 type MySQLTransactionManager struct {
 	db *sql.DB
 }
@@ -19,7 +29,17 @@ func NewMySQLTransactionManager(db *sql.DB) *MySQLTransactionManager {
 	return &MySQLTransactionManager{db: db}
 }
 
-func (tm *MySQLTransactionManager) DoInTx(ctx context.Context, fn func(repo ports.TodoRepository) error) error {
+// DoInTx runs fn in a transaction under a single span, so the time spent
+// in MySQL (including commit) shows up next to the work fn does inside it.
+func (tm *MySQLTransactionManager) DoInTx(ctx context.Context, fn func(repo ports.TodoRepository) error) (err error) {
+	ctx, span := tracer.Start(ctx, "MySQL transaction",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemMySQL))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
 	tx, err := tm.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -49,10 +69,19 @@ type MySQLTxTodoRepository struct {
 	tx *sql.Tx
 }
 
+const todoColumns = `id, tenant_id, owner_id, description, due_date, file_id, completed_at, created_at, updated_at, deleted_at, version, change_seq`
+
+const qualifiedTodoColumns = `t.id, t.tenant_id, t.owner_id, t.description, t.due_date, t.file_id, t.completed_at, t.created_at, t.updated_at, t.deleted_at, t.version, t.change_seq`
+
 func (r *MySQLTxTodoRepository) Create(ctx context.Context, todo *entities.TodoItem) error {
+	seq, err := r.nextChangeSeq(ctx, todo.TenantID)
+	if err != nil {
+		return err
+	}
+
 	query := `
-		INSERT INTO todos (id, description, due_date, file_id, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO todos (id, tenant_id, owner_id, description, due_date, file_id, completed_at, created_at, updated_at, version, change_seq)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	var fileID interface{}
@@ -60,18 +89,457 @@ func (r *MySQLTxTodoRepository) Create(ctx context.Context, todo *entities.TodoI
 		fileID = *todo.FileID
 	}
 
-	_, err := r.tx.ExecContext(ctx, query,
+	_, err = r.tx.ExecContext(ctx, query,
 		todo.ID.String(),
+		todo.TenantID,
+		todo.OwnerID,
 		todo.Description,
 		todo.DueDate,
 		fileID,
+		todo.CompletedAt,
 		todo.CreatedAt,
 		todo.UpdatedAt,
+		todo.Version,
+		seq,
 	)
 
+	if isDuplicateKey(err) {
+		return entities.ErrTodoAlreadyExists
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create todo: %w", err)
 	}
 
+	todo.ChangeSeq = seq
+	return nil
+}
+
+func (r *MySQLTxTodoRepository) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entities.TodoItem, error) {
+	return r.get(ctx, `SELECT `+todoColumns+` FROM todos WHERE id = ? AND tenant_id = ?`, tenantID, id)
+}
+
+func (r *MySQLTxTodoRepository) GetByIDForUpdate(ctx context.Context, tenantID string, id uuid.UUID) (*entities.TodoItem, error) {
+	return r.get(ctx, `SELECT `+todoColumns+` FROM todos WHERE id = ? AND tenant_id = ? FOR UPDATE`, tenantID, id)
+}
+
+func (r *MySQLTxTodoRepository) get(ctx context.Context, query, tenantID string, id uuid.UUID) (*entities.TodoItem, error) {
+	todo, err := scanTodo(r.tx.QueryRowContext(ctx, query, id.String(), tenantID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entities.ErrTodoNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get todo: %w", err)
+	}
+
+	return todo, nil
+}
+
+// List returns the todos userID owns together with those shared with them.
+// The grant join is restricted to userID, so it adds at most one row per
+// todo and the owner_id and grant branches can each use their own index.
+func (r *MySQLTxTodoRepository) List(ctx context.Context, tenantID, userID string, limit, offset int) ([]*entities.TodoItem, error) {
+	query := `
+		SELECT ` + qualifiedTodoColumns + `
+		FROM todos t
+		LEFT JOIN todo_grants g
+			ON g.todo_id = t.id AND g.tenant_id = t.tenant_id AND g.user_id = ?
+		WHERE t.tenant_id = ? AND (t.owner_id = ? OR g.user_id IS NOT NULL) AND t.deleted_at IS NULL
+		ORDER BY t.created_at DESC, t.id
+		LIMIT ? OFFSET ?
+	`
+
+	return r.list(ctx, query, userID, tenantID, userID, limit, offset)
+}
+
+// Search pages with a keyset on (created_at, id) rather than an offset, so
+// todos created while a client is paging neither repeat nor go missing.
+func (r *MySQLTxTodoRepository) Search(ctx context.Context, tenantID, userID string, filter entities.TodoFilter, after *entities.TodoCursor, limit int, now time.Time) ([]*entities.TodoItem, error) {
+	conditions, args := todoFilterConditions(filter, now)
+	if after != nil {
+		conditions += ` AND (t.created_at < ? OR (t.created_at = ? AND t.id > ?))`
+		args = append(args, after.CreatedAt, after.CreatedAt, after.ID.String())
+	}
+
+	query := `
+		SELECT ` + qualifiedTodoColumns + `
+		FROM todos t
+		LEFT JOIN todo_grants g
+			ON g.todo_id = t.id AND g.tenant_id = t.tenant_id AND g.user_id = ?
+		WHERE t.tenant_id = ? AND (t.owner_id = ? OR g.user_id IS NOT NULL) AND t.deleted_at IS NULL` + conditions + `
+		ORDER BY t.created_at DESC, t.id
+		LIMIT ?
+	`
+
+	args = append([]interface{}{userID, tenantID, userID}, args...)
+	return r.list(ctx, query, append(args, limit)...)
+}
+
+func (r *MySQLTxTodoRepository) CountByStatus(ctx context.Context, tenantID, userID string, now time.Time) (*entities.TodoCounts, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(t.completed_at IS NULL), 0),
+			COALESCE(SUM(t.completed_at IS NOT NULL), 0),
+			COALESCE(SUM(t.completed_at IS NULL AND t.due_date < ?), 0)
+		FROM todos t
+		LEFT JOIN todo_grants g
+			ON g.todo_id = t.id AND g.tenant_id = t.tenant_id AND g.user_id = ?
+		WHERE t.tenant_id = ? AND (t.owner_id = ? OR g.user_id IS NOT NULL) AND t.deleted_at IS NULL
+	`
+
+	var counts entities.TodoCounts
+	err := r.tx.QueryRowContext(ctx, query, now, userID, tenantID, userID).
+		Scan(&counts.Total, &counts.Open, &counts.Completed, &counts.Overdue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count todos: %w", err)
+	}
+
+	return &counts, nil
+}
+
+// todoFilterConditions returns the AND clauses for filter against the todos
+// table aliased as t. Search relies on the column's case-insensitive
+// collation.
+func todoFilterConditions(filter entities.TodoFilter, now time.Time) (string, []interface{}) {
+	var (
+		conditions strings.Builder
+		args       []interface{}
+	)
+
+	switch filter.Status {
+	case entities.TodoStatusOpen:
+		conditions.WriteString(` AND t.completed_at IS NULL`)
+	case entities.TodoStatusCompleted:
+		conditions.WriteString(` AND t.completed_at IS NOT NULL`)
+	case entities.TodoStatusOverdue:
+		conditions.WriteString(` AND t.completed_at IS NULL AND t.due_date < ?`)
+		args = append(args, now)
+	}
+	if filter.DueAfter != nil {
+		conditions.WriteString(` AND t.due_date >= ?`)
+		args = append(args, *filter.DueAfter)
+	}
+	if filter.DueBefore != nil {
+		conditions.WriteString(` AND t.due_date < ?`)
+		args = append(args, *filter.DueBefore)
+	}
+	if filter.Search != "" {
+		conditions.WriteString(` AND t.description LIKE ?`)
+		args = append(args, "%"+likeEscaper.Replace(filter.Search)+"%")
+	}
+	if filter.HasAttachment != nil {
+		if *filter.HasAttachment {
+			conditions.WriteString(` AND t.file_id IS NOT NULL`)
+		} else {
+			conditions.WriteString(` AND t.file_id IS NULL`)
+		}
+	}
+
+	return conditions.String(), args
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ListChanges returns the todos userID owns or has been granted that changed
+// after afterSeq, tombstones included, using the same access predicate as
+// List.
+func (r *MySQLTxTodoRepository) ListChanges(ctx context.Context, tenantID, userID string, afterSeq int64, limit int) ([]*entities.TodoItem, error) {
+	query := `
+		SELECT ` + qualifiedTodoColumns + `
+		FROM todos t
+		LEFT JOIN todo_grants g
+			ON g.todo_id = t.id AND g.tenant_id = t.tenant_id AND g.user_id = ?
+		WHERE t.tenant_id = ? AND (t.owner_id = ? OR g.user_id IS NOT NULL) AND t.change_seq > ?
+		ORDER BY t.change_seq
+		LIMIT ?
+	`
+
+	return r.list(ctx, query, userID, tenantID, userID, afterSeq, limit)
+}
+
+func (r *MySQLTxTodoRepository) list(ctx context.Context, query string, args ...interface{}) ([]*entities.TodoItem, error) {
+	rows, err := r.tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list todos: %w", err)
+	}
+	defer rows.Close()
+
+	var todos []*entities.TodoItem
+	for rows.Next() {
+		todo, err := scanTodo(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan todo: %w", err)
+		}
+		todos = append(todos, todo)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list todos: %w", err)
+	}
+
+	return todos, nil
+}
+
+// Update persists todo if the stored row is still at todo.Version and bumps
+// the version in the same statement, so concurrent writers cannot both
+// succeed against the same version.
+func (r *MySQLTxTodoRepository) Update(ctx context.Context, todo *entities.TodoItem) error {
+	seq, err := r.nextChangeSeq(ctx, todo.TenantID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE todos
+		SET description = ?, due_date = ?, file_id = ?, completed_at = ?, updated_at = ?, change_seq = ?, version = version + 1
+		WHERE id = ? AND tenant_id = ? AND owner_id = ? AND version = ? AND deleted_at IS NULL
+	`
+
+	var fileID interface{}
+	if todo.FileID != nil {
+		fileID = *todo.FileID
+	}
+
+	result, err := r.tx.ExecContext(ctx, query,
+		todo.Description,
+		todo.DueDate,
+		fileID,
+		todo.CompletedAt,
+		todo.UpdatedAt,
+		seq,
+		todo.ID.String(),
+		todo.TenantID,
+		todo.OwnerID,
+		todo.Version,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update todo: %w", err)
+	}
+
+	if err := r.checkVersionedWrite(ctx, result, todo); err != nil {
+		return err
+	}
+
+	todo.Version++
+	todo.ChangeSeq = seq
 	return nil
 }
+
+func (r *MySQLTxTodoRepository) Delete(ctx context.Context, todo *entities.TodoItem) error {
+	seq, err := r.nextChangeSeq(ctx, todo.TenantID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE todos
+		SET deleted_at = ?, updated_at = ?, change_seq = ?, version = version + 1
+		WHERE id = ? AND tenant_id = ? AND owner_id = ? AND version = ? AND deleted_at IS NULL
+	`
+
+	result, err := r.tx.ExecContext(ctx, query,
+		todo.DeletedAt, todo.UpdatedAt, seq, todo.ID.String(), todo.TenantID, todo.OwnerID, todo.Version)
+	if err != nil {
+		return fmt.Errorf("failed to delete todo: %w", err)
+	}
+
+	if err := r.checkVersionedWrite(ctx, result, todo); err != nil {
+		return err
+	}
+
+	todo.Version++
+	todo.ChangeSeq = seq
+	return nil
+}
+
+const grantColumns = `todo_id, tenant_id, user_id, role, granted_by, created_at, updated_at`
+
+func (r *MySQLTxTodoRepository) GetGrant(ctx context.Context, tenantID string, todoID uuid.UUID, userID string) (*entities.TodoGrant, error) {
+	query := `SELECT ` + grantColumns + ` FROM todo_grants WHERE todo_id = ? AND tenant_id = ? AND user_id = ?`
+
+	grant, err := scanGrant(r.tx.QueryRowContext(ctx, query, todoID.String(), tenantID, userID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entities.ErrTodoGrantNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get todo grant: %w", err)
+	}
+
+	return grant, nil
+}
+
+func (r *MySQLTxTodoRepository) ListGrants(ctx context.Context, tenantID string, todoID uuid.UUID) ([]*entities.TodoGrant, error) {
+	query := `SELECT ` + grantColumns + ` FROM todo_grants WHERE todo_id = ? AND tenant_id = ? ORDER BY created_at, user_id`
+
+	rows, err := r.tx.QueryContext(ctx, query, todoID.String(), tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list todo grants: %w", err)
+	}
+	defer rows.Close()
+
+	var grants []*entities.TodoGrant
+	for rows.Next() {
+		grant, err := scanGrant(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan todo grant: %w", err)
+		}
+		grants = append(grants, grant)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list todo grants: %w", err)
+	}
+
+	return grants, nil
+}
+
+// SaveGrant creates the grant or changes the role of an existing one. The
+// todo gets a new change sequence, without a new version, so the grantee's
+// next delta sync picks it up even though the todo itself did not change.
+func (r *MySQLTxTodoRepository) SaveGrant(ctx context.Context, grant *entities.TodoGrant) error {
+	query := `
+		INSERT INTO todo_grants (` + grantColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE role = VALUES(role), granted_by = VALUES(granted_by), updated_at = VALUES(updated_at)
+	`
+
+	_, err := r.tx.ExecContext(ctx, query,
+		grant.TodoID.String(),
+		grant.TenantID,
+		grant.UserID,
+		grant.Role,
+		grant.GrantedBy,
+		grant.CreatedAt,
+		grant.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save todo grant: %w", err)
+	}
+
+	seq, err := r.nextChangeSeq(ctx, grant.TenantID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.tx.ExecContext(ctx, `UPDATE todos SET change_seq = ? WHERE id = ? AND tenant_id = ?`,
+		seq, grant.TodoID.String(), grant.TenantID)
+	if err != nil {
+		return fmt.Errorf("failed to record todo grant change: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MySQLTxTodoRepository) DeleteGrant(ctx context.Context, tenantID string, todoID uuid.UUID, userID string) error {
+	result, err := r.tx.ExecContext(ctx,
+		`DELETE FROM todo_grants WHERE todo_id = ? AND tenant_id = ? AND user_id = ?`,
+		todoID.String(), tenantID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete todo grant: %w", err)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return entities.ErrTodoGrantNotFound
+	}
+
+	return nil
+}
+
+func (r *MySQLTxTodoRepository) checkVersionedWrite(ctx context.Context, result sql.Result, todo *entities.TodoItem) error {
+	affected, err := result.RowsAffected()
+	if err != nil || affected > 0 {
+		return nil
+	}
+
+	var exists bool
+	err = r.tx.QueryRowContext(ctx,
+		`SELECT EXISTS(SELECT 1 FROM todos WHERE id = ? AND tenant_id = ? AND owner_id = ? AND deleted_at IS NULL)`,
+		todo.ID.String(), todo.TenantID, todo.OwnerID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check todo version: %w", err)
+	}
+
+	if !exists {
+		return entities.ErrTodoNotFound
+	}
+	return entities.ErrTodoVersionMismatch
+}
+
+// nextChangeSeq allocates the next change sequence number of tenantID. The
+// row lock on the tenant's counter is held until the transaction ends, so
+// sequence numbers become visible to readers in allocation order; writers
+// in other tenants do not wait on it.
+func (r *MySQLTxTodoRepository) nextChangeSeq(ctx context.Context, tenantID string) (int64, error) {
+	result, err := r.tx.ExecContext(ctx, `
+		INSERT INTO todo_change_sequences (tenant_id, value) VALUES (?, LAST_INSERT_ID(1))
+		ON DUPLICATE KEY UPDATE value = LAST_INSERT_ID(value + 1)
+	`, tenantID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to allocate change sequence: %w", err)
+	}
+
+	seq, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to allocate change sequence: %w", err)
+	}
+
+	return seq, nil
+}
+
+func scanTodo(row rowScanner) (*entities.TodoItem, error) {
+	var (
+		todo        entities.TodoItem
+		id          string
+		fileID      sql.NullString
+		completedAt sql.NullTime
+		deletedAt   sql.NullTime
+	)
+
+	if err := row.Scan(&id, &todo.TenantID, &todo.OwnerID, &todo.Description, &todo.DueDate, &fileID, &completedAt,
+		&todo.CreatedAt, &todo.UpdatedAt, &deletedAt, &todo.Version, &todo.ChangeSeq); err != nil {
+		return nil, err
+	}
+
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid todo id %q: %w", id, err)
+	}
+	todo.ID = parsedID
+
+	if fileID.Valid {
+		todo.FileID = &fileID.String
+	}
+	if completedAt.Valid {
+		todo.CompletedAt = &completedAt.Time
+	}
+	if deletedAt.Valid {
+		todo.DeletedAt = &deletedAt.Time
+	}
+
+	return &todo, nil
+}
+
+func scanGrant(row rowScanner) (*entities.TodoGrant, error) {
+	var (
+		grant  entities.TodoGrant
+		todoID string
+	)
+
+	if err := row.Scan(&todoID, &grant.TenantID, &grant.UserID, &grant.Role, &grant.GrantedBy,
+		&grant.CreatedAt, &grant.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	parsedID, err := uuid.Parse(todoID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid todo id %q: %w", todoID, err)
+	}
+	grant.TodoID = parsedID
+
+	return &grant, nil
+}
+
+func isDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1062
+}
@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+)
+
+func TestListChanges_FollowsTodosSharedWithTheUser(t *testing.T) {
+	db := openTestDB(t)
+	tenantID := testTenants(t, db, 1)[0]
+	txManager := NewMySQLTransactionManager(db)
+	ctx := context.Background()
+
+	const granteeID = "user-2"
+
+	inTx := func(fn func(repo ports.TodoRepository)) {
+		t.Helper()
+		require.NoError(t, txManager.DoInTx(ctx, func(repo ports.TodoRepository) error {
+			fn(repo)
+			return nil
+		}))
+	}
+	create := func(ownerID, description string) *entities.TodoItem {
+		todo := entities.NewTodoItem(tenantID, ownerID, description, time.Now().Add(time.Hour), nil)
+		inTx(func(repo ports.TodoRepository) { require.NoError(t, repo.Create(ctx, todo)) })
+		return todo
+	}
+	changes := func(afterSeq int64) []*entities.TodoItem {
+		var todos []*entities.TodoItem
+		inTx(func(repo ports.TodoRepository) {
+			var err error
+			todos, err = repo.ListChanges(ctx, tenantID, granteeID, afterSeq, 100)
+			require.NoError(t, err)
+		})
+		return todos
+	}
+
+	shared := create(testUserID, "Plan offsite")
+	own := create(granteeID, "Book flights")
+	create("user-3", "Not shared")
+
+	initial := changes(0)
+	assert.Equal(t, []uuid.UUID{own.ID}, todoIDs(initial))
+	cursor := initial[0].ChangeSeq
+
+	// Sharing alone makes the todo part of the grantee's next pull.
+	grant, err := entities.NewTodoGrant(shared, granteeID, entities.RoleViewer, testUserID)
+	require.NoError(t, err)
+	inTx(func(repo ports.TodoRepository) { require.NoError(t, repo.SaveGrant(ctx, grant)) })
+
+	pulled := changes(cursor)
+	require.Equal(t, []uuid.UUID{shared.ID}, todoIDs(pulled))
+	assert.Equal(t, shared.Version, pulled[0].Version)
+	cursor = pulled[0].ChangeSeq
+
+	// The owner's edits and deletion reach the grantee too.
+	inTx(func(repo ports.TodoRepository) {
+		shared.Update("Plan the offsite", shared.DueDate, nil)
+		require.NoError(t, repo.Update(ctx, shared))
+	})
+	pulled = changes(cursor)
+	require.Len(t, pulled, 1)
+	assert.Equal(t, "Plan the offsite", pulled[0].Description)
+	cursor = pulled[0].ChangeSeq
+
+	inTx(func(repo ports.TodoRepository) {
+		shared.MarkDeleted()
+		require.NoError(t, repo.Delete(ctx, shared))
+	})
+	pulled = changes(cursor)
+	require.Len(t, pulled, 1)
+	assert.Equal(t, shared.ID, pulled[0].ID)
+	assert.True(t, pulled[0].IsDeleted())
+}
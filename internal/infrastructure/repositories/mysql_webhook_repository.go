@@ -0,0 +1,288 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"todo-service/internal/domain/entities"
+)
+
+type MySQLWebhookRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLWebhookRepository(db *sql.DB) *MySQLWebhookRepository {
+	return &MySQLWebhookRepository{db: db}
+}
+
+const webhookColumns = `id, tenant_id, owner_id, url, event_types, secret, active, consecutive_failures, disabled_at, created_at, updated_at`
+
+func (r *MySQLWebhookRepository) Create(ctx context.Context, webhook *entities.Webhook) error {
+	query := `
+		INSERT INTO webhooks (` + webhookColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	eventTypes, err := json.Marshal(webhook.EventTypes)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook event types: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		webhook.ID.String(),
+		webhook.TenantID,
+		webhook.OwnerID,
+		webhook.URL,
+		string(eventTypes),
+		webhook.Secret,
+		webhook.Active,
+		webhook.ConsecutiveFailures,
+		webhook.DisabledAt,
+		webhook.CreatedAt,
+		webhook.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MySQLWebhookRepository) GetByID(ctx context.Context, tenantID, ownerID string, id uuid.UUID) (*entities.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = ? AND tenant_id = ? AND owner_id = ?`
+
+	webhook, err := scanWebhook(r.db.QueryRowContext(ctx, query, id.String(), tenantID, ownerID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entities.ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func (r *MySQLWebhookRepository) List(ctx context.Context, tenantID, ownerID string) ([]*entities.Webhook, error) {
+	return r.list(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE tenant_id = ? AND owner_id = ? ORDER BY created_at`, tenantID, ownerID)
+}
+
+func (r *MySQLWebhookRepository) ListActive(ctx context.Context) ([]*entities.Webhook, error) {
+	return r.list(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE active = TRUE ORDER BY created_at`)
+}
+
+func (r *MySQLWebhookRepository) list(ctx context.Context, query string, args ...interface{}) ([]*entities.Webhook, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []*entities.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *MySQLWebhookRepository) UpdateStatus(ctx context.Context, webhook *entities.Webhook) error {
+	query := `
+		UPDATE webhooks
+		SET active = ?, consecutive_failures = ?, disabled_at = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		webhook.Active,
+		webhook.ConsecutiveFailures,
+		webhook.DisabledAt,
+		webhook.UpdatedAt,
+		webhook.ID.String(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook status: %w", err)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return entities.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (r *MySQLWebhookRepository) Delete(ctx context.Context, tenantID, ownerID string, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = ? AND tenant_id = ? AND owner_id = ?`, id.String(), tenantID, ownerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return entities.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+const deliveryColumns = `id, webhook_id, event_id, event_type, attempt, status_code, success, error, duration_ms, payload, next_attempt_at, created_at`
+
+func (r *MySQLWebhookRepository) CreateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (` + deliveryColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	var statusCode, deliveryErr, payload interface{}
+	if delivery.StatusCode != 0 {
+		statusCode = delivery.StatusCode
+	}
+	if delivery.Error != "" {
+		deliveryErr = delivery.Error
+	}
+	// Only attempts that will be retried need the event.
+	if delivery.NextAttemptAt != nil && delivery.Event != nil {
+		encoded, err := json.Marshal(delivery.Event)
+		if err != nil {
+			return fmt.Errorf("failed to encode webhook delivery event: %w", err)
+		}
+		payload = string(encoded)
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		delivery.ID.String(),
+		delivery.WebhookID.String(),
+		delivery.EventID,
+		delivery.EventType,
+		delivery.Attempt,
+		statusCode,
+		delivery.Success,
+		deliveryErr,
+		delivery.DurationMs,
+		payload,
+		delivery.NextAttemptAt,
+		delivery.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MySQLWebhookRepository) ListDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]*entities.WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE webhook_id = ? ORDER BY created_at DESC LIMIT ?`
+	return r.listDeliveries(ctx, query, webhookID.String(), limit)
+}
+
+func (r *MySQLWebhookRepository) ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*entities.WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE next_attempt_at <= ? ORDER BY next_attempt_at LIMIT ?`
+	return r.listDeliveries(ctx, query, now, limit)
+}
+
+func (r *MySQLWebhookRepository) ClaimDelivery(ctx context.Context, id uuid.UUID) (bool, error) {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE webhook_deliveries SET next_attempt_at = NULL WHERE id = ? AND next_attempt_at IS NOT NULL`, id.String())
+	if err != nil {
+		return false, fmt.Errorf("failed to claim webhook delivery: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim webhook delivery: %w", err)
+	}
+	return affected == 1, nil
+}
+
+func (r *MySQLWebhookRepository) listDeliveries(ctx context.Context, query string, args ...interface{}) ([]*entities.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*entities.WebhookDelivery
+	for rows.Next() {
+		var (
+			delivery      entities.WebhookDelivery
+			id, hookID    string
+			statusCode    sql.NullInt64
+			errMessage    sql.NullString
+			payload       []byte
+			nextAttemptAt sql.NullTime
+		)
+
+		if err := rows.Scan(&id, &hookID, &delivery.EventID, &delivery.EventType, &delivery.Attempt,
+			&statusCode, &delivery.Success, &errMessage, &delivery.DurationMs, &payload, &nextAttemptAt, &delivery.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+
+		delivery.ID, _ = uuid.Parse(id)
+		delivery.WebhookID, _ = uuid.Parse(hookID)
+		delivery.StatusCode = int(statusCode.Int64)
+		delivery.Error = errMessage.String
+		if nextAttemptAt.Valid {
+			delivery.NextAttemptAt = &nextAttemptAt.Time
+		}
+		if len(payload) > 0 {
+			delivery.Event = &entities.Event{}
+			if err := json.Unmarshal(payload, delivery.Event); err != nil {
+				return nil, fmt.Errorf("invalid webhook delivery event: %w", err)
+			}
+		}
+
+		deliveries = append(deliveries, &delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanWebhook(row rowScanner) (*entities.Webhook, error) {
+	var (
+		webhook    entities.Webhook
+		id         string
+		eventTypes []byte
+		disabledAt sql.NullTime
+	)
+
+	if err := row.Scan(&id, &webhook.TenantID, &webhook.OwnerID, &webhook.URL, &eventTypes, &webhook.Secret, &webhook.Active,
+		&webhook.ConsecutiveFailures, &disabledAt, &webhook.CreatedAt, &webhook.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook id %q: %w", id, err)
+	}
+	webhook.ID = parsedID
+
+	if err := json.Unmarshal(eventTypes, &webhook.EventTypes); err != nil {
+		return nil, fmt.Errorf("invalid webhook event types: %w", err)
+	}
+
+	if disabledAt.Valid {
+		webhook.DisabledAt = &disabledAt.Time
+	}
+
+	return &webhook, nil
+}
@@ -0,0 +1,201 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+	"todo-service/internal/infrastructure/migrations"
+)
+
+const testUserID = "user-1"
+
+// openTestDB connects to the MySQL database named by MYSQL_TEST_DSN and
+// migrates it to the latest schema. Tests using it are skipped when the
+// variable is unset; `make test-mysql` runs them against docker compose.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv("MYSQL_TEST_DSN")
+	if dsn == "" {
+		t.Skip("MYSQL_TEST_DSN is not set")
+	}
+
+	db, err := sql.Open("mysql", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := migrations.NewMigrator(db, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, migrator.Up(context.Background()))
+
+	return db
+}
+
+// testTenants returns fresh tenant IDs whose rows are removed once the test
+// is done, so runs never see each other's data.
+func testTenants(t *testing.T, db *sql.DB, n int) []string {
+	t.Helper()
+
+	cleaner := NewMySQLTenantCleaner(db)
+	tenants := make([]string, n)
+	for i := range tenants {
+		tenantID := "test-" + uuid.NewString()[:8]
+		tenants[i] = tenantID
+		t.Cleanup(func() {
+			_, err := cleaner.DeleteTenant(context.Background(), tenantID)
+			assert.NoError(t, err)
+		})
+	}
+	return tenants
+}
+
+func createTodo(t *testing.T, txManager *MySQLTransactionManager, tenantID string) *entities.TodoItem {
+	t.Helper()
+
+	todo := entities.NewTodoItem(tenantID, testUserID, "Todo of "+tenantID, time.Now().Add(time.Hour), nil)
+	require.NoError(t, txManager.DoInTx(context.Background(), func(repo ports.TodoRepository) error {
+		return repo.Create(context.Background(), todo)
+	}))
+	return todo
+}
+
+func todoIDs(todos []*entities.TodoItem) []uuid.UUID {
+	ids := make([]uuid.UUID, len(todos))
+	for i, todo := range todos {
+		ids[i] = todo.ID
+	}
+	return ids
+}
+
+// The tests below use the same user ID in both tenants, so only the tenant
+// keeps their data apart.
+
+func TestTenantIsolation_Todos(t *testing.T) {
+	db := openTestDB(t)
+	tenants := testTenants(t, db, 2)
+	txManager := NewMySQLTransactionManager(db)
+	ctx := context.Background()
+
+	theirs := createTodo(t, txManager, tenants[0])
+	ours := createTodo(t, txManager, tenants[1])
+
+	grant, err := entities.NewTodoGrant(theirs, "user-2", entities.RoleEditor, testUserID)
+	require.NoError(t, err)
+	require.NoError(t, txManager.DoInTx(ctx, func(repo ports.TodoRepository) error {
+		return repo.SaveGrant(ctx, grant)
+	}))
+
+	err = txManager.DoInTx(ctx, func(repo ports.TodoRepository) error {
+		_, err := repo.GetByID(ctx, tenants[1], theirs.ID)
+		assert.ErrorIs(t, err, entities.ErrTodoNotFound)
+
+		_, err = repo.GetByIDForUpdate(ctx, tenants[1], theirs.ID)
+		assert.ErrorIs(t, err, entities.ErrTodoNotFound)
+
+		_, err = repo.GetGrant(ctx, tenants[1], theirs.ID, "user-2")
+		assert.ErrorIs(t, err, entities.ErrTodoGrantNotFound)
+
+		grants, err := repo.ListGrants(ctx, tenants[1], theirs.ID)
+		require.NoError(t, err)
+		assert.Empty(t, grants)
+
+		listed, err := repo.List(ctx, tenants[1], testUserID, 100, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{ours.ID}, todoIDs(listed))
+
+		found, err := repo.Search(ctx, tenants[1], testUserID, entities.TodoFilter{}, nil, 100, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{ours.ID}, todoIDs(found))
+
+		counts, err := repo.CountByStatus(ctx, tenants[1], testUserID, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 1, counts.Total)
+
+		changes, err := repo.ListChanges(ctx, tenants[1], testUserID, 0, 100)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{ours.ID}, todoIDs(changes))
+
+		// Writes naming the other tenant's todo under our tenant miss it.
+		forged := *theirs
+		forged.TenantID = tenants[1]
+		forged.Description = "Overwritten"
+		assert.ErrorIs(t, repo.Update(ctx, &forged), entities.ErrTodoNotFound)
+
+		forged.MarkDeleted()
+		assert.ErrorIs(t, repo.Delete(ctx, &forged), entities.ErrTodoNotFound)
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, txManager.DoInTx(ctx, func(repo ports.TodoRepository) error {
+		stored, err := repo.GetByID(ctx, tenants[0], theirs.ID)
+		require.NoError(t, err)
+		assert.Equal(t, theirs.Description, stored.Description)
+		assert.False(t, stored.IsDeleted())
+		return nil
+	}))
+}
+
+func TestTenantIsolation_Files(t *testing.T) {
+	db := openTestDB(t)
+	tenants := testTenants(t, db, 2)
+	repo := NewMySQLFileRepository(db)
+	ctx := context.Background()
+
+	theirs := entities.NewFile(tenants[0], testUserID, "theirs.txt", "text/plain", 5)
+	ours := entities.NewFile(tenants[1], testUserID, "ours.txt", "text/plain", 5)
+	require.NoError(t, repo.Create(ctx, theirs))
+	require.NoError(t, repo.Create(ctx, ours))
+
+	_, err := repo.GetByID(ctx, tenants[1], testUserID, theirs.ID)
+	assert.ErrorIs(t, err, entities.ErrFileNotFound)
+
+	files, err := repo.ListByIDs(ctx, tenants[1], testUserID, []uuid.UUID{theirs.ID, ours.ID})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, ours.ID, files[0].ID)
+
+	assert.ErrorIs(t, repo.Delete(ctx, tenants[1], testUserID, theirs.ID), entities.ErrFileNotFound)
+
+	stored, err := repo.GetByID(ctx, tenants[0], testUserID, theirs.ID)
+	require.NoError(t, err)
+	assert.Equal(t, theirs.StoragePath, stored.StoragePath)
+}
+
+func TestTenantIsolation_Webhooks(t *testing.T) {
+	db := openTestDB(t)
+	tenants := testTenants(t, db, 2)
+	repo := NewMySQLWebhookRepository(db)
+	ctx := context.Background()
+
+	theirs, err := entities.NewWebhook(tenants[0], testUserID, "https://hooks.example.com/theirs", nil, "")
+	require.NoError(t, err)
+	ours, err := entities.NewWebhook(tenants[1], testUserID, "https://hooks.example.com/ours", nil, "")
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(ctx, theirs))
+	require.NoError(t, repo.Create(ctx, ours))
+
+	_, err = repo.GetByID(ctx, tenants[1], testUserID, theirs.ID)
+	assert.ErrorIs(t, err, entities.ErrWebhookNotFound)
+
+	webhooks, err := repo.List(ctx, tenants[1], testUserID)
+	require.NoError(t, err)
+	require.Len(t, webhooks, 1)
+	assert.Equal(t, ours.ID, webhooks[0].ID)
+
+	assert.ErrorIs(t, repo.Delete(ctx, tenants[1], testUserID, theirs.ID), entities.ErrWebhookNotFound)
+
+	_, err = repo.GetByID(ctx, tenants[0], testUserID, theirs.ID)
+	assert.NoError(t, err)
+}
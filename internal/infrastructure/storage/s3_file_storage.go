@@ -3,15 +3,26 @@ package storage
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/infrastructure/tracing"
 )
 
+var tracer = otel.Tracer("todo-service/internal/infrastructure/storage")
+
 type S3FileStorage struct {
 	client *s3.S3
 	bucket string
@@ -33,9 +44,15 @@ func NewS3FileStorage(sess *session.Session, bucket string) (*S3FileStorage, err
 	return storage, nil
 }
 
-func (s *S3FileStorage) UploadFile(ctx context.Context, storagePath, contentType string, data io.Reader, size int64) error {
+func (s *S3FileStorage) UploadFile(ctx context.Context, storagePath, contentType string, data io.Reader, size int64) (err error) {
+	ctx, span := s.startSpan(ctx, "PutObject", storagePath, attribute.Int64("aws.s3.content_length", size))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
 	buf := make([]byte, size)
-	_, err := io.ReadFull(data, buf)
+	_, err = io.ReadFull(data, buf)
 	if err != nil {
 		return fmt.Errorf("failed to read file data: %w", err)
 	}
@@ -56,6 +73,57 @@ func (s *S3FileStorage) UploadFile(ctx context.Context, storagePath, contentType
 	return nil
 }
 
+func (s *S3FileStorage) DownloadFile(ctx context.Context, storagePath string) (_ io.ReadCloser, err error) {
+	ctx, span := s.startSpan(ctx, "GetObject", storagePath)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	output, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(storagePath),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, entities.ErrFileNotFound
+		}
+		return nil, fmt.Errorf("failed to download file from S3: %w", err)
+	}
+
+	return output.Body, nil
+}
+
+func (s *S3FileStorage) DeleteFile(ctx context.Context, storagePath string) (err error) {
+	ctx, span := s.startSpan(ctx, "DeleteObject", storagePath)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	_, err = s.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(storagePath),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete file from S3: %w", err)
+	}
+
+	return nil
+}
+
+func (s *S3FileStorage) startSpan(ctx context.Context, operation, key string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs,
+		semconv.RPCSystemKey.String("aws-api"),
+		semconv.RPCService("S3"),
+		semconv.RPCMethod(operation),
+		semconv.AWSS3BucketKey.String(s.bucket),
+		semconv.AWSS3KeyKey.String(key),
+	)
+	return tracer.Start(ctx, "S3."+operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
 func (s *S3FileStorage) ensureBucket(ctx context.Context) error {
 	_, err := s.client.HeadBucketWithContext(ctx, &s3.HeadBucketInput{
 		Bucket: aws.String(s.bucket),
@@ -72,3 +140,11 @@ func (s *S3FileStorage) ensureBucket(ctx context.Context) error {
 
 	return nil
 }
+
+// Ping checks that the bucket is reachable without creating it.
+func (s *S3FileStorage) Ping(ctx context.Context) error {
+	_, err := s.client.HeadBucketWithContext(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(s.bucket),
+	})
+	return err
+}
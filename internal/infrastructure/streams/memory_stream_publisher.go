@@ -0,0 +1,152 @@
+package streams
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+)
+
+// MemoryStreamPublisher is an in-process event bus for local development and
+// tests. It keeps the most recent events in memory and assigns them
+// Redis-style "<sequence>-0" IDs so the live feed can resume from them.
+type MemoryStreamPublisher struct {
+	mu       sync.Mutex
+	events   []*entities.Event
+	sequence uint64
+	capacity int
+	notify   chan struct{}
+}
+
+func NewMemoryStreamPublisher(capacity int) *MemoryStreamPublisher {
+	if capacity <= 0 {
+		capacity = 1000
+	}
+
+	return &MemoryStreamPublisher{
+		capacity: capacity,
+		notify:   make(chan struct{}),
+	}
+}
+
+func (p *MemoryStreamPublisher) Publish(ctx context.Context, event *entities.Event) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.sequence++
+	stored := *event
+	stored.ID = formatSequenceID(p.sequence)
+
+	p.events = append(p.events, &stored)
+	if len(p.events) > p.capacity {
+		p.events = p.events[len(p.events)-p.capacity:]
+	}
+
+	close(p.notify)
+	p.notify = make(chan struct{})
+
+	return nil
+}
+
+func (p *MemoryStreamPublisher) Events() []*entities.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	events := make([]*entities.Event, len(p.events))
+	copy(events, p.events)
+	return events
+}
+
+func (p *MemoryStreamPublisher) LastID(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return formatSequenceID(p.sequence), nil
+}
+
+func (p *MemoryStreamPublisher) Read(ctx context.Context, afterID string, count int64, block time.Duration) ([]*entities.Event, error) {
+	after, err := parseSequenceID(afterID)
+	if err != nil {
+		return nil, err
+	}
+
+	var timeout <-chan time.Time
+	if block > 0 {
+		timer := time.NewTimer(block)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	for {
+		events, notify := p.eventsAfter(after, count)
+		if len(events) > 0 || timeout == nil {
+			return events, nil
+		}
+
+		select {
+		case <-notify:
+		case <-timeout:
+			return nil, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (p *MemoryStreamPublisher) Consume(ctx context.Context, handler ports.EventHandler) error {
+	cursor, _ := p.LastID(ctx)
+
+	for ctx.Err() == nil {
+		events, err := p.Read(ctx, cursor, 100, 5*time.Second)
+		if err != nil {
+			continue
+		}
+
+		for _, event := range events {
+			cursor = event.ID
+			handler(ctx, event)
+		}
+	}
+
+	return nil
+}
+
+func (p *MemoryStreamPublisher) eventsAfter(after uint64, count int64) ([]*entities.Event, <-chan struct{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var events []*entities.Event
+	for _, event := range p.events {
+		sequence, _ := parseSequenceID(event.ID)
+		if sequence <= after {
+			continue
+		}
+		events = append(events, event)
+		if count > 0 && int64(len(events)) >= count {
+			break
+		}
+	}
+
+	return events, p.notify
+}
+
+func formatSequenceID(sequence uint64) string {
+	return strconv.FormatUint(sequence, 10) + "-0"
+}
+
+func parseSequenceID(id string) (uint64, error) {
+	sequence, err := strconv.ParseUint(strings.SplitN(id, "-", 2)[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid event id %q", id)
+	}
+	return sequence, nil
+}
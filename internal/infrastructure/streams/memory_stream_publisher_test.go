@@ -0,0 +1,60 @@
+package streams
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/domain/entities"
+)
+
+func TestMemoryStreamPublisher_ReadAfterID(t *testing.T) {
+	bus := NewMemoryStreamPublisher(10)
+	ctx := context.Background()
+
+	for _, todoID := range []string{"a", "b", "c"} {
+		require.NoError(t, bus.Publish(ctx, &entities.Event{Type: entities.EventTypeTodoCreated, TodoID: todoID}))
+	}
+
+	lastID, err := bus.LastID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "3-0", lastID)
+
+	events, err := bus.Read(ctx, "1-0", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "b", events[0].TodoID)
+	assert.Equal(t, "3-0", events[1].ID)
+}
+
+func TestMemoryStreamPublisher_ReadBlocksUntilPublish(t *testing.T) {
+	bus := NewMemoryStreamPublisher(10)
+	ctx := context.Background()
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		bus.Publish(ctx, &entities.Event{Type: entities.EventTypeTodoCreated, TodoID: "late"})
+	}()
+
+	events, err := bus.Read(ctx, "0-0", 10, 2*time.Second)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "late", events[0].TodoID)
+}
+
+func TestMemoryStreamPublisher_DropsOldestBeyondCapacity(t *testing.T) {
+	bus := NewMemoryStreamPublisher(2)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, bus.Publish(ctx, &entities.Event{Type: entities.EventTypeTodoCreated}))
+	}
+
+	events := bus.Events()
+	require.Len(t, events, 2)
+	assert.Equal(t, "4-0", events[0].ID)
+	assert.Equal(t, "5-0", events[1].ID)
+}
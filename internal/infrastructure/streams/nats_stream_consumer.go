@@ -0,0 +1,143 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+	"todo-service/internal/infrastructure/tracing"
+	"todo-service/internal/logging"
+)
+
+// NATSStreamConsumer delivers events from a JetStream stream through a
+// durable pull consumer. Every instance shares the durable consumer, so each
+// event is handled once; events the handler fails on are not acknowledged
+// and JetStream redelivers them once the ack wait expires.
+type NATSStreamConsumer struct {
+	js         jetstream.JetStream
+	streamName string
+	durable    string
+	batchSize  int
+	logger     *zap.Logger
+}
+
+func NewNATSStreamConsumer(conn *nats.Conn, streamName, durable string, logger *zap.Logger) (*NATSStreamConsumer, error) {
+	js, err := jetstream.New(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+
+	return &NATSStreamConsumer{
+		js:         js,
+		streamName: streamName,
+		durable:    durable,
+		batchSize:  10,
+		logger:     logger,
+	}, nil
+}
+
+func (c *NATSStreamConsumer) Consume(ctx context.Context, handler ports.EventHandler) error {
+	consumer, err := c.js.CreateOrUpdateConsumer(ctx, c.streamName, jetstream.ConsumerConfig{
+		Durable:       c.durable,
+		DeliverPolicy: jetstream.DeliverNewPolicy,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create JetStream consumer %s: %w", c.durable, err)
+	}
+
+	messages, err := consumer.Messages(jetstream.PullMaxMessages(c.batchSize))
+	if err != nil {
+		return fmt.Errorf("failed to consume from JetStream consumer %s: %w", c.durable, err)
+	}
+	stop := context.AfterFunc(ctx, messages.Stop)
+	defer stop()
+
+	for {
+		msg, err := messages.Next()
+		if errors.Is(err, jetstream.ErrMsgIteratorClosed) {
+			return nil
+		}
+		if err != nil {
+			c.logger.Warn("Failed to read from event stream", zap.String("stream", c.streamName), zap.Error(err))
+			if !sleepContext(ctx, time.Second) {
+				return nil
+			}
+			continue
+		}
+
+		c.handle(ctx, msg, handler)
+	}
+}
+
+// handle processes one message as a child of the span that published it.
+func (c *NATSStreamConsumer) handle(ctx context.Context, msg jetstream.Msg, handler ports.EventHandler) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(msg.Headers()))
+	ctx, span := tracer.Start(ctx, c.streamName+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String("nats"),
+			semconv.MessagingDestinationName(msg.Subject()),
+			attribute.String("messaging.consumer.group.name", c.durable),
+			semconv.MessagingOperationTypeDeliver,
+		))
+	defer span.End()
+
+	ctx = logging.WithLogger(ctx, c.logger.With(zap.String("stream", c.streamName), zap.String("subject", msg.Subject())))
+	logger := logging.FromContext(ctx)
+
+	event, err := decodeNATSMessage(msg)
+	if err != nil {
+		logger.Error("Dropping malformed stream message", zap.Error(err))
+		c.ack(ctx, msg)
+		return
+	}
+
+	if err := handler(ctx, event); err != nil {
+		tracing.RecordError(span, err)
+		logger.Error("Failed to handle stream event", zap.String("event_type", event.Type), zap.Error(err))
+		return
+	}
+
+	c.ack(ctx, msg)
+}
+
+func (c *NATSStreamConsumer) ack(ctx context.Context, msg jetstream.Msg) {
+	if err := msg.Ack(); err != nil {
+		logging.FromContext(ctx).Warn("Failed to acknowledge stream message", zap.Error(err))
+	}
+}
+
+// decodeNATSMessage reads an event published by NATSStreamPublisher. Its ID
+// is the stream sequence in the "<sequence>-0" form the live feed resumes
+// from.
+func decodeNATSMessage(msg jetstream.Msg) (*entities.Event, error) {
+	metadata, err := msg.Metadata()
+	if err != nil {
+		return nil, fmt.Errorf("invalid stream message: %w", err)
+	}
+
+	var event entities.Event
+	if err := json.Unmarshal(msg.Data(), &event); err != nil {
+		return nil, fmt.Errorf("invalid event data: %w", err)
+	}
+	if event.Type == "" {
+		return nil, fmt.Errorf("stream message has no event type")
+	}
+
+	event.ID = formatSequenceID(metadata.Sequence.Stream)
+	return &event, nil
+}
@@ -0,0 +1,73 @@
+package streams
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"todo-service/internal/domain/entities"
+)
+
+func publishTodoEvents(t *testing.T, publisher *NATSStreamPublisher, descriptions ...string) {
+	t.Helper()
+
+	for _, description := range descriptions {
+		todo := entities.NewTodoItem("acme", "user-1", description, time.Now().Add(time.Hour), nil)
+		event, err := entities.NewTodoEvent(entities.EventTypeTodoCreated, todo)
+		require.NoError(t, err)
+		require.NoError(t, publisher.Publish(context.Background(), event))
+	}
+}
+
+func TestNATSStreamConsumer_SharesDurableConsumerBetweenInstances(t *testing.T) {
+	conn := startEmbeddedNATS(t)
+
+	publisher, err := NewNATSStreamPublisher(conn, "TODO_EVENTS", "todo-events")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		received []string
+	)
+	handler := func(ctx context.Context, event *entities.Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, event.ID)
+		return nil
+	}
+
+	for i := 0; i < 2; i++ {
+		consumer, err := NewNATSStreamConsumer(conn, "TODO_EVENTS", "webhooks", zap.NewNop())
+		require.NoError(t, err)
+		go consumer.Consume(ctx, handler)
+	}
+
+	js, err := jetstream.New(conn)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		_, err := js.Consumer(ctx, "TODO_EVENTS", "webhooks")
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	publishTodoEvents(t, publisher, "first", "second", "third")
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) >= 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	time.Sleep(100 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.ElementsMatch(t, []string{"1-0", "2-0", "3-0"}, received)
+}
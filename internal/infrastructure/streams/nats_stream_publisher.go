@@ -0,0 +1,72 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+
+	"todo-service/internal/domain/entities"
+)
+
+type NATSStreamPublisher struct {
+	js            jetstream.JetStream
+	streamName    string
+	subjectPrefix string
+}
+
+// NewNATSStreamPublisher creates (or reuses) a JetStream stream capturing
+// "<subjectPrefix>.>" and publishes each event to "<subjectPrefix>.<type>".
+func NewNATSStreamPublisher(conn *nats.Conn, streamName, subjectPrefix string) (*NATSStreamPublisher, error) {
+	js, err := jetstream.New(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     streamName,
+		Subjects: []string{subjectPrefix + ".>"},
+		Storage:  jetstream.FileStorage,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to ensure JetStream stream %s: %w", streamName, err)
+	}
+
+	return &NATSStreamPublisher{
+		js:            js,
+		streamName:    streamName,
+		subjectPrefix: subjectPrefix,
+	}, nil
+}
+
+func (p *NATSStreamPublisher) Publish(ctx context.Context, event *entities.Event) error {
+	eventData, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s event: %w", event.Type, err)
+	}
+
+	msg := nats.NewMsg(p.Subject(event.Type))
+	msg.Data = eventData
+	msg.Header.Set("Event-Type", event.Type)
+	msg.Header.Set("Todo-ID", event.TodoID)
+	msg.Header.Set("Tenant-ID", event.TenantID)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(msg.Header))
+
+	if _, err := p.js.PublishMsg(ctx, msg); err != nil {
+		return fmt.Errorf("failed to publish event to JetStream: %w", err)
+	}
+
+	return nil
+}
+
+func (p *NATSStreamPublisher) Subject(eventType string) string {
+	return p.subjectPrefix + "." + eventType
+}
@@ -0,0 +1,93 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/domain/entities"
+)
+
+func startEmbeddedNATS(t *testing.T) *nats.Conn {
+	t.Helper()
+
+	srv, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      server.RANDOM_PORT,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	require.NoError(t, err)
+
+	go srv.Start()
+	t.Cleanup(srv.Shutdown)
+
+	if !srv.ReadyForConnections(5 * time.Second) {
+		t.Fatal("embedded NATS server did not start")
+	}
+
+	conn, err := nats.Connect(srv.ClientURL())
+	require.NoError(t, err)
+	t.Cleanup(conn.Close)
+
+	return conn
+}
+
+func TestNATSStreamPublisher_Publish(t *testing.T) {
+	conn := startEmbeddedNATS(t)
+
+	publisher, err := NewNATSStreamPublisher(conn, "TODO_EVENTS", "todo-events")
+	require.NoError(t, err)
+
+	todo := entities.NewTodoItem("acme", "user-1", "Ship release notes", time.Now().Add(time.Hour), nil)
+	event, err := entities.NewTodoEvent(entities.EventTypeTodoCreated, todo)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, publisher.Publish(ctx, event))
+
+	js, err := jetstream.New(conn)
+	require.NoError(t, err)
+
+	consumer, err := js.OrderedConsumer(ctx, "TODO_EVENTS", jetstream.OrderedConsumerConfig{
+		FilterSubjects: []string{"todo-events.todo.*"},
+	})
+	require.NoError(t, err)
+
+	msg, err := consumer.Next(jetstream.FetchMaxWait(2 * time.Second))
+	require.NoError(t, err)
+
+	assert.Equal(t, "todo-events.todo.created", msg.Subject())
+	assert.Equal(t, entities.EventTypeTodoCreated, msg.Headers().Get("Event-Type"))
+	assert.Equal(t, todo.ID.String(), msg.Headers().Get("Todo-ID"))
+	assert.Equal(t, "acme", msg.Headers().Get("Tenant-ID"))
+
+	var received entities.Event
+	require.NoError(t, json.Unmarshal(msg.Data(), &received))
+	assert.Equal(t, event.TodoID, received.TodoID)
+
+	var receivedTodo entities.TodoItem
+	require.NoError(t, json.Unmarshal(received.Data, &receivedTodo))
+	assert.Equal(t, todo.Description, receivedTodo.Description)
+}
+
+func TestNATSStreamPublisher_ReusesExistingStream(t *testing.T) {
+	conn := startEmbeddedNATS(t)
+
+	_, err := NewNATSStreamPublisher(conn, "TODO_EVENTS", "todo-events")
+	require.NoError(t, err)
+
+	_, err = NewNATSStreamPublisher(conn, "TODO_EVENTS", "todo-events")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,104 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"todo-service/internal/domain/entities"
+)
+
+// NATSStreamReader reads a JetStream stream for the live event feed. Event
+// IDs are stream sequences in the "<sequence>-0" form, so they resume and
+// order like Redis stream IDs.
+type NATSStreamReader struct {
+	js         jetstream.JetStream
+	streamName string
+}
+
+func NewNATSStreamReader(conn *nats.Conn, streamName string) (*NATSStreamReader, error) {
+	js, err := jetstream.New(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+
+	return &NATSStreamReader{
+		js:         js,
+		streamName: streamName,
+	}, nil
+}
+
+func (r *NATSStreamReader) LastID(ctx context.Context) (string, error) {
+	stream, err := r.js.Stream(ctx, r.streamName)
+	if err != nil {
+		return "", fmt.Errorf("failed to read stream tail: %w", err)
+	}
+
+	return formatSequenceID(stream.CachedInfo().State.LastSeq), nil
+}
+
+// Read fetches through an ephemeral consumer starting after afterID, which
+// is removed again once the batch has been read.
+func (r *NATSStreamReader) Read(ctx context.Context, afterID string, count int64, block time.Duration) ([]*entities.Event, error) {
+	after, err := parseSequenceID(afterID)
+	if err != nil {
+		return nil, err
+	}
+
+	consumer, err := r.js.CreateConsumer(ctx, r.streamName, jetstream.ConsumerConfig{
+		DeliverPolicy:     jetstream.DeliverByStartSequencePolicy,
+		OptStartSeq:       after + 1,
+		AckPolicy:         jetstream.AckNonePolicy,
+		MemoryStorage:     true,
+		InactiveThreshold: block + time.Minute,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read from stream: %w", err)
+	}
+	defer r.js.DeleteConsumer(context.WithoutCancel(ctx), r.streamName, consumer.CachedInfo().Name)
+
+	var events []*entities.Event
+	if block > 0 {
+		msg, err := consumer.Next(jetstream.FetchMaxWait(block))
+		if errors.Is(err, nats.ErrTimeout) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read from stream: %w", err)
+		}
+		events = appendNATSEvent(events, msg)
+	}
+
+	// Whatever else is already in the stream comes without waiting.
+	if remaining := int(count) - len(events); remaining > 0 {
+		batch, err := consumer.FetchNoWait(remaining)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read from stream: %w", err)
+		}
+		for msg := range batch.Messages() {
+			events = appendNATSEvent(events, msg)
+		}
+		if err := batch.Error(); err != nil && !errors.Is(err, nats.ErrTimeout) && !errors.Is(err, jetstream.ErrNoMessages) {
+			return nil, fmt.Errorf("failed to read from stream: %w", err)
+		}
+	}
+
+	return events, nil
+}
+
+func appendNATSEvent(events []*entities.Event, msg jetstream.Msg) []*entities.Event {
+	event, err := decodeNATSMessage(msg)
+	if err != nil {
+		// Keep the cursor moving past messages we cannot decode.
+		metadata, metadataErr := msg.Metadata()
+		if metadataErr != nil {
+			return events
+		}
+		event = &entities.Event{ID: formatSequenceID(metadata.Sequence.Stream)}
+	}
+	return append(events, event)
+}
@@ -0,0 +1,64 @@
+package streams
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/domain/entities"
+)
+
+func TestNATSStreamReader_ReadsAfterID(t *testing.T) {
+	conn := startEmbeddedNATS(t)
+
+	publisher, err := NewNATSStreamPublisher(conn, "TODO_EVENTS", "todo-events")
+	require.NoError(t, err)
+	reader, err := NewNATSStreamReader(conn, "TODO_EVENTS")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	tail, err := reader.LastID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "0-0", tail)
+
+	publishTodoEvents(t, publisher, "first", "second", "third")
+
+	tail, err = reader.LastID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "3-0", tail)
+
+	events, err := reader.Read(ctx, "1-0", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "2-0", events[0].ID)
+	assert.Equal(t, "3-0", events[1].ID)
+	assert.Equal(t, entities.EventTypeTodoCreated, events[1].Type)
+	assert.Equal(t, "user-1", events[1].OwnerID)
+
+	events, err = reader.Read(ctx, "3-0", 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestNATSStreamReader_BlocksForNewEvents(t *testing.T) {
+	conn := startEmbeddedNATS(t)
+
+	publisher, err := NewNATSStreamPublisher(conn, "TODO_EVENTS", "todo-events")
+	require.NoError(t, err)
+	reader, err := NewNATSStreamReader(conn, "TODO_EVENTS")
+	require.NoError(t, err)
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		publishTodoEvents(t, publisher, "late")
+	}()
+
+	events, err := reader.Read(context.Background(), "0-0", 10, 5*time.Second)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "1-0", events[0].ID)
+}
@@ -0,0 +1,199 @@
+package streams
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+	"todo-service/internal/infrastructure/tracing"
+	"todo-service/internal/logging"
+)
+
+type RedisStreamConsumer struct {
+	client       *redis.Client
+	streamName   string
+	group        string
+	consumerName string
+	batchSize    int64
+	block        time.Duration
+	logger       *zap.Logger
+}
+
+func NewRedisStreamConsumer(client *redis.Client, streamName, group, consumerName string, logger *zap.Logger) *RedisStreamConsumer {
+	return &RedisStreamConsumer{
+		client:       client,
+		streamName:   streamName,
+		group:        group,
+		consumerName: consumerName,
+		batchSize:    10,
+		block:        5 * time.Second,
+		logger:       logger,
+	}
+}
+
+func (c *RedisStreamConsumer) Consume(ctx context.Context, handler ports.EventHandler) error {
+	if err := c.ensureGroup(ctx); err != nil {
+		return err
+	}
+
+	// Entries delivered to this consumer before a restart but never
+	// acknowledged are drained first, then the loop switches to new entries.
+	cursor := "0"
+
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		streams, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    c.group,
+			Consumer: c.consumerName,
+			Streams:  []string{c.streamName, cursor},
+			Count:    c.batchSize,
+			Block:    c.block,
+		}).Result()
+
+		if err != nil {
+			if errors.Is(err, redis.Nil) {
+				continue
+			}
+			if ctx.Err() != nil {
+				return nil
+			}
+			c.logger.Warn("Failed to read from event stream", zap.String("stream", c.streamName), zap.Error(err))
+			if !sleepContext(ctx, time.Second) {
+				return nil
+			}
+			continue
+		}
+
+		delivered := 0
+		for _, stream := range streams {
+			for _, message := range stream.Messages {
+				delivered++
+				c.handle(ctx, message, handler)
+				if cursor != ">" {
+					cursor = message.ID
+				}
+			}
+		}
+
+		if cursor != ">" && delivered == 0 {
+			cursor = ">"
+		}
+	}
+}
+
+// handle processes one entry as a child of the span that published it.
+func (c *RedisStreamConsumer) handle(ctx context.Context, message redis.XMessage, handler ports.EventHandler) {
+	ctx, span := tracer.Start(extractTraceContext(ctx, message.Values), c.streamName+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String("redis"),
+			semconv.MessagingDestinationName(c.streamName),
+			attribute.String("messaging.consumer.group.name", c.group),
+			semconv.MessagingOperationTypeDeliver,
+			semconv.MessagingMessageID(message.ID),
+		))
+	defer span.End()
+
+	ctx = logging.WithLogger(ctx, c.logger.With(zap.String("stream", c.streamName), zap.String("id", message.ID)))
+	logger := logging.FromContext(ctx)
+
+	event, err := decodeEvent(message)
+	if err != nil {
+		logger.Error("Dropping malformed stream entry", zap.Error(err))
+		c.ack(ctx, message.ID)
+		return
+	}
+
+	if err := handler(ctx, event); err != nil {
+		tracing.RecordError(span, err)
+		logger.Error("Failed to handle stream event", zap.String("event_type", event.Type), zap.Error(err))
+		return
+	}
+
+	c.ack(ctx, message.ID)
+}
+
+func (c *RedisStreamConsumer) ack(ctx context.Context, id string) {
+	if err := c.client.XAck(ctx, c.streamName, c.group, id).Err(); err != nil {
+		c.logger.Warn("Failed to acknowledge stream entry", zap.String("id", id), zap.Error(err))
+	}
+}
+
+func (c *RedisStreamConsumer) ensureGroup(ctx context.Context) error {
+	err := c.client.XGroupCreateMkStream(ctx, c.streamName, c.group, "$").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("failed to create consumer group %s: %w", c.group, err)
+	}
+	return nil
+}
+
+func decodeEvent(message redis.XMessage) (*entities.Event, error) {
+	eventType, _ := message.Values["event_type"].(string)
+	todoID, _ := message.Values["todo_id"].(string)
+	tenantID, _ := message.Values["tenant_id"].(string)
+	data, _ := message.Values["data"].(string)
+
+	if eventType == "" {
+		return nil, fmt.Errorf("stream entry has no event_type")
+	}
+
+	event := &entities.Event{
+		ID:       message.ID,
+		Type:     eventType,
+		TodoID:   todoID,
+		TenantID: tenantID,
+	}
+
+	if data == "" {
+		return event, nil
+	}
+
+	// Entries written before the generic event envelope carried the todo
+	// under "todo_item" instead of "data".
+	var envelope struct {
+		Data       json.RawMessage `json:"data"`
+		TodoItem   json.RawMessage `json:"todo_item"`
+		OwnerID    string          `json:"owner_id"`
+		GranteeIDs []string        `json:"grantee_ids"`
+		Timestamp  int64           `json:"timestamp"`
+	}
+	if err := json.Unmarshal([]byte(data), &envelope); err != nil {
+		return nil, fmt.Errorf("invalid event data: %w", err)
+	}
+
+	event.Data = envelope.Data
+	if len(event.Data) == 0 {
+		event.Data = envelope.TodoItem
+	}
+	event.OwnerID = envelope.OwnerID
+	event.GranteeIDs = envelope.GranteeIDs
+	event.Timestamp = envelope.Timestamp
+
+	return event, nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"time"
 
 	"github.com/go-redis/redis/v8"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 
 	"todo-service/internal/domain/entities"
+	"todo-service/internal/infrastructure/tracing"
 )
 
 type RedisStreamPublisher struct {
@@ -23,39 +25,71 @@ func NewRedisStreamPublisher(client *redis.Client, streamName string) *RedisStre
 	}
 }
 
-type TodoEvent struct {
-	Type      string             `json:"type"`
-	TodoID    string             `json:"todo_id"`
-	TodoItem  *entities.TodoItem `json:"todo_item,omitempty"`
-	Timestamp int64              `json:"timestamp"`
-}
-
-func (p *RedisStreamPublisher) PublishTodoCreated(ctx context.Context, todo *entities.TodoItem) error {
-	event := TodoEvent{
-		Type:      "todo.created",
-		TodoID:    todo.ID.String(),
-		TodoItem:  todo,
-		Timestamp: time.Now().Unix(),
-	}
+func (p *RedisStreamPublisher) Publish(ctx context.Context, event *entities.Event) (err error) {
+	ctx, span := tracer.Start(ctx, p.streamName+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String("redis"),
+			semconv.MessagingDestinationName(p.streamName),
+			semconv.MessagingOperationTypePublish,
+		))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
 
 	eventData, err := json.Marshal(event)
 	if err != nil {
-		return fmt.Errorf("failed to marshal todo event: %w", err)
+		return fmt.Errorf("failed to marshal %s event: %w", event.Type, err)
 	}
 
-	args := &redis.XAddArgs{
-		Stream: p.streamName,
-		Values: map[string]interface{}{
-			"event_type": event.Type,
-			"todo_id":    event.TodoID,
-			"data":       string(eventData),
-		},
+	values := map[string]interface{}{
+		"event_type": event.Type,
+		"todo_id":    event.TodoID,
+		"tenant_id":  event.TenantID,
+		"data":       string(eventData),
 	}
+	injectTraceContext(ctx, values)
 
-	_, err = p.client.XAdd(ctx, args).Result()
+	_, err = p.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: p.streamName,
+		Values: values,
+	}).Result()
 	if err != nil {
 		return fmt.Errorf("failed to publish event to stream: %w", err)
 	}
 
 	return nil
 }
+
+// DeleteTenantEvents removes a tenant's entries from the stream. Consumer
+// groups that haven't read them yet simply never see them.
+func (p *RedisStreamPublisher) DeleteTenantEvents(ctx context.Context, tenantID string) (int64, error) {
+	var deleted int64
+	start := "-"
+	for {
+		messages, err := p.client.XRangeN(ctx, p.streamName, start, "+", 500).Result()
+		if err != nil {
+			return deleted, err
+		}
+		if len(messages) == 0 {
+			return deleted, nil
+		}
+
+		var ids []string
+		for _, message := range messages {
+			if message.Values["tenant_id"] == tenantID {
+				ids = append(ids, message.ID)
+			}
+		}
+		if len(ids) > 0 {
+			n, err := p.client.XDel(ctx, p.streamName, ids...).Result()
+			if err != nil {
+				return deleted, err
+			}
+			deleted += n
+		}
+
+		start = "(" + messages[len(messages)-1].ID
+	}
+}
@@ -0,0 +1,112 @@
+package streams
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"todo-service/internal/domain/entities"
+)
+
+func TestRedisStream_RoundTripsTenantOwnerAndGrantees(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	ctx := context.Background()
+	todo := entities.NewTodoItem("acme", "user-1", "Ship release notes", time.Now().Add(time.Hour), nil)
+	grant, err := entities.NewTodoGrant(todo, "user-2", entities.RoleViewer, "user-1")
+	require.NoError(t, err)
+	event, err := entities.NewTodoEvent(entities.EventTypeTodoUpdated, todo, grant)
+	require.NoError(t, err)
+
+	require.NoError(t, NewRedisStreamPublisher(client, "todo-events").Publish(ctx, event))
+
+	entries, err := client.XRange(ctx, "todo-events", "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "acme", entries[0].Values["tenant_id"])
+
+	events, err := NewRedisStreamReader(client, "todo-events").Read(ctx, "0-0", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "acme", events[0].TenantID)
+	assert.Equal(t, "user-1", events[0].OwnerID)
+	assert.Equal(t, []string{"user-2"}, events[0].GranteeIDs)
+	assert.Equal(t, todo.ID.String(), events[0].TodoID)
+}
+
+func TestRedisStream_ConsumerContinuesPublisherTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	todo := entities.NewTodoItem("acme", "user-1", "Trace me", time.Now().Add(time.Hour), nil)
+	event, err := entities.NewTodoEvent(entities.EventTypeTodoCreated, todo)
+	require.NoError(t, err)
+
+	ctx, request := provider.Tracer("test").Start(context.Background(), "POST /api/v1/todo")
+	require.NoError(t, NewRedisStreamPublisher(client, "todo-events").Publish(ctx, event))
+	request.End()
+
+	entries, err := client.XRange(context.Background(), "todo-events", "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Contains(t, entries[0].Values["traceparent"], request.SpanContext().TraceID().String())
+
+	var handled trace.SpanContext
+	consumer := NewRedisStreamConsumer(client, "todo-events", "webhooks", "test", zap.NewNop())
+	consumer.handle(context.Background(), entries[0], func(ctx context.Context, event *entities.Event) error {
+		handled = trace.SpanContextFromContext(ctx)
+		return nil
+	})
+
+	assert.Equal(t, request.SpanContext().TraceID(), handled.TraceID())
+
+	var names []string
+	for _, span := range recorder.Ended() {
+		names = append(names, span.Name())
+	}
+	assert.ElementsMatch(t, []string{"todo-events publish", "POST /api/v1/todo", "todo-events process"}, names)
+}
+
+func TestRedisStream_DeleteTenantEvents(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	ctx := context.Background()
+	publisher := NewRedisStreamPublisher(client, "todo-events")
+	for _, tenant := range []string{"acme", "globex", "acme"} {
+		todo := entities.NewTodoItem(tenant, "user-1", "Ship release notes", time.Now().Add(time.Hour), nil)
+		event, err := entities.NewTodoEvent(entities.EventTypeTodoCreated, todo)
+		require.NoError(t, err)
+		require.NoError(t, publisher.Publish(ctx, event))
+	}
+
+	deleted, err := publisher.DeleteTenantEvents(ctx, "acme")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	entries, err := client.XRange(ctx, "todo-events", "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "globex", entries[0].Values["tenant_id"])
+}
@@ -0,0 +1,71 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"todo-service/internal/domain/entities"
+)
+
+type RedisStreamReader struct {
+	client     *redis.Client
+	streamName string
+}
+
+func NewRedisStreamReader(client *redis.Client, streamName string) *RedisStreamReader {
+	return &RedisStreamReader{
+		client:     client,
+		streamName: streamName,
+	}
+}
+
+func (r *RedisStreamReader) LastID(ctx context.Context) (string, error) {
+	messages, err := r.client.XRevRangeN(ctx, r.streamName, "+", "-", 1).Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to read stream tail: %w", err)
+	}
+
+	if len(messages) == 0 {
+		return "0-0", nil
+	}
+
+	return messages[0].ID, nil
+}
+
+func (r *RedisStreamReader) Read(ctx context.Context, afterID string, count int64, block time.Duration) ([]*entities.Event, error) {
+	// XREAD BLOCK 0 waits forever; a negative Block leaves the option out.
+	if block <= 0 {
+		block = -1
+	}
+
+	streams, err := r.client.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{r.streamName, afterID},
+		Count:   count,
+		Block:   block,
+	}).Result()
+
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read from stream: %w", err)
+	}
+
+	var events []*entities.Event
+	for _, stream := range streams {
+		for _, message := range stream.Messages {
+			event, err := decodeEvent(message)
+			if err != nil {
+				// Keep the cursor moving past entries we cannot decode.
+				event = &entities.Event{ID: message.ID}
+			}
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
@@ -0,0 +1,30 @@
+package streams
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+var tracer = otel.Tracer("todo-service/internal/infrastructure/streams")
+
+// injectTraceContext adds the W3C traceparent/tracestate of ctx to a stream
+// entry so consumers can continue the producer's trace.
+func injectTraceContext(ctx context.Context, values map[string]interface{}) {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	for key, value := range carrier {
+		values[key] = value
+	}
+}
+
+func extractTraceContext(ctx context.Context, values map[string]interface{}) context.Context {
+	carrier := propagation.MapCarrier{}
+	for _, key := range otel.GetTextMapPropagator().Fields() {
+		if value, ok := values[key].(string); ok {
+			carrier[key] = value
+		}
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
@@ -0,0 +1,153 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"todo-service/internal/domain/entities"
+)
+
+const (
+	SignatureHeader = "X-Todo-Signature"
+	TimestampHeader = "X-Todo-Timestamp"
+	EventTypeHeader = "X-Todo-Event"
+	EventIDHeader   = "X-Todo-Event-ID"
+)
+
+type HTTPWebhookSender struct {
+	client               *http.Client
+	resolver             *net.Resolver
+	allowPrivateNetworks bool
+}
+
+// NewHTTPWebhookSender refuses to connect to non-public addresses unless
+// allowPrivateNetworks is set, e.g. for local development.
+func NewHTTPWebhookSender(timeout time.Duration, allowPrivateNetworks bool) *HTTPWebhookSender {
+	s := &HTTPWebhookSender{
+		resolver:             net.DefaultResolver,
+		allowPrivateNetworks: allowPrivateNetworks,
+	}
+
+	dialer := &net.Dialer{Timeout: timeout, Control: s.checkDial}
+	s.client = &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			// No proxy: it would be the address checked instead of the
+			// webhook's.
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: time.Second,
+		},
+	}
+	return s
+}
+
+// CheckDestination resolves the URL's host and rejects it if any of its
+// addresses is not public.
+func (s *HTTPWebhookSender) CheckDestination(ctx context.Context, rawURL string) error {
+	if s.allowPrivateNetworks {
+		return nil
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", err)
+	}
+
+	host := parsed.Hostname()
+	addrs, err := s.resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if !entities.IsPublicAddress(addr) {
+			return fmt.Errorf("%w: %s resolves to %s", entities.ErrForbiddenWebhookDestination, host, addr)
+		}
+	}
+	return nil
+}
+
+// checkDial runs for the address actually dialed, after resolution and on
+// every redirect, so a host that re-resolves to a private address later is
+// still refused.
+func (s *HTTPWebhookSender) checkDial(network, address string, _ syscall.RawConn) error {
+	if s.allowPrivateNetworks {
+		return nil
+	}
+
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("unexpected dial address %q: %w", address, err)
+	}
+	if !entities.IsPublicAddress(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", entities.ErrForbiddenWebhookDestination, addrPort.Addr())
+	}
+	return nil
+}
+
+func (s *HTTPWebhookSender) Send(ctx context.Context, webhook *entities.Webhook, event *entities.Event) (int, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "todo-service-webhooks/1.0")
+	req.Header.Set(EventTypeHeader, event.Type)
+	req.Header.Set(EventIDHeader, event.ID)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, "sha256="+Sign(webhook.Secret, timestamp, payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to deliver webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook endpoint responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Sign computes the hex HMAC-SHA256 of "<timestamp>.<payload>". Receivers
+// recompute it with their secret and compare in constant time.
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func Verify(secret, timestamp string, payload []byte, signature string) bool {
+	expected := "sha256=" + Sign(secret, timestamp, payload)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
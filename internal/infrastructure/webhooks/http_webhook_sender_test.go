@@ -0,0 +1,139 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/domain/entities"
+)
+
+func TestHTTPWebhookSender_SignsPayload(t *testing.T) {
+	webhook, err := entities.NewWebhook("acme", "user-1", "", []string{"todo.*"}, "super-secret-signing-key")
+	require.NoError(t, err)
+
+	event := &entities.Event{
+		ID:        "1700000000000-0",
+		Type:      entities.EventTypeTodoCreated,
+		TodoID:    "8b7d3c36-6a4f-4b7e-9d55-3a9b0f4a2f10",
+		Data:      json.RawMessage(`{"description":"Write report"}`),
+		Timestamp: 1700000000,
+	}
+
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	webhook.URL = server.URL
+
+	sender := NewHTTPWebhookSender(5*time.Second, true)
+	status, err := sender.Send(context.Background(), webhook, event)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, status)
+	assert.Equal(t, http.MethodPost, received.Method)
+	assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+	assert.Equal(t, entities.EventTypeTodoCreated, received.Header.Get(EventTypeHeader))
+	assert.Equal(t, event.ID, received.Header.Get(EventIDHeader))
+	assert.True(t, Verify(webhook.Secret, received.Header.Get(TimestampHeader), body, received.Header.Get(SignatureHeader)))
+	assert.False(t, Verify("another-secret-entirely", received.Header.Get(TimestampHeader), body, received.Header.Get(SignatureHeader)))
+
+	var payload entities.Event
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, event.TodoID, payload.TodoID)
+	assert.JSONEq(t, string(event.Data), string(payload.Data))
+}
+
+func TestHTTPWebhookSender_NonSuccessStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	webhook, err := entities.NewWebhook("acme", "user-1", server.URL, nil, "")
+	require.NoError(t, err)
+
+	sender := NewHTTPWebhookSender(5*time.Second, true)
+	status, err := sender.Send(context.Background(), webhook, &entities.Event{ID: "1-0", Type: entities.EventTypeTodoCreated})
+
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadGateway, status)
+	assert.Contains(t, err.Error(), "status 502")
+}
+
+func TestHTTPWebhookSender_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	webhook, err := entities.NewWebhook("acme", "user-1", server.URL, nil, "")
+	require.NoError(t, err)
+
+	sender := NewHTTPWebhookSender(20*time.Millisecond, true)
+	status, err := sender.Send(context.Background(), webhook, &entities.Event{ID: "1-0", Type: entities.EventTypeTodoCreated})
+
+	assert.Error(t, err)
+	assert.Zero(t, status)
+}
+
+func TestHTTPWebhookSender_CheckDestination(t *testing.T) {
+	tests := []struct {
+		url       string
+		forbidden bool
+	}{
+		{"http://127.0.0.1:8080/hook", true},
+		{"http://localhost/hook", true},
+		{"http://[::1]/hook", true},
+		{"http://10.1.2.3/hook", true},
+		{"http://192.168.0.10/hook", true},
+		{"http://172.16.5.4/hook", true},
+		{"http://169.254.169.254/latest/meta-data/", true},
+		{"http://[fe80::1]/hook", true},
+		{"http://[fd00::1]/hook", true},
+		{"http://100.64.0.1/hook", true},
+		{"http://0.0.0.0/hook", true},
+		{"http://[::ffff:127.0.0.1]/hook", true},
+		{"https://93.184.215.14/hook", false},
+		{"https://[2606:4700::6810:84e5]/hook", false},
+	}
+
+	sender := NewHTTPWebhookSender(time.Second, false)
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := sender.CheckDestination(context.Background(), tt.url)
+			if tt.forbidden {
+				assert.ErrorIs(t, err, entities.ErrForbiddenWebhookDestination)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	assert.NoError(t, NewHTTPWebhookSender(time.Second, true).CheckDestination(context.Background(), "http://127.0.0.1/hook"))
+}
+
+func TestHTTPWebhookSender_RefusesToDialPrivateAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the request must not reach a loopback address")
+	}))
+	defer server.Close()
+
+	webhook, err := entities.NewWebhook("acme", "user-1", server.URL, nil, "super-secret-signing-key")
+	require.NoError(t, err)
+
+	_, err = NewHTTPWebhookSender(time.Second, false).Send(context.Background(), webhook, &entities.Event{Type: entities.EventTypeTodoCreated})
+
+	assert.ErrorIs(t, err, entities.ErrForbiddenWebhookDestination)
+}
@@ -0,0 +1,169 @@
+// Package apierr decides how errors returned by the use cases are reported,
+// so the HTTP, gRPC and GraphQL APIs fail the same way for the same cause.
+package apierr
+
+import (
+	"errors"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/interfaces/authn"
+	"todo-service/internal/usecases"
+)
+
+// Domain qualifies the error codes sent in gRPC ErrorInfo details.
+const Domain = "todo-service"
+
+// Stable error codes for causes that are not tied to one resource. Not
+// found and conflict errors carry their own, e.g. "todo_not_found".
+const (
+	CodeValidationFailed = "validation_failed"
+	CodeInvalidFile      = "invalid_file"
+	CodeQuotaExceeded    = "quota_exceeded"
+	CodeUnavailable      = "service_unavailable"
+	CodeInternal         = "internal_error"
+)
+
+type mapping struct {
+	err  error
+	http int
+	grpc codes.Code
+	code string
+}
+
+// mappings is checked in order with errors.Is, before falling back to the
+// kind of domain error; anything else is an internal error.
+var mappings = []mapping{
+	{entities.ErrUnauthenticated, http.StatusUnauthorized, codes.Unauthenticated, "unauthenticated"},
+	{entities.ErrInvalidCredentials, http.StatusUnauthorized, codes.Unauthenticated, "invalid_credentials"},
+	{authn.ErrUnsupportedScheme, http.StatusUnauthorized, codes.Unauthenticated, "unsupported_auth_scheme"},
+	{entities.ErrInsufficientScope, http.StatusForbidden, codes.PermissionDenied, "insufficient_scope"},
+	{entities.ErrPermissionDenied, http.StatusForbidden, codes.PermissionDenied, "permission_denied"},
+	{entities.ErrInvalidRole, http.StatusBadRequest, codes.InvalidArgument, "invalid_role"},
+	{entities.ErrInvalidCursor, http.StatusBadRequest, codes.InvalidArgument, "invalid_cursor"},
+	{usecases.ErrInvalidSyncToken, http.StatusBadRequest, codes.InvalidArgument, "invalid_sync_token"},
+	{usecases.ErrInvalidEventID, http.StatusBadRequest, codes.InvalidArgument, "invalid_event_id"},
+	{entities.ErrTodoVersionMismatch, http.StatusPreconditionFailed, codes.Aborted, "version_mismatch"},
+	{entities.ErrIdempotencyKeyMismatch, http.StatusUnprocessableEntity, codes.FailedPrecondition, "idempotency_key_reused"},
+}
+
+// Class is how every API reports one error.
+type Class struct {
+	HTTPStatus int
+	GRPCCode   codes.Code
+	// Code is stable and meant for programs, e.g. "todo_not_found".
+	Code string
+	// Detail explains the error to the client. It is empty for internal
+	// errors, whose cause must only be logged.
+	Detail     string
+	Violations []entities.FieldViolation
+}
+
+// Internal reports whether the error is the server's fault, in which case
+// it should be logged.
+func (c Class) Internal() bool {
+	return c.HTTPStatus >= http.StatusInternalServerError
+}
+
+// GRPCStatus reports the error over gRPC with its code in an ErrorInfo and
+// any violations in a BadRequest detail. Internal errors carry only message.
+func (c Class) GRPCStatus(message string) *status.Status {
+	if c.Detail != "" {
+		message += ": " + c.Detail
+	}
+	st := status.New(c.GRPCCode, message)
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: c.Code, Domain: Domain}}
+	if len(c.Violations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, violation := range c.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Message,
+			})
+		}
+		details = append(details, badRequest)
+	}
+
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+// Classify decides how err is reported. The detail comes from the domain
+// error itself rather than err.Error(), so context added by wrapping it
+// never reaches clients.
+func Classify(err error) Class {
+	for _, m := range mappings {
+		if errors.Is(err, m.err) {
+			return Class{HTTPStatus: m.http, GRPCCode: m.grpc, Code: m.code, Detail: m.err.Error()}
+		}
+	}
+
+	var (
+		quotaErr       *entities.QuotaExceededError
+		fileErr        *entities.FileValidationError
+		validationErr  *entities.ValidationError
+		notFoundErr    *entities.NotFoundError
+		conflictErr    *entities.ConflictError
+		unavailableErr *entities.UnavailableError
+	)
+	switch {
+	case errors.As(err, &quotaErr):
+		// 413 when the file is larger than the whole quota, 507 when it
+		// would fit once other files are deleted.
+		status := http.StatusInsufficientStorage
+		if quotaErr.TooLarge() {
+			status = http.StatusRequestEntityTooLarge
+		}
+		return Class{HTTPStatus: status, GRPCCode: codes.ResourceExhausted, Code: CodeQuotaExceeded, Detail: quotaErr.Error()}
+
+	case errors.Is(err, entities.ErrStorageQuotaExceeded):
+		return Class{HTTPStatus: http.StatusInsufficientStorage, GRPCCode: codes.ResourceExhausted, Code: CodeQuotaExceeded, Detail: entities.ErrStorageQuotaExceeded.Error()}
+
+	case errors.As(err, &fileErr):
+		return Class{
+			HTTPStatus: http.StatusBadRequest,
+			GRPCCode:   codes.InvalidArgument,
+			Code:       CodeInvalidFile,
+			Detail:     fileErr.Message,
+			Violations: []entities.FieldViolation{{Field: "file", Code: fileErr.Reason, Message: fileErr.Message}},
+		}
+
+	case errors.As(err, &validationErr):
+		return Class{
+			HTTPStatus: http.StatusBadRequest,
+			GRPCCode:   codes.InvalidArgument,
+			Code:       CodeValidationFailed,
+			Detail:     validationErr.Error(),
+			Violations: validationErr.Violations,
+		}
+
+	case errors.As(err, &notFoundErr):
+		return Class{HTTPStatus: http.StatusNotFound, GRPCCode: codes.NotFound, Code: notFoundErr.Code(), Detail: notFoundErr.Error()}
+
+	case errors.As(err, &conflictErr):
+		return Class{HTTPStatus: http.StatusConflict, GRPCCode: codes.Aborted, Code: conflictErr.Code, Detail: conflictErr.Error()}
+
+	case errors.As(err, &unavailableErr):
+		return Class{HTTPStatus: http.StatusServiceUnavailable, GRPCCode: codes.Unavailable, Code: CodeUnavailable}
+	}
+
+	return Class{HTTPStatus: http.StatusInternalServerError, GRPCCode: codes.Internal, Code: CodeInternal}
+}
+
+// HTTPStatus returns the status code err is reported with over HTTP.
+func HTTPStatus(err error) int {
+	return Classify(err).HTTPStatus
+}
+
+// GRPCCode returns the status code err is reported with over gRPC.
+func GRPCCode(err error) codes.Code {
+	return Classify(err).GRPCCode
+}
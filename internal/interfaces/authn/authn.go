@@ -0,0 +1,81 @@
+// Package authn turns the credentials a client sends into a principal, the
+// same way for every transport.
+package authn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+)
+
+const (
+	SchemeBearer = "Bearer"
+	SchemeAPIKey = "ApiKey"
+)
+
+var ErrUnsupportedScheme = errors.New("unsupported authorization scheme")
+
+type Authenticator struct {
+	verifiers  map[string]ports.TokenVerifier
+	challenges []string
+}
+
+// NewAuthenticator accepts credentials for each scheme in verifiers, e.g.
+// SchemeBearer and SchemeAPIKey.
+func NewAuthenticator(verifiers map[string]ports.TokenVerifier) *Authenticator {
+	challenges := make([]string, 0, len(verifiers))
+	for scheme := range verifiers {
+		challenges = append(challenges, scheme)
+	}
+	sort.Strings(challenges)
+
+	return &Authenticator{
+		verifiers:  verifiers,
+		challenges: challenges,
+	}
+}
+
+// Challenges lists the accepted schemes, sorted.
+func (a *Authenticator) Challenges() []string {
+	return a.challenges
+}
+
+// Authenticate verifies credentials presented with scheme. It returns
+// entities.ErrUnauthenticated when there are none, ErrUnsupportedScheme for
+// an unknown scheme and the verifier's error when they are rejected.
+func (a *Authenticator) Authenticate(ctx context.Context, scheme, credentials string) (*entities.Principal, error) {
+	if credentials == "" {
+		return nil, entities.ErrUnauthenticated
+	}
+
+	for name, verifier := range a.verifiers {
+		if strings.EqualFold(name, scheme) {
+			return verifier.Verify(ctx, credentials)
+		}
+	}
+	return nil, fmt.Errorf("%w: use one of: %s", ErrUnsupportedScheme, strings.Join(a.challenges, ", "))
+}
+
+// ParseAuthorization splits an Authorization header into its scheme and
+// credentials.
+func ParseAuthorization(header string) (string, string) {
+	scheme, credentials, found := strings.Cut(strings.TrimSpace(header), " ")
+	if !found {
+		return "", ""
+	}
+	return scheme, strings.TrimSpace(credentials)
+}
+
+// RequireScope fails with entities.ErrInsufficientScope unless principal
+// holds scope.
+func RequireScope(principal *entities.Principal, scope string) error {
+	if !principal.HasScope(scope) {
+		return fmt.Errorf("%w: this operation requires the %s scope", entities.ErrInsufficientScope, scope)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package resolvers
+
+import (
+	"context"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/interfaces/apierr"
+	"todo-service/internal/logging"
+)
+
+const (
+	CodeBadUserInput        = "BAD_USER_INPUT"
+	CodeUnauthenticated     = "UNAUTHENTICATED"
+	CodeForbidden           = "FORBIDDEN"
+	CodeNotFound            = "NOT_FOUND"
+	CodeConflict            = "CONFLICT"
+	CodeVersionRequired     = "VERSION_REQUIRED"
+	CodeVersionMismatch     = "VERSION_MISMATCH"
+	CodeQuotaExceeded       = "QUOTA_EXCEEDED"
+	CodeUnavailable         = "SERVICE_UNAVAILABLE"
+	CodeInternalServerError = "INTERNAL_SERVER_ERROR"
+)
+
+// codes follows the status the REST API answers the same error with.
+var codes = map[int]string{
+	http.StatusBadRequest:            CodeBadUserInput,
+	http.StatusUnauthorized:          CodeUnauthenticated,
+	http.StatusForbidden:             CodeForbidden,
+	http.StatusNotFound:              CodeNotFound,
+	http.StatusConflict:              CodeConflict,
+	http.StatusPreconditionFailed:    CodeVersionMismatch,
+	http.StatusRequestEntityTooLarge: CodeQuotaExceeded,
+	http.StatusUnprocessableEntity:   CodeConflict,
+	http.StatusInsufficientStorage:   CodeQuotaExceeded,
+	http.StatusServiceUnavailable:    CodeUnavailable,
+}
+
+// Error is a resolver error reported with extensions.code, so clients can
+// tell causes apart without parsing messages. Reason is the stable error
+// code the REST and gRPC APIs report for the same cause.
+type Error struct {
+	Message    string
+	Code       string
+	Reason     string
+	Violations []entities.FieldViolation
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{"code": e.Code}
+	if e.Reason != "" {
+		extensions["reason"] = e.Reason
+	}
+	if len(e.Violations) > 0 {
+		extensions["fields"] = e.Violations
+	}
+	return extensions
+}
+
+func badInput(message string) error {
+	return &Error{Message: message, Code: CodeBadUserInput}
+}
+
+// fail reports err the way the REST API would. The cause of internal
+// errors is logged rather than sent to the client.
+func fail(ctx context.Context, message string, err error) error {
+	class := apierr.Classify(err)
+	if class.Internal() {
+		logging.FromContext(ctx).Error(message, zap.Error(err))
+	}
+
+	code, ok := codes[class.HTTPStatus]
+	if !ok {
+		code = CodeInternalServerError
+	}
+	if class.Detail != "" {
+		message += ": " + class.Detail
+	}
+	return &Error{Message: message, Code: code, Reason: class.Code, Violations: class.Violations}
+}
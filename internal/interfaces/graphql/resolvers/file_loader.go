@@ -0,0 +1,84 @@
+package resolvers
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/usecases"
+)
+
+// fileLoader batches attachment lookups. Resolvers that produce several
+// todos queue their file IDs up front, and the first Load fetches every
+// queued file in one call; later loads are served from the cache. Todo
+// fields resolve concurrently, so all access goes through mu.
+type fileLoader struct {
+	fileUseCase *usecases.FileUseCase
+
+	mu      sync.Mutex
+	queued  map[uuid.UUID]struct{}
+	files   map[uuid.UUID]*entities.File
+	fetched map[uuid.UUID]bool
+}
+
+func newFileLoader(fileUseCase *usecases.FileUseCase) *fileLoader {
+	return &fileLoader{
+		fileUseCase: fileUseCase,
+		queued:      make(map[uuid.UUID]struct{}),
+		files:       make(map[uuid.UUID]*entities.File),
+		fetched:     make(map[uuid.UUID]bool),
+	}
+}
+
+// queue records the attachments of todos so they are fetched together.
+func (l *fileLoader) queue(todos []*entities.TodoItem) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, todo := range todos {
+		if id, ok := attachmentID(todo); ok && !l.fetched[id] {
+			l.queued[id] = struct{}{}
+		}
+	}
+}
+
+// Load returns the file with the given ID, or nil when there is none.
+func (l *fileLoader) Load(ctx context.Context, id uuid.UUID) (*entities.File, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.fetched[id] {
+		return l.files[id], nil
+	}
+
+	l.queued[id] = struct{}{}
+	ids := make([]uuid.UUID, 0, len(l.queued))
+	for queued := range l.queued {
+		ids = append(ids, queued)
+	}
+
+	files, err := l.fileUseCase.GetFiles(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, queued := range ids {
+		l.files[queued] = files[queued]
+		l.fetched[queued] = true
+	}
+	l.queued = make(map[uuid.UUID]struct{})
+
+	return l.files[id], nil
+}
+
+// attachmentID parses the todo's file ID. Todos may reference files by IDs
+// that are not UUIDs, which cannot match any file.
+func attachmentID(todo *entities.TodoItem) (uuid.UUID, bool) {
+	if todo.FileID == nil {
+		return uuid.Nil, false
+	}
+	id, err := uuid.Parse(*todo.FileID)
+	return id, err == nil
+}
@@ -0,0 +1,344 @@
+package resolvers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/graph-gophers/graphql-go"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/interfaces/authn"
+	"todo-service/internal/usecases"
+)
+
+type Options struct {
+	// RequireExpectedVersion rejects mutations of existing todos without an
+	// expectedVersion, like REQUIRE_IF_MATCH does for the REST API.
+	RequireExpectedVersion bool
+}
+
+// Resolver is the root of the schema. Each operation checks the scope it
+// needs itself, since a single request can both read and write.
+type Resolver struct {
+	todoUseCase *usecases.TodoUseCase
+	fileUseCase *usecases.FileUseCase
+	eventFeed   *usecases.EventFeedUseCase
+	options     Options
+}
+
+type todoFilterInput struct {
+	Status        *string
+	DueAfter      *graphql.Time
+	DueBefore     *graphql.Time
+	Search        *string
+	HasAttachment *bool
+}
+
+type todoInput struct {
+	Description string
+	DueDate     graphql.Time
+	FileID      *graphql.ID
+}
+
+func (r *Resolver) Todo(ctx context.Context, args struct{ ID graphql.ID }) (*todoResolver, error) {
+	if err := authorize(ctx, entities.ScopeTodosRead); err != nil {
+		return nil, err
+	}
+
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	todo, err := r.todoUseCase.GetTodo(ctx, id)
+	if errors.Is(err, entities.ErrTodoNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fail(ctx, "Failed to get todo", err)
+	}
+
+	return &todoResolver{todo: todo, files: r.files(ctx)}, nil
+}
+
+func (r *Resolver) Todos(ctx context.Context, args struct {
+	First  *int32
+	After  *string
+	Filter *todoFilterInput
+}) (*todoConnectionResolver, error) {
+	if err := authorize(ctx, entities.ScopeTodosRead); err != nil {
+		return nil, err
+	}
+
+	limit := 0
+	if args.First != nil {
+		if *args.First < 1 {
+			return nil, badInput("first must be at least 1")
+		}
+		limit = int(*args.First)
+	}
+
+	var after *entities.TodoCursor
+	if args.After != nil {
+		cursor, err := entities.DecodeTodoCursor(*args.After)
+		if err != nil {
+			return nil, fail(ctx, "Invalid after", err)
+		}
+		after = &cursor
+	}
+
+	page, err := r.todoUseCase.SearchTodos(ctx, toTodoFilter(args.Filter), after, limit)
+	if err != nil {
+		return nil, fail(ctx, "Failed to list todos", err)
+	}
+
+	return newTodoConnection(page, r.files(ctx)), nil
+}
+
+func (r *Resolver) TodoCounts(ctx context.Context) (*todoCountsResolver, error) {
+	if err := authorize(ctx, entities.ScopeTodosRead); err != nil {
+		return nil, err
+	}
+
+	counts, err := r.todoUseCase.CountTodos(ctx)
+	if err != nil {
+		return nil, fail(ctx, "Failed to count todos", err)
+	}
+
+	return &todoCountsResolver{counts: counts}, nil
+}
+
+func (r *Resolver) CreateTodo(ctx context.Context, args struct{ Input todoInput }) (*todoResolver, error) {
+	if err := authorize(ctx, entities.ScopeTodosWrite); err != nil {
+		return nil, err
+	}
+	if args.Input.Description == "" {
+		return nil, badInput("description is required")
+	}
+
+	todo, err := r.todoUseCase.CreateTodo(ctx, usecases.CreateTodoRequest{
+		Description: args.Input.Description,
+		DueDate:     args.Input.DueDate.Time,
+		FileID:      fileID(args.Input.FileID),
+	})
+	if err != nil {
+		return nil, fail(ctx, "Failed to create todo", err)
+	}
+
+	return &todoResolver{todo: todo, files: r.files(ctx)}, nil
+}
+
+func (r *Resolver) UpdateTodo(ctx context.Context, args struct {
+	ID              graphql.ID
+	Input           todoInput
+	ExpectedVersion *int32
+}) (*todoResolver, error) {
+	if err := authorize(ctx, entities.ScopeTodosWrite); err != nil {
+		return nil, err
+	}
+
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	if args.Input.Description == "" {
+		return nil, badInput("description is required")
+	}
+	expectedVersion, err := r.expectedVersion(args.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	todo, err := r.todoUseCase.UpdateTodo(ctx, id, usecases.UpdateTodoRequest{
+		Description:     args.Input.Description,
+		DueDate:         args.Input.DueDate.Time,
+		FileID:          fileID(args.Input.FileID),
+		ExpectedVersion: expectedVersion,
+	})
+	if err != nil {
+		return nil, fail(ctx, "Failed to update todo", err)
+	}
+
+	return &todoResolver{todo: todo, files: r.files(ctx)}, nil
+}
+
+type versionedIDArgs struct {
+	ID              graphql.ID
+	ExpectedVersion *int32
+}
+
+func (r *Resolver) CompleteTodo(ctx context.Context, args versionedIDArgs) (*todoResolver, error) {
+	return r.setCompleted(ctx, args, true)
+}
+
+func (r *Resolver) ReopenTodo(ctx context.Context, args versionedIDArgs) (*todoResolver, error) {
+	return r.setCompleted(ctx, args, false)
+}
+
+func (r *Resolver) setCompleted(ctx context.Context, args versionedIDArgs, completed bool) (*todoResolver, error) {
+	if err := authorize(ctx, entities.ScopeTodosWrite); err != nil {
+		return nil, err
+	}
+
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	expectedVersion, err := r.expectedVersion(args.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	todo, err := r.todoUseCase.SetCompleted(ctx, id, completed, expectedVersion)
+	if err != nil {
+		return nil, fail(ctx, "Failed to set todo completion", err)
+	}
+
+	return &todoResolver{todo: todo, files: r.files(ctx)}, nil
+}
+
+func (r *Resolver) DeleteTodo(ctx context.Context, args versionedIDArgs) (graphql.ID, error) {
+	if err := authorize(ctx, entities.ScopeTodosWrite); err != nil {
+		return "", err
+	}
+
+	id, err := parseID(args.ID)
+	if err != nil {
+		return "", err
+	}
+	expectedVersion, err := r.expectedVersion(args.ExpectedVersion)
+	if err != nil {
+		return "", err
+	}
+
+	if err := r.todoUseCase.DeleteTodo(ctx, id, expectedVersion); err != nil {
+		return "", fail(ctx, "Failed to delete todo", err)
+	}
+
+	return args.ID, nil
+}
+
+// TodoEvents resolves each event's todo from the event itself rather than
+// reloading it, so subscribers see the todo as of the change.
+func (r *Resolver) TodoEvents(ctx context.Context, args struct {
+	Types  *[]string
+	TodoID *graphql.ID
+}) (<-chan *todoEventResolver, error) {
+	if err := authorize(ctx, entities.ScopeTodosRead); err != nil {
+		return nil, err
+	}
+	if r.eventFeed == nil {
+		return nil, &Error{Message: "Live events are not available", Code: CodeUnavailable}
+	}
+
+	var filter usecases.EventFilter
+	if args.Types != nil {
+		filter.Types = *args.Types
+	}
+	if args.TodoID != nil {
+		filter.TodoID = string(*args.TodoID)
+	}
+
+	events, err := r.eventFeed.Subscribe(ctx, "", filter)
+	if err != nil {
+		return nil, fail(ctx, "Failed to subscribe to events", err)
+	}
+
+	resolvers := make(chan *todoEventResolver)
+	go func() {
+		defer close(resolvers)
+
+		for event := range events {
+			resolver := &todoEventResolver{event: event}
+			switch event.Type {
+			case entities.EventTypeTodoCreated, entities.EventTypeTodoUpdated, entities.EventTypeTodoDeleted:
+				var todo entities.TodoItem
+				if err := json.Unmarshal(event.Data, &todo); err == nil {
+					resolver.todo = &todoResolver{todo: &todo, files: newFileLoader(r.fileUseCase)}
+				}
+			}
+
+			select {
+			case resolvers <- resolver:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return resolvers, nil
+}
+
+func (r *Resolver) expectedVersion(version *int32) (*int, error) {
+	if version == nil {
+		if r.options.RequireExpectedVersion {
+			return nil, &Error{Message: "expectedVersion is required: fetch the todo and send its version", Code: CodeVersionRequired}
+		}
+		return nil, nil
+	}
+
+	expected := int(*version)
+	return &expected, nil
+}
+
+// files returns the request's file loader, which Schema attaches to ctx.
+func (r *Resolver) files(ctx context.Context) *fileLoader {
+	if loader, ok := ctx.Value(fileLoaderKey{}).(*fileLoader); ok {
+		return loader
+	}
+	return newFileLoader(r.fileUseCase)
+}
+
+// authorize checks the caller's scope. Todo.attachment checks files:read on
+// its own, so a todos:read key sees the todo but not its file.
+func authorize(ctx context.Context, scope string) error {
+	principal, ok := entities.PrincipalFromContext(ctx)
+	if !ok {
+		return fail(ctx, "Authentication required", entities.ErrUnauthenticated)
+	}
+	if err := authn.RequireScope(principal, scope); err != nil {
+		return fail(ctx, "Insufficient scope", err)
+	}
+	return nil
+}
+
+func toTodoFilter(input *todoFilterInput) entities.TodoFilter {
+	var filter entities.TodoFilter
+	if input == nil {
+		return filter
+	}
+
+	if input.Status != nil {
+		filter.Status = strings.ToLower(*input.Status)
+	}
+	if input.DueAfter != nil {
+		filter.DueAfter = &input.DueAfter.Time
+	}
+	if input.DueBefore != nil {
+		filter.DueBefore = &input.DueBefore.Time
+	}
+	if input.Search != nil {
+		filter.Search = *input.Search
+	}
+	filter.HasAttachment = input.HasAttachment
+	return filter
+}
+
+func fileID(id *graphql.ID) *string {
+	if id == nil {
+		return nil
+	}
+	value := string(*id)
+	return &value
+}
+
+func parseID(id graphql.ID) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(string(id))
+	if err != nil {
+		return uuid.Nil, badInput("invalid id: " + err.Error())
+	}
+	return parsed, nil
+}
@@ -0,0 +1,81 @@
+// Package resolvers serves the GraphQL API used by the web dashboard on top
+// of the same use cases as the REST and gRPC APIs.
+package resolvers
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/graph-gophers/graphql-go"
+	"go.uber.org/zap"
+
+	"todo-service/internal/logging"
+	"todo-service/internal/usecases"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+const (
+	maxDepth       = 10
+	maxParallelism = 20
+)
+
+type fileLoaderKey struct{}
+
+// Request is a GraphQL request as sent over HTTP.
+type Request struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type Schema struct {
+	schema      *graphql.Schema
+	fileUseCase *usecases.FileUseCase
+}
+
+// NewSchema builds the schema. eventFeed may be nil, in which case
+// subscriptions fail as unavailable.
+func NewSchema(todoUseCase *usecases.TodoUseCase, fileUseCase *usecases.FileUseCase, eventFeed *usecases.EventFeedUseCase, options Options) (*Schema, error) {
+	schema, err := graphql.ParseSchema(schemaSDL, &Resolver{
+		todoUseCase: todoUseCase,
+		fileUseCase: fileUseCase,
+		eventFeed:   eventFeed,
+		options:     options,
+	},
+		graphql.MaxDepth(maxDepth),
+		graphql.MaxParallelism(maxParallelism),
+		graphql.Logger(panicLogger{}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GraphQL schema: %w", err)
+	}
+
+	return &Schema{schema: schema, fileUseCase: fileUseCase}, nil
+}
+
+// Exec runs a query or mutation. Attachments are loaded once for the whole
+// request, however many todos it returns.
+func (s *Schema) Exec(ctx context.Context, req Request) *graphql.Response {
+	return s.schema.Exec(s.withFileLoader(ctx), req.Query, req.OperationName, req.Variables)
+}
+
+// Subscribe runs any operation and delivers its *graphql.Response values
+// until ctx is cancelled; queries and mutations produce a single response.
+func (s *Schema) Subscribe(ctx context.Context, req Request) (<-chan interface{}, error) {
+	return s.schema.Subscribe(s.withFileLoader(ctx), req.Query, req.OperationName, req.Variables)
+}
+
+func (s *Schema) withFileLoader(ctx context.Context) context.Context {
+	return context.WithValue(ctx, fileLoaderKey{}, newFileLoader(s.fileUseCase))
+}
+
+// panicLogger reports panics recovered by the executor, which answers the
+// affected field with an error instead of crashing.
+type panicLogger struct{}
+
+func (panicLogger) LogPanic(ctx context.Context, value interface{}) {
+	logging.FromContext(ctx).Error("Panic while resolving GraphQL field", zap.Any("panic", value), zap.Stack("stack"))
+}
@@ -0,0 +1,195 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/usecases"
+)
+
+type todoResolver struct {
+	todo  *entities.TodoItem
+	files *fileLoader
+}
+
+func (r *todoResolver) ID() graphql.ID {
+	return graphql.ID(r.todo.ID.String())
+}
+
+func (r *todoResolver) OwnerID() string {
+	return r.todo.OwnerID
+}
+
+func (r *todoResolver) Description() string {
+	return r.todo.Description
+}
+
+func (r *todoResolver) DueDate() graphql.Time {
+	return graphql.Time{Time: r.todo.DueDate}
+}
+
+func (r *todoResolver) Completed() bool {
+	return r.todo.CompletedAt != nil
+}
+
+func (r *todoResolver) CompletedAt() *graphql.Time {
+	if r.todo.CompletedAt == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *r.todo.CompletedAt}
+}
+
+func (r *todoResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.todo.CreatedAt}
+}
+
+func (r *todoResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: r.todo.UpdatedAt}
+}
+
+func (r *todoResolver) Version() int32 {
+	return int32(r.todo.Version)
+}
+
+func (r *todoResolver) Attachment(ctx context.Context) (*fileResolver, error) {
+	id, ok := attachmentID(r.todo)
+	if !ok {
+		return nil, nil
+	}
+	if err := authorize(ctx, entities.ScopeFilesRead); err != nil {
+		return nil, err
+	}
+
+	file, err := r.files.Load(ctx, id)
+	if err != nil {
+		return nil, fail(ctx, "Failed to load attachment", err)
+	}
+	if file == nil {
+		return nil, nil
+	}
+	return &fileResolver{file: file}, nil
+}
+
+type fileResolver struct {
+	file *entities.File
+}
+
+func (r *fileResolver) ID() graphql.ID {
+	return graphql.ID(r.file.ID.String())
+}
+
+func (r *fileResolver) FileName() string {
+	return r.file.FileName
+}
+
+func (r *fileResolver) ContentType() string {
+	return r.file.ContentType
+}
+
+func (r *fileResolver) Size() int32 {
+	return int32(r.file.Size)
+}
+
+func (r *fileResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.file.CreatedAt}
+}
+
+type todoConnectionResolver struct {
+	page  *usecases.TodoPage
+	files *fileLoader
+}
+
+func newTodoConnection(page *usecases.TodoPage, files *fileLoader) *todoConnectionResolver {
+	files.queue(page.Todos)
+	return &todoConnectionResolver{page: page, files: files}
+}
+
+func (r *todoConnectionResolver) Edges() []*todoEdgeResolver {
+	edges := make([]*todoEdgeResolver, 0, len(r.page.Todos))
+	for _, todo := range r.page.Todos {
+		edges = append(edges, &todoEdgeResolver{todo: &todoResolver{todo: todo, files: r.files}})
+	}
+	return edges
+}
+
+func (r *todoConnectionResolver) PageInfo() *pageInfoResolver {
+	info := &pageInfoResolver{hasNextPage: r.page.HasNextPage}
+	if len(r.page.Todos) > 0 {
+		cursor := entities.NewTodoCursor(r.page.Todos[len(r.page.Todos)-1]).Encode()
+		info.endCursor = &cursor
+	}
+	return info
+}
+
+type todoEdgeResolver struct {
+	todo *todoResolver
+}
+
+func (r *todoEdgeResolver) Cursor() string {
+	return entities.NewTodoCursor(r.todo.todo).Encode()
+}
+
+func (r *todoEdgeResolver) Node() *todoResolver {
+	return r.todo
+}
+
+type pageInfoResolver struct {
+	hasNextPage bool
+	endCursor   *string
+}
+
+func (r *pageInfoResolver) HasNextPage() bool {
+	return r.hasNextPage
+}
+
+func (r *pageInfoResolver) EndCursor() *string {
+	return r.endCursor
+}
+
+type todoCountsResolver struct {
+	counts *entities.TodoCounts
+}
+
+func (r *todoCountsResolver) Total() int32 {
+	return int32(r.counts.Total)
+}
+
+func (r *todoCountsResolver) Open() int32 {
+	return int32(r.counts.Open)
+}
+
+func (r *todoCountsResolver) Completed() int32 {
+	return int32(r.counts.Completed)
+}
+
+func (r *todoCountsResolver) Overdue() int32 {
+	return int32(r.counts.Overdue)
+}
+
+type todoEventResolver struct {
+	event *entities.Event
+	todo  *todoResolver
+}
+
+func (r *todoEventResolver) ID() graphql.ID {
+	return graphql.ID(r.event.ID)
+}
+
+func (r *todoEventResolver) Type() string {
+	return r.event.Type
+}
+
+func (r *todoEventResolver) TodoID() graphql.ID {
+	return graphql.ID(r.event.TodoID)
+}
+
+func (r *todoEventResolver) Timestamp() graphql.Time {
+	return graphql.Time{Time: time.Unix(r.event.Timestamp, 0)}
+}
+
+func (r *todoEventResolver) Todo() *todoResolver {
+	return r.todo
+}
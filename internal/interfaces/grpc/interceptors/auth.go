@@ -0,0 +1,99 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/interfaces/apierr"
+	"todo-service/internal/interfaces/authn"
+	"todo-service/internal/logging"
+)
+
+type AuthOptions struct {
+	// Scopes maps full method names to the scope a call needs. Methods
+	// that are neither listed here nor public are refused.
+	Scopes map[string]string
+	// Public lists services, e.g. "grpc.health.v1.Health", whose methods
+	// need no credentials. Every other method does.
+	Public []string
+}
+
+// UnaryAuth is the unary form of StreamAuth.
+func UnaryAuth(authenticator *authn.Authenticator, options AuthOptions) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, authenticator, options, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuth verifies the credentials in the authorization metadata key the
+// same way the HTTP API verifies the Authorization header, and stores the
+// principal on the context, where the use cases pick it up.
+func StreamAuth(authenticator *authn.Authenticator, options AuthOptions) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(stream.Context(), authenticator, options, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+func authenticate(ctx context.Context, authenticator *authn.Authenticator, options AuthOptions, method string) (context.Context, error) {
+	if isPublic(options.Public, method) {
+		return ctx, nil
+	}
+
+	scheme, credentials := authn.ParseAuthorization(strings.Join(metadata.ValueFromIncomingContext(ctx, "authorization"), ""))
+	principal, err := authenticator.Authenticate(ctx, scheme, credentials)
+	switch {
+	case errors.Is(err, entities.ErrUnauthenticated):
+		return nil, status.Error(codes.Unauthenticated, "Authentication required")
+	case err != nil:
+		class := apierr.Classify(err)
+		if class.Internal() {
+			logging.FromContext(ctx).Error("Failed to authenticate", zap.Error(err))
+		}
+		return nil, class.GRPCStatus("Failed to authenticate").Err()
+	}
+
+	scope, ok := options.Scopes[method]
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "Method is not available")
+	}
+	if err := authn.RequireScope(principal, scope); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	return entities.ContextWithPrincipal(ctx, principal), nil
+}
+
+func isPublic(services []string, method string) bool {
+	for _, service := range services {
+		if strings.HasPrefix(method, "/"+service+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// contextStream replaces the context of a server stream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,64 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+	"todo-service/internal/interfaces/authn"
+)
+
+type verifierFunc func(ctx context.Context, token string) (*entities.Principal, error)
+
+func (f verifierFunc) Verify(ctx context.Context, token string) (*entities.Principal, error) {
+	return f(ctx, token)
+}
+
+func TestUnaryAuth(t *testing.T) {
+	verifier := verifierFunc(func(ctx context.Context, token string) (*entities.Principal, error) {
+		if token != "reader" {
+			return nil, entities.ErrInvalidCredentials
+		}
+		return &entities.Principal{ID: "user-1", TenantID: "acme", Method: entities.AuthMethodAPIKey, Scopes: []string{entities.ScopeTodosRead}}, nil
+	})
+	interceptor := UnaryAuth(authn.NewAuthenticator(map[string]ports.TokenVerifier{authn.SchemeBearer: verifier}), AuthOptions{
+		Scopes: map[string]string{
+			"/todo.v1.TodoService/GetTodo":    entities.ScopeTodosRead,
+			"/todo.v1.TodoService/CreateTodo": entities.ScopeTodosWrite,
+		},
+		Public: []string{"grpc.health.v1.Health"},
+	})
+	reader := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer reader"))
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		method   string
+		wantCode codes.Code
+	}{
+		{name: "public", ctx: context.Background(), method: "/grpc.health.v1.Health/Check", wantCode: codes.OK},
+		{name: "no credentials", ctx: context.Background(), method: "/todo.v1.TodoService/GetTodo", wantCode: codes.Unauthenticated},
+		{name: "scope held", ctx: reader, method: "/todo.v1.TodoService/GetTodo", wantCode: codes.OK},
+		{name: "scope missing", ctx: reader, method: "/todo.v1.TodoService/CreateTodo", wantCode: codes.PermissionDenied},
+		{name: "unmapped method", ctx: reader, method: "/todo.v1.TodoService/PurgeTodos", wantCode: codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called bool
+			_, err := interceptor(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return nil, nil
+			})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantCode == codes.OK, called)
+		})
+	}
+}
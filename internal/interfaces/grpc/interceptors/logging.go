@@ -0,0 +1,78 @@
+package interceptors
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"todo-service/internal/logging"
+)
+
+const RequestIDKey = "x-request-id"
+
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// UnaryLogging is the unary form of StreamLogging.
+func UnaryLogging(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		ctx = withRequestLogger(ctx, logger)
+
+		resp, err := handler(ctx, req)
+		logCall(ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamLogging propagates the caller's x-request-id or generates one,
+// returns it in the response header, stores a logger tagged with it on the
+// context and logs every call once it finishes. It must run first.
+func StreamLogging(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx := withRequestLogger(stream.Context(), logger)
+
+		err := handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+		logCall(ctx, info.FullMethod, start, err)
+		return err
+	}
+}
+
+func withRequestLogger(ctx context.Context, logger *zap.Logger) context.Context {
+	var id string
+	if values := metadata.ValueFromIncomingContext(ctx, RequestIDKey); len(values) > 0 {
+		id = values[0]
+	}
+	if !requestIDPattern.MatchString(id) {
+		id = uuid.NewString()
+	}
+
+	grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, id))
+	return logging.WithLogger(ctx, logger.With(zap.String("request_id", id)))
+}
+
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+
+	level := zapcore.InfoLevel
+	switch code {
+	case codes.OK:
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		level = zapcore.ErrorLevel
+	default:
+		level = zapcore.WarnLevel
+	}
+
+	logging.FromContext(ctx).Log(level, "gRPC call",
+		zap.String("method", method),
+		zap.String("code", code.String()),
+		zap.Duration("duration", time.Since(start)))
+}
@@ -0,0 +1,39 @@
+package interceptors
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"todo-service/internal/logging"
+)
+
+// UnaryRecovery is the unary form of StreamRecovery.
+func UnaryRecovery() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer recoverCall(ctx, &err)
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecovery turns a panicking handler into a logged INTERNAL error
+// instead of taking down the server.
+func StreamRecovery() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer recoverCall(stream.Context(), &err)
+		return handler(srv, stream)
+	}
+}
+
+func recoverCall(ctx context.Context, err *error) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	logging.FromContext(ctx).Error("Panic while handling call", zap.Any("panic", recovered), zap.Stack("stack"))
+	*err = status.Error(codes.Internal, "Internal server error")
+}
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/interfaces/apierr"
+	"todo-service/internal/logging"
+	"todo-service/internal/usecases"
+	todov1 "todo-service/pkg/api/todo/v1"
+)
+
+type TodoServiceOptions struct {
+	// RequireExpectedVersion rejects updates and deletes without an
+	// expected_version, like REQUIRE_IF_MATCH does for the REST API.
+	RequireExpectedVersion bool
+}
+
+type TodoService struct {
+	todov1.UnimplementedTodoServiceServer
+
+	todoUseCase *usecases.TodoUseCase
+	fileUseCase *usecases.FileUseCase
+	options     TodoServiceOptions
+}
+
+func NewTodoService(todoUseCase *usecases.TodoUseCase, fileUseCase *usecases.FileUseCase, options TodoServiceOptions) *TodoService {
+	return &TodoService{
+		todoUseCase: todoUseCase,
+		fileUseCase: fileUseCase,
+		options:     options,
+	}
+}
+
+func (s *TodoService) CreateTodo(ctx context.Context, req *todov1.CreateTodoRequest) (*todov1.CreateTodoResponse, error) {
+	if req.GetDescription() == "" {
+		return nil, status.Error(codes.InvalidArgument, "description is required")
+	}
+	if err := req.GetDueDate().CheckValid(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "due_date is required")
+	}
+
+	todo, err := s.todoUseCase.CreateTodo(ctx, usecases.CreateTodoRequest{
+		Description: req.GetDescription(),
+		DueDate:     req.GetDueDate().AsTime(),
+		FileID:      req.FileId,
+	})
+	if err != nil {
+		return nil, fail(ctx, "Failed to create todo", err)
+	}
+
+	return &todov1.CreateTodoResponse{Todo: toProto(todo)}, nil
+}
+
+func (s *TodoService) GetTodo(ctx context.Context, req *todov1.GetTodoRequest) (*todov1.GetTodoResponse, error) {
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	todo, err := s.todoUseCase.GetTodo(ctx, id)
+	if err != nil {
+		return nil, fail(ctx, "Failed to get todo", err)
+	}
+
+	return &todov1.GetTodoResponse{Todo: toProto(todo)}, nil
+}
+
+func (s *TodoService) ListTodos(ctx context.Context, req *todov1.ListTodosRequest) (*todov1.ListTodosResponse, error) {
+	todos, err := s.todoUseCase.ListTodos(ctx, int(req.GetLimit()), int(req.GetOffset()))
+	if err != nil {
+		return nil, fail(ctx, "Failed to list todos", err)
+	}
+
+	response := &todov1.ListTodosResponse{Todos: make([]*todov1.Todo, 0, len(todos))}
+	for _, todo := range todos {
+		response.Todos = append(response.Todos, toProto(todo))
+	}
+	return response, nil
+}
+
+func (s *TodoService) UpdateTodo(ctx context.Context, req *todov1.UpdateTodoRequest) (*todov1.UpdateTodoResponse, error) {
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetDescription() == "" {
+		return nil, status.Error(codes.InvalidArgument, "description is required")
+	}
+	if err := req.GetDueDate().CheckValid(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "due_date is required")
+	}
+
+	expectedVersion, err := s.expectedVersion(req.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	todo, err := s.todoUseCase.UpdateTodo(ctx, id, usecases.UpdateTodoRequest{
+		Description:     req.GetDescription(),
+		DueDate:         req.GetDueDate().AsTime(),
+		FileID:          req.FileId,
+		ExpectedVersion: expectedVersion,
+	})
+	if err != nil {
+		return nil, fail(ctx, "Failed to update todo", err)
+	}
+
+	return &todov1.UpdateTodoResponse{Todo: toProto(todo)}, nil
+}
+
+func (s *TodoService) DeleteTodo(ctx context.Context, req *todov1.DeleteTodoRequest) (*todov1.DeleteTodoResponse, error) {
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	expectedVersion, err := s.expectedVersion(req.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.todoUseCase.DeleteTodo(ctx, id, expectedVersion); err != nil {
+		return nil, fail(ctx, "Failed to delete todo", err)
+	}
+
+	return &todov1.DeleteTodoResponse{}, nil
+}
+
+func (s *TodoService) UploadFile(stream todov1.TodoService_UploadFileServer) error {
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return status.Error(codes.InvalidArgument, "the file info is required")
+	}
+	if err != nil {
+		return err
+	}
+	info := first.GetInfo()
+	if info == nil {
+		return status.Error(codes.InvalidArgument, "the first message must carry the file info")
+	}
+
+	data := &uploadReader{stream: stream, size: info.GetSize()}
+	response, err := s.fileUseCase.UploadFile(ctx, usecases.UploadFileRequest{
+		FileName:    info.GetFileName(),
+		ContentType: info.GetContentType(),
+		Data:        data,
+		Size:        info.GetSize(),
+	})
+	if data.err != nil {
+		return status.Error(codes.InvalidArgument, "Failed to upload file: "+data.err.Error())
+	}
+	if err != nil {
+		return fail(ctx, "Failed to upload file", err)
+	}
+
+	return stream.SendAndClose(&todov1.UploadFileResponse{FileId: response.FileID})
+}
+
+func (s *TodoService) expectedVersion(version *int32) (*int, error) {
+	if version == nil {
+		if s.options.RequireExpectedVersion {
+			return nil, status.Error(codes.FailedPrecondition, "expected_version is required: fetch the todo and send its version")
+		}
+		return nil, nil
+	}
+
+	expected := int(*version)
+	return &expected, nil
+}
+
+// uploadReader reads the chunks following an UploadFile stream's file info
+// and fails as soon as they stop adding up to the declared size.
+type uploadReader struct {
+	stream todov1.TodoService_UploadFileServer
+	size   int64
+	read   int64
+	chunk  []byte
+	// err is set when the client sent a malformed stream.
+	err error
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		message, err := r.stream.Recv()
+		if errors.Is(err, io.EOF) {
+			if r.read < r.size {
+				r.err = fmt.Errorf("received %d of the declared %d bytes", r.read, r.size)
+				return 0, r.err
+			}
+			return 0, io.EOF
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		chunk, ok := message.GetData().(*todov1.UploadFileRequest_Chunk)
+		if !ok {
+			r.err = errors.New("the file info may only be sent once")
+			return 0, r.err
+		}
+		r.read += int64(len(chunk.Chunk))
+		if r.read > r.size {
+			r.err = fmt.Errorf("received more than the declared %d bytes", r.size)
+			return 0, r.err
+		}
+		r.chunk = chunk.Chunk
+	}
+
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
+
+func parseID(value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "invalid id: "+err.Error())
+	}
+	return id, nil
+}
+
+// fail reports err the way the HTTP API would. The cause of internal errors
+// is logged rather than sent to the client.
+func fail(ctx context.Context, message string, err error) error {
+	class := apierr.Classify(err)
+	if class.Internal() {
+		logging.FromContext(ctx).Error(message, zap.Error(err))
+	}
+	return class.GRPCStatus(message).Err()
+}
+
+func toProto(todo *entities.TodoItem) *todov1.Todo {
+	message := &todov1.Todo{
+		Id:          todo.ID.String(),
+		TenantId:    todo.TenantID,
+		OwnerId:     todo.OwnerID,
+		Description: todo.Description,
+		DueDate:     timestamppb.New(todo.DueDate),
+		FileId:      todo.FileID,
+		CreatedAt:   timestamppb.New(todo.CreatedAt),
+		UpdatedAt:   timestamppb.New(todo.UpdatedAt),
+		Version:     int32(todo.Version),
+	}
+	if todo.CompletedAt != nil {
+		message.CompletedAt = timestamppb.New(*todo.CompletedAt)
+	}
+	return message
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/interfaces/http/problem"
+	"todo-service/internal/usecases"
+)
+
+type APIKeyHandler struct {
+	apiKeyUseCase *usecases.APIKeyUseCase
+}
+
+func NewAPIKeyHandler(apiKeyUseCase *usecases.APIKeyUseCase) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyUseCase: apiKeyUseCase,
+	}
+}
+
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var req usecases.CreateAPIKeyRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	key, err := h.apiKeyUseCase.CreateAPIKey(c.Request.Context(), req)
+	if err != nil {
+		problem.Respond(c, "Failed to create api key", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "API key created successfully; store the key now, it is not shown again",
+		"data":    key,
+	})
+}
+
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	keys, err := h.apiKeyUseCase.ListAPIKeys(c.Request.Context())
+	if err != nil {
+		problem.Respond(c, "Failed to list api keys", err)
+		return
+	}
+
+	if keys == nil {
+		keys = []*entities.APIKey{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": keys,
+	})
+}
+
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.apiKeyUseCase.RevokeAPIKey(c.Request.Context(), id); err != nil {
+		problem.Respond(c, "Failed to revoke api key", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
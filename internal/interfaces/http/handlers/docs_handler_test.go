@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSwaggerUIPage_PinsAssets(t *testing.T) {
+	assert.Regexp(t, `^\d+\.\d+\.\d+$`, swaggerUIVersion)
+
+	external := regexp.MustCompile(`<(?:script|link)[^>]*(?:src|href)="https://[^>]*>`).FindAllString(swaggerUIPage, -1)
+	assert.Len(t, external, 2)
+	for _, tag := range external {
+		assert.Contains(t, tag, "swagger-ui-dist@"+swaggerUIVersion+"/")
+		assert.Contains(t, tag, ` integrity="`)
+		assert.Contains(t, tag, ` crossorigin="anonymous"`)
+	}
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/interfaces/http/problem"
+)
+
+func init() {
+	// Report binding violations under the JSON names clients send.
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			return name
+		})
+	}
+}
+
+// bindJSON decodes the request body into req. A malformed body or missing
+// fields are reported as a validation problem listing every violation.
+func bindJSON(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		problem.Respond(c, "Invalid request body", bindingError(err))
+		return false
+	}
+	return true
+}
+
+func bindingError(err error) error {
+	var (
+		validationErrs validator.ValidationErrors
+		typeErr        *json.UnmarshalTypeError
+		timeErr        *time.ParseError
+	)
+	switch {
+	case errors.As(err, &validationErrs):
+		errs := &entities.ValidationError{}
+		for _, fieldErr := range validationErrs {
+			// Namespaces start with the request type, e.g.
+			// "CreateTodoRequest.description".
+			_, field, _ := strings.Cut(fieldErr.Namespace(), ".")
+			errs.Add(field, fieldErr.Tag(), validationMessage(fieldErr))
+		}
+		return errs
+
+	case errors.As(err, &typeErr):
+		return entities.NewValidationError(entities.FieldViolation{Field: typeErr.Field, Code: "invalid_type", Message: "must not be a JSON " + typeErr.Value})
+
+	case errors.As(err, &timeErr) && timeErr.LayoutElem == "Z07:00":
+		// A local time is ambiguous; the due date could be hours off.
+		return entities.NewValidationError(entities.FieldViolation{Field: "body", Code: "missing_timezone", Message: "timestamps must include a UTC offset or Z"})
+
+	case errors.As(err, &timeErr):
+		return entities.NewValidationError(entities.FieldViolation{Field: "body", Code: "invalid_time", Message: "timestamps must be RFC 3339"})
+
+	case errors.Is(err, io.EOF):
+		return entities.NewValidationError(entities.FieldViolation{Field: "body", Code: "required", Message: "is required"})
+	}
+
+	return entities.NewValidationError(entities.FieldViolation{Field: "body", Code: "invalid_json", Message: "must be valid JSON"})
+}
+
+func validationMessage(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "oneof":
+		return "must be one of: " + fieldErr.Param()
+	default:
+		return "is invalid"
+	}
+}
+
+// pathID parses the UUID in the "id" path parameter.
+func pathID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		problem.Invalid(c, entities.FieldViolation{Field: "id", Code: "invalid_uuid", Message: "must be a UUID"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
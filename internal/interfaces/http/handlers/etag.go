@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+func formatETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", formatETag(version))
+}
+
+// unmatchableVersion is a version no todo has; versions start at 1.
+const unmatchableVersion = 0
+
+// parseIfMatch returns the version a conditional write expects. A missing
+// header or "*" yields nil (no version check); present reports whether the
+// header was sent at all. Only one version may be supplied since a todo has
+// a single current representation. If-Match uses the strong comparison,
+// which a weak tag never passes, so weak tags expect unmatchableVersion.
+func parseIfMatch(header string) (version *int, present bool, ok bool) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return nil, false, true
+	}
+	if header == "*" {
+		return nil, true, true
+	}
+
+	tags := strings.Split(header, ",")
+	if len(tags) != 1 {
+		return nil, true, false
+	}
+
+	parsed, ok := parseETag(tags[0])
+	if !ok {
+		return nil, true, false
+	}
+	if strings.HasPrefix(strings.TrimSpace(tags[0]), "W/") {
+		parsed = unmatchableVersion
+	}
+	return &parsed, true, true
+}
+
+// etagMatches implements the weak comparison used by If-None-Match.
+func etagMatches(header string, version int) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+		if parsed, ok := parseETag(tag); ok && parsed == version {
+			return true
+		}
+	}
+	return false
+}
+
+func parseETag(tag string) (int, bool) {
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, false
+	}
+
+	version, err := strconv.Atoi(tag[1 : len(tag)-1])
+	if err != nil || version < 1 {
+		return 0, false
+	}
+	return version, true
+}
@@ -1,10 +1,14 @@
 package handlers
 
 import (
+	"errors"
+	"mime"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/interfaces/http/problem"
 	"todo-service/internal/usecases"
 )
 
@@ -21,10 +25,11 @@ func NewFileHandler(fileUseCase *usecases.FileUseCase) *FileHandler {
 func (h *FileHandler) UploadFile(c *gin.Context) {
 	file, header, err := c.Request.FormFile("file")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Failed to get file from request",
-			"details": err.Error(),
-		})
+		violation := entities.FieldViolation{Field: "file", Code: "required", Message: "is required"}
+		if !errors.Is(err, http.ErrMissingFile) {
+			violation = entities.FieldViolation{Field: "body", Code: "invalid_multipart", Message: "must be a valid multipart form"}
+		}
+		problem.Invalid(c, violation)
 		return
 	}
 	defer file.Close()
@@ -38,10 +43,7 @@ func (h *FileHandler) UploadFile(c *gin.Context) {
 
 	response, err := h.fileUseCase.UploadFile(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Failed to upload file",
-			"details": err.Error(),
-		})
+		problem.Respond(c, "Failed to upload file", err)
 		return
 	}
 
@@ -50,3 +52,52 @@ func (h *FileHandler) UploadFile(c *gin.Context) {
 		"data":    response,
 	})
 }
+
+func (h *FileHandler) DownloadFile(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	file, data, err := h.fileUseCase.DownloadFile(c.Request.Context(), id)
+	if err != nil {
+		problem.Respond(c, "Failed to download file", err)
+		return
+	}
+	defer data.Close()
+
+	contentType := file.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	c.DataFromReader(http.StatusOK, file.Size, contentType, data, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": file.FileName}),
+	})
+}
+
+func (h *FileHandler) DeleteFile(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.fileUseCase.DeleteFile(c.Request.Context(), id); err != nil {
+		problem.Respond(c, "Failed to delete file", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *FileHandler) GetUsage(c *gin.Context) {
+	usage, err := h.fileUseCase.GetUsage(c.Request.Context())
+	if err != nil {
+		problem.Respond(c, "Failed to get storage usage", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": usage,
+	})
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports/mocks"
+	"todo-service/internal/usecases"
+)
+
+type fileMocks struct {
+	storage  *mocks.MockFileStorage
+	fileRepo *mocks.MockFileRepository
+}
+
+func newFileRouter(t *testing.T) (*gin.Engine, *fileMocks) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	m := &fileMocks{
+		storage:  mocks.NewMockFileStorage(t),
+		fileRepo: mocks.NewMockFileRepository(t),
+	}
+	useCase := usecases.NewFileUseCase(m.storage, m.fileRepo, mocks.NewMockStorageUsageRepository(t), entities.StorageQuota{}, nil)
+	handler := NewFileHandler(useCase)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		principal := &entities.Principal{ID: testUserID, TenantID: testTenantID, Method: entities.AuthMethodJWT}
+		c.Request = c.Request.WithContext(entities.ContextWithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	})
+	router.GET("/files/:id", handler.DownloadFile)
+
+	return router, m
+}
+
+// closeTracker records whether the handler closed the download stream.
+type closeTracker struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closeTracker) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestDownloadFile_StreamsAttachment(t *testing.T) {
+	tests := []struct {
+		name        string
+		fileName    string
+		contentType string
+		wantType    string
+	}{
+		{name: "typed file", fileName: "notes.txt", contentType: "text/plain", wantType: "text/plain"},
+		{name: "untyped file", fileName: "blob.bin", wantType: "application/octet-stream"},
+		{name: "name needing quoting", fileName: `Q3 "final" report.pdf`, contentType: "application/pdf", wantType: "application/pdf"},
+		{name: "non-ASCII name", fileName: "Übersicht.txt", contentType: "text/plain", wantType: "text/plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, m := newFileRouter(t)
+			file := entities.NewFile(testTenantID, testUserID, tt.fileName, tt.contentType, 5)
+			data := &closeTracker{Reader: strings.NewReader("hello")}
+
+			m.fileRepo.EXPECT().GetByID(mock.Anything, testTenantID, testUserID, file.ID).Return(file, nil)
+			m.storage.EXPECT().DownloadFile(mock.Anything, file.StoragePath).Return(data, nil)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/files/"+file.ID.String(), nil))
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, "hello", rec.Body.String())
+			assert.Equal(t, tt.wantType, rec.Header().Get("Content-Type"))
+			assert.Equal(t, "5", rec.Header().Get("Content-Length"))
+			assert.True(t, data.closed)
+
+			disposition, params, err := mime.ParseMediaType(rec.Header().Get("Content-Disposition"))
+			require.NoError(t, err)
+			assert.Equal(t, "attachment", disposition)
+			assert.Equal(t, tt.fileName, params["filename"])
+		})
+	}
+}
+
+func TestDownloadFile_Errors(t *testing.T) {
+	router, m := newFileRouter(t)
+	id := uuid.New()
+
+	m.fileRepo.EXPECT().GetByID(mock.Anything, testTenantID, testUserID, id).Return(nil, entities.ErrFileNotFound)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/files/"+id.String(), nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/files/not-a-uuid", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
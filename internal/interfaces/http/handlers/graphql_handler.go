@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"todo-service/internal/interfaces/graphql/resolvers"
+
+	"todo-service/internal/interfaces/http/problem"
+)
+
+type GraphQLOptions struct {
+	// MaxStreams bounds the concurrent event-stream requests, which hold a
+	// subscription to the event stream for as long as they are open.
+	MaxStreams        int
+	HeartbeatInterval time.Duration
+}
+
+type GraphQLHandler struct {
+	schema  *resolvers.Schema
+	options GraphQLOptions
+	streams int64
+}
+
+func NewGraphQLHandler(schema *resolvers.Schema, options GraphQLOptions) *GraphQLHandler {
+	return &GraphQLHandler{schema: schema, options: options}
+}
+
+// Serve answers queries and mutations with a single JSON response. Clients
+// that accept text/event-stream get every response as a "next" event
+// followed by "complete", which is how subscriptions are delivered.
+func (h *GraphQLHandler) Serve(c *gin.Context) {
+	var req resolvers.Request
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if !strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+		c.JSON(http.StatusOK, h.schema.Exec(c.Request.Context(), req))
+		return
+	}
+
+	if atomic.AddInt64(&h.streams, 1) > int64(h.options.MaxStreams) {
+		atomic.AddInt64(&h.streams, -1)
+
+		c.Header("Retry-After", "5")
+		problem.Write(c, problem.New(http.StatusServiceUnavailable, "too_many_streams",
+			fmt.Sprintf("this instance accepts at most %d concurrent streams", h.options.MaxStreams)))
+		return
+	}
+	defer atomic.AddInt64(&h.streams, -1)
+
+	h.stream(c, req)
+}
+
+func (h *GraphQLHandler) stream(c *gin.Context, req resolvers.Request) {
+	responses, err := h.schema.Subscribe(c.Request.Context(), req)
+	if err != nil {
+		problem.Respond(c, "Failed to execute GraphQL request", err)
+		return
+	}
+
+	controller := http.NewResponseController(c.Writer)
+	extendDeadline := func() {
+		controller.SetWriteDeadline(time.Now().Add(2 * h.options.HeartbeatInterval))
+	}
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	extendDeadline()
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.options.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case response, open := <-responses:
+			if !open {
+				extendDeadline()
+				fmt.Fprint(c.Writer, "event: complete\ndata:\n\n")
+				c.Writer.Flush()
+				return
+			}
+
+			payload, err := json.Marshal(response)
+			if err != nil {
+				continue
+			}
+
+			extendDeadline()
+			if _, err := fmt.Fprintf(c.Writer, "event: next\ndata: %s\n\n", payload); err != nil {
+				return
+			}
+			c.Writer.Flush()
+
+		case <-heartbeat.C:
+			extendDeadline()
+			if _, err := fmt.Fprint(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"todo-service/internal/health"
+)
+
+// HealthHandler serves the liveness, readiness and dependency health probes.
+// Readiness starts out failing and only passes between SetReady(true) and
+// SetReady(false), so load balancers stop routing before shutdown drains.
+type HealthHandler struct {
+	checker *health.Checker
+	ready   atomic.Bool
+}
+
+func NewHealthHandler(checker *health.Checker) *HealthHandler {
+	return &HealthHandler{checker: checker}
+}
+
+func (h *HealthHandler) SetReady(ready bool) {
+	h.ready.Store(ready)
+}
+
+// Live reports that the process is up; it never touches dependencies so a
+// database outage doesn't get the container restarted.
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "alive",
+		"timestamp": time.Now().Unix(),
+	})
+}
+
+func (h *HealthHandler) Ready(c *gin.Context) {
+	if !h.ready.Load() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status":    "not_ready",
+			"timestamp": time.Now().Unix(),
+		})
+		return
+	}
+
+	h.respondReport(c, h.checker.Check(c.Request.Context()))
+}
+
+func (h *HealthHandler) Health(c *gin.Context) {
+	h.respondReport(c, h.checker.Check(c.Request.Context()))
+}
+
+func (h *HealthHandler) respondReport(c *gin.Context, report *health.Report) {
+	if report.Status == health.StatusUnhealthy {
+		c.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
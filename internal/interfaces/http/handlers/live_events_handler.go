@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/interfaces/http/problem"
+	"todo-service/internal/usecases"
+)
+
+type LiveEventsOptions struct {
+	MaxConnections    int
+	HeartbeatInterval time.Duration
+	AllowedOrigins    []string
+}
+
+type LiveEventsHandler struct {
+	eventFeed   *usecases.EventFeedUseCase
+	options     LiveEventsOptions
+	upgrader    websocket.Upgrader
+	connections int64
+}
+
+func NewLiveEventsHandler(eventFeed *usecases.EventFeedUseCase, options LiveEventsOptions) *LiveEventsHandler {
+	h := &LiveEventsHandler{
+		eventFeed: eventFeed,
+		options:   options,
+	}
+
+	h.upgrader = websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 4096,
+	}
+	if len(options.AllowedOrigins) > 0 {
+		h.upgrader.CheckOrigin = h.checkOrigin
+	}
+
+	return h
+}
+
+func (h *LiveEventsHandler) StreamSSE(c *gin.Context) {
+	if !h.acquire(c) {
+		return
+	}
+	defer h.release()
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	events, ok := h.subscribe(c, lastEventID)
+	if !ok {
+		return
+	}
+
+	controller := http.NewResponseController(c.Writer)
+	extendDeadline := func() {
+		controller.SetWriteDeadline(time.Now().Add(2 * h.options.HeartbeatInterval))
+	}
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	extendDeadline()
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", 3000)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.options.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, open := <-events:
+			if !open {
+				return
+			}
+
+			payload, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+
+			extendDeadline()
+			if _, err := fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, payload); err != nil {
+				return
+			}
+			c.Writer.Flush()
+
+		case <-heartbeat.C:
+			extendDeadline()
+			if _, err := fmt.Fprint(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+func (h *LiveEventsHandler) StreamWebSocket(c *gin.Context) {
+	if !h.acquire(c) {
+		return
+	}
+	defer h.release()
+
+	events, ok := h.subscribe(c, c.Query("last_event_id"))
+	if !ok {
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	pongWait := 2 * h.options.HeartbeatInterval
+	conn.SetReadLimit(4096)
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	// The read loop only exists to process control frames and to notice
+	// when the client goes away; client messages are ignored.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(h.options.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, open := <-events:
+			if !open {
+				return
+			}
+
+			conn.SetWriteDeadline(time.Now().Add(h.options.HeartbeatInterval))
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(h.options.HeartbeatInterval)); err != nil {
+				return
+			}
+
+		case <-closed:
+			return
+		}
+	}
+}
+
+func (h *LiveEventsHandler) subscribe(c *gin.Context, lastEventID string) (<-chan *entities.Event, bool) {
+	filter := usecases.EventFilter{
+		TodoID: c.Query("todo_id"),
+	}
+	if types := c.Query("types"); types != "" {
+		for _, eventType := range strings.Split(types, ",") {
+			if eventType = strings.TrimSpace(eventType); eventType != "" {
+				filter.Types = append(filter.Types, eventType)
+			}
+		}
+	}
+
+	events, err := h.eventFeed.Subscribe(c.Request.Context(), lastEventID, filter)
+	if err != nil {
+		problem.Respond(c, "Failed to subscribe to events", err)
+		return nil, false
+	}
+
+	return events, true
+}
+
+func (h *LiveEventsHandler) acquire(c *gin.Context) bool {
+	if atomic.AddInt64(&h.connections, 1) > int64(h.options.MaxConnections) {
+		atomic.AddInt64(&h.connections, -1)
+
+		c.Header("Retry-After", "5")
+		problem.Write(c, problem.New(http.StatusServiceUnavailable, "too_many_streams",
+			fmt.Sprintf("this instance accepts at most %d concurrent connections", h.options.MaxConnections)))
+		return false
+	}
+	return true
+}
+
+func (h *LiveEventsHandler) release() {
+	atomic.AddInt64(&h.connections, -1)
+}
+
+func (h *LiveEventsHandler) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	for _, allowed := range h.options.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
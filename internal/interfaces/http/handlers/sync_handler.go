@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"todo-service/internal/interfaces/http/problem"
+	"todo-service/internal/usecases"
+)
+
+type SyncHandler struct {
+	syncUseCase *usecases.SyncUseCase
+}
+
+func NewSyncHandler(syncUseCase *usecases.SyncUseCase) *SyncHandler {
+	return &SyncHandler{
+		syncUseCase: syncUseCase,
+	}
+}
+
+func (h *SyncHandler) Pull(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	response, err := h.syncUseCase.Pull(c.Request.Context(), c.Query("since"), limit)
+	if err != nil {
+		problem.Respond(c, "Failed to load changes", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+func (h *SyncHandler) Push(c *gin.Context) {
+	var req usecases.SyncPushRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.syncUseCase.Push(c.Request.Context(), req)
+	if err != nil {
+		problem.Respond(c, "Failed to apply changes", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
@@ -2,44 +2,184 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/interfaces/http/problem"
 	"todo-service/internal/usecases"
 )
 
+type TodoHandlerOptions struct {
+	RequireIfMatch bool
+}
+
 type TodoHandler struct {
 	todoUseCase *usecases.TodoUseCase
+	options     TodoHandlerOptions
 }
 
-func NewTodoHandler(todoUseCase *usecases.TodoUseCase) *TodoHandler {
+func NewTodoHandler(todoUseCase *usecases.TodoUseCase, options TodoHandlerOptions) *TodoHandler {
 	return &TodoHandler{
 		todoUseCase: todoUseCase,
+		options:     options,
 	}
 }
 
 func (h *TodoHandler) CreateTodo(c *gin.Context) {
 	var req usecases.CreateTodoRequest
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+	if !bindJSON(c, &req) {
 		return
 	}
 
 	todo, err := h.todoUseCase.CreateTodo(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to create todo",
-			"details": err.Error(),
-		})
+		problem.Respond(c, "Failed to create todo", err)
 		return
 	}
 
+	setETag(c, todo.Version)
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "Todo created successfully",
 		"data":    todo,
 	})
 }
+
+func (h *TodoHandler) ListTodos(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+
+	todos, err := h.todoUseCase.ListTodos(c.Request.Context(), limit, offset)
+	if err != nil {
+		problem.Respond(c, "Failed to list todos", err)
+		return
+	}
+
+	if todos == nil {
+		todos = []*entities.TodoItem{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": todos,
+	})
+}
+
+func (h *TodoHandler) GetTodo(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	todo, err := h.todoUseCase.GetTodo(c.Request.Context(), id)
+	if err != nil {
+		problem.Respond(c, "Failed to get todo", err)
+		return
+	}
+
+	setETag(c, todo.Version)
+	if ifNoneMatch := c.GetHeader("If-None-Match"); ifNoneMatch != "" && etagMatches(ifNoneMatch, todo.Version) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": todo,
+	})
+}
+
+func (h *TodoHandler) UpdateTodo(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	expectedVersion, ok := h.expectedVersion(c)
+	if !ok {
+		return
+	}
+
+	var req usecases.UpdateTodoRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	req.ExpectedVersion = expectedVersion
+	todo, err := h.todoUseCase.UpdateTodo(c.Request.Context(), id, req)
+	if err != nil {
+		problem.Respond(c, "Failed to update todo", err)
+		return
+	}
+
+	setETag(c, todo.Version)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Todo updated successfully",
+		"data":    todo,
+	})
+}
+
+func (h *TodoHandler) CompleteTodo(c *gin.Context) {
+	h.setCompleted(c, true)
+}
+
+func (h *TodoHandler) ReopenTodo(c *gin.Context) {
+	h.setCompleted(c, false)
+}
+
+func (h *TodoHandler) setCompleted(c *gin.Context, completed bool) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	expectedVersion, ok := h.expectedVersion(c)
+	if !ok {
+		return
+	}
+
+	todo, err := h.todoUseCase.SetCompleted(c.Request.Context(), id, completed, expectedVersion)
+	if err != nil {
+		problem.Respond(c, "Failed to update todo", err)
+		return
+	}
+
+	setETag(c, todo.Version)
+	c.JSON(http.StatusOK, gin.H{
+		"data": todo,
+	})
+}
+
+func (h *TodoHandler) DeleteTodo(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	expectedVersion, ok := h.expectedVersion(c)
+	if !ok {
+		return
+	}
+
+	if err := h.todoUseCase.DeleteTodo(c.Request.Context(), id, expectedVersion); err != nil {
+		problem.Respond(c, "Failed to delete todo", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *TodoHandler) expectedVersion(c *gin.Context) (*int, bool) {
+	version, present, ok := parseIfMatch(c.GetHeader("If-Match"))
+	if !ok {
+		problem.Invalid(c, entities.FieldViolation{Field: "If-Match", Code: "invalid_etag", Message: "must be a single ETag returned by this API or *"})
+		return nil, false
+	}
+
+	if !present && h.options.RequireIfMatch {
+		problem.Write(c, problem.New(http.StatusPreconditionRequired, "if_match_required", "fetch the todo and send its ETag in If-Match"))
+		return nil, false
+	}
+
+	return version, true
+}
@@ -0,0 +1,307 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+	"todo-service/internal/domain/ports/mocks"
+	"todo-service/internal/usecases"
+)
+
+const (
+	testTenantID = "acme"
+	testUserID   = "user-1"
+)
+
+type todoMocks struct {
+	txManager *mocks.MockTransactionManager
+	publisher *mocks.MockStreamPublisher
+}
+
+func newTodoRouter(t *testing.T, options TodoHandlerOptions) (*gin.Engine, *todoMocks) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	m := &todoMocks{
+		txManager: mocks.NewMockTransactionManager(t),
+		publisher: mocks.NewMockStreamPublisher(t),
+	}
+	handler := NewTodoHandler(usecases.NewTodoUseCase(m.txManager, m.publisher, nil, entities.DefaultTodoRules(), nil), options)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		principal := &entities.Principal{ID: testUserID, TenantID: testTenantID, Method: entities.AuthMethodJWT}
+		c.Request = c.Request.WithContext(entities.ContextWithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	})
+	router.POST("/todo", handler.CreateTodo)
+	router.GET("/todo/:id", handler.GetTodo)
+	router.PUT("/todo/:id", handler.UpdateTodo)
+	router.DELETE("/todo/:id", handler.DeleteTodo)
+	router.POST("/todo/:id/complete", handler.CompleteTodo)
+	router.POST("/todo/:id/reopen", handler.ReopenTodo)
+
+	return router, m
+}
+
+func expectTodoTx(t *testing.T, txManager *mocks.MockTransactionManager, setup func(repo *mocks.MockTodoRepository)) {
+	txManager.EXPECT().DoInTx(mock.Anything, mock.AnythingOfType("func(ports.TodoRepository) error")).
+		RunAndReturn(func(ctx context.Context, fn func(repo ports.TodoRepository) error) error {
+			repo := mocks.NewMockTodoRepository(t)
+			setup(repo)
+			return fn(repo)
+		}).Once()
+}
+
+// storedTodo returns a todo of the test user at version 3.
+func storedTodo() *entities.TodoItem {
+	todo := entities.NewTodoItem(testTenantID, testUserID, "Original", time.Now().Add(time.Hour), nil)
+	todo.Version = 3
+	return todo
+}
+
+func TestCreateTodo_DueDateNeedsAnOffset(t *testing.T) {
+	tests := []struct {
+		name    string
+		dueDate string
+		status  int
+		code    string
+		want    time.Time
+	}{
+		{name: "UTC", dueDate: "2030-01-02T15:00:00Z", status: http.StatusCreated, want: time.Date(2030, 1, 2, 15, 0, 0, 0, time.UTC)},
+		{name: "offset", dueDate: "2030-01-02T15:00:00+02:00", status: http.StatusCreated, want: time.Date(2030, 1, 2, 13, 0, 0, 0, time.UTC)},
+		{name: "no offset", dueDate: "2030-01-02T15:00:00", status: http.StatusBadRequest, code: "missing_timezone"},
+		{name: "not RFC 3339", dueDate: "2030-01-02 15:00", status: http.StatusBadRequest, code: "invalid_time"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, m := newTodoRouter(t, TodoHandlerOptions{})
+			if tt.status == http.StatusCreated {
+				expectTodoTx(t, m.txManager, func(repo *mocks.MockTodoRepository) {
+					repo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(todo *entities.TodoItem) bool {
+						return todo.DueDate.Equal(tt.want) && todo.DueDate.Location() == time.UTC
+					})).Return(nil)
+				})
+				m.publisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/todo", strings.NewReader(`{"description":"Buy milk","due_date":"`+tt.dueDate+`"}`))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code, rec.Body.String())
+			if tt.code != "" {
+				assert.Contains(t, rec.Body.String(), `"code":"`+tt.code+`"`)
+			}
+		})
+	}
+}
+
+func TestUpdateTodo_ConditionalRequests(t *testing.T) {
+	tests := []struct {
+		name           string
+		requireIfMatch bool
+		ifMatch        string
+		stored         bool
+		applied        bool
+		status         int
+	}{
+		{name: "missing If-Match when required", requireIfMatch: true, status: http.StatusPreconditionRequired},
+		{name: "missing If-Match when optional", stored: true, applied: true, status: http.StatusOK},
+		{name: "matching ETag", requireIfMatch: true, ifMatch: `"3"`, stored: true, applied: true, status: http.StatusOK},
+		{name: "any version", requireIfMatch: true, ifMatch: `*`, stored: true, applied: true, status: http.StatusOK},
+		{name: "stale ETag", ifMatch: `"2"`, stored: true, status: http.StatusPreconditionFailed},
+		{name: "weak ETag of the current version", ifMatch: `W/"3"`, stored: true, status: http.StatusPreconditionFailed},
+		{name: "malformed ETag", ifMatch: `3`, status: http.StatusBadRequest},
+		{name: "several ETags", ifMatch: `"2", "3"`, status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, m := newTodoRouter(t, TodoHandlerOptions{RequireIfMatch: tt.requireIfMatch})
+			todo := storedTodo()
+
+			if tt.stored {
+				expectTodoTx(t, m.txManager, func(repo *mocks.MockTodoRepository) {
+					repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, todo.ID).Return(todo, nil)
+					if tt.applied {
+						repo.EXPECT().Update(mock.Anything, todo).RunAndReturn(func(ctx context.Context, todo *entities.TodoItem) error {
+							todo.Version++
+							return nil
+						})
+						repo.EXPECT().ListGrants(mock.Anything, testTenantID, todo.ID).Return(nil, nil)
+					}
+				})
+			}
+			if tt.applied {
+				m.publisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil)
+			}
+
+			body := `{"description":"Edited","due_date":"` + time.Now().Add(2*time.Hour).UTC().Format(time.RFC3339) + `"}`
+			req := httptest.NewRequest(http.MethodPut, "/todo/"+todo.ID.String(), strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code, rec.Body.String())
+			if tt.applied {
+				assert.Equal(t, `"4"`, rec.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestDeleteTodo_RejectsWeakIfMatch(t *testing.T) {
+	router, m := newTodoRouter(t, TodoHandlerOptions{RequireIfMatch: true})
+	todo := storedTodo()
+
+	expectTodoTx(t, m.txManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, todo.ID).Return(todo, nil)
+	})
+
+	req := httptest.NewRequest(http.MethodDelete, "/todo/"+todo.ID.String(), nil)
+	req.Header.Set("If-Match", `W/"3"`)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	assert.False(t, todo.IsDeleted())
+}
+
+func TestGetTodo_IfNoneMatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		status      int
+	}{
+		{name: "current ETag", ifNoneMatch: `"3"`, status: http.StatusNotModified},
+		{name: "weak current ETag", ifNoneMatch: `W/"3"`, status: http.StatusNotModified},
+		{name: "one of several ETags", ifNoneMatch: `"1", "3"`, status: http.StatusNotModified},
+		{name: "any ETag", ifNoneMatch: `*`, status: http.StatusNotModified},
+		{name: "stale ETag", ifNoneMatch: `"2"`, status: http.StatusOK},
+		{name: "no ETag", status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, m := newTodoRouter(t, TodoHandlerOptions{})
+			todo := storedTodo()
+
+			expectTodoTx(t, m.txManager, func(repo *mocks.MockTodoRepository) {
+				repo.EXPECT().GetByID(mock.Anything, testTenantID, todo.ID).Return(todo, nil)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/todo/"+todo.ID.String(), nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+			if tt.status == http.StatusNotModified {
+				assert.Empty(t, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestSetCompleted_Routes(t *testing.T) {
+	tests := []struct {
+		name      string
+		action    string
+		completed bool
+		ifMatch   string
+		applied   bool
+		status    int
+	}{
+		{name: "complete", action: "complete", applied: true, status: http.StatusOK},
+		{name: "reopen", action: "reopen", completed: true, applied: true, status: http.StatusOK},
+		{name: "complete completed todo", action: "complete", completed: true, status: http.StatusOK},
+		{name: "complete matching ETag", action: "complete", ifMatch: `"3"`, applied: true, status: http.StatusOK},
+		{name: "complete stale ETag", action: "complete", ifMatch: `"2"`, status: http.StatusPreconditionFailed},
+		{name: "reopen weak ETag", action: "reopen", completed: true, ifMatch: `W/"3"`, status: http.StatusPreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, m := newTodoRouter(t, TodoHandlerOptions{})
+			todo := storedTodo()
+			todo.SetCompleted(tt.completed)
+
+			expectTodoTx(t, m.txManager, func(repo *mocks.MockTodoRepository) {
+				repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, todo.ID).Return(todo, nil)
+				if tt.applied {
+					repo.EXPECT().Update(mock.Anything, todo).RunAndReturn(func(ctx context.Context, todo *entities.TodoItem) error {
+						todo.Version++
+						return nil
+					})
+					repo.EXPECT().ListGrants(mock.Anything, testTenantID, todo.ID).Return(nil, nil)
+				}
+			})
+			if tt.applied {
+				m.publisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/todo/"+todo.ID.String()+"/"+tt.action, nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code, rec.Body.String())
+			if tt.status != http.StatusOK {
+				assert.Equal(t, tt.completed, todo.IsCompleted())
+				return
+			}
+
+			wantCompleted := tt.action == "complete"
+			assert.Equal(t, wantCompleted, todo.IsCompleted())
+			if wantCompleted {
+				assert.Contains(t, rec.Body.String(), `"completed_at":`)
+			} else {
+				assert.NotContains(t, rec.Body.String(), `"completed_at":"`)
+			}
+
+			version := `"3"`
+			if tt.applied {
+				version = `"4"`
+			}
+			assert.Equal(t, version, rec.Header().Get("ETag"))
+		})
+	}
+}
+
+func TestSetCompleted_NotFound(t *testing.T) {
+	router, m := newTodoRouter(t, TodoHandlerOptions{})
+	id := storedTodo().ID
+
+	expectTodoTx(t, m.txManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, id).Return(nil, entities.ErrTodoNotFound)
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/todo/"+id.String()+"/complete", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/todo/not-a-uuid/reopen", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
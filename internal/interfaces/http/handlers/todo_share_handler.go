@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/interfaces/http/problem"
+	"todo-service/internal/usecases"
+)
+
+func (h *TodoHandler) ShareTodo(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	var req usecases.ShareTodoRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	grant, err := h.todoUseCase.ShareTodo(c.Request.Context(), id, req)
+	if err != nil {
+		problem.Respond(c, "Failed to share todo", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Todo shared successfully",
+		"data":    grant,
+	})
+}
+
+func (h *TodoHandler) ListShares(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	grants, err := h.todoUseCase.ListShares(c.Request.Context(), id)
+	if err != nil {
+		problem.Respond(c, "Failed to list todo shares", err)
+		return
+	}
+
+	if grants == nil {
+		grants = []*entities.TodoGrant{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": grants,
+	})
+}
+
+func (h *TodoHandler) UnshareTodo(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.todoUseCase.UnshareTodo(c.Request.Context(), id, c.Param("user_id")); err != nil {
+		problem.Respond(c, "Failed to unshare todo", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/interfaces/http/problem"
+	"todo-service/internal/usecases"
+)
+
+type WebhookHandler struct {
+	webhookUseCase *usecases.WebhookUseCase
+}
+
+func NewWebhookHandler(webhookUseCase *usecases.WebhookUseCase) *WebhookHandler {
+	return &WebhookHandler{
+		webhookUseCase: webhookUseCase,
+	}
+}
+
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req usecases.CreateWebhookRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	webhook, err := h.webhookUseCase.CreateWebhook(c.Request.Context(), req)
+	if err != nil {
+		problem.Respond(c, "Failed to create webhook", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Webhook created successfully",
+		"data":    webhook,
+	})
+}
+
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	webhooks, err := h.webhookUseCase.ListWebhooks(c.Request.Context())
+	if err != nil {
+		problem.Respond(c, "Failed to list webhooks", err)
+		return
+	}
+
+	if webhooks == nil {
+		webhooks = []*entities.Webhook{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": webhooks,
+	})
+}
+
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhookUseCase.GetWebhook(c.Request.Context(), id)
+	if err != nil {
+		problem.Respond(c, "Failed to get webhook", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": webhook,
+	})
+}
+
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookUseCase.DeleteWebhook(c.Request.Context(), id); err != nil {
+		problem.Respond(c, "Failed to delete webhook", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *WebhookHandler) EnableWebhook(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhookUseCase.EnableWebhook(c.Request.Context(), id)
+	if err != nil {
+		problem.Respond(c, "Failed to enable webhook", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook enabled successfully",
+		"data":    webhook,
+	})
+}
+
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	deliveries, err := h.webhookUseCase.ListDeliveries(c.Request.Context(), id, limit)
+	if err != nil {
+		problem.Respond(c, "Failed to list webhook deliveries", err)
+		return
+	}
+
+	if deliveries == nil {
+		deliveries = []*entities.WebhookDelivery{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": deliveries,
+	})
+}
@@ -0,0 +1,149 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"todo-service/internal/logging"
+)
+
+const defaultMaxLoggedBodyBytes = 4096
+
+type AccessLogOptions struct {
+	// SkipPaths are logged only when the response is a server error, so
+	// probes and scrapes do not drown out real traffic.
+	SkipPaths []string
+	// Headers adds the request headers, with credentials redacted.
+	Headers bool
+	// Bodies adds request and response bodies up to MaxBodyBytes. JSON
+	// bodies are logged with secret fields redacted; other bodies only by
+	// size and type.
+	Bodies       bool
+	MaxBodyBytes int
+}
+
+// AccessLog writes one structured line per request through the request's
+// context logger, so each line carries the request ID, trace ID and
+// principal. It must run after RequestID.
+func AccessLog(options AccessLogOptions) gin.HandlerFunc {
+	if options.MaxBodyBytes <= 0 {
+		options.MaxBodyBytes = defaultMaxLoggedBodyBytes
+	}
+	skip := make(map[string]bool, len(options.SkipPaths))
+	for _, path := range options.SkipPaths {
+		skip[path] = true
+	}
+
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		var requestBody, responseBody *bodyCapture
+		if options.Bodies {
+			requestBody = &bodyCapture{limit: options.MaxBodyBytes}
+			c.Request.Body = &capturingReader{ReadCloser: c.Request.Body, capture: requestBody}
+			responseBody = &bodyCapture{limit: options.MaxBodyBytes}
+			c.Writer = &capturingWriter{ResponseWriter: c.Writer, capture: responseBody}
+		}
+
+		c.Next()
+
+		status := c.Writer.Status()
+		if skip[c.Request.URL.Path] && status < http.StatusInternalServerError {
+			return
+		}
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		fields := []zap.Field{
+			zap.String("method", c.Request.Method),
+			zap.String("route", route),
+			zap.String("path", c.Request.URL.Path),
+			zap.Int("status", status),
+			zap.Duration("duration", time.Since(start)),
+			zap.Int64("bytes_in", c.Request.ContentLength),
+			zap.Int("bytes_out", c.Writer.Size()),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_agent", c.Request.UserAgent()),
+		}
+		if query := c.Request.URL.RawQuery; query != "" {
+			fields = append(fields, zap.String("query", redactQuery(c.Request.URL.Query())))
+		}
+		if options.Headers {
+			fields = append(fields, zap.Any("headers", redactHeaders(c.Request.Header)))
+		}
+		if options.Bodies {
+			fields = append(fields,
+				zap.String("request_body", redactBody(c.ContentType(), requestBody)),
+				zap.String("response_body", redactBody(c.Writer.Header().Get("Content-Type"), responseBody)))
+		}
+		if errs := c.Errors.ByType(gin.ErrorTypePrivate); len(errs) > 0 {
+			fields = append(fields, zap.String("errors", errs.String()))
+		}
+
+		level := zapcore.InfoLevel
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = zapcore.ErrorLevel
+		case status >= http.StatusBadRequest:
+			level = zapcore.WarnLevel
+		}
+
+		logging.FromContext(c.Request.Context()).Log(level, "HTTP request", fields...)
+	}
+}
+
+// bodyCapture keeps the first limit bytes of a body and counts the rest.
+type bodyCapture struct {
+	buf   bytes.Buffer
+	limit int
+	total int
+}
+
+func (b *bodyCapture) write(data []byte) {
+	b.total += len(data)
+	if room := b.limit - b.buf.Len(); room > 0 {
+		if len(data) > room {
+			data = data[:room]
+		}
+		b.buf.Write(data)
+	}
+}
+
+func (b *bodyCapture) truncated() bool {
+	return b.total > b.buf.Len()
+}
+
+type capturingReader struct {
+	io.ReadCloser
+	capture *bodyCapture
+}
+
+func (r *capturingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.capture.write(p[:n])
+	return n, err
+}
+
+type capturingWriter struct {
+	gin.ResponseWriter
+	capture *bodyCapture
+}
+
+func (w *capturingWriter) Write(data []byte) (int, error) {
+	w.capture.write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *capturingWriter) WriteString(s string) (int, error) {
+	w.capture.write([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
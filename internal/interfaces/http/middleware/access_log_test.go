@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/logging"
+)
+
+func newLoggedRouter(t *testing.T, options AccessLogOptions) (*gin.Engine, *observer.ObservedLogs) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	router := gin.New()
+	router.Use(RequestID(zap.New(core)), AccessLog(options), Recovery())
+	return router, logs
+}
+
+func TestAccessLog_RedactsCredentials(t *testing.T) {
+	router, logs := newLoggedRouter(t, AccessLogOptions{Headers: true, Bodies: true})
+	router.POST("/api/v1/api-keys", func(c *gin.Context) {
+		ctx := entities.ContextWithPrincipal(c.Request.Context(), &entities.Principal{ID: "alice", TenantID: "acme", Method: entities.AuthMethodJWT})
+		c.Request = c.Request.WithContext(ctx)
+
+		var body map[string]interface{}
+		require.NoError(t, c.ShouldBindJSON(&body))
+		logging.FromContext(c.Request.Context()).Info("Issuing key")
+		c.JSON(http.StatusCreated, gin.H{"data": gin.H{"name": body["name"], "key": "tdk_plaintext"}})
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/api-keys?access_token=abc&page=2",
+		strings.NewReader(`{"name":"ci","password":"hunter2"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer secret-token")
+	req.Header.Set(RequestIDHeader, "req-123")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, "req-123", recorder.Header().Get(RequestIDHeader))
+
+	handlerLog := logs.FilterMessage("Issuing key").All()
+	require.Len(t, handlerLog, 1)
+	assert.Equal(t, "req-123", handlerLog[0].ContextMap()["request_id"])
+	assert.Equal(t, "alice", handlerLog[0].ContextMap()["user_id"])
+
+	accessLog := logs.FilterMessage("HTTP request").All()
+	require.Len(t, accessLog, 1)
+	fields := accessLog[0].ContextMap()
+	assert.Equal(t, "req-123", fields["request_id"])
+	assert.Equal(t, "/api/v1/api-keys", fields["route"])
+	assert.Equal(t, int64(http.StatusCreated), fields["status"])
+	assert.Equal(t, "alice", fields["user_id"])
+
+	encoded, err := json.Marshal(fields)
+	require.NoError(t, err)
+	assert.NotContains(t, string(encoded), "secret-token")
+	assert.NotContains(t, string(encoded), "hunter2")
+	assert.NotContains(t, string(encoded), "tdk_plaintext")
+	assert.NotContains(t, string(encoded), "access_token=abc")
+	assert.Contains(t, string(encoded), `\"name\":\"ci\"`)
+}
+
+func TestRequestID_ReplacesUnsafeValues(t *testing.T) {
+	router, _ := newLoggedRouter(t, AccessLogOptions{})
+	router.GET("/ping", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set(RequestIDHeader, "bad id\nwith newline")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	id := recorder.Header().Get(RequestIDHeader)
+	assert.Len(t, id, 36)
+}
+
+func TestRecovery_LogsPanicAndReturns500(t *testing.T) {
+	router, logs := newLoggedRouter(t, AccessLogOptions{})
+	router.GET("/boom", func(c *gin.Context) { panic("boom") })
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/boom", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), recorder.Header().Get(RequestIDHeader))
+	assert.Equal(t, 1, logs.FilterMessage("Panic while handling request").Len())
+	require.Equal(t, 1, logs.FilterMessage("HTTP request").Len())
+	assert.Equal(t, zapcore.ErrorLevel, logs.FilterMessage("HTTP request").All()[0].Level)
+}
+
+func TestAccessLog_SkipsProbesUnlessTheyFail(t *testing.T) {
+	router, logs := newLoggedRouter(t, AccessLogOptions{SkipPaths: []string{"/health"}})
+	healthy := true
+	router.GET("/health", func(c *gin.Context) {
+		if healthy {
+			c.Status(http.StatusOK)
+			return
+		}
+		c.Status(http.StatusServiceUnavailable)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Zero(t, logs.Len())
+
+	healthy = false
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, 1, logs.Len())
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+	"todo-service/internal/interfaces/authn"
+	"todo-service/internal/interfaces/http/problem"
+)
+
+const (
+	PrincipalKey = "principal"
+
+	SchemeBearer = authn.SchemeBearer
+	SchemeAPIKey = authn.SchemeAPIKey
+)
+
+type AuthOptions struct {
+	// AllowQueryToken accepts a bearer token from the access_token query
+	// parameter, for clients such as EventSource that cannot set headers.
+	AllowQueryToken bool
+}
+
+// Authenticate rejects requests without valid credentials for one of the
+// given Authorization schemes and stores the resulting principal both on the
+// Gin context and on the request context, where the use cases pick it up.
+func Authenticate(verifiers map[string]ports.TokenVerifier, options AuthOptions) gin.HandlerFunc {
+	authenticator := authn.NewAuthenticator(verifiers)
+
+	return func(c *gin.Context) {
+		scheme, credentials := authn.ParseAuthorization(c.GetHeader("Authorization"))
+		if credentials == "" && options.AllowQueryToken {
+			scheme, credentials = SchemeBearer, c.Query("access_token")
+		}
+
+		principal, err := authenticator.Authenticate(c.Request.Context(), scheme, credentials)
+		switch {
+		case errors.Is(err, entities.ErrUnauthenticated):
+			for _, challenge := range authenticator.Challenges() {
+				c.Writer.Header().Add("WWW-Authenticate", challenge)
+			}
+			problem.Respond(c, "Authentication required", err)
+			return
+
+		case errors.Is(err, authn.ErrUnsupportedScheme):
+			problem.Write(c, problem.New(http.StatusUnauthorized, "unsupported_auth_scheme",
+				"use one of: "+strings.Join(authenticator.Challenges(), ", ")))
+			return
+
+		case errors.Is(err, entities.ErrInvalidCredentials):
+			c.Header("WWW-Authenticate", scheme+` error="invalid_token"`)
+			problem.Respond(c, "Invalid credentials", err)
+			return
+
+		case err != nil:
+			problem.Respond(c, "Failed to authenticate", err)
+			return
+		}
+
+		c.Set(PrincipalKey, principal)
+		c.Request = c.Request.WithContext(entities.ContextWithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+// RequireScope rejects callers whose principal does not hold scope. It must
+// run after Authenticate.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := entities.PrincipalFromContext(c.Request.Context())
+		if !ok {
+			problem.Respond(c, "Authentication required", entities.ErrUnauthenticated)
+			return
+		}
+
+		if err := authn.RequireScope(principal, scope); err != nil {
+			c.Header("WWW-Authenticate", `Bearer error="insufficient_scope", scope="`+scope+`"`)
+			problem.Write(c, problem.New(http.StatusForbidden, "insufficient_scope", "this operation requires the "+scope+" scope"))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireAuthMethod restricts a route to principals authenticated a certain
+// way, e.g. keeping API key management away from API keys themselves.
+func RequireAuthMethod(method string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := entities.PrincipalFromContext(c.Request.Context())
+		if !ok || principal.Method != method {
+			problem.Write(c, problem.New(http.StatusForbidden, "auth_method_not_allowed", "this operation requires "+method+" authentication"))
+			return
+		}
+
+		c.Next()
+	}
+}
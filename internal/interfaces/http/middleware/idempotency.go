@@ -0,0 +1,289 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+	"todo-service/internal/interfaces/http/problem"
+	"todo-service/internal/logging"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	maxMultipartMemory        = 32 << 20
+	defaultIdempotencyTTL     = 24 * time.Hour
+	defaultIdempotencyLockTTL = time.Minute
+	defaultIdempotencyMaxBody = 1 << 20
+)
+
+// Only these headers are replayed; everything else is regenerated per
+// response.
+var replayedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+type IdempotencyOptions struct {
+	TTL     time.Duration
+	LockTTL time.Duration
+	// MaxBodyBytes caps the body read to fingerprint a request; larger
+	// requests are rejected with 413. Multipart uploads may additionally
+	// carry one file of up to entities.MaxFileSize.
+	MaxBodyBytes int64
+	// BodyLimit, when set, returns the whole cap for a request instead,
+	// such as its operation's limit from the API document, so a body the
+	// document accepts is never refused here. Zero falls back to
+	// MaxBodyBytes.
+	BodyLimit func(c *gin.Context) int64
+}
+
+// Idempotency makes POST handlers safe to retry. The first request with a
+// given Idempotency-Key runs normally and its response is stored; identical
+// retries get the stored response back, a retry with a different body is
+// rejected with 422, and a retry that arrives while the first request is
+// still running gets 409. Server errors release the key so the client can
+// try again. Bodies are read in full to fingerprint them, so keyed requests
+// beyond MaxBodyBytes are rejected with 413.
+func Idempotency(store ports.IdempotencyStore, options IdempotencyOptions) gin.HandlerFunc {
+	if options.TTL <= 0 {
+		options.TTL = defaultIdempotencyTTL
+	}
+	if options.LockTTL <= 0 {
+		options.LockTTL = defaultIdempotencyLockTTL
+	}
+	if options.MaxBodyBytes <= 0 {
+		options.MaxBodyBytes = defaultIdempotencyMaxBody
+	}
+
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		if !validIdempotencyKey(key) {
+			problem.Invalid(c, entities.FieldViolation{Field: IdempotencyKeyHeader, Code: "invalid", Message: "must be 1-255 printable ASCII characters"})
+			return
+		}
+
+		fingerprint, err := requestFingerprint(c, options.bodyLimit(c))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			problem.Write(c, problem.New(http.StatusRequestEntityTooLarge, "body_too_large",
+				fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit)))
+			return
+		}
+		if err != nil {
+			problem.Invalid(c, entities.FieldViolation{Field: "body", Code: "unreadable", Message: "could not be read"})
+			return
+		}
+
+		record := entities.NewIdempotencyRecord(scopedIdempotencyKey(c, key), fingerprint)
+		stored, err := store.Begin(c.Request.Context(), record, options.LockTTL)
+		switch {
+		case errors.Is(err, entities.ErrIdempotencyKeyMismatch):
+			problem.Respond(c, "Idempotency key reused", err)
+			return
+		case errors.Is(err, entities.ErrIdempotencyKeyInUse):
+			c.Header("Retry-After", "1")
+			problem.Respond(c, "Request already in progress", err)
+			return
+		case err != nil:
+			problem.Respond(c, "Idempotency store unavailable; retry the request later", entities.NewUnavailableError("idempotency store", err))
+			return
+		case stored != nil:
+			replay(c, stored)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		c.Next()
+
+		// Finish bookkeeping even if the client has gone away; otherwise the
+		// key stays locked until the lock expires.
+		ctx := context.WithoutCancel(c.Request.Context())
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			if err := store.Release(ctx, record); err != nil {
+				logging.FromContext(ctx).Warn("Failed to release idempotency key", zap.Error(err))
+			}
+			return
+		}
+
+		header := make(http.Header)
+		for _, name := range replayedHeaders {
+			if value := recorder.Header().Get(name); value != "" {
+				header.Set(name, value)
+			}
+		}
+
+		record.Complete(status, header, recorder.body.Bytes())
+		err = store.Complete(ctx, record, options.TTL)
+		if errors.Is(err, entities.ErrIdempotencyLockLost) {
+			logging.FromContext(ctx).Warn("Idempotency key expired before the response was stored; retries will run again",
+				zap.Duration("lock_ttl", options.LockTTL))
+		} else if err != nil {
+			logging.FromContext(ctx).Error("Failed to store idempotent response", zap.Error(err))
+		}
+	}
+}
+
+func replay(c *gin.Context, record *entities.IdempotencyRecord) {
+	for name, values := range record.Header {
+		for _, value := range values {
+			c.Writer.Header().Add(name, value)
+		}
+	}
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Status(record.StatusCode)
+	_, _ = c.Writer.Write(record.Body)
+	c.Abort()
+}
+
+// scopedIdempotencyKey namespaces client keys by tenant, caller and route so
+// the same key sent by different users or to different endpoints never
+// collides.
+func scopedIdempotencyKey(c *gin.Context, key string) string {
+	var owner string
+	if principal, ok := entities.PrincipalFromContext(c.Request.Context()); ok {
+		owner = principal.TenantID + "/" + principal.ID
+	}
+	return owner + ":" + c.Request.Method + " " + c.FullPath() + ":" + key
+}
+
+func validIdempotencyKey(key string) bool {
+	if len(key) > maxIdempotencyKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// requestFingerprint hashes the parts of a request that decide its outcome.
+// JSON bodies are canonicalised so formatting differences between retries
+// do not count as a different request; multipart bodies are hashed per part
+// because clients pick a fresh boundary on every attempt. Bodies beyond
+// maxBody fail with *http.MaxBytesError.
+func (o IdempotencyOptions) bodyLimit(c *gin.Context) int64 {
+	if o.BodyLimit != nil {
+		if limit := o.BodyLimit(c); limit > 0 {
+			return limit
+		}
+	}
+	if isMultipart(c.Request) {
+		return o.MaxBodyBytes + entities.MaxFileSize
+	}
+	return o.MaxBodyBytes
+}
+
+func isMultipart(r *http.Request) bool {
+	return strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data")
+}
+
+func requestFingerprint(c *gin.Context, maxBody int64) (string, error) {
+	r := c.Request
+	hash := sha256.New()
+	io.WriteString(hash, r.Method+" "+r.URL.Path+"\n")
+
+	if isMultipart(r) {
+		r.Body = http.MaxBytesReader(c.Writer, r.Body, maxBody)
+		if err := hashMultipart(hash, r); err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(hash.Sum(nil)), nil
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, r.Body, maxBody))
+	if err != nil {
+		return "", err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	var decoded interface{}
+	if json.Unmarshal(body, &decoded) == nil {
+		if canonical, err := json.Marshal(decoded); err == nil {
+			body = canonical
+		}
+	}
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func hashMultipart(hash io.Writer, r *http.Request) error {
+	if err := r.ParseMultipartForm(maxMultipartMemory); err != nil {
+		return err
+	}
+	form := r.MultipartForm
+
+	names := make([]string, 0, len(form.Value))
+	for name := range form.Value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range form.Value[name] {
+			io.WriteString(hash, "value:"+name+"="+value+"\n")
+		}
+	}
+
+	names = names[:0]
+	for name := range form.File {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, header := range form.File[name] {
+			io.WriteString(hash, "file:"+name+"="+header.Filename+"\n")
+
+			file, err := header.Open()
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(hash, file)
+			file.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,199 @@
+package middleware
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/infrastructure/idempotency"
+	"todo-service/internal/interfaces/http/openapi"
+)
+
+// newIdempotentRouter serves POST /todo behind the idempotency middleware;
+// handle decides the response and calls counts how often it ran.
+func newIdempotentRouter(t *testing.T, options IdempotencyOptions, handle gin.HandlerFunc) (*gin.Engine, *atomic.Int32) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	calls := &atomic.Int32{}
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		principal := &entities.Principal{ID: "alice", TenantID: "acme", Method: entities.AuthMethodJWT}
+		c.Request = c.Request.WithContext(entities.ContextWithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	})
+	router.POST("/todo", Idempotency(idempotency.NewRedisIdempotencyStore(client), options), func(c *gin.Context) {
+		calls.Add(1)
+		handle(c)
+	})
+	return router, calls
+}
+
+func postTodo(router http.Handler, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/todo", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IdempotencyKeyHeader, key)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func created(c *gin.Context) {
+	c.Header("Location", "/api/v1/todo/1")
+	c.JSON(http.StatusCreated, gin.H{"data": gin.H{"id": "1"}})
+}
+
+func TestIdempotency_ReplaysCompletedResponse(t *testing.T) {
+	router, calls := newIdempotentRouter(t, IdempotencyOptions{}, created)
+
+	first := postTodo(router, "key-1", `{"description":"Buy milk","due_date":"2030-01-01T00:00:00Z"}`)
+	require.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+
+	// Formatting and key order do not make it a different request.
+	retry := postTodo(router, "key-1", `{ "due_date": "2030-01-01T00:00:00Z", "description": "Buy milk" }`)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, "/api/v1/todo/1", retry.Header().Get("Location"))
+	assert.JSONEq(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestIdempotency_RejectsReusedKeyWithDifferentBody(t *testing.T) {
+	router, calls := newIdempotentRouter(t, IdempotencyOptions{}, created)
+
+	require.Equal(t, http.StatusCreated, postTodo(router, "key-1", `{"description":"Buy milk"}`).Code)
+
+	recorder := postTodo(router, "key-1", `{"description":"Buy bread"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "idempotency_key_reused")
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestIdempotency_RejectsRetryWhileInFlight(t *testing.T) {
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	router, calls := newIdempotentRouter(t, IdempotencyOptions{}, func(c *gin.Context) {
+		close(started)
+		<-finish
+		created(c)
+	})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- postTodo(router, "key-1", `{"description":"Buy milk"}`) }()
+	<-started
+
+	recorder := postTodo(router, "key-1", `{"description":"Buy milk"}`)
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+	assert.Equal(t, "1", recorder.Header().Get("Retry-After"))
+	assert.Contains(t, recorder.Body.String(), "request_in_progress")
+
+	close(finish)
+	assert.Equal(t, http.StatusCreated, (<-done).Code)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestIdempotency_ReleasesKeyAfterServerError(t *testing.T) {
+	var fail atomic.Bool
+	fail.Store(true)
+	router, calls := newIdempotentRouter(t, IdempotencyOptions{}, func(c *gin.Context) {
+		if fail.Load() {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"code": "unavailable"})
+			return
+		}
+		created(c)
+	})
+
+	assert.Equal(t, http.StatusServiceUnavailable, postTodo(router, "key-1", `{}`).Code)
+
+	fail.Store(false)
+	recorder := postTodo(router, "key-1", `{}`)
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Empty(t, recorder.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestIdempotency_RejectsOversizedBody(t *testing.T) {
+	router, calls := newIdempotentRouter(t, IdempotencyOptions{MaxBodyBytes: 64}, created)
+
+	recorder := postTodo(router, "key-1", `{"description":"`+strings.Repeat("a", 64)+`"}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "body_too_large")
+	assert.Equal(t, int32(0), calls.Load())
+
+	// Requests without a key are left to the handler.
+	req := httptest.NewRequest(http.MethodPost, "/todo", strings.NewReader(strings.Repeat("a", 128)))
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestIdempotency_UsesBodyLimit(t *testing.T) {
+	limit := func(c *gin.Context) int64 { return 256 }
+	router, calls := newIdempotentRouter(t, IdempotencyOptions{MaxBodyBytes: 64, BodyLimit: limit}, created)
+
+	recorder := postTodo(router, "key-1", `{"description":"`+strings.Repeat("a", 128)+`"}`)
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Equal(t, int32(1), calls.Load())
+
+	recorder = postTodo(router, "key-2", `{"description":"`+strings.Repeat("a", 256)+`"}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "request body exceeds 256 bytes")
+}
+
+func TestOperationBodyLimit(t *testing.T) {
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+
+	var got int64
+	router := gin.New()
+	record := func(c *gin.Context) { got = OperationBodyLimit(spec)(c) }
+	router.POST("/api/v1/todo", record)
+	router.POST("/api/v1/upload", record)
+	router.POST("/undocumented", record)
+
+	for path, want := range map[string]int64{
+		"/api/v1/todo":   openapi.DefaultMaxBodyBytes,
+		"/api/v1/upload": spec.Operation(http.MethodPost, "/api/v1/upload").RequestBody.MaxBytes,
+		"/undocumented":  0,
+	} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, path, nil))
+		assert.Equal(t, want, got, path)
+	}
+	assert.Greater(t, spec.Operation(http.MethodPost, "/api/v1/upload").MaxBodyBytes(), int64(openapi.DefaultMaxBodyBytes))
+}
+
+func TestIdempotency_RejectsOversizedUpload(t *testing.T) {
+	router, calls := newIdempotentRouter(t, IdempotencyOptions{MaxBodyBytes: 1024}, created)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "big.bin")
+	require.NoError(t, err)
+	_, err = part.Write(bytes.Repeat([]byte("a"), entities.MaxFileSize+2048))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/todo", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set(IdempotencyKeyHeader, "key-1")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	assert.Equal(t, int32(0), calls.Load())
+}
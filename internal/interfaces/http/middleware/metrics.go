@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const unmatchedRoute = "unmatched"
+
+// RequestObserver records one finished HTTP request.
+type RequestObserver interface {
+	ObserveRequest(method, route string, status int, duration time.Duration)
+}
+
+var knownMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+}
+
+// Metrics reports every request by method, route template and status. The
+// route is the Gin pattern (e.g. /api/v1/todo/:id), and unknown paths and
+// methods are folded into a single value, so clients cannot create new
+// label values.
+func Metrics(observer RequestObserver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		method := c.Request.Method
+		if !knownMethods[method] {
+			method = "OTHER"
+		}
+
+		observer.ObserveRequest(method, route, c.Writer.Status(), time.Since(start))
+	}
+}
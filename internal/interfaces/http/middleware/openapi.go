@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/interfaces/http/openapi"
+	"todo-service/internal/interfaces/http/problem"
+)
+
+// OperationBodyLimit returns the body limit spec documents for the request's
+// route, or zero for undocumented routes. It is meant for
+// IdempotencyOptions.BodyLimit.
+func OperationBodyLimit(spec *openapi.Spec) func(c *gin.Context) int64 {
+	return func(c *gin.Context) int64 {
+		operation := spec.Operation(c.Request.Method, openapi.PathFromRoute(c.FullPath()))
+		if operation == nil {
+			return 0
+		}
+		return operation.MaxBodyBytes()
+	}
+}
+
+// ValidateRequests rejects requests that do not match the operation the spec
+// documents for their route, listing every violation at once. Bodies in a
+// media type the operation does not accept get 415, and bodies beyond the
+// operation's size limit get 413. Undocumented routes are let through.
+func ValidateRequests(spec *openapi.Spec) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		operation := spec.Operation(c.Request.Method, openapi.PathFromRoute(c.FullPath()))
+		if operation == nil {
+			c.Next()
+			return
+		}
+
+		pathParams := make(map[string]string, len(c.Params))
+		for _, param := range c.Params {
+			pathParams[param.Key] = param.Value
+		}
+
+		err := spec.ValidateRequest(operation, c.Request, pathParams)
+		var violations openapi.Violations
+		var tooLarge *http.MaxBytesError
+		switch {
+		case err == nil:
+			c.Next()
+		case errors.As(err, &violations):
+			errs := &entities.ValidationError{}
+			for _, violation := range violations {
+				errs.Add(violation.Field, "schema_mismatch", violation.Message)
+			}
+			problem.Respond(c, "Request does not match the API specification", errs)
+		case errors.As(err, &tooLarge):
+			problem.Write(c, problem.New(http.StatusRequestEntityTooLarge, "body_too_large",
+				fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit)))
+		case errors.Is(err, openapi.ErrUnsupportedMediaType):
+			problem.Write(c, problem.New(http.StatusUnsupportedMediaType, "unsupported_media_type", err.Error()))
+		default:
+			problem.Invalid(c, entities.FieldViolation{Field: "body", Code: "unreadable", Message: "could not be read"})
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+	"todo-service/internal/interfaces/http/problem"
+	"todo-service/internal/logging"
+)
+
+const defaultRateLimitBucket = "default"
+
+type RateLimitOptions struct {
+	// Default applies to every route without its own entry in Routes. A
+	// zero limit disables it.
+	Default entities.RateLimit
+	// Routes is keyed by "<METHOD> <route pattern>", e.g.
+	// "POST /api/v1/upload". Each listed route has its own bucket.
+	Routes map[string]entities.RateLimit
+}
+
+// RateLimit admits requests according to per-caller limits and reports the
+// caller's quota in RateLimit-* headers. Callers are identified by API key,
+// then principal, so it must run after authentication; requests without a
+// principal are left to RateLimitByIP. Requests are let through when the
+// limiter fails.
+func RateLimit(limiter ports.RateLimiter, options RateLimitOptions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		subject, ok := rateLimitSubject(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		route := c.Request.Method + " " + c.FullPath()
+		limit, bucket := options.Default, defaultRateLimitBucket
+		if routeLimit, ok := options.Routes[route]; ok {
+			limit, bucket = routeLimit, route
+		}
+
+		if admit(c, limiter, subject+":"+bucket, limit) {
+			c.Next()
+		}
+	}
+}
+
+// RateLimitByIP admits requests per client IP before they are
+// authenticated, so callers without valid credentials are limited too. All
+// routes share one budget per IP. The client IP only honours forwarding
+// headers from the router's trusted proxies.
+func RateLimitByIP(limiter ports.RateLimiter, limit entities.RateLimit) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if admit(c, limiter, "ip:"+c.ClientIP(), limit) {
+			c.Next()
+		}
+	}
+}
+
+// admit counts the request against key and writes the RateLimit-* headers,
+// or the 429 response when the limit is exhausted. A zero limit admits
+// everything.
+func admit(c *gin.Context, limiter ports.RateLimiter, key string, limit entities.RateLimit) bool {
+	if limit.Limit <= 0 {
+		return true
+	}
+
+	result, err := limiter.Allow(c.Request.Context(), key, limit)
+	if err != nil {
+		logging.FromContext(c.Request.Context()).Error("Failed to apply rate limit",
+			zap.String("route", c.Request.Method+" "+c.FullPath()), zap.Error(err))
+		return true
+	}
+
+	c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Header("RateLimit-Reset", ceilSeconds(result.ResetAfter))
+	c.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%s", limit.Limit, ceilSeconds(limit.Period)))
+
+	if !result.Allowed {
+		c.Header("Retry-After", ceilSeconds(result.RetryAfter))
+		problem.Write(c, problem.New(http.StatusTooManyRequests, "rate_limited", fmt.Sprintf("rate limit of %s exceeded", limit)))
+		return false
+	}
+
+	return true
+}
+
+func rateLimitSubject(c *gin.Context) (string, bool) {
+	principal, ok := entities.PrincipalFromContext(c.Request.Context())
+	switch {
+	case !ok:
+		return "", false
+	case principal.APIKeyID != "":
+		return "key:" + principal.APIKeyID, true
+	default:
+		return "user:" + principal.TenantID + "/" + principal.ID, true
+	}
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
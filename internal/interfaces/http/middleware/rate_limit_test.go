@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/infrastructure/ratelimit"
+)
+
+func TestRateLimitByIP_UsesForwardedClientBehindTrustedProxy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	require.NoError(t, router.SetTrustedProxies([]string{"10.0.0.0/8"}))
+	router.Use(RateLimitByIP(ratelimit.NewMemoryRateLimiter(), entities.RateLimit{Limit: 1, Period: time.Minute}))
+	router.GET("/todo", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	get := func(forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodGet, "/todo", nil)
+		req.RemoteAddr = "10.1.2.3:40000"
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	assert.Equal(t, http.StatusNoContent, get("198.51.100.1"))
+	assert.Equal(t, http.StatusTooManyRequests, get("198.51.100.1"))
+	assert.Equal(t, http.StatusNoContent, get("198.51.100.2"))
+}
+
+func TestRateLimit_LeavesUnauthenticatedRequestsToRateLimitByIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	limiter := ratelimit.NewMemoryRateLimiter()
+	router := gin.New()
+	router.Use(RateLimit(limiter, RateLimitOptions{Default: entities.RateLimit{Limit: 1, Period: time.Minute}}))
+	router.GET("/todo", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	for i := 0; i < 3; i++ {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/todo", nil))
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+		assert.Empty(t, recorder.Header().Get("RateLimit-Limit"))
+	}
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"syscall"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"todo-service/internal/interfaces/apierr"
+	"todo-service/internal/interfaces/http/problem"
+	"todo-service/internal/logging"
+)
+
+// Recovery turns a panicking handler into a logged 500 response carrying
+// the request ID. Panics caused by the client hanging up are logged without
+// a response, since nobody is left to read it.
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			logger := logging.FromContext(c.Request.Context())
+
+			if err, ok := recovered.(error); ok && (errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET)) {
+				logger.Warn("Client connection lost", zap.Error(err))
+				c.Abort()
+				return
+			}
+
+			logger.Error("Panic while handling request", zap.Any("panic", recovered), zap.Stack("stack"))
+			problem.Write(c, problem.New(http.StatusInternalServerError, apierr.CodeInternal, "Internal server error"))
+		}()
+
+		c.Next()
+	}
+}
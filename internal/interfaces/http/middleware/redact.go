@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"X-Api-Key":           true,
+	"X-Auth-Token":        true,
+}
+
+// sensitiveFields are JSON fields and query parameters whose values are
+// never logged, compared case-insensitively.
+var sensitiveFields = map[string]bool{
+	"access_token":  true,
+	"refresh_token": true,
+	"token":         true,
+	"password":      true,
+	"secret":        true,
+	"client_secret": true,
+	"key":           true,
+	"api_key":       true,
+	"authorization": true,
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	values := make(map[string]string, len(header))
+	for name, value := range header {
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			values[name] = redacted
+			continue
+		}
+		values[name] = strings.Join(value, ", ")
+	}
+	return values
+}
+
+func redactQuery(query url.Values) string {
+	for name := range query {
+		if sensitiveFields[strings.ToLower(name)] {
+			query[name] = []string{redacted}
+		}
+	}
+	return query.Encode()
+}
+
+// redactBody renders a captured body for the access log. Only complete JSON
+// documents are logged verbatim (minus secret fields); anything that cannot
+// be parsed and checked is summarised instead of risking a leak.
+func redactBody(contentType string, body *bodyCapture) string {
+	if body == nil || body.total == 0 {
+		return ""
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json") {
+		return fmt.Sprintf("[%d bytes %s]", body.total, mediaType)
+	}
+	if body.truncated() {
+		return fmt.Sprintf("[%d bytes JSON, truncated]", body.total)
+	}
+
+	var document interface{}
+	if err := json.Unmarshal(body.buf.Bytes(), &document); err != nil {
+		return fmt.Sprintf("[%d bytes invalid JSON]", body.total)
+	}
+
+	encoded, err := json.Marshal(redactJSON(document))
+	if err != nil {
+		return fmt.Sprintf("[%d bytes JSON]", body.total)
+	}
+	return string(encoded)
+}
+
+func redactJSON(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for field, nested := range typed {
+			if sensitiveFields[strings.ToLower(field)] {
+				typed[field] = redacted
+				continue
+			}
+			typed[field] = redactJSON(nested)
+		}
+	case []interface{}:
+		for i, nested := range typed {
+			typed[i] = redactJSON(nested)
+		}
+	}
+	return value
+}
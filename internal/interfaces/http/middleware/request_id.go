@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"todo-service/internal/logging"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+
+	requestIDKey = "request_id"
+)
+
+// Client-supplied request IDs are echoed into logs and headers, so only
+// short, plain tokens are accepted; anything else is replaced.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID propagates the caller's X-Request-ID or generates one, returns
+// it in the response and stores a logger tagged with it in the request
+// context. It must run before every other middleware that logs.
+func RequestID(logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !requestIDPattern.MatchString(id) {
+			id = uuid.NewString()
+		}
+
+		c.Set(requestIDKey, id)
+		c.Header(RequestIDHeader, id)
+
+		ctx := logging.WithLogger(c.Request.Context(), logger.With(zap.String("request_id", id)))
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// GetRequestID returns the ID assigned by RequestID.
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
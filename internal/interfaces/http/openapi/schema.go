@@ -0,0 +1,376 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Schema is the subset of JSON Schema 2020-12 the document uses.
+type Schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 schemaTypes        `json:"type"`
+	Format               string             `json:"format"`
+	Enum                 []interface{}      `json:"enum"`
+	Properties           map[string]*Schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties *additional        `json:"additionalProperties"`
+	Items                *Schema            `json:"items"`
+	AllOf                []*Schema          `json:"allOf"`
+	AnyOf                []*Schema          `json:"anyOf"`
+	MinLength            *int               `json:"minLength"`
+	MaxLength            *int               `json:"maxLength"`
+	Pattern              string             `json:"pattern"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+	MinItems             *int               `json:"minItems"`
+	MaxItems             *int               `json:"maxItems"`
+
+	pattern *regexp.Regexp
+}
+
+// schemaTypes accepts both "type": "string" and "type": ["string", "null"].
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+// additional is either a boolean or a schema for undeclared properties.
+type additional struct {
+	allowed bool
+	schema  *Schema
+}
+
+func (a *additional) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &a.allowed); err == nil {
+		return nil
+	}
+	a.allowed = true
+	return json.Unmarshal(data, &a.schema)
+}
+
+// Violation is one way a value failed to match its schema. Field is named
+// like entities.FieldViolation: a body property's JSON path such as
+// "due_date" or "changes[1].op", a parameter's name such as "limit", or
+// "body" for the body as a whole.
+type Violation struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Violations is every mismatch found in a request or response.
+type Violations []Violation
+
+func (v Violations) Error() string {
+	messages := make([]string, len(v))
+	for i, violation := range v {
+		messages[i] = violation.Field + ": " + violation.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ValidateValue checks a decoded JSON value against schema. Numbers must be
+// decoded as json.Number so integers can be told apart from floats.
+func (s *Spec) ValidateValue(schema *Schema, value interface{}, field string) Violations {
+	v := &validator{spec: s}
+	v.validate(schema, value, field, false)
+	return v.violations
+}
+
+// validator collects violations for one value. In strict mode objects may
+// only carry declared properties even where the schema leaves them open,
+// which is how responses are checked: a field the handler sends must be
+// documented.
+type validator struct {
+	spec       *Spec
+	strict     bool
+	violations Violations
+}
+
+func (v *validator) fail(field, format string, args ...interface{}) {
+	v.violations = append(v.violations, Violation{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// validate checks value against schema. inAllOf is set for the parts of an
+// allOf, whose properties are only complete together.
+func (v *validator) validate(schema *Schema, value interface{}, field string, inAllOf bool) {
+	if schema == nil {
+		return
+	}
+	schema = v.spec.schema(schema)
+
+	for _, part := range schema.AllOf {
+		v.validate(part, value, field, true)
+	}
+	if len(schema.AnyOf) > 0 && !v.matchesAny(schema.AnyOf, value, field) {
+		v.fail(field, "must match one of %d schemas", len(schema.AnyOf))
+		return
+	}
+
+	if len(schema.Type) > 0 && !schema.Type.matches(value) {
+		v.fail(field, "must be %s", strings.Join(schema.Type, " or "))
+		return
+	}
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		v.fail(field, "must be one of %s", formatEnum(schema.Enum))
+		return
+	}
+
+	switch value := value.(type) {
+	case string:
+		v.validateString(schema, value, field)
+	case json.Number:
+		number, _ := value.Float64()
+		if schema.Minimum != nil && number < *schema.Minimum {
+			v.fail(field, "must be at least %v", *schema.Minimum)
+		}
+		if schema.Maximum != nil && number > *schema.Maximum {
+			v.fail(field, "must be at most %v", *schema.Maximum)
+		}
+	case []interface{}:
+		if schema.MinItems != nil && len(value) < *schema.MinItems {
+			v.fail(field, "must have at least %d items", *schema.MinItems)
+		}
+		if schema.MaxItems != nil && len(value) > *schema.MaxItems {
+			v.fail(field, "must have at most %d items", *schema.MaxItems)
+		}
+		for i, item := range value {
+			v.validate(schema.Items, item, fmt.Sprintf("%s[%d]", field, i), false)
+		}
+	case map[string]interface{}:
+		v.validateObject(schema, value, field, inAllOf)
+	}
+}
+
+func (v *validator) validateString(schema *Schema, value string, field string) {
+	length := utf8.RuneCountInString(value)
+	if schema.MinLength != nil && length < *schema.MinLength {
+		if *schema.MinLength == 1 {
+			v.fail(field, "must not be empty")
+		} else {
+			v.fail(field, "must be at least %d characters", *schema.MinLength)
+		}
+	}
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		v.fail(field, "must be at most %d characters", *schema.MaxLength)
+	}
+	if schema.pattern != nil && !schema.pattern.MatchString(value) {
+		v.fail(field, "must match %s", schema.Pattern)
+	}
+
+	switch schema.Format {
+	case "uuid":
+		if len(value) != 36 || uuid.Validate(value) != nil {
+			v.fail(field, "must be a UUID")
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339Nano, value); err != nil {
+			v.fail(field, "must be an RFC 3339 date-time")
+		}
+	case "uri":
+		if parsed, err := url.Parse(value); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			v.fail(field, "must be an absolute URI")
+		}
+	}
+}
+
+func (v *validator) validateObject(schema *Schema, value map[string]interface{}, field string, inAllOf bool) {
+	for _, name := range schema.Required {
+		if _, ok := value[name]; !ok {
+			v.fail(join(field, name), "is required")
+		}
+	}
+
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if property, ok := schema.Properties[name]; ok {
+			v.validate(property, value[name], join(field, name), false)
+			continue
+		}
+
+		switch {
+		case inAllOf:
+			// The enclosing schema decides about properties its parts
+			// don't declare.
+		case schema.AdditionalProperties != nil && schema.AdditionalProperties.allowed:
+			v.validate(schema.AdditionalProperties.schema, value[name], join(field, name), false)
+		case schema.AdditionalProperties != nil,
+			v.strict && (len(schema.Properties) > 0 || len(schema.AllOf) > 0) && !v.spec.declares(schema, name):
+			v.fail(join(field, name), "is not allowed")
+		}
+	}
+}
+
+// declares reports whether schema or one of its allOf parts declares the
+// property.
+func (s *Spec) declares(schema *Schema, name string) bool {
+	schema = s.schema(schema)
+	if _, ok := schema.Properties[name]; ok {
+		return true
+	}
+	for _, part := range schema.AllOf {
+		if s.declares(part, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Properties returns the properties the named component schema declares,
+// including those of its allOf parts, with references followed. It returns
+// nil for an unknown name.
+func (s *Spec) Properties(name string) map[string]*Schema {
+	schema, ok := s.components.Schemas[name]
+	if !ok {
+		return nil
+	}
+	properties := make(map[string]*Schema)
+	s.collectProperties(schema, properties)
+	return properties
+}
+
+func (s *Spec) collectProperties(schema *Schema, properties map[string]*Schema) {
+	schema = s.schema(schema)
+	for name, property := range schema.Properties {
+		properties[name] = s.schema(property)
+	}
+	for _, part := range schema.AllOf {
+		s.collectProperties(part, properties)
+	}
+}
+
+// schema follows a $ref; references were checked by Parse.
+func (s *Spec) schema(schema *Schema) *Schema {
+	for schema.Ref != "" {
+		schema = s.components.Schemas[refName(schema.Ref, "schemas")]
+	}
+	return schema
+}
+
+// prepare compiles patterns and checks references once so validation never
+// has to mutate shared schemas.
+func (s *Spec) prepare(schema *Schema) error {
+	if schema == nil {
+		return nil
+	}
+	if schema.Ref != "" {
+		if _, ok := s.components.Schemas[refName(schema.Ref, "schemas")]; !ok {
+			return fmt.Errorf("unresolved reference %s", schema.Ref)
+		}
+	}
+	if schema.Pattern != "" {
+		pattern, err := regexp.Compile(schema.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", schema.Pattern, err)
+		}
+		schema.pattern = pattern
+	}
+
+	children := append([]*Schema{schema.Items}, schema.AllOf...)
+	children = append(children, schema.AnyOf...)
+	for _, property := range schema.Properties {
+		children = append(children, property)
+	}
+	if schema.AdditionalProperties != nil {
+		children = append(children, schema.AdditionalProperties.schema)
+	}
+	for _, child := range children {
+		if err := s.prepare(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *validator) matchesAny(options []*Schema, value interface{}, field string) bool {
+	for _, option := range options {
+		attempt := &validator{spec: v.spec, strict: v.strict}
+		attempt.validate(option, value, field, false)
+		if len(attempt.violations) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (t schemaTypes) matches(value interface{}) bool {
+	for _, name := range t {
+		if typeMatches(name, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func typeMatches(name string, value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return name == "null"
+	case bool:
+		return name == "boolean"
+	case string:
+		return name == "string"
+	case json.Number:
+		if name == "number" {
+			return true
+		}
+		if name != "integer" {
+			return false
+		}
+		number, err := value.Float64()
+		return err == nil && number == math.Trunc(number)
+	case []interface{}:
+		return name == "array"
+	case map[string]interface{}:
+		return name == "object"
+	}
+	return false
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if fmt.Sprint(allowed) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatEnum(enum []interface{}) string {
+	values := make([]string, len(enum))
+	for i, value := range enum {
+		values[i] = fmt.Sprint(value)
+	}
+	return strings.Join(values, ", ")
+}
+
+func join(field, name string) string {
+	if field == "" {
+		return name
+	}
+	return field + "." + name
+}
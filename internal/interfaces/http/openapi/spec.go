@@ -0,0 +1,286 @@
+// Package openapi holds the service's OpenAPI 3.1 document and validates
+// requests and responses against it.
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed openapi.yaml
+var document []byte
+
+// Spec is a parsed OpenAPI document. Only the parts needed for validation
+// are modelled; the full document is kept for serving.
+type Spec struct {
+	json       []byte
+	paths      map[string]*pathItem
+	components components
+}
+
+type pathItem struct {
+	Parameters []*Parameter `json:"parameters"`
+	Get        *Operation   `json:"get"`
+	Put        *Operation   `json:"put"`
+	Post       *Operation   `json:"post"`
+	Delete     *Operation   `json:"delete"`
+	Patch      *Operation   `json:"patch"`
+}
+
+type components struct {
+	Schemas       map[string]*Schema      `json:"schemas"`
+	Parameters    map[string]*Parameter   `json:"parameters"`
+	Headers       map[string]*Header      `json:"headers"`
+	Responses     map[string]*Response    `json:"responses"`
+	RequestBodies map[string]*RequestBody `json:"requestBodies"`
+}
+
+// Operation is one method on one path. Parameters include those declared on
+// the path item.
+type Operation struct {
+	Method      string                `json:"-"`
+	Path        string                `json:"-"`
+	ID          string                `json:"operationId"`
+	Parameters  []*Parameter          `json:"parameters"`
+	RequestBody *RequestBody          `json:"requestBody"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security"`
+}
+
+// MaxBodyBytes is the largest request body the operation accepts: its
+// x-max-bytes, or DefaultMaxBodyBytes.
+func (o *Operation) MaxBodyBytes() int64 {
+	if o.RequestBody != nil && o.RequestBody.MaxBytes > 0 {
+		return o.RequestBody.MaxBytes
+	}
+	return DefaultMaxBodyBytes
+}
+
+type Parameter struct {
+	Ref      string  `json:"$ref"`
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+type Header struct {
+	Ref    string  `json:"$ref"`
+	Schema *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Ref      string                `json:"$ref"`
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+	// MaxBytes caps the body size; zero means DefaultMaxBodyBytes.
+	MaxBytes int64 `json:"x-max-bytes"`
+}
+
+type Response struct {
+	Ref     string                `json:"$ref"`
+	Headers map[string]*Header    `json:"headers"`
+	Content map[string]*MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Load parses the embedded document.
+func Load() (*Spec, error) {
+	return Parse(document)
+}
+
+// Parse reads an OpenAPI document in YAML or JSON and resolves its parameter,
+// header, request body and response references.
+func Parse(data []byte) (*Spec, error) {
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI document: %w", err)
+	}
+
+	var doc struct {
+		OpenAPI    string               `json:"openapi"`
+		Paths      map[string]*pathItem `json:"paths"`
+		Components components           `json:"components"`
+	}
+	if err := json.Unmarshal(encoded, &doc); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.1") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q", doc.OpenAPI)
+	}
+
+	spec := &Spec{json: encoded, paths: doc.Paths, components: doc.Components}
+	if err := spec.resolve(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// JSON returns the document as served at /openapi.json.
+func (s *Spec) JSON() []byte {
+	return s.json
+}
+
+// Operation finds the operation for method on path, written in OpenAPI form
+// ("/api/v1/todo/{id}"). It returns nil for undocumented routes.
+func (s *Spec) Operation(method, path string) *Operation {
+	item, ok := s.paths[path]
+	if !ok {
+		return nil
+	}
+	return item.operations()[strings.ToUpper(method)]
+}
+
+// Operations lists every documented operation, sorted by path and method.
+func (s *Spec) Operations() []*Operation {
+	var operations []*Operation
+	for _, item := range s.paths {
+		for _, operation := range item.operations() {
+			operations = append(operations, operation)
+		}
+	}
+	sort.Slice(operations, func(i, j int) bool {
+		if operations[i].Path != operations[j].Path {
+			return operations[i].Path < operations[j].Path
+		}
+		return operations[i].Method < operations[j].Method
+	})
+	return operations
+}
+
+// PathFromRoute converts a gin route ("/todo/:id") to OpenAPI form
+// ("/todo/{id}").
+func PathFromRoute(route string) string {
+	segments := strings.Split(route, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func (p *pathItem) operations() map[string]*Operation {
+	operations := make(map[string]*Operation)
+	for method, operation := range map[string]*Operation{
+		http.MethodGet:    p.Get,
+		http.MethodPut:    p.Put,
+		http.MethodPost:   p.Post,
+		http.MethodDelete: p.Delete,
+		http.MethodPatch:  p.Patch,
+	} {
+		if operation != nil {
+			operations[method] = operation
+		}
+	}
+	return operations
+}
+
+func (s *Spec) resolve() error {
+	for name, schema := range s.components.Schemas {
+		if err := s.prepare(schema); err != nil {
+			return fmt.Errorf("schema %s: %w", name, err)
+		}
+	}
+
+	for path, item := range s.paths {
+		for method, operation := range item.operations() {
+			operation.Method, operation.Path = method, path
+
+			// Operation parameters override path-level ones with the same
+			// name and location.
+			parameters := make([]*Parameter, 0, len(item.Parameters)+len(operation.Parameters))
+			seen := make(map[string]bool)
+			for _, list := range [][]*Parameter{operation.Parameters, item.Parameters} {
+				for _, parameter := range list {
+					resolved, err := s.parameter(parameter)
+					if err != nil {
+						return fmt.Errorf("%s %s: %w", method, path, err)
+					}
+					key := resolved.In + ":" + resolved.Name
+					if !seen[key] {
+						seen[key] = true
+						parameters = append(parameters, resolved)
+					}
+				}
+			}
+			operation.Parameters = parameters
+
+			if operation.RequestBody != nil && operation.RequestBody.Ref != "" {
+				body, ok := s.components.RequestBodies[refName(operation.RequestBody.Ref, "requestBodies")]
+				if !ok {
+					return fmt.Errorf("%s %s: unresolved reference %s", method, path, operation.RequestBody.Ref)
+				}
+				operation.RequestBody = body
+			}
+
+			for status, response := range operation.Responses {
+				if response.Ref == "" {
+					continue
+				}
+				resolved, ok := s.components.Responses[refName(response.Ref, "responses")]
+				if !ok {
+					return fmt.Errorf("%s %s: unresolved reference %s", method, path, response.Ref)
+				}
+				operation.Responses[status] = resolved
+			}
+
+			if err := s.prepareOperation(operation); err != nil {
+				return fmt.Errorf("%s %s: %w", method, path, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Spec) prepareOperation(operation *Operation) error {
+	var schemas []*Schema
+	for _, parameter := range operation.Parameters {
+		schemas = append(schemas, parameter.Schema)
+	}
+	if operation.RequestBody != nil {
+		for _, media := range operation.RequestBody.Content {
+			schemas = append(schemas, media.Schema)
+		}
+	}
+	for _, response := range operation.Responses {
+		for _, media := range response.Content {
+			schemas = append(schemas, media.Schema)
+		}
+	}
+
+	for _, schema := range schemas {
+		if err := s.prepare(schema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Spec) parameter(parameter *Parameter) (*Parameter, error) {
+	if parameter.Ref == "" {
+		return parameter, nil
+	}
+	resolved, ok := s.components.Parameters[refName(parameter.Ref, "parameters")]
+	if !ok {
+		return nil, fmt.Errorf("unresolved reference %s", parameter.Ref)
+	}
+	return resolved, nil
+}
+
+func refName(ref, kind string) string {
+	return strings.TrimPrefix(ref, "#/components/"+kind+"/")
+}
@@ -0,0 +1,268 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxMultipartMemory matches the limit the idempotency middleware parses
+// uploads with, so the form is only read once.
+const maxMultipartMemory = 32 << 20
+
+// DefaultMaxBodyBytes caps request bodies whose operation does not set
+// x-max-bytes.
+const DefaultMaxBodyBytes = 1 << 20
+
+var ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+// ValidateRequest checks r's parameters and body against operation and
+// returns every mismatch as Violations. pathParams holds the values the
+// router matched. A JSON body is read and replaced so handlers can still
+// bind it; a multipart body is parsed into r.MultipartForm. Bodies beyond
+// the operation's size limit fail with *http.MaxBytesError.
+func (s *Spec) ValidateRequest(operation *Operation, r *http.Request, pathParams map[string]string) error {
+	var violations Violations
+
+	query := r.URL.Query()
+	for _, parameter := range operation.Parameters {
+		var value string
+		var present bool
+		switch parameter.In {
+		case "path":
+			value, present = pathParams[parameter.Name]
+		case "query":
+			present = query.Has(parameter.Name)
+			value = query.Get(parameter.Name)
+		case "header":
+			value = r.Header.Get(parameter.Name)
+			present = value != ""
+		default:
+			continue
+		}
+
+		field := parameter.Name
+		if !present {
+			if parameter.Required {
+				violations = append(violations, Violation{Field: field, Message: "is required"})
+			}
+			continue
+		}
+
+		decoded, ok := s.coerce(parameter.Schema, value)
+		if !ok {
+			violations = append(violations, Violation{Field: field, Message: "must be " + strings.Join(s.schema(parameter.Schema).Type, " or ")})
+			continue
+		}
+		violations = append(violations, s.ValidateValue(parameter.Schema, decoded, field)...)
+	}
+
+	if operation.RequestBody != nil {
+		bodyViolations, err := s.validateRequestBody(operation.RequestBody, operation.MaxBodyBytes(), r)
+		if err != nil {
+			return err
+		}
+		violations = append(violations, bodyViolations...)
+	}
+
+	if len(violations) > 0 {
+		return violations
+	}
+	return nil
+}
+
+func (s *Spec) validateRequestBody(body *RequestBody, limit int64, r *http.Request) (Violations, error) {
+	if r.Body == nil || r.Body == http.NoBody || r.ContentLength == 0 {
+		if body.Required {
+			return Violations{{Field: "body", Message: "is required"}}, nil
+		}
+		return nil, nil
+	}
+
+	mediaType, media := lookupMedia(body.Content, r.Header.Get("Content-Type"))
+	if media == nil {
+		return nil, fmt.Errorf("%w %q: expected %s", ErrUnsupportedMediaType, r.Header.Get("Content-Type"), strings.Join(mediaTypes(body.Content), " or "))
+	}
+
+	if r.ContentLength > limit {
+		return nil, &http.MaxBytesError{Limit: limit}
+	}
+	r.Body = http.MaxBytesReader(nil, r.Body, limit)
+
+	switch {
+	case mediaType == "multipart/form-data":
+		if err := r.ParseMultipartForm(maxMultipartMemory); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return nil, err
+			}
+			return Violations{{Field: "body", Message: "must be a valid multipart form: " + err.Error()}}, nil
+		}
+		return s.validateForm(media.Schema, r), nil
+
+	case isJSON(mediaType):
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		r.Body = io.NopCloser(bytes.NewReader(data))
+
+		value, err := decodeJSON(data)
+		if err != nil {
+			return Violations{{Field: "body", Message: "must be valid JSON: " + err.Error()}}, nil
+		}
+		return atBody(s.ValidateValue(media.Schema, value, "")), nil
+	}
+
+	return nil, nil
+}
+
+// atBody names the violations of a body as a whole "body"; those of its
+// properties keep their JSON path.
+func atBody(violations Violations) Violations {
+	for i := range violations {
+		if violations[i].Field == "" {
+			violations[i].Field = "body"
+		}
+	}
+	return violations
+}
+
+// validateForm only checks that required parts are present; their contents
+// are the handler's business.
+func (s *Spec) validateForm(schema *Schema, r *http.Request) Violations {
+	if schema == nil {
+		return nil
+	}
+
+	var violations Violations
+	form := r.MultipartForm
+	for _, name := range s.schema(schema).Required {
+		if len(form.File[name]) == 0 && len(form.Value[name]) == 0 {
+			violations = append(violations, Violation{Field: name, Message: "is required"})
+		}
+	}
+	return violations
+}
+
+// ValidateResponse checks that status is documented for operation and that
+// the body matches the documented media type and schema, without any
+// undocumented properties.
+func (s *Spec) ValidateResponse(operation *Operation, status int, header http.Header, body []byte) error {
+	response := lookupResponse(operation.Responses, status)
+	if response == nil {
+		return fmt.Errorf("%s %s: status %d is not documented", operation.Method, operation.Path, status)
+	}
+
+	if len(response.Content) == 0 {
+		if len(body) > 0 {
+			return fmt.Errorf("%s %s: status %d is documented without a body", operation.Method, operation.Path, status)
+		}
+		return nil
+	}
+
+	mediaType, media := lookupMedia(response.Content, header.Get("Content-Type"))
+	if media == nil {
+		return fmt.Errorf("%s %s: status %d: %w %q", operation.Method, operation.Path, status, ErrUnsupportedMediaType, header.Get("Content-Type"))
+	}
+	if !isJSON(mediaType) {
+		return nil
+	}
+
+	value, err := decodeJSON(body)
+	if err != nil {
+		return fmt.Errorf("%s %s: status %d: invalid JSON: %w", operation.Method, operation.Path, status, err)
+	}
+	v := &validator{spec: s, strict: true}
+	v.validate(media.Schema, value, "", false)
+	if len(v.violations) > 0 {
+		return fmt.Errorf("%s %s: status %d: %w", operation.Method, operation.Path, status, atBody(v.violations))
+	}
+	return nil
+}
+
+// coerce turns a parameter's string value into the JSON value its schema
+// describes.
+func (s *Spec) coerce(schema *Schema, value string) (interface{}, bool) {
+	if schema == nil {
+		return value, true
+	}
+
+	types := s.schema(schema).Type
+	for _, name := range types {
+		switch name {
+		case "integer", "number":
+			if _, err := strconv.ParseFloat(value, 64); err == nil {
+				return json.Number(value), true
+			}
+		case "boolean":
+			if parsed, err := strconv.ParseBool(value); err == nil {
+				return parsed, true
+			}
+		case "string":
+			return value, true
+		}
+	}
+	return nil, len(types) == 0
+}
+
+func lookupResponse(responses map[string]*Response, status int) *Response {
+	code := strconv.Itoa(status)
+	for _, key := range []string{code, code[:1] + "XX", "default"} {
+		if response, ok := responses[key]; ok {
+			return response
+		}
+	}
+	return nil
+}
+
+// lookupMedia matches a Content-Type against documented media types,
+// including ranges such as "text/*" and "*/*".
+func lookupMedia(content map[string]*MediaType, contentType string) (string, *MediaType) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", nil
+	}
+
+	major, _, _ := strings.Cut(mediaType, "/")
+	for _, key := range []string{mediaType, major + "/*", "*/*"} {
+		if media, ok := content[key]; ok {
+			return mediaType, media
+		}
+	}
+	return mediaType, nil
+}
+
+func mediaTypes(content map[string]*MediaType) []string {
+	types := make([]string, 0, len(content))
+	for mediaType := range content {
+		types = append(types, mediaType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func decodeJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+	return value, nil
+}
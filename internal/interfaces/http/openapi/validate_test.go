@@ -0,0 +1,294 @@
+package openapi
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTodoID = "6f1c0a52-3b0e-4c55-9f43-3f7d2f0d8a11"
+
+func loadSpec(t *testing.T) *Spec {
+	t.Helper()
+	spec, err := Load()
+	require.NoError(t, err)
+	return spec
+}
+
+func TestLoad_ResolvesEveryOperation(t *testing.T) {
+	spec := loadSpec(t)
+
+	for _, operation := range spec.Operations() {
+		assert.NotEmpty(t, operation.ID, "%s %s has no operationId", operation.Method, operation.Path)
+		assert.NotEmpty(t, operation.Responses, "%s %s has no responses", operation.Method, operation.Path)
+	}
+
+	getTodo := spec.Operation(http.MethodGet, "/api/v1/todo/{id}")
+	require.NotNil(t, getTodo)
+	assert.Equal(t, "getTodo", getTodo.ID)
+	assert.Nil(t, spec.Operation(http.MethodPatch, "/api/v1/todo/{id}"))
+}
+
+func TestPathFromRoute(t *testing.T) {
+	assert.Equal(t, "/api/v1/todo/{id}/shares/{user_id}", PathFromRoute("/api/v1/todo/:id/shares/:user_id"))
+	assert.Equal(t, "/live", PathFromRoute("/live"))
+}
+
+func TestValidateRequest(t *testing.T) {
+	spec := loadSpec(t)
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		target      string
+		pathParams  map[string]string
+		contentType string
+		header      http.Header
+		body        string
+		want        Violations
+		wantErr     error
+	}{
+		{
+			name:        "valid create",
+			method:      http.MethodPost,
+			path:        "/api/v1/todo",
+			contentType: "application/json",
+			body:        `{"description":"Ship it","due_date":"2030-01-02T15:00:00Z","file_id":"` + testTodoID + `"}`,
+		},
+		{
+			name:        "every body violation at once",
+			method:      http.MethodPost,
+			path:        "/api/v1/todo",
+			contentType: "application/json",
+			body:        `{"description":"","due_date":"tomorrow","file_id":"abc","priority":1}`,
+			want: Violations{
+				{Field: "description", Message: "must not be empty"},
+				{Field: "due_date", Message: "must be an RFC 3339 date-time"},
+				{Field: "file_id", Message: "must be a UUID"},
+				{Field: "priority", Message: "is not allowed"},
+			},
+		},
+		{
+			name:        "missing required fields",
+			method:      http.MethodPost,
+			path:        "/api/v1/todo",
+			contentType: "application/json; charset=utf-8",
+			body:        `{}`,
+			want: Violations{
+				{Field: "description", Message: "is required"},
+				{Field: "due_date", Message: "is required"},
+			},
+		},
+		{
+			name:        "wrong type",
+			method:      http.MethodPost,
+			path:        "/api/v1/todo",
+			contentType: "application/json",
+			body:        `{"description":42,"due_date":"2030-01-02T15:00:00Z"}`,
+			want:        Violations{{Field: "description", Message: "must be string"}},
+		},
+		{
+			name:        "malformed JSON",
+			method:      http.MethodPost,
+			path:        "/api/v1/todo",
+			contentType: "application/json",
+			body:        `{"description":`,
+			want:        Violations{{Field: "body", Message: "must be valid JSON: unexpected EOF"}},
+		},
+		{
+			name:   "missing body",
+			method: http.MethodPost,
+			path:   "/api/v1/todo",
+			want:   Violations{{Field: "body", Message: "is required"}},
+		},
+		{
+			name:        "unsupported media type",
+			method:      http.MethodPost,
+			path:        "/api/v1/todo",
+			contentType: "application/x-www-form-urlencoded",
+			body:        `description=Ship+it`,
+			wantErr:     ErrUnsupportedMediaType,
+		},
+		{
+			name:       "path and query parameters",
+			method:     http.MethodGet,
+			path:       "/api/v1/webhooks/{id}/deliveries",
+			target:     "?limit=ten",
+			pathParams: map[string]string{"id": "not-a-uuid"},
+			want: Violations{
+				{Field: "limit", Message: "must be integer"},
+				{Field: "id", Message: "must be a UUID"},
+			},
+		},
+		{
+			name:   "negative offset",
+			method: http.MethodGet,
+			path:   "/api/v1/todo",
+			target: "?limit=10&offset=-1",
+			want:   Violations{{Field: "offset", Message: "must be at least 0"}},
+		},
+		{
+			name:        "enum and nested items",
+			method:      http.MethodPost,
+			path:        "/api/v1/sync",
+			contentType: "application/json",
+			body:        `{"changes":[{"op":"create","id":"` + testTodoID + `"},{"op":"upsert"}]}`,
+			want:        Violations{{Field: "changes[1].op", Message: "must be one of create, update, delete"}},
+		},
+		{
+			name:        "idempotency key header",
+			method:      http.MethodPost,
+			path:        "/api/v1/todo",
+			contentType: "application/json",
+			header:      http.Header{"Idempotency-Key": {strings.Repeat("k", 256)}},
+			body:        `{"description":"Ship it","due_date":"2030-01-02T15:00:00Z"}`,
+			want:        Violations{{Field: "Idempotency-Key", Message: "must be at most 255 characters"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operation := spec.Operation(tt.method, tt.path)
+			require.NotNil(t, operation)
+
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(tt.method, "/x"+tt.target, body)
+			for name, values := range tt.header {
+				req.Header[name] = values
+			}
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+
+			err := spec.ValidateRequest(operation, req, tt.pathParams)
+
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.want == nil:
+				assert.NoError(t, err)
+			default:
+				var violations Violations
+				require.ErrorAs(t, err, &violations)
+				assert.Equal(t, tt.want, violations)
+			}
+		})
+	}
+}
+
+func TestValidateRequest_KeepsJSONBodyReadable(t *testing.T) {
+	spec := loadSpec(t)
+	body := `{"user_id":"bob","role":"viewer"}`
+
+	req := httptest.NewRequest(http.MethodPost, "/x", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	require.NoError(t, spec.ValidateRequest(spec.Operation(http.MethodPost, "/api/v1/todo/{id}/shares"), req, map[string]string{"id": testTodoID}))
+
+	replayed, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(replayed))
+}
+
+func TestValidateRequest_Multipart(t *testing.T) {
+	spec := loadSpec(t)
+	operation := spec.Operation(http.MethodPost, "/api/v1/upload")
+
+	upload := func(field string) *http.Request {
+		var buf bytes.Buffer
+		writer := multipart.NewWriter(&buf)
+		part, err := writer.CreateFormFile(field, "notes.txt")
+		require.NoError(t, err)
+		part.Write([]byte("hello"))
+		require.NoError(t, writer.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/x", &buf)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		return req
+	}
+
+	req := upload("file")
+	require.NoError(t, spec.ValidateRequest(operation, req, nil))
+	_, header, err := req.FormFile("file")
+	require.NoError(t, err)
+	assert.Equal(t, "notes.txt", header.Filename)
+
+	err = spec.ValidateRequest(operation, upload("attachment"), nil)
+	assert.Equal(t, Violations{{Field: "file", Message: "is required"}}, err)
+}
+
+func TestValidateRequest_LimitsBodySize(t *testing.T) {
+	spec := loadSpec(t)
+	createTodo := spec.Operation(http.MethodPost, "/api/v1/todo")
+	body := `{"description":"` + strings.Repeat("a", DefaultMaxBodyBytes) + `"}`
+
+	// Declared lengths are rejected up front, streamed bodies while reading.
+	req := httptest.NewRequest(http.MethodPost, "/x", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	var tooLarge *http.MaxBytesError
+	require.ErrorAs(t, spec.ValidateRequest(createTodo, req, nil), &tooLarge)
+	assert.Equal(t, int64(DefaultMaxBodyBytes), tooLarge.Limit)
+
+	req = httptest.NewRequest(http.MethodPost, "/x", io.NopCloser(strings.NewReader(body)))
+	req.Header.Set("Content-Type", "application/json")
+	req.ContentLength = -1
+	assert.ErrorAs(t, spec.ValidateRequest(createTodo, req, nil), &tooLarge)
+
+	// Uploads get the larger limit their operation declares.
+	upload := spec.Operation(http.MethodPost, "/api/v1/upload")
+	require.Greater(t, upload.RequestBody.MaxBytes, int64(DefaultMaxBodyBytes))
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	part, err := writer.CreateFormFile("file", "big.bin")
+	require.NoError(t, err)
+	part.Write(bytes.Repeat([]byte{0}, 2*DefaultMaxBodyBytes))
+	require.NoError(t, writer.Close())
+
+	req = httptest.NewRequest(http.MethodPost, "/x", &buf)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	assert.NoError(t, spec.ValidateRequest(upload, req, nil))
+
+	buf.Reset()
+	writer = multipart.NewWriter(&buf)
+	part, err = writer.CreateFormFile("file", "huge.bin")
+	require.NoError(t, err)
+	part.Write(bytes.Repeat([]byte{0}, int(upload.RequestBody.MaxBytes)))
+	require.NoError(t, writer.Close())
+
+	req = httptest.NewRequest(http.MethodPost, "/x", io.NopCloser(&buf))
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.ContentLength = -1
+	assert.ErrorAs(t, spec.ValidateRequest(upload, req, nil), &tooLarge)
+}
+
+func TestValidateResponse(t *testing.T) {
+	spec := loadSpec(t)
+	operation := spec.Operation(http.MethodGet, "/api/v1/todo/{id}")
+	jsonHeader := http.Header{"Content-Type": {"application/json; charset=utf-8"}}
+
+	valid := `{"data":{"id":"` + testTodoID + `","tenant_id":"acme","owner_id":"u","description":"d",` +
+		`"due_date":"2030-01-02T15:00:00Z","completed_at":null,"created_at":"2030-01-01T00:00:00Z",` +
+		`"updated_at":"2030-01-01T00:00:00Z","version":1}}`
+	assert.NoError(t, spec.ValidateResponse(operation, http.StatusOK, jsonHeader, []byte(valid)))
+	assert.NoError(t, spec.ValidateResponse(operation, http.StatusNotModified, http.Header{}, nil))
+
+	missingVersion := strings.Replace(valid, `,"version":1`, "", 1)
+	assert.ErrorContains(t, spec.ValidateResponse(operation, http.StatusOK, jsonHeader, []byte(missingVersion)), "data.version: is required")
+
+	undocumented := strings.Replace(valid, `"version":1`, `"version":1,"priority":"high"`, 1)
+	assert.ErrorContains(t, spec.ValidateResponse(operation, http.StatusOK, jsonHeader, []byte(undocumented)), "data.priority: is not allowed")
+
+	assert.ErrorContains(t, spec.ValidateResponse(operation, http.StatusTeapot, jsonHeader, []byte(`{}`)), "status 418 is not documented")
+	assert.ErrorContains(t, spec.ValidateResponse(operation, http.StatusOK, http.Header{"Content-Type": {"text/plain"}}, []byte("hi")), "unsupported media type")
+}
@@ -0,0 +1,95 @@
+// Package problem writes HTTP errors as RFC 7807 problem details, so every
+// handler and middleware fails with the same document shape.
+package problem
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/interfaces/apierr"
+	"todo-service/internal/logging"
+	"todo-service/internal/usecases"
+)
+
+const (
+	ContentType = "application/problem+json"
+
+	typePrefix      = "urn:todo-service:problem:"
+	requestIDHeader = "X-Request-ID"
+)
+
+// Problem is an RFC 7807 problem details document. Code is the stable,
+// machine-readable cause; Type is derived from it.
+type Problem struct {
+	Type      string                         `json:"type"`
+	Title     string                         `json:"title"`
+	Status    int                            `json:"status"`
+	Detail    string                         `json:"detail,omitempty"`
+	Instance  string                         `json:"instance,omitempty"`
+	Code      string                         `json:"code"`
+	RequestID string                         `json:"request_id,omitempty"`
+	Errors    []entities.FieldViolation      `json:"errors,omitempty"`
+	Quota     *usecases.StorageUsageResponse `json:"quota,omitempty"`
+}
+
+func New(status int, code, detail string) *Problem {
+	return &Problem{
+		Type:   typePrefix + code,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// FromError builds the problem err is reported with. Internal errors get
+// message as their detail instead of anything taken from err.
+func FromError(message string, err error) *Problem {
+	class := apierr.Classify(err)
+
+	detail := class.Detail
+	if class.Internal() {
+		detail = message
+	}
+
+	p := New(class.HTTPStatus, class.Code, detail)
+	p.Errors = class.Violations
+
+	var quotaErr *entities.QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		p.Quota = usecases.NewStorageUsageResponse(&quotaErr.Usage, quotaErr.Quota)
+	}
+	return p
+}
+
+// Respond reports err and aborts the request. The cause of internal errors
+// is logged server-side only.
+func Respond(c *gin.Context, message string, err error) {
+	p := FromError(message, err)
+	if p.Status >= http.StatusInternalServerError {
+		logging.FromContext(c.Request.Context()).Error(message,
+			zap.Int("status", p.Status),
+			zap.String("code", p.Code),
+			zap.Error(err),
+		)
+	}
+	Write(c, p)
+}
+
+// Invalid reports a request that failed validation.
+func Invalid(c *gin.Context, violations ...entities.FieldViolation) {
+	Respond(c, "Invalid request", entities.NewValidationError(violations...))
+}
+
+// Write sends p and aborts the request.
+func Write(c *gin.Context, p *Problem) {
+	p.Instance = c.Request.URL.Path
+	p.RequestID = c.Writer.Header().Get(requestIDHeader)
+
+	c.Header("Content-Type", ContentType)
+	c.AbortWithStatusJSON(p.Status, p)
+}
@@ -0,0 +1,159 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/logging"
+)
+
+func TestFromError(t *testing.T) {
+	violation := entities.FieldViolation{Field: "description", Code: "required", Message: "is required"}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+		wantErrors []entities.FieldViolation
+	}{
+		{
+			name:       "validation",
+			err:        fmt.Errorf("failed to create todo: %w", entities.NewValidationError(violation)),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "validation_failed",
+			wantDetail: "validation failed: description: is required",
+			wantErrors: []entities.FieldViolation{violation},
+		},
+		{
+			name:       "invalid file",
+			err:        &entities.FileValidationError{Reason: entities.FileRejectedType, Message: "file type .exe is not allowed"},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "invalid_file",
+			wantDetail: "file type .exe is not allowed",
+			wantErrors: []entities.FieldViolation{{Field: "file", Code: entities.FileRejectedType, Message: "file type .exe is not allowed"}},
+		},
+		{
+			name:       "not found",
+			err:        fmt.Errorf("failed to get todo from mysql: %w", entities.ErrTodoNotFound),
+			wantStatus: http.StatusNotFound,
+			wantCode:   "todo_not_found",
+			wantDetail: "todo not found",
+		},
+		{
+			name:       "version mismatch",
+			err:        entities.ErrTodoVersionMismatch,
+			wantStatus: http.StatusPreconditionFailed,
+			wantCode:   "version_mismatch",
+			wantDetail: "todo version does not match",
+		},
+		{
+			name:       "conflict",
+			err:        entities.ErrIdempotencyKeyInUse,
+			wantStatus: http.StatusConflict,
+			wantCode:   "request_in_progress",
+			wantDetail: entities.ErrIdempotencyKeyInUse.Error(),
+		},
+		{
+			name:       "unavailable",
+			err:        entities.NewUnavailableError("file storage", errors.New("dial tcp 10.0.0.7:9000: connection refused")),
+			wantStatus: http.StatusServiceUnavailable,
+			wantCode:   "service_unavailable",
+			wantDetail: "Failed to do it",
+		},
+		{
+			name:       "internal",
+			err:        errors.New("Error 1146: Table 'todos.todo_items' doesn't exist"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "internal_error",
+			wantDetail: "Failed to do it",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := FromError("Failed to do it", tt.err)
+
+			assert.Equal(t, tt.wantStatus, p.Status)
+			assert.Equal(t, tt.wantCode, p.Code)
+			assert.Equal(t, "urn:todo-service:problem:"+tt.wantCode, p.Type)
+			assert.Equal(t, http.StatusText(tt.wantStatus), p.Title)
+			assert.Equal(t, tt.wantDetail, p.Detail)
+			assert.Equal(t, tt.wantErrors, p.Errors)
+		})
+	}
+}
+
+func TestFromError_Quota(t *testing.T) {
+	err := &entities.QuotaExceededError{
+		Quota:     entities.StorageQuota{MaxBytes: 100},
+		Usage:     entities.StorageUsage{Bytes: 90, Files: 1},
+		Requested: 20,
+	}
+
+	p := FromError("Failed to upload file", err)
+	assert.Equal(t, http.StatusInsufficientStorage, p.Status)
+	assert.Equal(t, "quota_exceeded", p.Code)
+	require.NotNil(t, p.Quota)
+	assert.Equal(t, int64(10), *p.Quota.RemainingBytes)
+}
+
+func TestRespond_LogsInternalErrorsOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	cause := errors.New("dial tcp 10.0.0.7:3306: connection refused")
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Header(requestIDHeader, "req-123")
+		c.Request = c.Request.WithContext(logging.WithLogger(c.Request.Context(), zap.New(core)))
+	})
+	router.GET("/todo", func(c *gin.Context) {
+		Respond(c, "Failed to list todos", fmt.Errorf("failed to list todos: %w", cause))
+	})
+	router.GET("/todo/:id", func(c *gin.Context) {
+		Respond(c, "Failed to get todo", entities.ErrTodoNotFound)
+	})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/todo", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Equal(t, ContentType, recorder.Header().Get("Content-Type"))
+	assert.NotContains(t, recorder.Body.String(), "10.0.0.7")
+
+	var body Problem
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	assert.Equal(t, Problem{
+		Type:      "urn:todo-service:problem:internal_error",
+		Title:     "Internal Server Error",
+		Status:    http.StatusInternalServerError,
+		Detail:    "Failed to list todos",
+		Instance:  "/todo",
+		Code:      "internal_error",
+		RequestID: "req-123",
+	}, body)
+
+	logged := logs.FilterMessage("Failed to list todos").All()
+	require.Len(t, logged, 1)
+	assert.Contains(t, logged[0].ContextMap()["error"], "10.0.0.7")
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/todo/42", nil))
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Equal(t, 1, logs.Len())
+}
@@ -0,0 +1,56 @@
+// Package logging carries a request-scoped zap logger through
+// context.Context, so use cases and adapters log with the request ID, trace
+// ID and principal of the request they are serving.
+package logging
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"todo-service/internal/domain/entities"
+)
+
+type loggerContextKey struct{}
+
+// WithLogger returns a copy of ctx carrying logger.
+func WithLogger(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// FromContext returns the logger stored in ctx, or the global zap logger,
+// with the current trace and the authenticated principal attached.
+func FromContext(ctx context.Context) *zap.Logger {
+	logger, ok := ctx.Value(loggerContextKey{}).(*zap.Logger)
+	if !ok {
+		logger = zap.L()
+	}
+
+	var fields []zap.Field
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		fields = append(fields,
+			zap.String("trace_id", span.TraceID().String()),
+			zap.String("span_id", span.SpanID().String()))
+	}
+	if principal, ok := entities.PrincipalFromContext(ctx); ok {
+		fields = append(fields, principalFields(principal)...)
+	}
+
+	if len(fields) == 0 {
+		return logger
+	}
+	return logger.With(fields...)
+}
+
+func principalFields(principal *entities.Principal) []zap.Field {
+	fields := []zap.Field{
+		zap.String("user_id", principal.ID),
+		zap.String("tenant_id", principal.TenantID),
+		zap.String("auth_method", principal.Method),
+	}
+	if principal.APIKeyID != "" {
+		fields = append(fields, zap.String("api_key_id", principal.APIKeyID))
+	}
+	return fields
+}
@@ -0,0 +1,118 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+)
+
+// lastUsedResolution bounds how often a busy key writes its last-used
+// timestamp back to the database.
+const lastUsedResolution = time.Minute
+
+type APIKeyUseCase struct {
+	repo ports.APIKeyRepository
+}
+
+func NewAPIKeyUseCase(repo ports.APIKeyRepository) *APIKeyUseCase {
+	return &APIKeyUseCase{
+		repo: repo,
+	}
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required"`
+	Scopes    []string   `json:"scopes" binding:"required"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type CreateAPIKeyResponse struct {
+	*entities.APIKey
+	Key string `json:"key"`
+}
+
+// CreateAPIKey issues a key for the caller. A key can never carry a scope
+// the caller does not hold itself.
+func (uc *APIKeyUseCase) CreateAPIKey(ctx context.Context, req CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	principal, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key, plaintext, err := entities.NewAPIKey(principal.TenantID, principal.ID, req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := key.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid api key: %w", err)
+	}
+
+	for _, scope := range key.Scopes {
+		if !principal.HasScope(scope) {
+			return nil, fmt.Errorf("%w: cannot grant %s", entities.ErrInsufficientScope, scope)
+		}
+	}
+
+	if err := uc.repo.Create(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return &CreateAPIKeyResponse{APIKey: key, Key: plaintext}, nil
+}
+
+func (uc *APIKeyUseCase) ListAPIKeys(ctx context.Context) ([]*entities.APIKey, error) {
+	principal, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := uc.repo.List(ctx, principal.TenantID, principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (uc *APIKeyUseCase) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	principal, err := caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	return uc.repo.Revoke(ctx, principal.TenantID, principal.ID, id, time.Now())
+}
+
+// Verify resolves a plaintext API key to the principal it acts as, so API
+// keys can be plugged into the same authentication middleware as JWTs.
+func (uc *APIKeyUseCase) Verify(ctx context.Context, plaintext string) (*entities.Principal, error) {
+	if !entities.LooksLikeAPIKey(plaintext) {
+		return nil, fmt.Errorf("%w: malformed api key", entities.ErrInvalidCredentials)
+	}
+
+	key, err := uc.repo.GetByHash(ctx, entities.HashAPIKey(plaintext))
+	if errors.Is(err, entities.ErrAPIKeyNotFound) {
+		return nil, fmt.Errorf("%w: unknown api key", entities.ErrInvalidCredentials)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !key.IsUsable(now) {
+		return nil, fmt.Errorf("%w: api key is revoked or expired", entities.ErrInvalidCredentials)
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		// Last-used tracking is best effort and must not fail the request.
+		uc.repo.TouchLastUsed(ctx, key.ID, now)
+	}
+
+	return key.Principal(), nil
+}
@@ -0,0 +1,146 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports/mocks"
+)
+
+func TestCreateAPIKey_StoresOnlyHash(t *testing.T) {
+	mockRepo := mocks.NewMockAPIKeyRepository(t)
+
+	var stored *entities.APIKey
+	mockRepo.EXPECT().Create(mock.Anything, mock.AnythingOfType("*entities.APIKey")).
+		Run(func(ctx context.Context, key *entities.APIKey) { stored = key }).
+		Return(nil)
+
+	useCase := NewAPIKeyUseCase(mockRepo)
+
+	resp, err := useCase.CreateAPIKey(authContext(), CreateAPIKeyRequest{
+		Name:   "ci",
+		Scopes: []string{entities.ScopeTodosRead},
+	})
+
+	require.NoError(t, err)
+	assert.True(t, entities.LooksLikeAPIKey(resp.Key))
+	assert.Equal(t, entities.HashAPIKey(resp.Key), stored.KeyHash)
+	assert.NotContains(t, stored.KeyHash, resp.Key)
+	assert.Equal(t, testOwnerID, stored.OwnerID)
+	assert.Equal(t, resp.Key[:len(stored.Prefix)], stored.Prefix)
+}
+
+func TestCreateAPIKey_RejectsUnknownScope(t *testing.T) {
+	useCase := NewAPIKeyUseCase(mocks.NewMockAPIKeyRepository(t))
+
+	_, err := useCase.CreateAPIKey(authContext(), CreateAPIKeyRequest{
+		Name:   "ci",
+		Scopes: []string{"todos:admin"},
+	})
+
+	assert.ErrorContains(t, err, "unknown scope")
+}
+
+func TestCreateAPIKey_CannotEscalateScopes(t *testing.T) {
+	useCase := NewAPIKeyUseCase(mocks.NewMockAPIKeyRepository(t))
+
+	ctx := entities.ContextWithPrincipal(context.Background(), &entities.Principal{
+		ID:       testOwnerID,
+		TenantID: testTenantID,
+		Method:   entities.AuthMethodJWT,
+		Scopes:   []string{entities.ScopeTodosRead},
+	})
+
+	_, err := useCase.CreateAPIKey(ctx, CreateAPIKeyRequest{
+		Name:   "ci",
+		Scopes: []string{entities.ScopeTodosRead, entities.ScopeTodosWrite},
+	})
+
+	assert.ErrorIs(t, err, entities.ErrInsufficientScope)
+}
+
+func TestCreateAPIKey_RequiresCaller(t *testing.T) {
+	useCase := NewAPIKeyUseCase(mocks.NewMockAPIKeyRepository(t))
+
+	_, err := useCase.CreateAPIKey(context.Background(), CreateAPIKeyRequest{Name: "ci"})
+
+	assert.ErrorIs(t, err, entities.ErrUnauthenticated)
+}
+
+func TestVerifyAPIKey(t *testing.T) {
+	key, plaintext, err := entities.NewAPIKey(testTenantID, testOwnerID, "ci", []string{entities.ScopeTodosRead}, nil)
+	require.NoError(t, err)
+
+	mockRepo := mocks.NewMockAPIKeyRepository(t)
+	mockRepo.EXPECT().GetByHash(mock.Anything, key.KeyHash).Return(key, nil)
+	mockRepo.EXPECT().TouchLastUsed(mock.Anything, key.ID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	useCase := NewAPIKeyUseCase(mockRepo)
+
+	principal, err := useCase.Verify(context.Background(), plaintext)
+
+	require.NoError(t, err)
+	assert.Equal(t, testOwnerID, principal.ID)
+	assert.Equal(t, entities.AuthMethodAPIKey, principal.Method)
+	assert.Equal(t, key.ID.String(), principal.APIKeyID)
+	assert.True(t, principal.HasScope(entities.ScopeTodosRead))
+	assert.False(t, principal.HasScope(entities.ScopeTodosWrite))
+}
+
+func TestVerifyAPIKey_SkipsRecentLastUsedUpdate(t *testing.T) {
+	key, plaintext, err := entities.NewAPIKey(testTenantID, testOwnerID, "ci", []string{entities.ScopeTodosRead}, nil)
+	require.NoError(t, err)
+	recent := time.Now().Add(-10 * time.Second)
+	key.LastUsedAt = &recent
+
+	mockRepo := mocks.NewMockAPIKeyRepository(t)
+	mockRepo.EXPECT().GetByHash(mock.Anything, key.KeyHash).Return(key, nil)
+
+	useCase := NewAPIKeyUseCase(mockRepo)
+
+	_, err = useCase.Verify(context.Background(), plaintext)
+
+	assert.NoError(t, err)
+}
+
+func TestVerifyAPIKey_RejectsUnusableKeys(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		modify func(key *entities.APIKey)
+	}{
+		{"revoked", func(key *entities.APIKey) { key.RevokedAt = &past }},
+		{"expired", func(key *entities.APIKey) { key.ExpiresAt = &past }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, plaintext, err := entities.NewAPIKey(testTenantID, testOwnerID, "ci", []string{entities.ScopeTodosRead}, nil)
+			require.NoError(t, err)
+			tt.modify(key)
+
+			mockRepo := mocks.NewMockAPIKeyRepository(t)
+			mockRepo.EXPECT().GetByHash(mock.Anything, key.KeyHash).Return(key, nil)
+
+			_, err = NewAPIKeyUseCase(mockRepo).Verify(context.Background(), plaintext)
+
+			assert.ErrorIs(t, err, entities.ErrInvalidCredentials)
+		})
+	}
+}
+
+func TestVerifyAPIKey_Unknown(t *testing.T) {
+	mockRepo := mocks.NewMockAPIKeyRepository(t)
+	mockRepo.EXPECT().GetByHash(mock.Anything, mock.Anything).Return(nil, entities.ErrAPIKeyNotFound)
+
+	_, err := NewAPIKeyUseCase(mockRepo).Verify(context.Background(), "tdk_0000000000000000000000000000000000000000000000000000000000000000")
+
+	assert.ErrorIs(t, err, entities.ErrInvalidCredentials)
+}
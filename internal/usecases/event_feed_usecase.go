@@ -0,0 +1,268 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+)
+
+var ErrInvalidEventID = errors.New("invalid event id")
+
+type EventFilter struct {
+	Types    []string
+	TodoID   string
+	TenantID string
+	UserID   string
+}
+
+func (f EventFilter) Matches(event *entities.Event) bool {
+	if event.TenantID != f.TenantID || !event.VisibleTo(f.UserID) {
+		return false
+	}
+	if f.TodoID != "" && event.TodoID != f.TodoID {
+		return false
+	}
+	if len(f.Types) == 0 {
+		return true
+	}
+	return entities.MatchesEventType(f.Types, event.Type)
+}
+
+// EventFeedUseCase serves live event feeds. One tail of the stream per
+// process fans events out to every subscriber in memory, so open feeds do
+// not each hold a blocking stream read; subscribers resuming from an earlier
+// event catch up with non-blocking reads before joining it.
+type EventFeedUseCase struct {
+	reader     ports.EventReader
+	batchSize  int64
+	block      time.Duration
+	retryDelay time.Duration
+	backlog    int
+
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+	stopTail    context.CancelFunc
+}
+
+func NewEventFeedUseCase(reader ports.EventReader) *EventFeedUseCase {
+	return &EventFeedUseCase{
+		reader:      reader,
+		batchSize:   100,
+		block:       5 * time.Second,
+		retryDelay:  time.Second,
+		backlog:     1000,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+type subscriber struct {
+	filter EventFilter
+	cursor string
+	events chan *entities.Event
+	// live receives the subscriber's events from the shared tail. The tail
+	// closes it when the subscriber falls more than backlog events behind.
+	live chan *entities.Event
+}
+
+// Subscribe tails the event stream from lastEventID (or from the current
+// end of the stream when empty) and delivers matching events until ctx is
+// cancelled, at which point the channel is closed. The channel is also
+// closed when the caller reads too slowly to keep up; clients then resume
+// from the last event ID they received.
+// Subscribe only ever delivers events about todos the caller owns or that
+// were shared with them, whatever TenantID and UserID the filter carries.
+func (uc *EventFeedUseCase) Subscribe(ctx context.Context, lastEventID string, filter EventFilter) (<-chan *entities.Event, error) {
+	principal, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filter.TenantID = principal.TenantID
+	filter.UserID = principal.ID
+
+	cursor := lastEventID
+	if cursor == "" {
+		tail, err := uc.reader.LastID(ctx)
+		if err != nil {
+			return nil, entities.NewUnavailableError("event stream", fmt.Errorf("failed to subscribe to events: %w", err))
+		}
+		cursor = tail
+	} else if !entities.IsValidEventID(cursor) {
+		return nil, ErrInvalidEventID
+	}
+
+	sub := &subscriber{
+		filter: filter,
+		cursor: cursor,
+		events: make(chan *entities.Event, uc.batchSize),
+		live:   make(chan *entities.Event, uc.backlog),
+	}
+	go uc.serve(ctx, sub)
+
+	return sub.events, nil
+}
+
+// serve catches sub up with the stream and then relays what the shared tail
+// hands it. Catching up again after joining the tail covers the events
+// published in between; the cursor drops those delivered twice.
+func (uc *EventFeedUseCase) serve(ctx context.Context, sub *subscriber) {
+	defer close(sub.events)
+
+	if !uc.catchUp(ctx, sub) {
+		return
+	}
+	uc.join(sub)
+	defer uc.leave(sub)
+	if !uc.catchUp(ctx, sub) {
+		return
+	}
+
+	for {
+		select {
+		case event, open := <-sub.live:
+			if !open || !sub.deliver(ctx, event) {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// catchUp delivers the events after sub's cursor that are already in the
+// stream, without waiting for new ones.
+func (uc *EventFeedUseCase) catchUp(ctx context.Context, sub *subscriber) bool {
+	for ctx.Err() == nil {
+		batch, err := uc.reader.Read(ctx, sub.cursor, uc.batchSize, 0)
+		if err != nil {
+			if !sleepContext(ctx, uc.retryDelay) {
+				return false
+			}
+			continue
+		}
+
+		for _, event := range batch {
+			if !sub.deliver(ctx, event) {
+				return false
+			}
+		}
+		if int64(len(batch)) < uc.batchSize {
+			return true
+		}
+	}
+	return false
+}
+
+// join adds sub to the subscribers of the shared tail, starting the tail
+// from sub's cursor when sub is the only one.
+func (uc *EventFeedUseCase) join(sub *subscriber) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	uc.subscribers[sub] = struct{}{}
+	if uc.stopTail == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		uc.stopTail = cancel
+		go uc.tail(ctx, sub.cursor)
+	}
+}
+
+func (uc *EventFeedUseCase) leave(sub *subscriber) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	delete(uc.subscribers, sub)
+	uc.stopIdleTail()
+}
+
+// tail reads the stream from cursor until ctx is cancelled and broadcasts
+// every batch.
+func (uc *EventFeedUseCase) tail(ctx context.Context, cursor string) {
+	for ctx.Err() == nil {
+		batch, err := uc.reader.Read(ctx, cursor, uc.batchSize, uc.block)
+		if err != nil {
+			if !sleepContext(ctx, uc.retryDelay) {
+				return
+			}
+			continue
+		}
+		if len(batch) == 0 {
+			continue
+		}
+
+		cursor = batch[len(batch)-1].ID
+		uc.broadcast(ctx, batch)
+	}
+}
+
+// broadcast hands each subscriber its events from batch without waiting on
+// any of them. Subscribers with a full backlog are dropped.
+func (uc *EventFeedUseCase) broadcast(ctx context.Context, batch []*entities.Event) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	// A stopped tail must not reach the subscribers of its successor.
+	if ctx.Err() != nil {
+		return
+	}
+
+	for sub := range uc.subscribers {
+		if !sub.offer(batch) {
+			delete(uc.subscribers, sub)
+			close(sub.live)
+		}
+	}
+	uc.stopIdleTail()
+}
+
+// stopIdleTail stops the shared tail once nobody is subscribed. Callers
+// hold uc.mu.
+func (uc *EventFeedUseCase) stopIdleTail() {
+	if len(uc.subscribers) == 0 && uc.stopTail != nil {
+		uc.stopTail()
+		uc.stopTail = nil
+	}
+}
+
+// offer queues the events of batch that sub wants, reporting false when
+// its backlog is full.
+func (s *subscriber) offer(batch []*entities.Event) bool {
+	for _, event := range batch {
+		if !s.wants(event) {
+			continue
+		}
+		select {
+		case s.live <- event:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// deliver sends event to the subscriber unless it has already been past
+// it, and moves the cursor on.
+func (s *subscriber) deliver(ctx context.Context, event *entities.Event) bool {
+	if entities.CompareEventIDs(event.ID, s.cursor) <= 0 {
+		return true
+	}
+	s.cursor = event.ID
+	if !s.wants(event) {
+		return true
+	}
+
+	select {
+	case s.events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (s *subscriber) wants(event *entities.Event) bool {
+	return event.Type != "" && s.filter.Matches(event)
+}
@@ -0,0 +1,225 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports/mocks"
+)
+
+func collectEvents(t *testing.T, events <-chan *entities.Event, n int) []*entities.Event {
+	t.Helper()
+
+	var received []*entities.Event
+	timeout := time.After(2 * time.Second)
+	for len(received) < n {
+		select {
+		case event := <-events:
+			received = append(received, event)
+		case <-timeout:
+			t.Fatalf("received %d of %d events before timeout", len(received), n)
+		}
+	}
+	return received
+}
+
+// noNewEvents stands in for a stream with nothing after afterID: catch-up
+// reads return at once and tail reads wait until the tail is stopped.
+func noNewEvents(ctx context.Context, afterID string, count int64, block time.Duration) ([]*entities.Event, error) {
+	if block <= 0 {
+		return nil, nil
+	}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestSubscribe_ResumesFromLastEventIDAndFilters(t *testing.T) {
+	mockReader := mocks.NewMockEventReader(t)
+
+	mockReader.EXPECT().Read(mock.Anything, "1700000000000-0", mock.Anything, mock.Anything).Return([]*entities.Event{
+		{ID: "1700000000001-0", Type: entities.EventTypeTodoCreated, TodoID: "a", TenantID: testTenantID, OwnerID: testOwnerID},
+		{ID: "1700000000002-0", Type: "file.uploaded", TenantID: testTenantID, OwnerID: testOwnerID},
+		{ID: "1700000000003-0", Type: entities.EventTypeTodoCreated, TodoID: "c", TenantID: "globex", OwnerID: testOwnerID},
+		{ID: "1700000000004-0", Type: entities.EventTypeTodoCreated, TodoID: "b", TenantID: testTenantID, OwnerID: testOwnerID},
+	}, nil).Once()
+	mockReader.EXPECT().Read(mock.Anything, "1700000000004-0", mock.Anything, mock.Anything).RunAndReturn(noNewEvents).Maybe()
+
+	ctx, cancel := context.WithCancel(authContext())
+	defer cancel()
+
+	useCase := NewEventFeedUseCase(mockReader)
+	events, err := useCase.Subscribe(ctx, "1700000000000-0", EventFilter{Types: []string{"todo.*"}})
+	require.NoError(t, err)
+
+	received := collectEvents(t, events, 2)
+	assert.Equal(t, "1700000000001-0", received[0].ID)
+	assert.Equal(t, "1700000000004-0", received[1].ID)
+}
+
+func TestSubscribe_DeliversEventsAboutSharedTodos(t *testing.T) {
+	mockReader := mocks.NewMockEventReader(t)
+
+	mockReader.EXPECT().Read(mock.Anything, "0-0", mock.Anything, mock.Anything).Return([]*entities.Event{
+		{ID: "1700000000001-0", Type: entities.EventTypeTodoUpdated, TodoID: "a", TenantID: testTenantID, OwnerID: "user-2", GranteeIDs: []string{"user-3", testOwnerID}},
+		{ID: "1700000000002-0", Type: entities.EventTypeTodoUpdated, TodoID: "b", TenantID: testTenantID, OwnerID: "user-2"},
+		{ID: "1700000000003-0", Type: entities.EventTypeTodoUpdated, TodoID: "c", TenantID: "globex", OwnerID: "user-2", GranteeIDs: []string{testOwnerID}},
+		{ID: "1700000000004-0", Type: entities.EventTypeTodoUnshared, TodoID: "a", TenantID: testTenantID, OwnerID: "user-2", GranteeIDs: []string{testOwnerID}},
+	}, nil).Once()
+	mockReader.EXPECT().Read(mock.Anything, "1700000000004-0", mock.Anything, mock.Anything).RunAndReturn(noNewEvents).Maybe()
+
+	ctx, cancel := context.WithCancel(authContext())
+	defer cancel()
+
+	useCase := NewEventFeedUseCase(mockReader)
+	events, err := useCase.Subscribe(ctx, "0-0", EventFilter{})
+	require.NoError(t, err)
+
+	received := collectEvents(t, events, 2)
+	assert.Equal(t, "1700000000001-0", received[0].ID)
+	assert.Equal(t, "1700000000004-0", received[1].ID)
+}
+
+func TestSubscribe_StartsAtStreamTail(t *testing.T) {
+	mockReader := mocks.NewMockEventReader(t)
+
+	mockReader.EXPECT().LastID(mock.Anything).Return("1700000000009-0", nil)
+	mockReader.EXPECT().Read(mock.Anything, "1700000000009-0", mock.Anything, mock.Anything).Return([]*entities.Event{
+		{ID: "1700000000010-0", Type: entities.EventTypeTodoCreated, TodoID: "a", TenantID: testTenantID, OwnerID: testOwnerID},
+		{ID: "1700000000011-0", Type: entities.EventTypeTodoCreated, TodoID: "b", TenantID: testTenantID, OwnerID: testOwnerID},
+	}, nil).Once()
+	mockReader.EXPECT().Read(mock.Anything, "1700000000011-0", mock.Anything, mock.Anything).RunAndReturn(noNewEvents).Maybe()
+
+	ctx, cancel := context.WithCancel(authContext())
+	defer cancel()
+
+	useCase := NewEventFeedUseCase(mockReader)
+	events, err := useCase.Subscribe(ctx, "", EventFilter{TodoID: "b"})
+	require.NoError(t, err)
+
+	received := collectEvents(t, events, 1)
+	assert.Equal(t, "b", received[0].TodoID)
+}
+
+func TestSubscribe_RejectsMalformedEventID(t *testing.T) {
+	mockReader := mocks.NewMockEventReader(t)
+
+	useCase := NewEventFeedUseCase(mockReader)
+	_, err := useCase.Subscribe(authContext(), "not-a-stream-id", EventFilter{})
+
+	assert.ErrorIs(t, err, ErrInvalidEventID)
+}
+
+func TestSubscribe_ClosesChannelOnCancel(t *testing.T) {
+	mockReader := mocks.NewMockEventReader(t)
+	mockReader.EXPECT().Read(mock.Anything, mock.Anything, mock.Anything, mock.Anything).RunAndReturn(noNewEvents).Maybe()
+
+	ctx, cancel := context.WithCancel(authContext())
+
+	useCase := NewEventFeedUseCase(mockReader)
+	events, err := useCase.Subscribe(ctx, "0-0", EventFilter{})
+	require.NoError(t, err)
+
+	cancel()
+
+	select {
+	case _, open := <-events:
+		assert.False(t, open)
+	case <-time.After(2 * time.Second):
+		t.Fatal("event channel was not closed after cancellation")
+	}
+	assert.Eventually(t, func() bool {
+		useCase.mu.Lock()
+		defer useCase.mu.Unlock()
+		return useCase.stopTail == nil
+	}, 2*time.Second, 10*time.Millisecond, "the shared tail kept running without subscribers")
+}
+
+func subscriberCount(uc *EventFeedUseCase) int {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	return len(uc.subscribers)
+}
+
+func TestSubscribe_SharesOneTailBetweenSubscribers(t *testing.T) {
+	mockReader := mocks.NewMockEventReader(t)
+	release := make(chan struct{})
+	var tailReads atomic.Int32
+
+	mockReader.EXPECT().LastID(mock.Anything).Return("1700000000001-0", nil)
+	mockReader.EXPECT().Read(mock.Anything, "1700000000001-0", mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, afterID string, count int64, block time.Duration) ([]*entities.Event, error) {
+			if block <= 0 {
+				return nil, nil
+			}
+			tailReads.Add(1)
+			<-release
+			return []*entities.Event{
+				{ID: "1700000000002-0", Type: entities.EventTypeTodoCreated, TodoID: "a", TenantID: testTenantID, OwnerID: testOwnerID},
+			}, nil
+		})
+	mockReader.EXPECT().Read(mock.Anything, "1700000000002-0", mock.Anything, mock.Anything).RunAndReturn(noNewEvents).Maybe()
+
+	ctx, cancel := context.WithCancel(authContext())
+	defer cancel()
+
+	useCase := NewEventFeedUseCase(mockReader)
+	first, err := useCase.Subscribe(ctx, "", EventFilter{})
+	require.NoError(t, err)
+	second, err := useCase.Subscribe(ctx, "", EventFilter{})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return subscriberCount(useCase) == 2 }, 2*time.Second, 10*time.Millisecond)
+	close(release)
+
+	assert.Equal(t, "1700000000002-0", collectEvents(t, first, 1)[0].ID)
+	assert.Equal(t, "1700000000002-0", collectEvents(t, second, 1)[0].ID)
+	assert.Equal(t, int32(1), tailReads.Load())
+}
+
+func TestSubscribe_DropsSubscribersThatFallBehind(t *testing.T) {
+	mockReader := mocks.NewMockEventReader(t)
+
+	var burst []*entities.Event
+	for i := 1; i <= 5; i++ {
+		burst = append(burst, &entities.Event{ID: fmt.Sprintf("1700000000000-%d", i), Type: entities.EventTypeTodoUpdated, TodoID: "a", TenantID: testTenantID, OwnerID: testOwnerID})
+	}
+	mockReader.EXPECT().Read(mock.Anything, "1700000000000-0", mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, afterID string, count int64, block time.Duration) ([]*entities.Event, error) {
+			if block <= 0 {
+				return nil, nil
+			}
+			return burst, nil
+		})
+	mockReader.EXPECT().Read(mock.Anything, "1700000000000-5", mock.Anything, mock.Anything).RunAndReturn(noNewEvents).Maybe()
+
+	ctx, cancel := context.WithCancel(authContext())
+	defer cancel()
+
+	useCase := NewEventFeedUseCase(mockReader)
+	useCase.batchSize = 1
+	useCase.backlog = 1
+	events, err := useCase.Subscribe(ctx, "1700000000000-0", EventFilter{})
+	require.NoError(t, err)
+
+	var received int
+	timeout := time.After(2 * time.Second)
+	for open := true; open; {
+		select {
+		case _, open = <-events:
+			if open {
+				received++
+			}
+		case <-timeout:
+			t.Fatal("event channel of a subscriber that fell behind was not closed")
+		}
+	}
+	assert.Less(t, received, len(burst))
+}
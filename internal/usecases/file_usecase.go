@@ -2,20 +2,31 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 
+	"github.com/google/uuid"
+
 	"todo-service/internal/domain/entities"
 	"todo-service/internal/domain/ports"
 )
 
 type FileUseCase struct {
 	fileStorage ports.FileStorage
+	fileRepo    ports.FileRepository
+	usageRepo   ports.StorageUsageRepository
+	quota       entities.StorageQuota
+	metrics     ports.Metrics
 }
 
-func NewFileUseCase(fileStorage ports.FileStorage) *FileUseCase {
+func NewFileUseCase(fileStorage ports.FileStorage, fileRepo ports.FileRepository, usageRepo ports.StorageUsageRepository, quota entities.StorageQuota, metrics ports.Metrics) *FileUseCase {
 	return &FileUseCase{
 		fileStorage: fileStorage,
+		fileRepo:    fileRepo,
+		usageRepo:   usageRepo,
+		quota:       quota,
+		metrics:     metricsOrNop(metrics),
 	}
 }
 
@@ -30,22 +41,193 @@ type UploadFileResponse struct {
 	FileID string `json:"file_id"`
 }
 
+// StorageUsageResponse reports a user's usage against their quota. Limits
+// and remaining amounts are null when unlimited.
+type StorageUsageResponse struct {
+	Bytes          int64  `json:"bytes"`
+	Files          int64  `json:"files"`
+	MaxBytes       *int64 `json:"max_bytes"`
+	MaxFiles       *int64 `json:"max_files"`
+	RemainingBytes *int64 `json:"remaining_bytes"`
+	RemainingFiles *int64 `json:"remaining_files"`
+}
+
+func NewStorageUsageResponse(usage *entities.StorageUsage, quota entities.StorageQuota) *StorageUsageResponse {
+	response := &StorageUsageResponse{
+		Bytes: usage.Bytes,
+		Files: usage.Files,
+	}
+	if quota.MaxBytes > 0 {
+		remaining := usage.RemainingBytes(quota)
+		response.MaxBytes = &quota.MaxBytes
+		response.RemainingBytes = &remaining
+	}
+	if quota.MaxFiles > 0 {
+		remaining := usage.RemainingFiles(quota)
+		response.MaxFiles = &quota.MaxFiles
+		response.RemainingFiles = &remaining
+	}
+	return response
+}
+
+// UploadFile reserves quota for the file before writing it to storage and
+// gives the reservation back if the upload fails, so usage only ever counts
+// files that were stored.
 func (uc *FileUseCase) UploadFile(ctx context.Context, req UploadFileRequest) (*UploadFileResponse, error) {
+	ctx, span := tracer.Start(ctx, "FileUseCase.UploadFile")
+	defer span.End()
+
+	principal, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := entities.ValidateFile(req.FileName, req.Size); err != nil {
+		uc.metrics.UploadRejected(entities.FileRejectionReason(err))
 		return nil, fmt.Errorf("file validation failed: %w", err)
 	}
 
-	file := entities.NewFile(req.FileName, req.ContentType, req.Size)
+	file := entities.NewFile(principal.TenantID, principal.ID, req.FileName, req.ContentType, req.Size)
 
 	if !file.IsValid() {
 		return nil, fmt.Errorf("invalid file data")
 	}
 
+	if err := uc.usageRepo.Reserve(ctx, file.TenantID, file.OwnerID, file.Size, uc.quota); err != nil {
+		if errors.Is(err, entities.ErrStorageQuotaExceeded) {
+			uc.metrics.UploadRejected(entities.FileRejectedQuota)
+			return nil, uc.quotaExceeded(ctx, file)
+		}
+		return nil, fmt.Errorf("failed to reserve storage quota: %w", err)
+	}
+
 	if err := uc.fileStorage.UploadFile(ctx, file.StoragePath, req.ContentType, req.Data, req.Size); err != nil {
-		return nil, fmt.Errorf("failed to upload file to storage: %w", err)
+		return nil, uc.release(ctx, file, entities.NewUnavailableError("file storage", fmt.Errorf("failed to upload file to storage: %w", err)))
+	}
+
+	if err := uc.fileRepo.Create(ctx, file); err != nil {
+		return nil, uc.release(ctx, file, fmt.Errorf("failed to record uploaded file: %w", err))
 	}
 
 	return &UploadFileResponse{
 		FileID: file.ID.String(),
 	}, nil
 }
+
+// GetFiles looks up the metadata of the caller's files and of the files
+// attached to todos the caller can see, which may belong to other users of
+// the tenant. IDs of other files are missing from the result.
+func (uc *FileUseCase) GetFiles(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*entities.File, error) {
+	ctx, span := tracer.Start(ctx, "FileUseCase.GetFiles")
+	defer span.End()
+
+	principal, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := uc.fileRepo.ListByIDs(ctx, principal.TenantID, principal.ID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get files: %w", err)
+	}
+
+	byID := make(map[uuid.UUID]*entities.File, len(files))
+	for _, file := range files {
+		byID[file.ID] = file
+	}
+	return byID, nil
+}
+
+// DownloadFile returns one of the caller's files and its contents; the
+// caller must close the reader.
+func (uc *FileUseCase) DownloadFile(ctx context.Context, id uuid.UUID) (*entities.File, io.ReadCloser, error) {
+	ctx, span := tracer.Start(ctx, "FileUseCase.DownloadFile")
+	defer span.End()
+
+	principal, err := caller(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := uc.fileRepo.GetByID(ctx, principal.TenantID, principal.ID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := uc.fileStorage.DownloadFile(ctx, file.StoragePath)
+	if err != nil {
+		return nil, nil, entities.NewUnavailableError("file storage", fmt.Errorf("failed to download file from storage: %w", err))
+	}
+
+	return file, data, nil
+}
+
+func (uc *FileUseCase) DeleteFile(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "FileUseCase.DeleteFile")
+	defer span.End()
+
+	principal, err := caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	file, err := uc.fileRepo.GetByID(ctx, principal.TenantID, principal.ID, id)
+	if err != nil {
+		return err
+	}
+
+	// S3 deletes are idempotent, so removing the object first lets a failed
+	// request be retried without leaving an orphaned object behind.
+	if err := uc.fileStorage.DeleteFile(ctx, file.StoragePath); err != nil {
+		return entities.NewUnavailableError("file storage", fmt.Errorf("failed to delete file from storage: %w", err))
+	}
+
+	// Only the request that actually removed the row gives the space back.
+	if err := uc.fileRepo.Delete(ctx, file.TenantID, file.OwnerID, file.ID); err != nil {
+		return err
+	}
+
+	if err := uc.usageRepo.Release(ctx, file.TenantID, file.OwnerID, file.Size); err != nil {
+		return fmt.Errorf("failed to release storage quota: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *FileUseCase) GetUsage(ctx context.Context) (*StorageUsageResponse, error) {
+	ctx, span := tracer.Start(ctx, "FileUseCase.GetUsage")
+	defer span.End()
+
+	principal, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := uc.usageRepo.Get(ctx, principal.TenantID, principal.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewStorageUsageResponse(usage, uc.quota), nil
+}
+
+func (uc *FileUseCase) quotaExceeded(ctx context.Context, file *entities.File) error {
+	quotaErr := &entities.QuotaExceededError{
+		Quota:     uc.quota,
+		Usage:     entities.StorageUsage{TenantID: file.TenantID, OwnerID: file.OwnerID},
+		Requested: file.Size,
+	}
+
+	if usage, err := uc.usageRepo.Get(ctx, file.TenantID, file.OwnerID); err == nil {
+		quotaErr.Usage = *usage
+	}
+
+	return quotaErr
+}
+
+func (uc *FileUseCase) release(ctx context.Context, file *entities.File, cause error) error {
+	if err := uc.usageRepo.Release(ctx, file.TenantID, file.OwnerID, file.Size); err != nil {
+		return errors.Join(cause, fmt.Errorf("failed to release storage quota: %w", err))
+	}
+	return cause
+}
@@ -0,0 +1,238 @@
+package usecases
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports/mocks"
+)
+
+// storageUsage returns a usage repository that accepts every reservation,
+// for tests that are not about quotas.
+func storageUsage(t *testing.T) *mocks.MockStorageUsageRepository {
+	usage := mocks.NewMockStorageUsageRepository(t)
+	usage.EXPECT().Reserve(mock.Anything, testTenantID, testOwnerID, mock.Anything, mock.Anything).Return(nil).Maybe()
+	usage.EXPECT().Release(mock.Anything, testTenantID, testOwnerID, mock.Anything).Return(nil).Maybe()
+	return usage
+}
+
+func uploadRequest(size int64) UploadFileRequest {
+	return UploadFileRequest{
+		FileName:    "notes.txt",
+		ContentType: "text/plain",
+		Data:        strings.NewReader(strings.Repeat("x", int(size))),
+		Size:        size,
+	}
+}
+
+func TestUploadFile_ReservesQuotaBeforeStorageWrite(t *testing.T) {
+	quota := entities.StorageQuota{MaxBytes: 4096, MaxFiles: 10}
+
+	mockStorage := mocks.NewMockFileStorage(t)
+	mockFileRepo := mocks.NewMockFileRepository(t)
+	mockUsage := mocks.NewMockStorageUsageRepository(t)
+
+	reserve := mockUsage.EXPECT().Reserve(mock.Anything, testTenantID, testOwnerID, int64(1024), quota).Return(nil).Once()
+	upload := mockStorage.EXPECT().UploadFile(mock.Anything, mock.Anything, "text/plain", mock.Anything, int64(1024)).Return(nil).Once()
+	upload.NotBefore(reserve)
+	mockFileRepo.EXPECT().Create(mock.Anything, mock.AnythingOfType("*entities.File")).Return(nil).Once()
+
+	useCase := NewFileUseCase(mockStorage, mockFileRepo, mockUsage, quota, nil)
+	response, err := useCase.UploadFile(authContext(), uploadRequest(1024))
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, response.FileID)
+}
+
+func TestUploadFile_QuotaExceeded(t *testing.T) {
+	quota := entities.StorageQuota{MaxBytes: 4096, MaxFiles: 10}
+
+	tests := []struct {
+		name           string
+		size           int64
+		usage          entities.StorageUsage
+		tooLarge       bool
+		remainingBytes int64
+	}{
+		{name: "bytes exhausted", size: 1024, usage: entities.StorageUsage{Bytes: 3500, Files: 3}, remainingBytes: 596},
+		{name: "file limit reached", size: 10, usage: entities.StorageUsage{Bytes: 100, Files: 10}, remainingBytes: 3996},
+		{name: "larger than quota", size: 5000, usage: entities.StorageUsage{}, tooLarge: true, remainingBytes: 4096},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsage := mocks.NewMockStorageUsageRepository(t)
+			mockUsage.EXPECT().Reserve(mock.Anything, testTenantID, testOwnerID, tt.size, quota).Return(entities.ErrStorageQuotaExceeded).Once()
+			mockUsage.EXPECT().Get(mock.Anything, testTenantID, testOwnerID).Return(&tt.usage, nil).Once()
+
+			useCase := NewFileUseCase(mocks.NewMockFileStorage(t), mocks.NewMockFileRepository(t), mockUsage, quota, nil)
+			_, err := useCase.UploadFile(authContext(), uploadRequest(tt.size))
+
+			require.ErrorIs(t, err, entities.ErrStorageQuotaExceeded)
+			var quotaErr *entities.QuotaExceededError
+			require.True(t, errors.As(err, &quotaErr))
+			assert.Equal(t, tt.tooLarge, quotaErr.TooLarge())
+			assert.Equal(t, tt.remainingBytes, quotaErr.Usage.RemainingBytes(quota))
+		})
+	}
+}
+
+func TestUploadFile_ReleasesQuotaWhenRecordFails(t *testing.T) {
+	mockStorage := mocks.NewMockFileStorage(t)
+	mockFileRepo := mocks.NewMockFileRepository(t)
+	mockUsage := mocks.NewMockStorageUsageRepository(t)
+
+	mockUsage.EXPECT().Reserve(mock.Anything, testTenantID, testOwnerID, int64(64), mock.Anything).Return(nil).Once()
+	mockStorage.EXPECT().UploadFile(mock.Anything, mock.Anything, mock.Anything, mock.Anything, int64(64)).Return(nil).Once()
+	mockFileRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(assert.AnError).Once()
+	mockUsage.EXPECT().Release(mock.Anything, testTenantID, testOwnerID, int64(64)).Return(nil).Once()
+
+	useCase := NewFileUseCase(mockStorage, mockFileRepo, mockUsage, entities.StorageQuota{}, nil)
+	_, err := useCase.UploadFile(authContext(), uploadRequest(64))
+
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestDeleteFile_ReleasesQuota(t *testing.T) {
+	file := entities.NewFile(testTenantID, testOwnerID, "notes.txt", "text/plain", 512)
+
+	mockStorage := mocks.NewMockFileStorage(t)
+	mockFileRepo := mocks.NewMockFileRepository(t)
+	mockUsage := mocks.NewMockStorageUsageRepository(t)
+
+	mockFileRepo.EXPECT().GetByID(mock.Anything, testTenantID, testOwnerID, file.ID).Return(file, nil).Once()
+	mockStorage.EXPECT().DeleteFile(mock.Anything, file.StoragePath).Return(nil).Once()
+	mockFileRepo.EXPECT().Delete(mock.Anything, testTenantID, testOwnerID, file.ID).Return(nil).Once()
+	mockUsage.EXPECT().Release(mock.Anything, testTenantID, testOwnerID, int64(512)).Return(nil).Once()
+
+	useCase := NewFileUseCase(mockStorage, mockFileRepo, mockUsage, entities.StorageQuota{}, nil)
+
+	assert.NoError(t, useCase.DeleteFile(authContext(), file.ID))
+}
+
+func TestDeleteFile_ConcurrentDeleteDoesNotReleaseTwice(t *testing.T) {
+	file := entities.NewFile(testTenantID, testOwnerID, "notes.txt", "text/plain", 512)
+
+	mockStorage := mocks.NewMockFileStorage(t)
+	mockFileRepo := mocks.NewMockFileRepository(t)
+
+	mockFileRepo.EXPECT().GetByID(mock.Anything, testTenantID, testOwnerID, file.ID).Return(file, nil).Once()
+	mockStorage.EXPECT().DeleteFile(mock.Anything, file.StoragePath).Return(nil).Once()
+	mockFileRepo.EXPECT().Delete(mock.Anything, testTenantID, testOwnerID, file.ID).Return(entities.ErrFileNotFound).Once()
+
+	useCase := NewFileUseCase(mockStorage, mockFileRepo, mocks.NewMockStorageUsageRepository(t), entities.StorageQuota{}, nil)
+
+	assert.ErrorIs(t, useCase.DeleteFile(authContext(), file.ID), entities.ErrFileNotFound)
+}
+
+func TestGetUsage_ReportsRemainingQuota(t *testing.T) {
+	mockUsage := mocks.NewMockStorageUsageRepository(t)
+	mockUsage.EXPECT().Get(mock.Anything, testTenantID, testOwnerID).Return(&entities.StorageUsage{
+		TenantID: testTenantID, OwnerID: testOwnerID, Bytes: 1000, Files: 2,
+	}, nil).Once()
+
+	useCase := NewFileUseCase(mocks.NewMockFileStorage(t), mocks.NewMockFileRepository(t), mockUsage, entities.StorageQuota{MaxBytes: 4096}, nil)
+	usage, err := useCase.GetUsage(authContext())
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), usage.Bytes)
+	assert.Equal(t, int64(3096), *usage.RemainingBytes)
+	assert.Nil(t, usage.MaxFiles)
+	assert.Nil(t, usage.RemainingFiles)
+}
+
+func TestDownloadFile(t *testing.T) {
+	file := entities.NewFile(testTenantID, testOwnerID, "notes.txt", "text/plain", 5)
+
+	mockStorage := mocks.NewMockFileStorage(t)
+	mockFileRepo := mocks.NewMockFileRepository(t)
+
+	mockFileRepo.EXPECT().GetByID(mock.Anything, testTenantID, testOwnerID, file.ID).Return(file, nil).Once()
+	mockStorage.EXPECT().DownloadFile(mock.Anything, file.StoragePath).Return(io.NopCloser(strings.NewReader("hello")), nil).Once()
+
+	useCase := NewFileUseCase(mockStorage, mockFileRepo, mocks.NewMockStorageUsageRepository(t), entities.StorageQuota{}, nil)
+
+	found, data, err := useCase.DownloadFile(authContext(), file.ID)
+	require.NoError(t, err)
+	defer data.Close()
+
+	content, err := io.ReadAll(data)
+	require.NoError(t, err)
+	assert.Equal(t, file, found)
+	assert.Equal(t, "hello", string(content))
+}
+
+func TestDownloadFile_NotFound(t *testing.T) {
+	id := uuid.New()
+	mockFileRepo := mocks.NewMockFileRepository(t)
+	mockFileRepo.EXPECT().GetByID(mock.Anything, testTenantID, testOwnerID, id).Return(nil, entities.ErrFileNotFound).Once()
+
+	// Storage is never asked for a file the caller does not own.
+	useCase := NewFileUseCase(mocks.NewMockFileStorage(t), mockFileRepo, mocks.NewMockStorageUsageRepository(t), entities.StorageQuota{}, nil)
+
+	_, _, err := useCase.DownloadFile(authContext(), id)
+	assert.ErrorIs(t, err, entities.ErrFileNotFound)
+}
+
+func TestDownloadFile_StorageFailure(t *testing.T) {
+	file := entities.NewFile(testTenantID, testOwnerID, "notes.txt", "text/plain", 5)
+
+	mockStorage := mocks.NewMockFileStorage(t)
+	mockFileRepo := mocks.NewMockFileRepository(t)
+	mockFileRepo.EXPECT().GetByID(mock.Anything, testTenantID, testOwnerID, file.ID).Return(file, nil).Once()
+	mockStorage.EXPECT().DownloadFile(mock.Anything, file.StoragePath).Return(nil, errors.New("connection reset")).Once()
+
+	useCase := NewFileUseCase(mockStorage, mockFileRepo, mocks.NewMockStorageUsageRepository(t), entities.StorageQuota{}, nil)
+
+	_, data, err := useCase.DownloadFile(authContext(), file.ID)
+	assert.Nil(t, data)
+	assert.ErrorContains(t, err, "failed to download file from storage")
+}
+
+func TestDeleteFile_NotFound(t *testing.T) {
+	id := uuid.New()
+	mockFileRepo := mocks.NewMockFileRepository(t)
+	mockFileRepo.EXPECT().GetByID(mock.Anything, testTenantID, testOwnerID, id).Return(nil, entities.ErrFileNotFound).Once()
+
+	useCase := NewFileUseCase(mocks.NewMockFileStorage(t), mockFileRepo, mocks.NewMockStorageUsageRepository(t), entities.StorageQuota{}, nil)
+
+	assert.ErrorIs(t, useCase.DeleteFile(authContext(), id), entities.ErrFileNotFound)
+}
+
+type recordingMetrics struct {
+	todosCreated int
+	rejections   []string
+}
+
+func (m *recordingMetrics) TodoCreated()                 { m.todosCreated++ }
+func (m *recordingMetrics) UploadRejected(reason string) { m.rejections = append(m.rejections, reason) }
+
+func TestUploadFile_CountsRejectionsByReason(t *testing.T) {
+	quota := entities.StorageQuota{MaxBytes: 100}
+	metrics := &recordingMetrics{}
+
+	mockUsage := mocks.NewMockStorageUsageRepository(t)
+	mockUsage.EXPECT().Reserve(mock.Anything, testTenantID, testOwnerID, int64(200), quota).Return(entities.ErrStorageQuotaExceeded).Once()
+	mockUsage.EXPECT().Get(mock.Anything, testTenantID, testOwnerID).Return(&entities.StorageUsage{}, nil).Once()
+
+	useCase := NewFileUseCase(mocks.NewMockFileStorage(t), mocks.NewMockFileRepository(t), mockUsage, quota, metrics)
+
+	for _, req := range []UploadFileRequest{
+		{FileName: "setup.exe", Size: 10, Data: strings.NewReader("")},
+		{FileName: "empty.txt", Size: 0, Data: strings.NewReader("")},
+		uploadRequest(200),
+	} {
+		_, err := useCase.UploadFile(authContext(), req)
+		require.Error(t, err)
+	}
+
+	assert.Equal(t, []string{entities.FileRejectedType, entities.FileRejectedEmpty, entities.FileRejectedQuota}, metrics.rejections)
+}
@@ -0,0 +1,16 @@
+package usecases
+
+import "todo-service/internal/domain/ports"
+
+type noMetrics struct{}
+
+func (noMetrics) TodoCreated()          {}
+func (noMetrics) UploadRejected(string) {}
+
+// metricsOrNop lets callers that do not collect metrics pass nil.
+func metricsOrNop(metrics ports.Metrics) ports.Metrics {
+	if metrics == nil {
+		return noMetrics{}
+	}
+	return metrics
+}
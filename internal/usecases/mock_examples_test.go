@@ -2,11 +2,13 @@ package usecases
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
@@ -24,7 +26,7 @@ func TestFileStorage_UploadWithSpecificExpectations(t *testing.T) {
 			return hasDeadline
 		}),
 		mock.MatchedBy(func(storagePath string) bool {
-			return strings.HasPrefix(storagePath, "files/") && strings.HasSuffix(storagePath, "/document.pdf")
+			return strings.HasPrefix(storagePath, "tenants/"+testTenantID+"/files/") && strings.HasSuffix(storagePath, "/document.pdf")
 		}),
 		"application/pdf",
 		mock.MatchedBy(func(data interface{}) bool {
@@ -33,9 +35,14 @@ func TestFileStorage_UploadWithSpecificExpectations(t *testing.T) {
 		int64(2048),
 	).Return(nil).Once()
 
-	useCase := NewFileUseCase(mockStorage)
+	mockFileRepo := mocks.NewMockFileRepository(t)
+	mockFileRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(file *entities.File) bool {
+		return file.OwnerID == testOwnerID && file.FileName == "document.pdf"
+	})).Return(nil).Once()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	useCase := NewFileUseCase(mockStorage, mockFileRepo, storageUsage(t), entities.StorageQuota{}, nil)
+
+	ctx, cancel := context.WithTimeout(authContext(), 10*time.Second)
 	defer cancel()
 
 	req := UploadFileRequest{
@@ -88,7 +95,7 @@ func TestFileStorage_ErrorScenarios(t *testing.T) {
 			mockStorage := mocks.NewMockFileStorage(t)
 			tt.setupMock(mockStorage)
 
-			useCase := NewFileUseCase(mockStorage)
+			useCase := NewFileUseCase(mockStorage, mocks.NewMockFileRepository(t), storageUsage(t), entities.StorageQuota{}, nil)
 
 			req := UploadFileRequest{
 				FileName:    "test.txt",
@@ -97,10 +104,13 @@ func TestFileStorage_ErrorScenarios(t *testing.T) {
 				Size:        1024,
 			}
 
-			_, err := useCase.UploadFile(context.Background(), req)
+			_, err := useCase.UploadFile(authContext(), req)
 
 			assert.Error(t, err)
 			assert.Contains(t, err.Error(), tt.expectedError)
+
+			var unavailable *entities.UnavailableError
+			assert.ErrorAs(t, err, &unavailable)
 		})
 	}
 }
@@ -116,32 +126,41 @@ func TestStreamPublisher_PublishWithSpecificExpectations(t *testing.T) {
 			return fn(mockRepo)
 		})
 
-	mockPublisher.EXPECT().PublishTodoCreated(
+	mockPublisher.EXPECT().Publish(
 		mock.MatchedBy(func(ctx context.Context) bool {
 			return ctx != nil
 		}),
-		mock.MatchedBy(func(todo *entities.TodoItem) bool {
-			return todo != nil &&
+		mock.MatchedBy(func(event *entities.Event) bool {
+			var todo entities.TodoItem
+			if event == nil || json.Unmarshal(event.Data, &todo) != nil {
+				return false
+			}
+			return event.Type == entities.EventTypeTodoCreated &&
+				event.TodoID == todo.ID.String() &&
 				todo.Description == "Important Task" &&
 				todo.FileID != nil &&
-				*todo.FileID == "file-123"
+				*todo.FileID == "6f1c2b1e-8d3a-4c55-9a57-3b0e2f6d9c11"
 		}),
 	).Return(nil).Once()
 
-	useCase := NewTodoUseCase(mockTxManager, mockPublisher)
+	mockFileRepo := mocks.NewMockFileRepository(t)
+	mockFileRepo.EXPECT().GetByID(mock.Anything, testTenantID, testOwnerID, uuid.MustParse("6f1c2b1e-8d3a-4c55-9a57-3b0e2f6d9c11")).
+		Return(entities.NewFile(testTenantID, testOwnerID, "notes.txt", "text/plain", 5), nil).Once()
 
-	fileID := "file-123"
+	useCase := NewTodoUseCase(mockTxManager, mockPublisher, mockFileRepo, entities.DefaultTodoRules(), nil)
+
+	fileID := "6f1c2b1e-8d3a-4c55-9a57-3b0e2f6d9c11"
 	req := CreateTodoRequest{
 		Description: "Important Task",
 		DueDate:     time.Now().Add(24 * time.Hour),
 		FileID:      &fileID,
 	}
 
-	todo, err := useCase.CreateTodo(context.Background(), req)
+	todo, err := useCase.CreateTodo(authContext(), req)
 
 	assert.NoError(t, err)
 	assert.Equal(t, "Important Task", todo.Description)
-	assert.Equal(t, "file-123", *todo.FileID)
+	assert.Equal(t, "6f1c2b1e-8d3a-4c55-9a57-3b0e2f6d9c11", *todo.FileID)
 }
 
 func TestStreamPublisher_ErrorScenarios(t *testing.T) {
@@ -159,7 +178,7 @@ func TestStreamPublisher_ErrorScenarios(t *testing.T) {
 						mockRepo.EXPECT().Create(mock.Anything, mock.AnythingOfType("*entities.TodoItem")).Return(nil)
 						return fn(mockRepo)
 					})
-				publisher.EXPECT().PublishTodoCreated(mock.Anything, mock.Anything).
+				publisher.EXPECT().Publish(mock.Anything, mock.Anything).
 					Return(errors.New("redis: connection refused"))
 			},
 			expectedError: "failed to create todo: redis: connection refused",
@@ -173,7 +192,7 @@ func TestStreamPublisher_ErrorScenarios(t *testing.T) {
 						mockRepo.EXPECT().Create(mock.Anything, mock.AnythingOfType("*entities.TodoItem")).Return(nil)
 						return fn(mockRepo)
 					})
-				publisher.EXPECT().PublishTodoCreated(mock.Anything, mock.Anything).
+				publisher.EXPECT().Publish(mock.Anything, mock.Anything).
 					Return(errors.New("stream length exceeded"))
 			},
 			expectedError: "failed to create todo: stream length exceeded",
@@ -187,7 +206,7 @@ func TestStreamPublisher_ErrorScenarios(t *testing.T) {
 						mockRepo.EXPECT().Create(mock.Anything, mock.AnythingOfType("*entities.TodoItem")).Return(nil)
 						return fn(mockRepo)
 					})
-				publisher.EXPECT().PublishTodoCreated(mock.Anything, mock.Anything).
+				publisher.EXPECT().Publish(mock.Anything, mock.Anything).
 					Return(errors.New("NOAUTH Authentication required"))
 			},
 			expectedError: "failed to create todo: NOAUTH Authentication required",
@@ -201,7 +220,7 @@ func TestStreamPublisher_ErrorScenarios(t *testing.T) {
 
 			tt.setupMocks(mockTxManager, mockPublisher)
 
-			useCase := NewTodoUseCase(mockTxManager, mockPublisher)
+			useCase := NewTodoUseCase(mockTxManager, mockPublisher, nil, entities.DefaultTodoRules(), nil)
 
 			req := CreateTodoRequest{
 				Description: "Test Todo",
@@ -209,7 +228,7 @@ func TestStreamPublisher_ErrorScenarios(t *testing.T) {
 				FileID:      nil,
 			}
 
-			_, err := useCase.CreateTodo(context.Background(), req)
+			_, err := useCase.CreateTodo(authContext(), req)
 
 			assert.Error(t, err)
 			assert.Contains(t, err.Error(), tt.expectedError)
@@ -226,7 +245,7 @@ func TestComplexScenario_FileUploadAndTodoCreation(t *testing.T) {
 	mockStorage.EXPECT().UploadFile(
 		mock.Anything,
 		mock.MatchedBy(func(storagePath string) bool {
-			return strings.HasPrefix(storagePath, "files/") && strings.HasSuffix(storagePath, "/report.pdf")
+			return strings.HasPrefix(storagePath, "tenants/"+testTenantID+"/files/") && strings.HasSuffix(storagePath, "/report.pdf")
 		}),
 		"application/pdf",
 		mock.Anything,
@@ -242,15 +261,32 @@ func TestComplexScenario_FileUploadAndTodoCreation(t *testing.T) {
 			return fn(mockRepo)
 		}).Once()
 
-	mockPublisher.EXPECT().PublishTodoCreated(
+	mockPublisher.EXPECT().Publish(
 		mock.Anything,
-		mock.MatchedBy(func(todo *entities.TodoItem) bool {
+		mock.MatchedBy(func(event *entities.Event) bool {
+			var todo entities.TodoItem
+			if json.Unmarshal(event.Data, &todo) != nil {
+				return false
+			}
 			return todo.Description == "Review uploaded report" && todo.FileID != nil
 		}),
 	).Return(nil).Once()
 
-	fileUseCase := NewFileUseCase(mockStorage)
-	todoUseCase := NewTodoUseCase(mockTxManager, mockPublisher)
+	// The todo may reference the file because the same user uploaded it.
+	var uploaded *entities.File
+	mockFileRepo := mocks.NewMockFileRepository(t)
+	mockFileRepo.EXPECT().Create(mock.Anything, mock.AnythingOfType("*entities.File")).
+		RunAndReturn(func(ctx context.Context, file *entities.File) error {
+			uploaded = file
+			return nil
+		}).Once()
+	mockFileRepo.EXPECT().GetByID(mock.Anything, testTenantID, testOwnerID, mock.Anything).
+		RunAndReturn(func(ctx context.Context, tenantID, ownerID string, id uuid.UUID) (*entities.File, error) {
+			return uploaded, nil
+		}).Once()
+
+	fileUseCase := NewFileUseCase(mockStorage, mockFileRepo, storageUsage(t), entities.StorageQuota{}, nil)
+	todoUseCase := NewTodoUseCase(mockTxManager, mockPublisher, mockFileRepo, entities.DefaultTodoRules(), nil)
 
 	uploadReq := UploadFileRequest{
 		FileName:    "report.pdf",
@@ -259,7 +295,7 @@ func TestComplexScenario_FileUploadAndTodoCreation(t *testing.T) {
 		Size:        5120,
 	}
 
-	uploadResp, err := fileUseCase.UploadFile(context.Background(), uploadReq)
+	uploadResp, err := fileUseCase.UploadFile(authContext(), uploadReq)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, uploadResp.FileID)
 
@@ -269,7 +305,7 @@ func TestComplexScenario_FileUploadAndTodoCreation(t *testing.T) {
 		FileID:      &uploadResp.FileID,
 	}
 
-	todo, err := todoUseCase.CreateTodo(context.Background(), todoReq)
+	todo, err := todoUseCase.CreateTodo(authContext(), todoReq)
 	assert.NoError(t, err)
 	assert.Equal(t, "Review uploaded report", todo.Description)
 	assert.Equal(t, uploadResp.FileID, *todo.FileID)
@@ -280,16 +316,16 @@ func TestCustomMatchers(t *testing.T) {
 
 	storagePathMatcher := mock.MatchedBy(func(path string) bool {
 		parts := strings.Split(path, "/")
-		if len(parts) != 3 {
+		if len(parts) != 5 {
 			return false
 		}
-		if parts[0] != "files" {
+		if parts[0] != "tenants" || parts[1] != testTenantID || parts[2] != "files" {
 			return false
 		}
-		if len(parts[1]) != 36 {
+		if len(parts[3]) != 36 {
 			return false
 		}
-		return parts[2] == "document.txt"
+		return parts[4] == "document.txt"
 	})
 
 	contentTypeMatcher := mock.MatchedBy(func(contentType string) bool {
@@ -304,7 +340,10 @@ func TestCustomMatchers(t *testing.T) {
 		int64(1024),
 	).Return(nil)
 
-	useCase := NewFileUseCase(mockStorage)
+	mockFileRepo := mocks.NewMockFileRepository(t)
+	mockFileRepo.EXPECT().Create(mock.Anything, mock.AnythingOfType("*entities.File")).Return(nil)
+
+	useCase := NewFileUseCase(mockStorage, mockFileRepo, storageUsage(t), entities.StorageQuota{}, nil)
 
 	req := UploadFileRequest{
 		FileName:    "document.txt",
@@ -313,7 +352,7 @@ func TestCustomMatchers(t *testing.T) {
 		Size:        1024,
 	}
 
-	response, err := useCase.UploadFile(context.Background(), req)
+	response, err := useCase.UploadFile(authContext(), req)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, response.FileID)
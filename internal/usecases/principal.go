@@ -0,0 +1,17 @@
+package usecases
+
+import (
+	"context"
+
+	"todo-service/internal/domain/entities"
+)
+
+// caller returns the authenticated principal whose tenant and ID every
+// repository query is scoped to.
+func caller(ctx context.Context) (*entities.Principal, error) {
+	principal, ok := entities.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, entities.ErrUnauthenticated
+	}
+	return principal, nil
+}
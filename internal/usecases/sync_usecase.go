@@ -0,0 +1,386 @@
+package usecases
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+)
+
+const (
+	defaultSyncPageSize = 200
+	maxSyncPageSize     = 1000
+	maxSyncPushBatch    = 100
+
+	syncTokenPrefix = "v1."
+)
+
+const (
+	SyncOpCreate = "create"
+	SyncOpUpdate = "update"
+	SyncOpDelete = "delete"
+)
+
+const (
+	SyncStatusApplied  = "applied"
+	SyncStatusConflict = "conflict"
+	SyncStatusNotFound = "not_found"
+	SyncStatusInvalid  = "invalid"
+	SyncStatusError    = "error"
+)
+
+var (
+	ErrInvalidSyncToken = errors.New("invalid sync token")
+	errSyncConflict     = errors.New("sync conflict")
+)
+
+type SyncUseCase struct {
+	txManager       ports.TransactionManager
+	streamPublisher ports.StreamPublisher
+	fileRepo        ports.FileRepository
+	rules           entities.TodoRules
+	metrics         ports.Metrics
+}
+
+func NewSyncUseCase(
+	txManager ports.TransactionManager,
+	streamPublisher ports.StreamPublisher,
+	fileRepo ports.FileRepository,
+	rules entities.TodoRules,
+	metrics ports.Metrics,
+) *SyncUseCase {
+	return &SyncUseCase{
+		txManager:       txManager,
+		streamPublisher: streamPublisher,
+		fileRepo:        fileRepo,
+		rules:           rules,
+		metrics:         metricsOrNop(metrics),
+	}
+}
+
+type SyncChange struct {
+	ID      uuid.UUID          `json:"id"`
+	Deleted bool               `json:"deleted"`
+	Todo    *entities.TodoItem `json:"todo,omitempty"`
+}
+
+type SyncPullResponse struct {
+	Changes   []SyncChange `json:"changes"`
+	NextToken string       `json:"next_token"`
+	HasMore   bool         `json:"has_more"`
+}
+
+type SyncPushItem struct {
+	Op            string     `json:"op" binding:"required,oneof=create update delete"`
+	ID            uuid.UUID  `json:"id"`
+	Description   string     `json:"description,omitempty"`
+	DueDate       time.Time  `json:"due_date,omitempty"`
+	FileID        *string    `json:"file_id,omitempty"`
+	BaseUpdatedAt *time.Time `json:"base_updated_at,omitempty"`
+	BaseVersion   *int       `json:"base_version,omitempty"`
+}
+
+type SyncPushRequest struct {
+	Changes []SyncPushItem `json:"changes" binding:"required,dive"`
+}
+
+type SyncPushResult struct {
+	ID     uuid.UUID `json:"id"`
+	Op     string    `json:"op"`
+	Status string    `json:"status"`
+	Error  string    `json:"error,omitempty"`
+	// Errors lists every violation when Status is invalid.
+	Errors []entities.FieldViolation `json:"errors,omitempty"`
+	Todo   *entities.TodoItem        `json:"todo,omitempty"`
+}
+
+type SyncPushResponse struct {
+	Results []SyncPushResult `json:"results"`
+}
+
+// Pull returns todos changed after the position encoded in token. An empty
+// token starts a full sync, which omits tombstones since the client has
+// nothing to delete yet.
+func (uc *SyncUseCase) Pull(ctx context.Context, token string, limit int) (*SyncPullResponse, error) {
+	principal, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	afterSeq, err := DecodeSyncToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultSyncPageSize
+	}
+	if limit > maxSyncPageSize {
+		limit = maxSyncPageSize
+	}
+
+	var todos []*entities.TodoItem
+	err = uc.txManager.DoInTx(ctx, func(repo ports.TodoRepository) error {
+		var err error
+		todos, err = repo.ListChanges(ctx, principal.TenantID, principal.ID, afterSeq, limit)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load changes: %w", err)
+	}
+
+	response := &SyncPullResponse{
+		Changes:   []SyncChange{},
+		NextToken: EncodeSyncToken(afterSeq),
+		HasMore:   len(todos) == limit,
+	}
+
+	for _, todo := range todos {
+		response.NextToken = EncodeSyncToken(todo.ChangeSeq)
+
+		if todo.IsDeleted() {
+			if token != "" {
+				response.Changes = append(response.Changes, SyncChange{ID: todo.ID, Deleted: true})
+			}
+			continue
+		}
+
+		response.Changes = append(response.Changes, SyncChange{ID: todo.ID, Todo: todo})
+	}
+
+	return response, nil
+}
+
+// Push applies client-side changes one by one, each in its own transaction,
+// so a conflict on one item does not block the rest of the batch. Updates
+// must (and deletes may) carry the version or UpdatedAt the client last saw;
+// if the server copy has moved on, the item is reported as a conflict
+// together with the current server state.
+func (uc *SyncUseCase) Push(ctx context.Context, req SyncPushRequest) (*SyncPushResponse, error) {
+	principal, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Changes) > maxSyncPushBatch {
+		return nil, entities.NewValidationError(entities.FieldViolation{Field: "changes", Code: "too_many", Message: fmt.Sprintf("must have at most %d items", maxSyncPushBatch)})
+	}
+
+	response := &SyncPushResponse{Results: make([]SyncPushResult, 0, len(req.Changes))}
+	for _, item := range req.Changes {
+		response.Results = append(response.Results, uc.apply(ctx, principal, item))
+	}
+
+	return response, nil
+}
+
+func (uc *SyncUseCase) apply(ctx context.Context, principal *entities.Principal, item SyncPushItem) SyncPushResult {
+	result := SyncPushResult{ID: item.ID, Op: item.Op}
+
+	if item.ID == uuid.Nil {
+		result.Status = SyncStatusInvalid
+		result.Error = "id is required"
+		return result
+	}
+
+	if item.Op == SyncOpUpdate && item.BaseUpdatedAt == nil && item.BaseVersion == nil {
+		result.Status = SyncStatusInvalid
+		result.Error = "base_version or base_updated_at is required for updates"
+		return result
+	}
+
+	var todo *entities.TodoItem
+	err := uc.txManager.DoInTx(ctx, func(repo ports.TodoRepository) error {
+		var err error
+		switch item.Op {
+		case SyncOpCreate:
+			todo, err = uc.applyCreate(ctx, repo, principal, item)
+		case SyncOpUpdate:
+			todo, err = uc.applyUpdate(ctx, repo, principal, item)
+		case SyncOpDelete:
+			todo, err = uc.applyDelete(ctx, repo, principal, item)
+		default:
+			err = fmt.Errorf("unknown operation %q", item.Op)
+		}
+		return err
+	})
+
+	result.Todo = todo
+
+	var validationErr *entities.ValidationError
+	switch {
+	case err == nil:
+		result.Status = SyncStatusApplied
+		if item.Op == SyncOpCreate {
+			uc.metrics.TodoCreated()
+		}
+	case errors.Is(err, errSyncConflict), errors.Is(err, entities.ErrTodoVersionMismatch):
+		result.Status = SyncStatusConflict
+	case errors.Is(err, entities.ErrTodoAlreadyExists):
+		result.Status = SyncStatusConflict
+		result.Error = "id is already in use"
+		result.Todo = nil
+	case errors.Is(err, entities.ErrTodoNotFound):
+		result.Status = SyncStatusNotFound
+		result.Todo = nil
+	case errors.As(err, &validationErr):
+		result.Status = SyncStatusInvalid
+		result.Error = validationErr.Error()
+		result.Errors = validationErr.Violations
+		result.Todo = nil
+	default:
+		result.Status = SyncStatusError
+		result.Error = "failed to apply change"
+		result.Todo = nil
+	}
+
+	return result
+}
+
+func (uc *SyncUseCase) applyCreate(ctx context.Context, repo ports.TodoRepository, principal *entities.Principal, item SyncPushItem) (*entities.TodoItem, error) {
+	existing, err := repo.GetByID(ctx, principal.TenantID, item.ID)
+	if err == nil {
+		if existing.OwnerID != principal.ID {
+			return nil, entities.ErrTodoAlreadyExists
+		}
+		return existing, errSyncConflict
+	}
+	if !errors.Is(err, entities.ErrTodoNotFound) {
+		return nil, err
+	}
+
+	todo := entities.NewTodoItem(principal.TenantID, principal.ID, item.Description, item.DueDate, item.FileID)
+	todo.ID = item.ID
+	if err := uc.rules.Validate(todo, entities.Now()); err != nil {
+		return nil, err
+	}
+	if err := checkAttachment(ctx, uc.fileRepo, principal, nil, todo.FileID); err != nil {
+		return nil, err
+	}
+
+	if err := repo.Create(ctx, todo); err != nil {
+		return nil, err
+	}
+
+	return todo, uc.publish(ctx, entities.EventTypeTodoCreated, todo)
+}
+
+func (uc *SyncUseCase) applyUpdate(ctx context.Context, repo ports.TodoRepository, principal *entities.Principal, item SyncPushItem) (*entities.TodoItem, error) {
+	todo, err := uc.ownTodo(ctx, repo, principal, item.ID)
+	if err != nil {
+		return nil, err
+	}
+	if todo.IsDeleted() || !item.matchesBase(todo) {
+		return todo, errSyncConflict
+	}
+
+	previousDueDate, previousFileID := todo.DueDate, todo.FileID
+	todo.Update(item.Description, item.DueDate, item.FileID)
+	if err := uc.rules.ValidateUpdate(todo, previousDueDate, entities.Now()); err != nil {
+		return nil, err
+	}
+	if err := checkAttachment(ctx, uc.fileRepo, principal, previousFileID, todo.FileID); err != nil {
+		return nil, err
+	}
+
+	if err := repo.Update(ctx, todo); err != nil {
+		return nil, err
+	}
+
+	return todo, uc.publishChange(ctx, repo, entities.EventTypeTodoUpdated, todo)
+}
+
+func (uc *SyncUseCase) applyDelete(ctx context.Context, repo ports.TodoRepository, principal *entities.Principal, item SyncPushItem) (*entities.TodoItem, error) {
+	todo, err := uc.ownTodo(ctx, repo, principal, item.ID)
+	if err != nil {
+		return nil, err
+	}
+	if todo.IsDeleted() {
+		return todo, nil
+	}
+	if !item.matchesBase(todo) {
+		return todo, errSyncConflict
+	}
+
+	todo.MarkDeleted()
+	if err := repo.Delete(ctx, todo); err != nil {
+		return nil, err
+	}
+
+	return todo, uc.publishChange(ctx, repo, entities.EventTypeTodoDeleted, todo)
+}
+
+// ownTodo locks a todo for a sync write. Sync only covers the caller's own
+// todos; shared todos are changed through the todo endpoints.
+func (uc *SyncUseCase) ownTodo(ctx context.Context, repo ports.TodoRepository, principal *entities.Principal, id uuid.UUID) (*entities.TodoItem, error) {
+	todo, err := repo.GetByIDForUpdate(ctx, principal.TenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if todo.OwnerID != principal.ID {
+		return nil, entities.ErrTodoNotFound
+	}
+	return todo, nil
+}
+
+// publishChange publishes a change to an existing todo, which everyone it
+// is currently shared with may see.
+func (uc *SyncUseCase) publishChange(ctx context.Context, repo ports.TodoRepository, eventType string, todo *entities.TodoItem) error {
+	grants, err := repo.ListGrants(ctx, todo.TenantID, todo.ID)
+	if err != nil {
+		return err
+	}
+
+	return uc.publish(ctx, eventType, todo, grants...)
+}
+
+func (uc *SyncUseCase) publish(ctx context.Context, eventType string, todo *entities.TodoItem, grants ...*entities.TodoGrant) error {
+	event, err := entities.NewTodoEvent(eventType, todo, grants...)
+	if err != nil {
+		return err
+	}
+
+	return uc.streamPublisher.Publish(ctx, event)
+}
+
+// matchesBase reports whether the server copy is still the one the client
+// based its change on. The version is authoritative when supplied.
+func (item SyncPushItem) matchesBase(todo *entities.TodoItem) bool {
+	if item.BaseVersion != nil {
+		return *item.BaseVersion == todo.Version
+	}
+	if item.BaseUpdatedAt != nil {
+		return todo.UpdatedAt.Equal(*item.BaseUpdatedAt)
+	}
+	return true
+}
+
+func EncodeSyncToken(seq int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(syncTokenPrefix + strconv.FormatInt(seq, 10)))
+}
+
+func DecodeSyncToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || !strings.HasPrefix(string(raw), syncTokenPrefix) {
+		return 0, ErrInvalidSyncToken
+	}
+
+	seq, err := strconv.ParseInt(strings.TrimPrefix(string(raw), syncTokenPrefix), 10, 64)
+	if err != nil || seq < 0 {
+		return 0, ErrInvalidSyncToken
+	}
+
+	return seq, nil
+}
@@ -0,0 +1,283 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+	"todo-service/internal/domain/ports/mocks"
+)
+
+func withRepo(t *testing.T, txManager *mocks.MockTransactionManager, setup func(repo *mocks.MockTodoRepository)) {
+	txManager.EXPECT().DoInTx(mock.Anything, mock.AnythingOfType("func(ports.TodoRepository) error")).
+		RunAndReturn(func(ctx context.Context, fn func(repo ports.TodoRepository) error) error {
+			mockRepo := mocks.NewMockTodoRepository(t)
+			setup(mockRepo)
+			return fn(mockRepo)
+		}).Once()
+}
+
+func TestSyncToken_RoundTrip(t *testing.T) {
+	seq, err := DecodeSyncToken(EncodeSyncToken(42))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), seq)
+
+	seq, err = DecodeSyncToken("")
+	assert.NoError(t, err)
+	assert.Zero(t, seq)
+
+	_, err = DecodeSyncToken("definitely-not-a-token")
+	assert.ErrorIs(t, err, ErrInvalidSyncToken)
+}
+
+func TestSyncPull_ReturnsChangesAndTombstones(t *testing.T) {
+	mockTxManager := mocks.NewMockTransactionManager(t)
+	mockPublisher := mocks.NewMockStreamPublisher(t)
+
+	live := entities.NewTodoItem(testTenantID, testOwnerID, "Buy milk", time.Now().Add(time.Hour), nil)
+	live.ChangeSeq = 11
+	deleted := entities.NewTodoItem(testTenantID, testOwnerID, "Old task", time.Now().Add(time.Hour), nil)
+	deleted.MarkDeleted()
+	deleted.ChangeSeq = 12
+
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().ListChanges(mock.Anything, testTenantID, testOwnerID, int64(10), 2).Return([]*entities.TodoItem{live, deleted}, nil)
+	})
+
+	useCase := NewSyncUseCase(mockTxManager, mockPublisher, nil, entities.DefaultTodoRules(), nil)
+	resp, err := useCase.Pull(authContext(), EncodeSyncToken(10), 2)
+
+	require.NoError(t, err)
+	require.Len(t, resp.Changes, 2)
+	assert.Equal(t, live.ID, resp.Changes[0].ID)
+	assert.False(t, resp.Changes[0].Deleted)
+	assert.Equal(t, deleted.ID, resp.Changes[1].ID)
+	assert.True(t, resp.Changes[1].Deleted)
+	assert.Nil(t, resp.Changes[1].Todo)
+	assert.True(t, resp.HasMore)
+	assert.Equal(t, EncodeSyncToken(12), resp.NextToken)
+}
+
+func TestSyncPull_InitialSyncSkipsTombstones(t *testing.T) {
+	mockTxManager := mocks.NewMockTransactionManager(t)
+	mockPublisher := mocks.NewMockStreamPublisher(t)
+
+	deleted := entities.NewTodoItem(testTenantID, testOwnerID, "Old task", time.Now().Add(time.Hour), nil)
+	deleted.MarkDeleted()
+	deleted.ChangeSeq = 3
+
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().ListChanges(mock.Anything, testTenantID, testOwnerID, int64(0), defaultSyncPageSize).Return([]*entities.TodoItem{deleted}, nil)
+	})
+
+	useCase := NewSyncUseCase(mockTxManager, mockPublisher, nil, entities.DefaultTodoRules(), nil)
+	resp, err := useCase.Pull(authContext(), "", 0)
+
+	require.NoError(t, err)
+	assert.Empty(t, resp.Changes)
+	assert.False(t, resp.HasMore)
+	assert.Equal(t, EncodeSyncToken(3), resp.NextToken)
+}
+
+func TestSyncPull_IncludesTodosSharedWithCaller(t *testing.T) {
+	mockTxManager := mocks.NewMockTransactionManager(t)
+	mockPublisher := mocks.NewMockStreamPublisher(t)
+
+	shared := entities.NewTodoItem(testTenantID, "user-2", "Plan offsite", time.Now().Add(time.Hour), nil)
+	shared.ChangeSeq = 7
+
+	// The grantee's own ID selects the changes; the repository matches it
+	// against owners and grants alike.
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().ListChanges(mock.Anything, testTenantID, testOwnerID, int64(5), defaultSyncPageSize).Return([]*entities.TodoItem{shared}, nil)
+	})
+
+	useCase := NewSyncUseCase(mockTxManager, mockPublisher, nil, entities.DefaultTodoRules(), nil)
+	resp, err := useCase.Pull(authContext(), EncodeSyncToken(5), 0)
+
+	require.NoError(t, err)
+	require.Len(t, resp.Changes, 1)
+	assert.Equal(t, shared, resp.Changes[0].Todo)
+	assert.Equal(t, EncodeSyncToken(7), resp.NextToken)
+}
+
+func TestSyncPush_PerItemResults(t *testing.T) {
+	mockTxManager := mocks.NewMockTransactionManager(t)
+	mockPublisher := mocks.NewMockStreamPublisher(t)
+
+	current := entities.NewTodoItem(testTenantID, testOwnerID, "Server copy", time.Now().Add(time.Hour), nil)
+	stale := current.UpdatedAt.Add(-time.Minute)
+
+	fresh := entities.NewTodoItem(testTenantID, testOwnerID, "Fresh copy", time.Now().Add(time.Hour), nil)
+	freshBase := fresh.UpdatedAt
+
+	createdID := uuid.New()
+	missingID := uuid.New()
+
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().GetByID(mock.Anything, testTenantID, createdID).Return(nil, entities.ErrTodoNotFound)
+		repo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(todo *entities.TodoItem) bool {
+			return todo.ID == createdID
+		})).Return(nil)
+	})
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, current.ID).Return(current, nil)
+	})
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, fresh.ID).Return(fresh, nil)
+		repo.EXPECT().Update(mock.Anything, fresh).Return(nil)
+		repo.EXPECT().ListGrants(mock.Anything, testTenantID, fresh.ID).Return(nil, nil)
+	})
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, missingID).Return(nil, entities.ErrTodoNotFound)
+	})
+
+	mockPublisher.EXPECT().Publish(mock.Anything, mock.MatchedBy(func(event *entities.Event) bool {
+		return event.Type == entities.EventTypeTodoCreated
+	})).Return(nil).Once()
+	mockPublisher.EXPECT().Publish(mock.Anything, mock.MatchedBy(func(event *entities.Event) bool {
+		return event.Type == entities.EventTypeTodoUpdated
+	})).Return(nil).Once()
+
+	useCase := NewSyncUseCase(mockTxManager, mockPublisher, nil, entities.DefaultTodoRules(), nil)
+	resp, err := useCase.Push(authContext(), SyncPushRequest{Changes: []SyncPushItem{
+		{Op: SyncOpCreate, ID: createdID, Description: "Created offline", DueDate: time.Now().Add(time.Hour)},
+		{Op: SyncOpUpdate, ID: current.ID, Description: "Edited offline", DueDate: time.Now(), BaseUpdatedAt: &stale},
+		{Op: SyncOpUpdate, ID: fresh.ID, Description: "Edited offline", DueDate: time.Now(), BaseUpdatedAt: &freshBase},
+		{Op: SyncOpDelete, ID: missingID},
+		{Op: SyncOpUpdate, ID: uuid.New(), Description: "No base"},
+	}})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 5)
+
+	assert.Equal(t, SyncStatusApplied, resp.Results[0].Status)
+	assert.Equal(t, SyncStatusConflict, resp.Results[1].Status)
+	assert.Equal(t, "Server copy", resp.Results[1].Todo.Description)
+	assert.Equal(t, SyncStatusApplied, resp.Results[2].Status)
+	assert.Equal(t, "Edited offline", resp.Results[2].Todo.Description)
+	assert.Equal(t, SyncStatusNotFound, resp.Results[3].Status)
+	assert.Equal(t, SyncStatusInvalid, resp.Results[4].Status)
+}
+
+func TestSyncPush_ReportsEveryViolation(t *testing.T) {
+	mockTxManager := mocks.NewMockTransactionManager(t)
+	mockPublisher := mocks.NewMockStreamPublisher(t)
+
+	id := uuid.New()
+	fileID := "not-a-uuid"
+
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().GetByID(mock.Anything, testTenantID, id).Return(nil, entities.ErrTodoNotFound)
+	})
+
+	useCase := NewSyncUseCase(mockTxManager, mockPublisher, nil, entities.DefaultTodoRules(), nil)
+	resp, err := useCase.Push(authContext(), SyncPushRequest{Changes: []SyncPushItem{
+		{Op: SyncOpCreate, ID: id, Description: "\t", FileID: &fileID},
+	}})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 1)
+	assert.Equal(t, SyncStatusInvalid, resp.Results[0].Status)
+	assert.Nil(t, resp.Results[0].Todo)
+	assert.Equal(t, []entities.FieldViolation{
+		{Field: "description", Code: "required", Message: "is required"},
+		{Field: "due_date", Code: "required", Message: "is required"},
+		{Field: "file_id", Code: "invalid_uuid", Message: "must be a UUID"},
+	}, resp.Results[0].Errors)
+}
+
+func TestSyncPush_RejectsOversizedBatch(t *testing.T) {
+	useCase := NewSyncUseCase(mocks.NewMockTransactionManager(t), mocks.NewMockStreamPublisher(t), nil, entities.DefaultTodoRules(), nil)
+
+	changes := make([]SyncPushItem, maxSyncPushBatch+1)
+	for i := range changes {
+		changes[i] = SyncPushItem{Op: SyncOpDelete, ID: uuid.New()}
+	}
+	_, err := useCase.Push(authContext(), SyncPushRequest{Changes: changes})
+
+	var validationErr *entities.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []entities.FieldViolation{{Field: "changes", Code: "too_many", Message: "must have at most 100 items"}}, validationErr.Violations)
+}
+
+func TestSyncPush_RejectsOtherUsersFiles(t *testing.T) {
+	mockTxManager := mocks.NewMockTransactionManager(t)
+	mockPublisher := mocks.NewMockStreamPublisher(t)
+
+	theirs := entities.NewFile(testTenantID, "user-2", "theirs.txt", "text/plain", 5)
+	fileID := theirs.ID.String()
+	id := uuid.New()
+
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().GetByID(mock.Anything, testTenantID, id).Return(nil, entities.ErrTodoNotFound)
+	})
+
+	useCase := NewSyncUseCase(mockTxManager, mockPublisher, callerFiles(t, theirs), entities.DefaultTodoRules(), nil)
+	resp, err := useCase.Push(authContext(), SyncPushRequest{Changes: []SyncPushItem{
+		{Op: SyncOpCreate, ID: id, Description: "Offline", DueDate: time.Now().Add(time.Hour), FileID: &fileID},
+	}})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 1)
+	assert.Equal(t, SyncStatusInvalid, resp.Results[0].Status)
+	assert.Equal(t, []entities.FieldViolation{{Field: "file_id", Code: "file_not_found", Message: "must be the ID of one of your files"}}, resp.Results[0].Errors)
+}
+
+func TestSyncPush_BaseVersionConflict(t *testing.T) {
+	mockTxManager := mocks.NewMockTransactionManager(t)
+	mockPublisher := mocks.NewMockStreamPublisher(t)
+
+	current := entities.NewTodoItem(testTenantID, testOwnerID, "Server copy", time.Now().Add(time.Hour), nil)
+	current.Version = 4
+	staleVersion := 3
+
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, current.ID).Return(current, nil)
+	})
+
+	useCase := NewSyncUseCase(mockTxManager, mockPublisher, nil, entities.DefaultTodoRules(), nil)
+	resp, err := useCase.Push(authContext(), SyncPushRequest{Changes: []SyncPushItem{
+		{Op: SyncOpUpdate, ID: current.ID, Description: "Edited offline", DueDate: time.Now(), BaseVersion: &staleVersion},
+	}})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 1)
+	assert.Equal(t, SyncStatusConflict, resp.Results[0].Status)
+	assert.Equal(t, 4, resp.Results[0].Todo.Version)
+}
+
+func TestSyncPush_IgnoresOtherUsersTodos(t *testing.T) {
+	mockTxManager := mocks.NewMockTransactionManager(t)
+	mockPublisher := mocks.NewMockStreamPublisher(t)
+
+	foreign := entities.NewTodoItem(testTenantID, "user-2", "Someone else's", time.Now().Add(time.Hour), nil)
+	baseVersion := foreign.Version
+
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().GetByID(mock.Anything, testTenantID, foreign.ID).Return(foreign, nil)
+	})
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, foreign.ID).Return(foreign, nil)
+	})
+
+	useCase := NewSyncUseCase(mockTxManager, mockPublisher, nil, entities.DefaultTodoRules(), nil)
+	resp, err := useCase.Push(authContext(), SyncPushRequest{Changes: []SyncPushItem{
+		{Op: SyncOpCreate, ID: foreign.ID, Description: "Mine now", DueDate: time.Now()},
+		{Op: SyncOpUpdate, ID: foreign.ID, Description: "Edited", DueDate: time.Now(), BaseVersion: &baseVersion},
+	}})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 2)
+	assert.Equal(t, SyncStatusConflict, resp.Results[0].Status)
+	assert.Nil(t, resp.Results[0].Todo)
+	assert.Equal(t, SyncStatusNotFound, resp.Results[1].Status)
+	assert.Nil(t, resp.Results[1].Todo)
+}
@@ -0,0 +1,150 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+	"todo-service/internal/domain/ports/mocks"
+)
+
+// otherTenantID belongs to a second tenant whose user has the same subject
+// ID as testOwnerID, the case tenant scoping exists for.
+const otherTenantID = "globex"
+
+func otherTenantContext() context.Context {
+	return entities.ContextWithPrincipal(context.Background(), &entities.Principal{
+		ID:       testOwnerID,
+		TenantID: otherTenantID,
+		Method:   entities.AuthMethodJWT,
+	})
+}
+
+// tenantTodoStore backs todo repository mocks with one todo that, like the
+// MySQL repository, is only found under its own tenant.
+func tenantTodoStore(t *testing.T, todo *entities.TodoItem) *mocks.MockTransactionManager {
+	find := func(ctx context.Context, tenantID string, id uuid.UUID) (*entities.TodoItem, error) {
+		if tenantID != todo.TenantID || id != todo.ID {
+			return nil, entities.ErrTodoNotFound
+		}
+		return todo, nil
+	}
+
+	txManager := mocks.NewMockTransactionManager(t)
+	txManager.EXPECT().DoInTx(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(repo ports.TodoRepository) error) error {
+			repo := mocks.NewMockTodoRepository(t)
+			repo.EXPECT().GetByID(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(find).Maybe()
+			repo.EXPECT().GetByIDForUpdate(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(find).Maybe()
+			repo.EXPECT().List(mock.Anything, otherTenantID, testOwnerID, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+			return fn(repo)
+		})
+	return txManager
+}
+
+func TestTenantIsolation_Todos(t *testing.T) {
+	todo := entities.NewTodoItem(testTenantID, testOwnerID, "Quarterly report", time.Now().Add(time.Hour), nil)
+	useCase := NewTodoUseCase(tenantTodoStore(t, todo), mocks.NewMockStreamPublisher(t), nil, entities.DefaultTodoRules(), nil)
+	ctx := otherTenantContext()
+
+	// The todo's own tenant sees it, so the lookups below fail on the tenant.
+	found, err := useCase.GetTodo(authContext(), todo.ID)
+	require.NoError(t, err)
+	assert.Equal(t, todo, found)
+
+	_, err = useCase.GetTodo(ctx, todo.ID)
+	assert.ErrorIs(t, err, entities.ErrTodoNotFound)
+
+	_, err = useCase.UpdateTodo(ctx, todo.ID, UpdateTodoRequest{Description: "Taken over", DueDate: time.Now().Add(time.Hour)})
+	assert.ErrorIs(t, err, entities.ErrTodoNotFound)
+
+	_, err = useCase.SetCompleted(ctx, todo.ID, true, nil)
+	assert.ErrorIs(t, err, entities.ErrTodoNotFound)
+
+	_, err = useCase.ShareTodo(ctx, todo.ID, ShareTodoRequest{UserID: "user-2", Role: entities.RoleEditor})
+	assert.ErrorIs(t, err, entities.ErrTodoNotFound)
+
+	_, err = useCase.ListShares(ctx, todo.ID)
+	assert.ErrorIs(t, err, entities.ErrTodoNotFound)
+
+	assert.ErrorIs(t, useCase.DeleteTodo(ctx, todo.ID, nil), entities.ErrTodoNotFound)
+
+	todos, err := useCase.ListTodos(ctx, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, todos)
+
+	assert.False(t, todo.IsDeleted())
+	assert.False(t, todo.IsCompleted())
+	assert.Equal(t, "Quarterly report", todo.Description)
+}
+
+func TestTenantIsolation_Files(t *testing.T) {
+	file := entities.NewFile(testTenantID, testOwnerID, "notes.txt", "text/plain", 5)
+
+	fileRepo := mocks.NewMockFileRepository(t)
+	fileRepo.EXPECT().GetByID(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, tenantID, ownerID string, id uuid.UUID) (*entities.File, error) {
+			if tenantID != file.TenantID || ownerID != file.OwnerID || id != file.ID {
+				return nil, entities.ErrFileNotFound
+			}
+			return file, nil
+		})
+	fileRepo.EXPECT().ListByIDs(mock.Anything, otherTenantID, testOwnerID, []uuid.UUID{file.ID}).Return(nil, nil)
+
+	// Neither storage nor quota may be touched for another tenant's file.
+	useCase := NewFileUseCase(mocks.NewMockFileStorage(t), fileRepo, mocks.NewMockStorageUsageRepository(t), entities.StorageQuota{}, nil)
+	ctx := otherTenantContext()
+
+	_, _, err := useCase.DownloadFile(ctx, file.ID)
+	assert.ErrorIs(t, err, entities.ErrFileNotFound)
+
+	assert.ErrorIs(t, useCase.DeleteFile(ctx, file.ID), entities.ErrFileNotFound)
+
+	files, err := useCase.GetFiles(ctx, []uuid.UUID{file.ID})
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestTenantIsolation_Webhooks(t *testing.T) {
+	webhook := newTestWebhook(t, "https://hooks.example.com/todos")
+
+	repo := mocks.NewMockWebhookRepository(t)
+	find := func(ctx context.Context, tenantID, ownerID string, id uuid.UUID) (*entities.Webhook, error) {
+		if tenantID != webhook.TenantID || ownerID != webhook.OwnerID || id != webhook.ID {
+			return nil, entities.ErrWebhookNotFound
+		}
+		return webhook, nil
+	}
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything, mock.Anything, mock.Anything).RunAndReturn(find)
+	repo.EXPECT().Delete(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, tenantID, ownerID string, id uuid.UUID) error {
+			_, err := find(ctx, tenantID, ownerID, id)
+			return err
+		})
+	repo.EXPECT().List(mock.Anything, otherTenantID, testOwnerID).Return(nil, nil)
+
+	useCase := NewWebhookUseCase(repo, mocks.NewMockWebhookSender(t), testRetryPolicy)
+	ctx := otherTenantContext()
+
+	_, err := useCase.GetWebhook(ctx, webhook.ID)
+	assert.ErrorIs(t, err, entities.ErrWebhookNotFound)
+
+	_, err = useCase.EnableWebhook(ctx, webhook.ID)
+	assert.ErrorIs(t, err, entities.ErrWebhookNotFound)
+
+	_, err = useCase.ListDeliveries(ctx, webhook.ID, 10)
+	assert.ErrorIs(t, err, entities.ErrWebhookNotFound)
+
+	assert.ErrorIs(t, useCase.DeleteWebhook(ctx, webhook.ID), entities.ErrWebhookNotFound)
+
+	webhooks, err := useCase.ListWebhooks(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, webhooks)
+}
@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+)
+
+// roleOn returns the role principal holds on todo: owner for the todo's
+// owner, the granted role for users it was shared with, and "" otherwise.
+func roleOn(ctx context.Context, repo ports.TodoRepository, principal *entities.Principal, todo *entities.TodoItem) (string, error) {
+	if todo.OwnerID == principal.ID {
+		return entities.RoleOwner, nil
+	}
+
+	grant, err := repo.GetGrant(ctx, todo.TenantID, todo.ID, principal.ID)
+	if errors.Is(err, entities.ErrTodoGrantNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return grant.Role, nil
+}
+
+// authorize fails unless principal holds at least the required role on
+// todo. Users without any access get ErrTodoNotFound, so the existence of
+// other people's todos is not revealed.
+func authorize(ctx context.Context, repo ports.TodoRepository, principal *entities.Principal, todo *entities.TodoItem, required string) error {
+	if todo.IsDeleted() {
+		return entities.ErrTodoNotFound
+	}
+
+	role, err := roleOn(ctx, repo, principal, todo)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case role == "":
+		return entities.ErrTodoNotFound
+	case !entities.RoleAtLeast(role, required):
+		return entities.ErrPermissionDenied
+	}
+	return nil
+}
+
+// checkAttachment fails unless next, the file a todo is about to reference,
+// is one of principal's own files. Keeping the attachment the todo already
+// has is always allowed, so editors of a shared todo can change it without
+// owning its file. Malformed IDs are left to the todo rules.
+func checkAttachment(ctx context.Context, files ports.FileRepository, principal *entities.Principal, previous, next *string) error {
+	if next == nil || (previous != nil && *previous == *next) {
+		return nil
+	}
+	id, err := uuid.Parse(*next)
+	if err != nil {
+		return nil
+	}
+
+	_, err = files.GetByID(ctx, principal.TenantID, principal.ID, id)
+	if errors.Is(err, entities.ErrFileNotFound) {
+		return entities.NewValidationError(entities.FieldViolation{Field: "file_id", Code: "file_not_found", Message: "must be the ID of one of your files"})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up attachment: %w", err)
+	}
+	return nil
+}
@@ -5,22 +5,38 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
+
 	"todo-service/internal/domain/entities"
 	"todo-service/internal/domain/ports"
 )
 
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
 type TodoUseCase struct {
 	txManager       ports.TransactionManager
 	streamPublisher ports.StreamPublisher
+	fileRepo        ports.FileRepository
+	rules           entities.TodoRules
+	metrics         ports.Metrics
 }
 
 func NewTodoUseCase(
 	txManager ports.TransactionManager,
 	streamPublisher ports.StreamPublisher,
+	fileRepo ports.FileRepository,
+	rules entities.TodoRules,
+	metrics ports.Metrics,
 ) *TodoUseCase {
 	return &TodoUseCase{
 		txManager:       txManager,
 		streamPublisher: streamPublisher,
+		fileRepo:        fileRepo,
+		rules:           rules,
+		metrics:         metricsOrNop(metrics),
 	}
 }
 
@@ -30,24 +46,466 @@ type CreateTodoRequest struct {
 	FileID      *string   `json:"file_id,omitempty"`
 }
 
+type UpdateTodoRequest struct {
+	Description     string    `json:"description" binding:"required"`
+	DueDate         time.Time `json:"due_date" binding:"required"`
+	FileID          *string   `json:"file_id,omitempty"`
+	ExpectedVersion *int      `json:"-"`
+}
+
 func (uc *TodoUseCase) CreateTodo(ctx context.Context, req CreateTodoRequest) (*entities.TodoItem, error) {
-	todo := entities.NewTodoItem(req.Description, req.DueDate, req.FileID)
+	ctx, span := tracer.Start(ctx, "TodoUseCase.CreateTodo")
+	defer span.End()
 
-	if !todo.IsValid() {
-		return nil, fmt.Errorf("invalid todo item: description is required")
+	principal, err := caller(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	err := uc.txManager.DoInTx(ctx, func(repo ports.TodoRepository) error {
+	todo := entities.NewTodoItem(principal.TenantID, principal.ID, req.Description, req.DueDate, req.FileID)
+
+	if err := uc.rules.Validate(todo, entities.Now()); err != nil {
+		return nil, err
+	}
+	if err := checkAttachment(ctx, uc.fileRepo, principal, nil, todo.FileID); err != nil {
+		return nil, err
+	}
+
+	err = uc.txManager.DoInTx(ctx, func(repo ports.TodoRepository) error {
 		if err := repo.Create(ctx, todo); err != nil {
 			return err
 		}
 
-		return uc.streamPublisher.PublishTodoCreated(ctx, todo)
+		return uc.publish(ctx, entities.EventTypeTodoCreated, todo)
 	})
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to create todo: %w", err)
 	}
 
+	uc.metrics.TodoCreated()
 	return todo, nil
 }
+
+func (uc *TodoUseCase) GetTodo(ctx context.Context, id uuid.UUID) (*entities.TodoItem, error) {
+	ctx, span := tracer.Start(ctx, "TodoUseCase.GetTodo")
+	defer span.End()
+
+	principal, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var todo *entities.TodoItem
+
+	err = uc.txManager.DoInTx(ctx, func(repo ports.TodoRepository) error {
+		found, err := repo.GetByID(ctx, principal.TenantID, id)
+		if err != nil {
+			return err
+		}
+		if err := authorize(ctx, repo, principal, found, entities.RoleViewer); err != nil {
+			return err
+		}
+
+		todo = found
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get todo: %w", err)
+	}
+
+	return todo, nil
+}
+
+func (uc *TodoUseCase) ListTodos(ctx context.Context, limit, offset int) ([]*entities.TodoItem, error) {
+	ctx, span := tracer.Start(ctx, "TodoUseCase.ListTodos")
+	defer span.End()
+
+	principal, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	var todos []*entities.TodoItem
+
+	err = uc.txManager.DoInTx(ctx, func(repo ports.TodoRepository) error {
+		var err error
+		todos, err = repo.List(ctx, principal.TenantID, principal.ID, limit, offset)
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list todos: %w", err)
+	}
+
+	return todos, nil
+}
+
+// TodoPage is one page of a todo search; HasNextPage reports whether more
+// todos follow the last one.
+type TodoPage struct {
+	Todos       []*entities.TodoItem
+	HasNextPage bool
+}
+
+// SearchTodos returns the caller's todos matching filter in listing order,
+// starting after the given cursor when one is set.
+func (uc *TodoUseCase) SearchTodos(ctx context.Context, filter entities.TodoFilter, after *entities.TodoCursor, limit int) (*TodoPage, error) {
+	ctx, span := tracer.Start(ctx, "TodoUseCase.SearchTodos")
+	defer span.End()
+
+	principal, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	var todos []*entities.TodoItem
+
+	// One extra row tells whether there is a next page without a count query.
+	err = uc.txManager.DoInTx(ctx, func(repo ports.TodoRepository) error {
+		var err error
+		todos, err = repo.Search(ctx, principal.TenantID, principal.ID, filter, after, limit+1, time.Now())
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to search todos: %w", err)
+	}
+
+	page := &TodoPage{Todos: todos}
+	if len(todos) > limit {
+		page.Todos, page.HasNextPage = todos[:limit], true
+	}
+
+	return page, nil
+}
+
+func (uc *TodoUseCase) CountTodos(ctx context.Context) (*entities.TodoCounts, error) {
+	ctx, span := tracer.Start(ctx, "TodoUseCase.CountTodos")
+	defer span.End()
+
+	principal, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var counts *entities.TodoCounts
+
+	err = uc.txManager.DoInTx(ctx, func(repo ports.TodoRepository) error {
+		var err error
+		counts, err = repo.CountByStatus(ctx, principal.TenantID, principal.ID, time.Now())
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to count todos: %w", err)
+	}
+
+	return counts, nil
+}
+
+func (uc *TodoUseCase) UpdateTodo(ctx context.Context, id uuid.UUID, req UpdateTodoRequest) (*entities.TodoItem, error) {
+	ctx, span := tracer.Start(ctx, "TodoUseCase.UpdateTodo")
+	defer span.End()
+
+	principal, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var todo *entities.TodoItem
+
+	err = uc.txManager.DoInTx(ctx, func(repo ports.TodoRepository) error {
+		found, err := repo.GetByIDForUpdate(ctx, principal.TenantID, id)
+		if err != nil {
+			return err
+		}
+		if err := authorize(ctx, repo, principal, found, entities.RoleEditor); err != nil {
+			return err
+		}
+		if req.ExpectedVersion != nil && *req.ExpectedVersion != found.Version {
+			return entities.ErrTodoVersionMismatch
+		}
+
+		previousDueDate, previousFileID := found.DueDate, found.FileID
+		found.Update(req.Description, req.DueDate, req.FileID)
+		if err := uc.rules.ValidateUpdate(found, previousDueDate, entities.Now()); err != nil {
+			return err
+		}
+		if err := checkAttachment(ctx, uc.fileRepo, principal, previousFileID, found.FileID); err != nil {
+			return err
+		}
+
+		if err := repo.Update(ctx, found); err != nil {
+			return err
+		}
+
+		todo = found
+		return uc.publishChange(ctx, repo, entities.EventTypeTodoUpdated, todo)
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to update todo: %w", err)
+	}
+
+	return todo, nil
+}
+
+// SetCompleted completes or reopens a todo. Editors may do either; asking
+// for the state the todo is already in changes nothing.
+func (uc *TodoUseCase) SetCompleted(ctx context.Context, id uuid.UUID, completed bool, expectedVersion *int) (*entities.TodoItem, error) {
+	ctx, span := tracer.Start(ctx, "TodoUseCase.SetCompleted")
+	defer span.End()
+
+	principal, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var todo *entities.TodoItem
+
+	err = uc.txManager.DoInTx(ctx, func(repo ports.TodoRepository) error {
+		found, err := repo.GetByIDForUpdate(ctx, principal.TenantID, id)
+		if err != nil {
+			return err
+		}
+		if err := authorize(ctx, repo, principal, found, entities.RoleEditor); err != nil {
+			return err
+		}
+		if expectedVersion != nil && *expectedVersion != found.Version {
+			return entities.ErrTodoVersionMismatch
+		}
+
+		todo = found
+		if found.IsCompleted() == completed {
+			return nil
+		}
+
+		found.SetCompleted(completed)
+		if err := repo.Update(ctx, found); err != nil {
+			return err
+		}
+
+		return uc.publishChange(ctx, repo, entities.EventTypeTodoUpdated, found)
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to set todo completion: %w", err)
+	}
+
+	return todo, nil
+}
+
+func (uc *TodoUseCase) DeleteTodo(ctx context.Context, id uuid.UUID, expectedVersion *int) error {
+	ctx, span := tracer.Start(ctx, "TodoUseCase.DeleteTodo")
+	defer span.End()
+
+	principal, err := caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = uc.txManager.DoInTx(ctx, func(repo ports.TodoRepository) error {
+		todo, err := repo.GetByIDForUpdate(ctx, principal.TenantID, id)
+		if err != nil {
+			return err
+		}
+		if err := authorize(ctx, repo, principal, todo, entities.RoleOwner); err != nil {
+			return err
+		}
+		if expectedVersion != nil && *expectedVersion != todo.Version {
+			return entities.ErrTodoVersionMismatch
+		}
+
+		todo.MarkDeleted()
+		if err := repo.Delete(ctx, todo); err != nil {
+			return err
+		}
+
+		return uc.publishChange(ctx, repo, entities.EventTypeTodoDeleted, todo)
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to delete todo: %w", err)
+	}
+
+	return nil
+}
+
+type ShareTodoRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+	Role   string `json:"role" binding:"required"`
+}
+
+// ShareTodo grants another user of the caller's tenant access to a todo, or
+// changes the role they already have. Only owners may share.
+func (uc *TodoUseCase) ShareTodo(ctx context.Context, id uuid.UUID, req ShareTodoRequest) (*entities.TodoGrant, error) {
+	ctx, span := tracer.Start(ctx, "TodoUseCase.ShareTodo")
+	defer span.End()
+
+	principal, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var grant *entities.TodoGrant
+
+	err = uc.txManager.DoInTx(ctx, func(repo ports.TodoRepository) error {
+		todo, err := repo.GetByIDForUpdate(ctx, principal.TenantID, id)
+		if err != nil {
+			return err
+		}
+		if err := authorize(ctx, repo, principal, todo, entities.RoleOwner); err != nil {
+			return err
+		}
+		if req.UserID == todo.OwnerID {
+			return fmt.Errorf("%w: the todo's owner cannot be given another role", entities.ErrInvalidRole)
+		}
+
+		grant, err = entities.NewTodoGrant(todo, req.UserID, req.Role, principal.ID)
+		if err != nil {
+			return err
+		}
+		if err := repo.SaveGrant(ctx, grant); err != nil {
+			return err
+		}
+
+		return uc.publishGrant(ctx, repo, entities.EventTypeTodoShared, todo, grant)
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to share todo: %w", err)
+	}
+
+	return grant, nil
+}
+
+// UnshareTodo revokes a user's access to a todo. Owners may revoke anyone's
+// access; other users may only remove themselves.
+func (uc *TodoUseCase) UnshareTodo(ctx context.Context, id uuid.UUID, userID string) error {
+	ctx, span := tracer.Start(ctx, "TodoUseCase.UnshareTodo")
+	defer span.End()
+
+	principal, err := caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = uc.txManager.DoInTx(ctx, func(repo ports.TodoRepository) error {
+		todo, err := repo.GetByIDForUpdate(ctx, principal.TenantID, id)
+		if err != nil {
+			return err
+		}
+
+		required := entities.RoleOwner
+		if userID == principal.ID {
+			required = entities.RoleViewer
+		}
+		if err := authorize(ctx, repo, principal, todo, required); err != nil {
+			return err
+		}
+
+		grant, err := repo.GetGrant(ctx, todo.TenantID, todo.ID, userID)
+		if err != nil {
+			return err
+		}
+		if err := repo.DeleteGrant(ctx, todo.TenantID, todo.ID, userID); err != nil {
+			return err
+		}
+
+		return uc.publishGrant(ctx, repo, entities.EventTypeTodoUnshared, todo, grant)
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to unshare todo: %w", err)
+	}
+
+	return nil
+}
+
+// ListShares returns who a todo has been shared with. Anyone with access to
+// the todo may see it.
+func (uc *TodoUseCase) ListShares(ctx context.Context, id uuid.UUID) ([]*entities.TodoGrant, error) {
+	ctx, span := tracer.Start(ctx, "TodoUseCase.ListShares")
+	defer span.End()
+
+	principal, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var grants []*entities.TodoGrant
+
+	err = uc.txManager.DoInTx(ctx, func(repo ports.TodoRepository) error {
+		todo, err := repo.GetByID(ctx, principal.TenantID, id)
+		if err != nil {
+			return err
+		}
+		if err := authorize(ctx, repo, principal, todo, entities.RoleViewer); err != nil {
+			return err
+		}
+
+		grants, err = repo.ListGrants(ctx, todo.TenantID, todo.ID)
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list todo shares: %w", err)
+	}
+
+	return grants, nil
+}
+
+// publishGrant publishes a change to who can access todo. The user of grant
+// sees the event even when it revokes their access.
+func (uc *TodoUseCase) publishGrant(ctx context.Context, repo ports.TodoRepository, eventType string, todo *entities.TodoItem, grant *entities.TodoGrant) error {
+	grants, err := repo.ListGrants(ctx, todo.TenantID, todo.ID)
+	if err != nil {
+		return err
+	}
+
+	event, err := entities.NewTodoGrantEvent(eventType, todo, grant, grants...)
+	if err != nil {
+		return err
+	}
+
+	return uc.streamPublisher.Publish(ctx, event)
+}
+
+// publishChange publishes a change to an existing todo, which everyone it
+// is currently shared with may see.
+func (uc *TodoUseCase) publishChange(ctx context.Context, repo ports.TodoRepository, eventType string, todo *entities.TodoItem) error {
+	grants, err := repo.ListGrants(ctx, todo.TenantID, todo.ID)
+	if err != nil {
+		return err
+	}
+
+	return uc.publish(ctx, eventType, todo, grants...)
+}
+
+func (uc *TodoUseCase) publish(ctx context.Context, eventType string, todo *entities.TodoItem, grants ...*entities.TodoGrant) error {
+	event, err := entities.NewTodoEvent(eventType, todo, grants...)
+	if err != nil {
+		return err
+	}
+
+	return uc.streamPublisher.Publish(ctx, event)
+}
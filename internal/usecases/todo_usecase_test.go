@@ -6,13 +6,45 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"todo-service/internal/domain/entities"
 	"todo-service/internal/domain/ports"
 	"todo-service/internal/domain/ports/mocks"
 )
 
+const (
+	testTenantID = "acme"
+	testOwnerID  = "user-1"
+)
+
+func authContext() context.Context {
+	return entities.ContextWithPrincipal(context.Background(), &entities.Principal{
+		ID:       testOwnerID,
+		TenantID: testTenantID,
+		Method:   entities.AuthMethodJWT,
+	})
+}
+
+// callerFiles answers file lookups with the given files, so a todo may only
+// reference those.
+func callerFiles(t *testing.T, files ...*entities.File) *mocks.MockFileRepository {
+	repo := mocks.NewMockFileRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, tenantID, ownerID string, id uuid.UUID) (*entities.File, error) {
+			for _, file := range files {
+				if file.TenantID == tenantID && file.OwnerID == ownerID && file.ID == id {
+					return file, nil
+				}
+			}
+			return nil, entities.ErrFileNotFound
+		}).Maybe()
+	return repo
+}
+
 func TestCreateTodo(t *testing.T) {
 	mockTxManager := mocks.NewMockTransactionManager(t)
 	mockPublisher := mocks.NewMockStreamPublisher(t)
@@ -24,25 +56,80 @@ func TestCreateTodo(t *testing.T) {
 			return fn(mockRepo)
 		})
 
-	mockPublisher.EXPECT().PublishTodoCreated(mock.Anything, mock.AnythingOfType("*entities.TodoItem")).Return(nil)
+	mockPublisher.EXPECT().Publish(mock.Anything, mock.AnythingOfType("*entities.Event")).Return(nil)
 
-	useCase := NewTodoUseCase(mockTxManager, mockPublisher)
+	file := entities.NewFile(testTenantID, testOwnerID, "notes.txt", "text/plain", 5)
+	useCase := NewTodoUseCase(mockTxManager, mockPublisher, callerFiles(t, file), entities.DefaultTodoRules(), nil)
 
 	dueDate := time.Now().Add(24 * time.Hour)
-	fileID := "test-file-id"
+	fileID := file.ID.String()
 	req := CreateTodoRequest{
 		Description: "Test Todo",
 		DueDate:     dueDate,
 		FileID:      &fileID,
 	}
 
-	todo, err := useCase.CreateTodo(context.Background(), req)
+	todo, err := useCase.CreateTodo(authContext(), req)
 
 	assert.NoError(t, err)
 	assert.Equal(t, req.Description, todo.Description)
 	assert.True(t, todo.DueDate.Equal(req.DueDate))
 	assert.NotNil(t, todo.FileID)
 	assert.Equal(t, *req.FileID, *todo.FileID)
+	assert.Equal(t, testOwnerID, todo.OwnerID)
+}
+
+func TestCreateTodoRequiresPrincipal(t *testing.T) {
+	mockTxManager := mocks.NewMockTransactionManager(t)
+	mockPublisher := mocks.NewMockStreamPublisher(t)
+
+	useCase := NewTodoUseCase(mockTxManager, mockPublisher, nil, entities.DefaultTodoRules(), nil)
+
+	_, err := useCase.CreateTodo(context.Background(), CreateTodoRequest{
+		Description: "Test Todo",
+		DueDate:     time.Now().Add(24 * time.Hour),
+	})
+
+	assert.ErrorIs(t, err, entities.ErrUnauthenticated)
+}
+
+func TestListTodosScopedToCaller(t *testing.T) {
+	mockTxManager := mocks.NewMockTransactionManager(t)
+	mockPublisher := mocks.NewMockStreamPublisher(t)
+
+	owned := entities.NewTodoItem(testTenantID, testOwnerID, "Mine", time.Now().Add(time.Hour), nil)
+
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().List(mock.Anything, testTenantID, testOwnerID, defaultListLimit, 0).Return([]*entities.TodoItem{owned}, nil)
+	})
+
+	useCase := NewTodoUseCase(mockTxManager, mockPublisher, nil, entities.DefaultTodoRules(), nil)
+	todos, err := useCase.ListTodos(authContext(), 0, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.TodoItem{owned}, todos)
+}
+
+func TestSearchTodosReportsNextPage(t *testing.T) {
+	mockTxManager := mocks.NewMockTransactionManager(t)
+	mockPublisher := mocks.NewMockStreamPublisher(t)
+
+	first := entities.NewTodoItem(testTenantID, testOwnerID, "First", time.Now().Add(time.Hour), nil)
+	second := entities.NewTodoItem(testTenantID, testOwnerID, "Second", time.Now().Add(time.Hour), nil)
+	filter := entities.TodoFilter{Status: entities.TodoStatusOpen}
+	after := entities.NewTodoCursor(first)
+
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().Search(mock.Anything, testTenantID, testOwnerID, filter, &after, 2, mock.Anything).
+			Return([]*entities.TodoItem{first, second}, nil)
+	})
+
+	useCase := NewTodoUseCase(mockTxManager, mockPublisher, nil, entities.DefaultTodoRules(), nil)
+	page, err := useCase.SearchTodos(authContext(), filter, &after, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, []*entities.TodoItem{first}, page.Todos)
+	assert.True(t, page.HasNextPage)
 }
 
 func TestCreateTodoWithRedisFailureRollback(t *testing.T) {
@@ -56,10 +143,10 @@ func TestCreateTodoWithRedisFailureRollback(t *testing.T) {
 			return fn(mockRepo)
 		})
 
-	mockPublisher.EXPECT().PublishTodoCreated(mock.Anything, mock.AnythingOfType("*entities.TodoItem")).
+	mockPublisher.EXPECT().Publish(mock.Anything, mock.AnythingOfType("*entities.Event")).
 		Return(assert.AnError)
 
-	useCase := NewTodoUseCase(mockTxManager, mockPublisher)
+	useCase := NewTodoUseCase(mockTxManager, mockPublisher, nil, entities.DefaultTodoRules(), nil)
 
 	dueDate := time.Now().Add(24 * time.Hour)
 	req := CreateTodoRequest{
@@ -68,7 +155,7 @@ func TestCreateTodoWithRedisFailureRollback(t *testing.T) {
 		FileID:      nil,
 	}
 
-	_, err := useCase.CreateTodo(context.Background(), req)
+	_, err := useCase.CreateTodo(authContext(), req)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to create todo")
@@ -81,7 +168,7 @@ func TestCreateTodoWithTransactionFailure(t *testing.T) {
 	mockTxManager.EXPECT().DoInTx(mock.Anything, mock.AnythingOfType("func(ports.TodoRepository) error")).
 		Return(assert.AnError)
 
-	useCase := NewTodoUseCase(mockTxManager, mockPublisher)
+	useCase := NewTodoUseCase(mockTxManager, mockPublisher, nil, entities.DefaultTodoRules(), nil)
 
 	dueDate := time.Now().Add(24 * time.Hour)
 	req := CreateTodoRequest{
@@ -90,7 +177,7 @@ func TestCreateTodoWithTransactionFailure(t *testing.T) {
 		FileID:      nil,
 	}
 
-	_, err := useCase.CreateTodo(context.Background(), req)
+	_, err := useCase.CreateTodo(authContext(), req)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to create todo")
@@ -100,7 +187,7 @@ func TestCreateTodoWithInvalidData(t *testing.T) {
 	mockTxManager := mocks.NewMockTransactionManager(t)
 	mockPublisher := mocks.NewMockStreamPublisher(t)
 
-	useCase := NewTodoUseCase(mockTxManager, mockPublisher)
+	useCase := NewTodoUseCase(mockTxManager, mockPublisher, nil, entities.DefaultTodoRules(), nil)
 
 	req := CreateTodoRequest{
 		Description: "",
@@ -108,10 +195,284 @@ func TestCreateTodoWithInvalidData(t *testing.T) {
 		FileID:      nil,
 	}
 
-	_, err := useCase.CreateTodo(context.Background(), req)
+	_, err := useCase.CreateTodo(authContext(), req)
 
-	assert.Error(t, err)
-	assert.Equal(t, "invalid todo item: description is required", err.Error())
+	var validationErr *entities.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []entities.FieldViolation{{Field: "description", Code: "required", Message: "is required"}}, validationErr.Violations)
+}
+
+func TestCreateTodoWithPastDueDate(t *testing.T) {
+	lastWeek := time.Now().Add(-7 * 24 * time.Hour)
+	req := CreateTodoRequest{Description: "Imported", DueDate: lastWeek}
+
+	useCase := NewTodoUseCase(mocks.NewMockTransactionManager(t), mocks.NewMockStreamPublisher(t), nil, entities.DefaultTodoRules(), nil)
+	_, err := useCase.CreateTodo(authContext(), req)
+
+	var validationErr *entities.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []entities.FieldViolation{{Field: "due_date", Code: "in_past", Message: "must not be in the past"}}, validationErr.Violations)
+
+	mockTxManager := mocks.NewMockTransactionManager(t)
+	mockPublisher := mocks.NewMockStreamPublisher(t)
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().Create(mock.Anything, mock.Anything).Return(nil)
+	})
+	mockPublisher.EXPECT().Publish(mock.Anything, mock.AnythingOfType("*entities.Event")).Return(nil)
+
+	rules := entities.DefaultTodoRules()
+	rules.AllowPastDueDates = true
+	useCase = NewTodoUseCase(mockTxManager, mockPublisher, nil, rules, nil)
+	todo, err := useCase.CreateTodo(authContext(), req)
+
+	require.NoError(t, err)
+	assert.True(t, todo.DueDate.Equal(lastWeek))
+}
+
+func TestUpdateOverdueTodoKeepingItsDueDate(t *testing.T) {
+	mockTxManager := mocks.NewMockTransactionManager(t)
+	mockPublisher := mocks.NewMockStreamPublisher(t)
+
+	existing := entities.NewTodoItem(testTenantID, testOwnerID, "Original", time.Now().Add(-time.Hour), nil)
+
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, existing.ID).Return(existing, nil)
+		repo.EXPECT().Update(mock.Anything, existing).Return(nil)
+		repo.EXPECT().ListGrants(mock.Anything, testTenantID, existing.ID).Return(nil, nil)
+	})
+	mockPublisher.EXPECT().Publish(mock.Anything, mock.AnythingOfType("*entities.Event")).Return(nil)
+
+	useCase := NewTodoUseCase(mockTxManager, mockPublisher, nil, entities.DefaultTodoRules(), nil)
+
+	todo, err := useCase.UpdateTodo(authContext(), existing.ID, UpdateTodoRequest{
+		Description: "Edited",
+		DueDate:     existing.DueDate,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Edited", todo.Description)
+}
+
+func TestCreateTodoRejectsOtherUsersFiles(t *testing.T) {
+	theirs := entities.NewFile(testTenantID, "user-2", "theirs.txt", "text/plain", 5)
+	useCase := NewTodoUseCase(mocks.NewMockTransactionManager(t), mocks.NewMockStreamPublisher(t), callerFiles(t, theirs), entities.DefaultTodoRules(), nil)
+
+	for _, fileID := range []string{theirs.ID.String(), uuid.NewString()} {
+		_, err := useCase.CreateTodo(authContext(), CreateTodoRequest{
+			Description: "Steal an attachment",
+			DueDate:     time.Now().Add(time.Hour),
+			FileID:      &fileID,
+		})
+
+		var validationErr *entities.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []entities.FieldViolation{{Field: "file_id", Code: "file_not_found", Message: "must be the ID of one of your files"}}, validationErr.Violations)
+	}
+}
+
+func TestUpdateTodoAttachments(t *testing.T) {
+	mine := entities.NewFile(testTenantID, testOwnerID, "mine.txt", "text/plain", 5)
+	theirs := entities.NewFile(testTenantID, "user-2", "theirs.txt", "text/plain", 5)
+	other := entities.NewFile(testTenantID, "user-2", "other.txt", "text/plain", 5)
+	theirsID, mineID, otherID := theirs.ID.String(), mine.ID.String(), other.ID.String()
+
+	tests := []struct {
+		name    string
+		fileID  *string
+		wantErr bool
+	}{
+		{name: "keep the owner's attachment", fileID: &theirsID},
+		{name: "attach own file", fileID: &mineID},
+		{name: "detach", fileID: nil},
+		{name: "attach another of the owner's files", fileID: &otherID, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTxManager := mocks.NewMockTransactionManager(t)
+			mockPublisher := mocks.NewMockStreamPublisher(t)
+			mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil).Maybe()
+			useCase := NewTodoUseCase(mockTxManager, mockPublisher, callerFiles(t, mine, theirs, other), entities.DefaultTodoRules(), nil)
+
+			// The caller edits a todo user-2 shared with them, which carries
+			// user-2's file.
+			withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+				todo := sharedTodo(t, repo, entities.RoleEditor)
+				todo.FileID = &theirsID
+				repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, mock.Anything).Return(todo, nil)
+				if !tt.wantErr {
+					repo.EXPECT().Update(mock.Anything, todo).Return(nil)
+					repo.EXPECT().ListGrants(mock.Anything, testTenantID, todo.ID).Return(nil, nil)
+				}
+			})
+
+			todo, err := useCase.UpdateTodo(authContext(), uuid.New(), UpdateTodoRequest{
+				Description: "Edited",
+				DueDate:     time.Now().Add(time.Hour),
+				FileID:      tt.fileID,
+			})
+			if tt.wantErr {
+				var validationErr *entities.ValidationError
+				assert.ErrorAs(t, err, &validationErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.fileID, todo.FileID)
+		})
+	}
+}
+
+func TestUpdateTodoWithMatchingVersion(t *testing.T) {
+	mockTxManager := mocks.NewMockTransactionManager(t)
+	mockPublisher := mocks.NewMockStreamPublisher(t)
+
+	existing := entities.NewTodoItem(testTenantID, testOwnerID, "Original", time.Now().Add(time.Hour), nil)
+	existing.Version = 3
+
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, existing.ID).Return(existing, nil)
+		repo.EXPECT().Update(mock.Anything, existing).RunAndReturn(func(ctx context.Context, todo *entities.TodoItem) error {
+			todo.Version++
+			return nil
+		})
+		repo.EXPECT().ListGrants(mock.Anything, testTenantID, existing.ID).Return([]*entities.TodoGrant{
+			{TodoID: existing.ID, TenantID: testTenantID, UserID: "user-2", Role: entities.RoleViewer},
+		}, nil)
+	})
+	mockPublisher.EXPECT().Publish(mock.Anything, mock.MatchedBy(func(event *entities.Event) bool {
+		return event.VisibleTo(testOwnerID) && event.VisibleTo("user-2") && !event.VisibleTo("user-3")
+	})).Return(nil)
+
+	useCase := NewTodoUseCase(mockTxManager, mockPublisher, nil, entities.DefaultTodoRules(), nil)
+
+	expected := 3
+	todo, err := useCase.UpdateTodo(authContext(), existing.ID, UpdateTodoRequest{
+		Description:     "Edited",
+		DueDate:         time.Now().Add(2 * time.Hour),
+		ExpectedVersion: &expected,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Edited", todo.Description)
+	assert.Equal(t, 4, todo.Version)
+}
+
+func TestUpdateTodoWithStaleVersion(t *testing.T) {
+	mockTxManager := mocks.NewMockTransactionManager(t)
+	mockPublisher := mocks.NewMockStreamPublisher(t)
+
+	existing := entities.NewTodoItem(testTenantID, testOwnerID, "Original", time.Now().Add(time.Hour), nil)
+	existing.Version = 3
+
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, existing.ID).Return(existing, nil)
+	})
+
+	useCase := NewTodoUseCase(mockTxManager, mockPublisher, nil, entities.DefaultTodoRules(), nil)
+
+	stale := 2
+	_, err := useCase.UpdateTodo(authContext(), existing.ID, UpdateTodoRequest{
+		Description:     "Edited",
+		DueDate:         time.Now().Add(2 * time.Hour),
+		ExpectedVersion: &stale,
+	})
+
+	assert.ErrorIs(t, err, entities.ErrTodoVersionMismatch)
+}
+
+func TestDeleteTodoWithStaleVersion(t *testing.T) {
+	mockTxManager := mocks.NewMockTransactionManager(t)
+	mockPublisher := mocks.NewMockStreamPublisher(t)
+
+	existing := entities.NewTodoItem(testTenantID, testOwnerID, "Original", time.Now().Add(time.Hour), nil)
+	existing.Version = 5
+
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, existing.ID).Return(existing, nil)
+	})
+
+	useCase := NewTodoUseCase(mockTxManager, mockPublisher, nil, entities.DefaultTodoRules(), nil)
+
+	stale := 4
+	err := useCase.DeleteTodo(authContext(), existing.ID, &stale)
+
+	assert.ErrorIs(t, err, entities.ErrTodoVersionMismatch)
+	assert.False(t, existing.IsDeleted())
+}
+
+func TestSetCompleted(t *testing.T) {
+	mockTxManager := mocks.NewMockTransactionManager(t)
+	mockPublisher := mocks.NewMockStreamPublisher(t)
+
+	existing := entities.NewTodoItem(testTenantID, testOwnerID, "Original", time.Now().Add(time.Hour), nil)
+
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, existing.ID).Return(existing, nil)
+		repo.EXPECT().Update(mock.Anything, existing).RunAndReturn(func(ctx context.Context, todo *entities.TodoItem) error {
+			todo.Version++
+			return nil
+		}).Once()
+		repo.EXPECT().ListGrants(mock.Anything, testTenantID, existing.ID).Return(nil, nil).Once()
+	})
+	mockPublisher.EXPECT().Publish(mock.Anything, mock.AnythingOfType("*entities.Event")).Return(nil).Once()
+
+	useCase := NewTodoUseCase(mockTxManager, mockPublisher, nil, entities.DefaultTodoRules(), nil)
+
+	todo, err := useCase.SetCompleted(authContext(), existing.ID, true, nil)
+	require.NoError(t, err)
+	require.NotNil(t, todo.CompletedAt)
+	completedAt := *todo.CompletedAt
+
+	// Completing again is a no-op: no write, no event, same completion time.
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, existing.ID).Return(existing, nil)
+	})
+	todo, err = useCase.SetCompleted(authContext(), existing.ID, true, nil)
+	require.NoError(t, err)
+	assert.Equal(t, completedAt, *todo.CompletedAt)
+	assert.Equal(t, 2, todo.Version)
+}
+
+func TestSetCompleted_Reopen(t *testing.T) {
+	mockTxManager := mocks.NewMockTransactionManager(t)
+	mockPublisher := mocks.NewMockStreamPublisher(t)
+
+	existing := entities.NewTodoItem(testTenantID, testOwnerID, "Original", time.Now().Add(time.Hour), nil)
+	existing.SetCompleted(true)
+
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, existing.ID).Return(existing, nil)
+		repo.EXPECT().Update(mock.Anything, existing).Return(nil).Once()
+		repo.EXPECT().ListGrants(mock.Anything, testTenantID, existing.ID).Return(nil, nil).Once()
+	})
+	mockPublisher.EXPECT().Publish(mock.Anything, mock.MatchedBy(func(event *entities.Event) bool {
+		return event.Type == entities.EventTypeTodoUpdated
+	})).Return(nil).Once()
+
+	useCase := NewTodoUseCase(mockTxManager, mockPublisher, nil, entities.DefaultTodoRules(), nil)
+
+	todo, err := useCase.SetCompleted(authContext(), existing.ID, false, nil)
+	require.NoError(t, err)
+	assert.False(t, todo.IsCompleted())
+	assert.Nil(t, todo.CompletedAt)
+}
+
+func TestSetCompleted_StaleVersion(t *testing.T) {
+	mockTxManager := mocks.NewMockTransactionManager(t)
+
+	existing := entities.NewTodoItem(testTenantID, testOwnerID, "Original", time.Now().Add(time.Hour), nil)
+	existing.Version = 5
+
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, existing.ID).Return(existing, nil)
+	})
+
+	useCase := NewTodoUseCase(mockTxManager, mocks.NewMockStreamPublisher(t), nil, entities.DefaultTodoRules(), nil)
+
+	stale := 4
+	_, err := useCase.SetCompleted(authContext(), existing.ID, true, &stale)
+	assert.ErrorIs(t, err, entities.ErrTodoVersionMismatch)
+	assert.False(t, existing.IsCompleted())
 }
 
 func TestUploadFile(t *testing.T) {
@@ -120,14 +481,17 @@ func TestUploadFile(t *testing.T) {
 	mockStorage.EXPECT().UploadFile(
 		mock.Anything,
 		mock.MatchedBy(func(storagePath string) bool {
-			return strings.HasPrefix(storagePath, "files/") && strings.HasSuffix(storagePath, "/test.txt")
+			return strings.HasPrefix(storagePath, "tenants/"+testTenantID+"/files/") && strings.HasSuffix(storagePath, "/test.txt")
 		}),
 		"text/plain",
 		mock.Anything,
 		int64(1024),
 	).Return(nil)
 
-	useCase := NewFileUseCase(mockStorage)
+	mockFileRepo := mocks.NewMockFileRepository(t)
+	mockFileRepo.EXPECT().Create(mock.Anything, mock.AnythingOfType("*entities.File")).Return(nil)
+
+	useCase := NewFileUseCase(mockStorage, mockFileRepo, storageUsage(t), entities.StorageQuota{}, nil)
 
 	req := UploadFileRequest{
 		FileName:    "test.txt",
@@ -136,7 +500,7 @@ func TestUploadFile(t *testing.T) {
 		Size:        1024,
 	}
 
-	response, err := useCase.UploadFile(context.Background(), req)
+	response, err := useCase.UploadFile(authContext(), req)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, response.FileID)
@@ -145,7 +509,7 @@ func TestUploadFile(t *testing.T) {
 func TestUploadFileWithInvalidData(t *testing.T) {
 	mockStorage := mocks.NewMockFileStorage(t)
 
-	useCase := NewFileUseCase(mockStorage)
+	useCase := NewFileUseCase(mockStorage, mocks.NewMockFileRepository(t), storageUsage(t), entities.StorageQuota{}, nil)
 
 	req := UploadFileRequest{
 		FileName:    "test.exe",
@@ -154,7 +518,7 @@ func TestUploadFileWithInvalidData(t *testing.T) {
 		Size:        1024,
 	}
 
-	_, err := useCase.UploadFile(context.Background(), req)
+	_, err := useCase.UploadFile(authContext(), req)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "file validation failed")
@@ -167,14 +531,14 @@ func TestUploadFileWithStorageFailure(t *testing.T) {
 	mockStorage.EXPECT().UploadFile(
 		mock.Anything,
 		mock.MatchedBy(func(storagePath string) bool {
-			return strings.HasPrefix(storagePath, "files/") && strings.HasSuffix(storagePath, "/test.txt")
+			return strings.HasPrefix(storagePath, "tenants/"+testTenantID+"/files/") && strings.HasSuffix(storagePath, "/test.txt")
 		}),
 		"text/plain",
 		mock.Anything,
 		int64(1024),
 	).Return(assert.AnError)
 
-	useCase := NewFileUseCase(mockStorage)
+	useCase := NewFileUseCase(mockStorage, mocks.NewMockFileRepository(t), storageUsage(t), entities.StorageQuota{}, nil)
 
 	req := UploadFileRequest{
 		FileName:    "test.txt",
@@ -183,8 +547,160 @@ func TestUploadFileWithStorageFailure(t *testing.T) {
 		Size:        1024,
 	}
 
-	_, err := useCase.UploadFile(context.Background(), req)
+	_, err := useCase.UploadFile(authContext(), req)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to upload file to storage")
 }
+
+func sharedTodo(t *testing.T, repo *mocks.MockTodoRepository, role string) *entities.TodoItem {
+	todo := entities.NewTodoItem(testTenantID, "user-2", "Shared", time.Now().Add(time.Hour), nil)
+
+	if role == "" {
+		repo.EXPECT().GetGrant(mock.Anything, testTenantID, todo.ID, testOwnerID).Return(nil, entities.ErrTodoGrantNotFound)
+		return todo
+	}
+
+	grant, err := entities.NewTodoGrant(todo, testOwnerID, role, "user-2")
+	require.NoError(t, err)
+	repo.EXPECT().GetGrant(mock.Anything, testTenantID, todo.ID, testOwnerID).Return(grant, nil)
+	return todo
+}
+
+func TestTodoAccessByRole(t *testing.T) {
+	tests := []struct {
+		role        string
+		getErr      error
+		updateErr   error
+		completeErr error
+		deleteErr   error
+	}{
+		{role: entities.RoleViewer, updateErr: entities.ErrPermissionDenied, completeErr: entities.ErrPermissionDenied, deleteErr: entities.ErrPermissionDenied},
+		{role: entities.RoleEditor, deleteErr: entities.ErrPermissionDenied},
+		{role: entities.RoleOwner},
+		{role: "", getErr: entities.ErrTodoNotFound, updateErr: entities.ErrTodoNotFound, completeErr: entities.ErrTodoNotFound, deleteErr: entities.ErrTodoNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run("role="+tt.role, func(t *testing.T) {
+			mockTxManager := mocks.NewMockTransactionManager(t)
+			mockPublisher := mocks.NewMockStreamPublisher(t)
+			mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil).Maybe()
+			useCase := NewTodoUseCase(mockTxManager, mockPublisher, nil, entities.DefaultTodoRules(), nil)
+
+			withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+				todo := sharedTodo(t, repo, tt.role)
+				repo.EXPECT().GetByID(mock.Anything, testTenantID, mock.Anything).Return(todo, nil)
+			})
+			_, err := useCase.GetTodo(authContext(), uuid.New())
+			assertErrorIs(t, err, tt.getErr)
+
+			withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+				todo := sharedTodo(t, repo, tt.role)
+				repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, mock.Anything).Return(todo, nil)
+				repo.EXPECT().Update(mock.Anything, todo).Return(nil).Maybe()
+				repo.EXPECT().ListGrants(mock.Anything, testTenantID, todo.ID).Return(nil, nil).Maybe()
+			})
+			_, err = useCase.UpdateTodo(authContext(), uuid.New(), UpdateTodoRequest{Description: "Edited", DueDate: time.Now()})
+			assertErrorIs(t, err, tt.updateErr)
+
+			withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+				todo := sharedTodo(t, repo, tt.role)
+				repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, mock.Anything).Return(todo, nil)
+				repo.EXPECT().Update(mock.Anything, todo).Return(nil).Maybe()
+				repo.EXPECT().ListGrants(mock.Anything, testTenantID, todo.ID).Return(nil, nil).Maybe()
+			})
+			_, err = useCase.SetCompleted(authContext(), uuid.New(), true, nil)
+			assertErrorIs(t, err, tt.completeErr)
+
+			withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+				todo := sharedTodo(t, repo, tt.role)
+				repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, mock.Anything).Return(todo, nil)
+				repo.EXPECT().Delete(mock.Anything, todo).Return(nil).Maybe()
+				repo.EXPECT().ListGrants(mock.Anything, testTenantID, todo.ID).Return(nil, nil).Maybe()
+			})
+			err = useCase.DeleteTodo(authContext(), uuid.New(), nil)
+			assertErrorIs(t, err, tt.deleteErr)
+		})
+	}
+}
+
+func assertErrorIs(t *testing.T, err, expected error) {
+	t.Helper()
+	if expected == nil {
+		assert.NoError(t, err)
+		return
+	}
+	assert.ErrorIs(t, err, expected)
+}
+
+func TestShareTodoPublishesSharedEvent(t *testing.T) {
+	mockTxManager := mocks.NewMockTransactionManager(t)
+	mockPublisher := mocks.NewMockStreamPublisher(t)
+
+	todo := entities.NewTodoItem(testTenantID, testOwnerID, "Mine", time.Now().Add(time.Hour), nil)
+
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, todo.ID).Return(todo, nil)
+		repo.EXPECT().SaveGrant(mock.Anything, mock.MatchedBy(func(grant *entities.TodoGrant) bool {
+			return grant.TodoID == todo.ID && grant.UserID == "user-2" && grant.Role == entities.RoleEditor
+		})).Return(nil)
+		repo.EXPECT().ListGrants(mock.Anything, testTenantID, todo.ID).Return([]*entities.TodoGrant{
+			{TodoID: todo.ID, TenantID: testTenantID, UserID: "user-2", Role: entities.RoleEditor},
+			{TodoID: todo.ID, TenantID: testTenantID, UserID: "user-3", Role: entities.RoleViewer},
+		}, nil)
+	})
+	mockPublisher.EXPECT().Publish(mock.Anything, mock.MatchedBy(func(event *entities.Event) bool {
+		return event.Type == entities.EventTypeTodoShared && event.OwnerID == testOwnerID && event.TenantID == testTenantID &&
+			assert.ObjectsAreEqual([]string{"user-2", "user-3"}, event.GranteeIDs)
+	})).Return(nil)
+
+	useCase := NewTodoUseCase(mockTxManager, mockPublisher, nil, entities.DefaultTodoRules(), nil)
+
+	grant, err := useCase.ShareTodo(authContext(), todo.ID, ShareTodoRequest{UserID: "user-2", Role: entities.RoleEditor})
+
+	require.NoError(t, err)
+	assert.Equal(t, testOwnerID, grant.GrantedBy)
+}
+
+func TestShareTodoRejectsInvalidRoleAndNonOwners(t *testing.T) {
+	mockTxManager := mocks.NewMockTransactionManager(t)
+	useCase := NewTodoUseCase(mockTxManager, mocks.NewMockStreamPublisher(t), nil, entities.DefaultTodoRules(), nil)
+
+	todo := entities.NewTodoItem(testTenantID, testOwnerID, "Mine", time.Now().Add(time.Hour), nil)
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, todo.ID).Return(todo, nil)
+	})
+	_, err := useCase.ShareTodo(authContext(), todo.ID, ShareTodoRequest{UserID: "user-2", Role: "admin"})
+	assert.ErrorIs(t, err, entities.ErrInvalidRole)
+
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		shared := sharedTodo(t, repo, entities.RoleEditor)
+		repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, mock.Anything).Return(shared, nil)
+	})
+	_, err = useCase.ShareTodo(authContext(), uuid.New(), ShareTodoRequest{UserID: "user-3", Role: entities.RoleViewer})
+	assert.ErrorIs(t, err, entities.ErrPermissionDenied)
+}
+
+func TestUnshareTodoNotifiesRevokedUser(t *testing.T) {
+	mockTxManager := mocks.NewMockTransactionManager(t)
+	mockPublisher := mocks.NewMockStreamPublisher(t)
+
+	todo := entities.NewTodoItem(testTenantID, testOwnerID, "Mine", time.Now().Add(time.Hour), nil)
+	grant, err := entities.NewTodoGrant(todo, "user-2", entities.RoleViewer, testOwnerID)
+	require.NoError(t, err)
+
+	withRepo(t, mockTxManager, func(repo *mocks.MockTodoRepository) {
+		repo.EXPECT().GetByIDForUpdate(mock.Anything, testTenantID, todo.ID).Return(todo, nil)
+		repo.EXPECT().GetGrant(mock.Anything, testTenantID, todo.ID, "user-2").Return(grant, nil)
+		repo.EXPECT().DeleteGrant(mock.Anything, testTenantID, todo.ID, "user-2").Return(nil)
+		repo.EXPECT().ListGrants(mock.Anything, testTenantID, todo.ID).Return(nil, nil)
+	})
+	mockPublisher.EXPECT().Publish(mock.Anything, mock.MatchedBy(func(event *entities.Event) bool {
+		return event.Type == entities.EventTypeTodoUnshared && event.VisibleTo("user-2")
+	})).Return(nil)
+
+	useCase := NewTodoUseCase(mockTxManager, mockPublisher, nil, entities.DefaultTodoRules(), nil)
+
+	require.NoError(t, useCase.UnshareTodo(authContext(), todo.ID, "user-2"))
+}
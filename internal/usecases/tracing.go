@@ -0,0 +1,5 @@
+package usecases
+
+import "go.opentelemetry.io/otel"
+
+var tracer = otel.Tracer("todo-service/internal/usecases")
@@ -0,0 +1,324 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports"
+	"todo-service/internal/logging"
+)
+
+const (
+	defaultDeliveryLogLimit = 50
+	maxDeliveryLogLimit     = 500
+
+	webhookRetryBatch = 100
+)
+
+type WebhookRetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	DisableAfter   int
+}
+
+func (p WebhookRetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if p.MaxBackoff > 0 && delay >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	return delay
+}
+
+type WebhookUseCase struct {
+	repo   ports.WebhookRepository
+	sender ports.WebhookSender
+	policy WebhookRetryPolicy
+}
+
+func NewWebhookUseCase(
+	repo ports.WebhookRepository,
+	sender ports.WebhookSender,
+	policy WebhookRetryPolicy,
+) *WebhookUseCase {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 1
+	}
+
+	return &WebhookUseCase{
+		repo:   repo,
+		sender: sender,
+		policy: policy,
+	}
+}
+
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" binding:"required"`
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"secret,omitempty"`
+}
+
+type CreateWebhookResponse struct {
+	*entities.Webhook
+	Secret string `json:"secret"`
+}
+
+func (uc *WebhookUseCase) CreateWebhook(ctx context.Context, req CreateWebhookRequest) (*CreateWebhookResponse, error) {
+	principal, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook, err := entities.NewWebhook(principal.TenantID, principal.ID, req.URL, req.EventTypes, req.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := webhook.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid webhook: %w", err)
+	}
+	if err := uc.sender.CheckDestination(ctx, webhook.URL); err != nil {
+		return nil, fmt.Errorf("invalid webhook: %w", destinationError(err))
+	}
+
+	if err := uc.repo.Create(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return &CreateWebhookResponse{Webhook: webhook, Secret: webhook.Secret}, nil
+}
+
+func (uc *WebhookUseCase) ListWebhooks(ctx context.Context) ([]*entities.Webhook, error) {
+	principal, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := uc.repo.List(ctx, principal.TenantID, principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+func (uc *WebhookUseCase) GetWebhook(ctx context.Context, id uuid.UUID) (*entities.Webhook, error) {
+	principal, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.repo.GetByID(ctx, principal.TenantID, principal.ID, id)
+}
+
+func (uc *WebhookUseCase) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	principal, err := caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	return uc.repo.Delete(ctx, principal.TenantID, principal.ID, id)
+}
+
+func (uc *WebhookUseCase) EnableWebhook(ctx context.Context, id uuid.UUID) (*entities.Webhook, error) {
+	webhook, err := uc.GetWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.Enable()
+
+	if err := uc.repo.UpdateStatus(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("failed to enable webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func (uc *WebhookUseCase) ListDeliveries(ctx context.Context, id uuid.UUID, limit int) ([]*entities.WebhookDelivery, error) {
+	if _, err := uc.GetWebhook(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultDeliveryLogLimit
+	}
+	if limit > maxDeliveryLogLimit {
+		limit = maxDeliveryLogLimit
+	}
+
+	deliveries, err := uc.repo.ListDeliveries(ctx, id, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// HandleEvent makes the first delivery attempt to every active subscriber.
+// Failed attempts are scheduled for RetryDue rather than retried here, so a
+// slow endpoint never holds up the stream. An error means an attempt could
+// not be recorded; the entry is then left unacknowledged.
+func (uc *WebhookUseCase) HandleEvent(ctx context.Context, event *entities.Event) error {
+	webhooks, err := uc.repo.ListActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load webhooks: %w", err)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(event) {
+			continue
+		}
+
+		wg.Add(1)
+		go func(webhook *entities.Webhook) {
+			defer wg.Done()
+			if err := uc.attempt(ctx, webhook, event, 1); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(webhook)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// RetryDue resends every failed attempt whose retry is due. Each retry is
+// claimed first, so several workers can share the schedule.
+func (uc *WebhookUseCase) RetryDue(ctx context.Context) error {
+	deliveries, err := uc.repo.ListDueDeliveries(ctx, time.Now(), webhookRetryBatch)
+	if err != nil {
+		return fmt.Errorf("failed to load due webhook retries: %w", err)
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	webhooks, err := uc.repo.ListActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load webhooks: %w", err)
+	}
+	active := make(map[uuid.UUID]*entities.Webhook, len(webhooks))
+	for _, webhook := range webhooks {
+		active[webhook.ID] = webhook
+	}
+
+	var errs []error
+	for _, delivery := range deliveries {
+		claimed, err := uc.repo.ClaimDelivery(ctx, delivery.ID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		// Retries of webhooks disabled or deleted since are dropped.
+		webhook, ok := active[delivery.WebhookID]
+		if !claimed || !ok || delivery.Event == nil {
+			continue
+		}
+
+		if err := uc.attempt(ctx, webhook, delivery.Event, delivery.Attempt+1); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// RunRetries calls RetryDue every interval until ctx is done.
+func (uc *WebhookUseCase) RunRetries(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := uc.RetryDue(ctx); err != nil && ctx.Err() == nil {
+				logging.FromContext(ctx).Error("Failed to retry webhook deliveries", zap.Error(err))
+			}
+		}
+	}
+}
+
+// attempt sends event once and records the outcome, scheduling the next
+// attempt when this one failed and the policy allows another.
+func (uc *WebhookUseCase) attempt(ctx context.Context, webhook *entities.Webhook, event *entities.Event, attempt int) error {
+	logger := logging.FromContext(ctx).With(
+		zap.String("webhook_id", webhook.ID.String()),
+		zap.String("event_id", event.ID),
+		zap.String("event_type", event.Type),
+		zap.Int("attempt", attempt))
+
+	delivery := entities.NewWebhookDelivery(webhook.ID, event, attempt)
+
+	start := time.Now()
+	statusCode, err := uc.sender.Send(ctx, webhook, event)
+	delivery.DurationMs = time.Since(start).Milliseconds()
+	delivery.StatusCode = statusCode
+	delivery.Success = err == nil
+	if err != nil {
+		delivery.Error = err.Error()
+		if attempt < uc.policy.MaxAttempts {
+			next := time.Now().Add(uc.policy.backoff(attempt))
+			delivery.NextAttemptAt = &next
+		}
+	}
+
+	if err := uc.repo.CreateDelivery(ctx, delivery); err != nil {
+		return fmt.Errorf("failed to record delivery of event %s to webhook %s: %w", event.ID, webhook.ID, err)
+	}
+
+	switch {
+	case err == nil && webhook.ConsecutiveFailures > 0:
+		webhook.RecordSuccess()
+	case err == nil, delivery.NextAttemptAt != nil:
+		return nil
+	default:
+		webhook.RecordFailure(uc.policy.DisableAfter)
+		if !webhook.Active {
+			logger.Warn("Disabling webhook after repeated delivery failures",
+				zap.Int("consecutive_failures", webhook.ConsecutiveFailures))
+		}
+	}
+
+	if err := uc.repo.UpdateStatus(ctx, webhook); err != nil {
+		return fmt.Errorf("failed to update status of webhook %s: %w", webhook.ID, err)
+	}
+	return nil
+}
+
+func destinationError(err error) error {
+	if errors.Is(err, entities.ErrForbiddenWebhookDestination) {
+		return entities.NewValidationError(entities.FieldViolation{Field: "url", Code: "forbidden_destination", Message: "must not point to a private, loopback or link-local address"})
+	}
+	return entities.NewValidationError(entities.FieldViolation{Field: "url", Code: "unresolvable_host", Message: "host must resolve to an address"})
+}
+
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
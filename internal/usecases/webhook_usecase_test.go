@@ -0,0 +1,274 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/domain/entities"
+	"todo-service/internal/domain/ports/mocks"
+	"todo-service/internal/infrastructure/webhooks"
+)
+
+var testRetryPolicy = WebhookRetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     5 * time.Millisecond,
+	DisableAfter:   2,
+}
+
+func newTestWebhook(t *testing.T, url string, eventTypes ...string) *entities.Webhook {
+	webhook, err := entities.NewWebhook(testTenantID, testOwnerID, url, eventTypes, "")
+	require.NoError(t, err)
+	return webhook
+}
+
+func testEvent() *entities.Event {
+	return &entities.Event{
+		ID:        "1700000000000-0",
+		Type:      entities.EventTypeTodoCreated,
+		TodoID:    "8b7d3c36-6a4f-4b7e-9d55-3a9b0f4a2f10",
+		TenantID:  testTenantID,
+		OwnerID:   testOwnerID,
+		Timestamp: time.Now().Unix(),
+	}
+}
+
+func TestHandleEvent_SchedulesRetriesUntilDelivered(t *testing.T) {
+	var calls int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	webhook := newTestWebhook(t, receiver.URL, "todo.*")
+	webhook.ConsecutiveFailures = 1
+
+	mockRepo := mocks.NewMockWebhookRepository(t)
+	mockRepo.EXPECT().ListActive(mock.Anything).Return([]*entities.Webhook{webhook}, nil)
+
+	var deliveries []*entities.WebhookDelivery
+	mockRepo.EXPECT().CreateDelivery(mock.Anything, mock.AnythingOfType("*entities.WebhookDelivery")).
+		Run(func(ctx context.Context, delivery *entities.WebhookDelivery) {
+			deliveries = append(deliveries, delivery)
+		}).Return(nil).Times(3)
+	mockRepo.EXPECT().ListDueDeliveries(mock.Anything, mock.Anything, webhookRetryBatch).
+		RunAndReturn(func(ctx context.Context, now time.Time, limit int) ([]*entities.WebhookDelivery, error) {
+			last := deliveries[len(deliveries)-1]
+			return []*entities.WebhookDelivery{last}, nil
+		}).Twice()
+	mockRepo.EXPECT().ClaimDelivery(mock.Anything, mock.Anything).Return(true, nil).Twice()
+	mockRepo.EXPECT().UpdateStatus(mock.Anything, webhook).Return(nil).Once()
+
+	useCase := NewWebhookUseCase(mockRepo, webhooks.NewHTTPWebhookSender(time.Second, true), testRetryPolicy)
+
+	require.NoError(t, useCase.HandleEvent(context.Background(), testEvent()))
+	require.Len(t, deliveries, 1)
+	assert.False(t, deliveries[0].Success)
+	assert.Equal(t, http.StatusServiceUnavailable, deliveries[0].StatusCode)
+	require.NotNil(t, deliveries[0].NextAttemptAt)
+	assert.WithinDuration(t, time.Now().Add(testRetryPolicy.InitialBackoff), *deliveries[0].NextAttemptAt, time.Second)
+	assert.Equal(t, testEvent().ID, deliveries[0].Event.ID)
+	assert.Equal(t, 1, webhook.ConsecutiveFailures, "a scheduled retry is not a failure yet")
+
+	require.NoError(t, useCase.RetryDue(context.Background()))
+	require.NoError(t, useCase.RetryDue(context.Background()))
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	require.Len(t, deliveries, 3)
+	assert.Equal(t, 2, deliveries[1].Attempt)
+	assert.NotNil(t, deliveries[1].NextAttemptAt)
+	assert.Equal(t, 3, deliveries[2].Attempt)
+	assert.True(t, deliveries[2].Success)
+	assert.Nil(t, deliveries[2].NextAttemptAt)
+	assert.Zero(t, webhook.ConsecutiveFailures)
+	assert.True(t, webhook.Active)
+}
+
+func TestHandleEvent_DisablesAfterRepeatedFailures(t *testing.T) {
+	var calls int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	webhook := newTestWebhook(t, receiver.URL)
+
+	mockRepo := mocks.NewMockWebhookRepository(t)
+	mockRepo.EXPECT().ListActive(mock.Anything).Return([]*entities.Webhook{webhook}, nil)
+	mockRepo.EXPECT().CreateDelivery(mock.Anything, mock.MatchedBy(func(delivery *entities.WebhookDelivery) bool {
+		return delivery.NextAttemptAt == nil
+	})).Return(nil)
+	mockRepo.EXPECT().UpdateStatus(mock.Anything, webhook).Return(nil).Twice()
+
+	policy := testRetryPolicy
+	policy.MaxAttempts = 1
+	useCase := NewWebhookUseCase(mockRepo, webhooks.NewHTTPWebhookSender(time.Second, true), policy)
+
+	assert.NoError(t, useCase.HandleEvent(context.Background(), testEvent()))
+	assert.True(t, webhook.Active)
+	assert.Equal(t, 1, webhook.ConsecutiveFailures)
+
+	assert.NoError(t, useCase.HandleEvent(context.Background(), testEvent()))
+	assert.False(t, webhook.Active)
+	assert.NotNil(t, webhook.DisabledAt)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestHandleEvent_ReturnsDeliveryLogErrors(t *testing.T) {
+	webhook := newTestWebhook(t, "https://partner.example.com/hooks/todo")
+
+	mockRepo := mocks.NewMockWebhookRepository(t)
+	mockSender := mocks.NewMockWebhookSender(t)
+	mockRepo.EXPECT().ListActive(mock.Anything).Return([]*entities.Webhook{webhook}, nil)
+	mockSender.EXPECT().Send(mock.Anything, webhook, mock.Anything).Return(http.StatusBadGateway, assert.AnError)
+	mockRepo.EXPECT().CreateDelivery(mock.Anything, mock.Anything).Return(errors.New("connection refused"))
+
+	useCase := NewWebhookUseCase(mockRepo, mockSender, testRetryPolicy)
+
+	err := useCase.HandleEvent(context.Background(), testEvent())
+
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestRetryDue_SkipsClaimedAndDisabled(t *testing.T) {
+	webhook := newTestWebhook(t, "https://partner.example.com/hooks/todo")
+	event := testEvent()
+
+	claimedElsewhere := entities.NewWebhookDelivery(webhook.ID, event, 1)
+	disabled := entities.NewWebhookDelivery(uuid.New(), event, 1)
+
+	mockRepo := mocks.NewMockWebhookRepository(t)
+	mockSender := mocks.NewMockWebhookSender(t)
+	mockRepo.EXPECT().ListDueDeliveries(mock.Anything, mock.Anything, webhookRetryBatch).
+		Return([]*entities.WebhookDelivery{claimedElsewhere, disabled}, nil)
+	mockRepo.EXPECT().ListActive(mock.Anything).Return([]*entities.Webhook{webhook}, nil)
+	mockRepo.EXPECT().ClaimDelivery(mock.Anything, claimedElsewhere.ID).Return(false, nil)
+	mockRepo.EXPECT().ClaimDelivery(mock.Anything, disabled.ID).Return(true, nil)
+
+	useCase := NewWebhookUseCase(mockRepo, mockSender, testRetryPolicy)
+
+	assert.NoError(t, useCase.RetryDue(context.Background()))
+}
+
+func TestHandleEvent_SkipsUnsubscribedWebhooks(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected delivery to %s", r.URL)
+	}))
+	defer receiver.Close()
+
+	mockRepo := mocks.NewMockWebhookRepository(t)
+	mockRepo.EXPECT().ListActive(mock.Anything).
+		Return([]*entities.Webhook{newTestWebhook(t, receiver.URL, "file.uploaded")}, nil)
+
+	useCase := NewWebhookUseCase(mockRepo, webhooks.NewHTTPWebhookSender(time.Second, true), testRetryPolicy)
+
+	assert.NoError(t, useCase.HandleEvent(context.Background(), testEvent()))
+}
+
+func TestHandleEvent_SkipsOtherOwnersWebhooks(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected delivery to %s", r.URL)
+	}))
+	defer receiver.Close()
+
+	foreign := newTestWebhook(t, receiver.URL, "*")
+	foreign.OwnerID = "user-2"
+
+	mockRepo := mocks.NewMockWebhookRepository(t)
+	mockRepo.EXPECT().ListActive(mock.Anything).Return([]*entities.Webhook{foreign}, nil)
+
+	useCase := NewWebhookUseCase(mockRepo, webhooks.NewHTTPWebhookSender(time.Second, true), testRetryPolicy)
+
+	assert.NoError(t, useCase.HandleEvent(context.Background(), testEvent()))
+}
+
+func TestHandleEvent_SkipsOtherTenantsWebhooks(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected delivery to %s", r.URL)
+	}))
+	defer receiver.Close()
+
+	foreign := newTestWebhook(t, receiver.URL, "*")
+	foreign.TenantID = "globex"
+
+	mockRepo := mocks.NewMockWebhookRepository(t)
+	mockRepo.EXPECT().ListActive(mock.Anything).Return([]*entities.Webhook{foreign}, nil)
+
+	useCase := NewWebhookUseCase(mockRepo, webhooks.NewHTTPWebhookSender(time.Second, true), testRetryPolicy)
+
+	assert.NoError(t, useCase.HandleEvent(context.Background(), testEvent()))
+}
+
+func TestCreateWebhook_RejectsInvalidURL(t *testing.T) {
+	mockRepo := mocks.NewMockWebhookRepository(t)
+	mockSender := mocks.NewMockWebhookSender(t)
+
+	useCase := NewWebhookUseCase(mockRepo, mockSender, testRetryPolicy)
+
+	_, err := useCase.CreateWebhook(authContext(), CreateWebhookRequest{URL: "ftp://example.com/hook"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid webhook")
+}
+
+func TestCreateWebhook_GeneratesSecret(t *testing.T) {
+	mockRepo := mocks.NewMockWebhookRepository(t)
+	mockSender := mocks.NewMockWebhookSender(t)
+
+	mockSender.EXPECT().CheckDestination(mock.Anything, "https://partner.example.com/hooks/todo").Return(nil)
+	mockRepo.EXPECT().Create(mock.Anything, mock.AnythingOfType("*entities.Webhook")).Return(nil)
+
+	useCase := NewWebhookUseCase(mockRepo, mockSender, testRetryPolicy)
+
+	resp, err := useCase.CreateWebhook(authContext(), CreateWebhookRequest{URL: "https://partner.example.com/hooks/todo"})
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.Secret)
+	assert.Equal(t, []string{"*"}, resp.EventTypes)
+	assert.True(t, resp.Active)
+}
+
+func TestCreateWebhook_RejectsPrivateDestinations(t *testing.T) {
+	tests := []struct {
+		name     string
+		checkErr error
+		wantCode string
+	}{
+		{"private address", fmt.Errorf("%w: metadata resolves to 169.254.169.254", entities.ErrForbiddenWebhookDestination), "forbidden_destination"},
+		{"unresolvable host", errors.New("no such host"), "unresolvable_host"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockWebhookRepository(t)
+			mockSender := mocks.NewMockWebhookSender(t)
+			mockSender.EXPECT().CheckDestination(mock.Anything, "http://metadata.internal/latest").Return(tt.checkErr)
+
+			useCase := NewWebhookUseCase(mockRepo, mockSender, testRetryPolicy)
+
+			_, err := useCase.CreateWebhook(authContext(), CreateWebhookRequest{URL: "http://metadata.internal/latest"})
+
+			var validationErr *entities.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			require.Len(t, validationErr.Violations, 1)
+			assert.Equal(t, "url", validationErr.Violations[0].Field)
+			assert.Equal(t, tt.wantCode, validationErr.Violations[0].Code)
+		})
+	}
+}
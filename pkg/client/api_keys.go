@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type APIKey struct {
+	ID         string     `json:"id"`
+	TenantID   string     `json:"tenant_id"`
+	OwnerID    string     `json:"owner_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// CreatedAPIKey carries the plaintext key, which is only returned once.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// CreateAPIKey issues a key acting as the caller. API keys can only be
+// managed by clients authenticated WithToken.
+func (c *Client) CreateAPIKey(ctx context.Context, req CreateAPIKeyRequest) (*CreatedAPIKey, error) {
+	var key CreatedAPIKey
+	err := c.do(ctx, request{method: http.MethodPost, path: "/api-keys", body: req, out: &key})
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (c *Client) ListAPIKeys(ctx context.Context) ([]*APIKey, error) {
+	var keys []*APIKey
+	err := c.do(ctx, request{method: http.MethodGet, path: "/api-keys", out: &keys})
+	return keys, err
+}
+
+func (c *Client) RevokeAPIKey(ctx context.Context, id string) error {
+	err := c.do(ctx, request{method: http.MethodDelete, path: "/api-keys" + pathID(id)})
+	return err
+}
@@ -0,0 +1,256 @@
+// Package client is a typed Go client for the todo service REST API.
+//
+//	c, err := client.New("https://todo.example.com", client.WithToken(jwt))
+//	todo, err := c.CreateTodo(ctx, client.CreateTodoRequest{Description: "Ship it", DueDate: due})
+//
+// Every method returns an *Error for responses the server rejected, so
+// callers can branch on the status code with IsNotFound and friends.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	apiPrefix        = "/api/v1"
+	defaultUserAgent = "todo-service-go-client"
+)
+
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	authHeader string
+	userAgent  string
+}
+
+type Option func(*Client)
+
+// WithToken authenticates requests with a JWT bearer token.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.authHeader = "Bearer " + token
+	}
+}
+
+// WithAPIKey authenticates requests with an API key issued by the service.
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.authHeader = "ApiKey " + key
+	}
+}
+
+// WithHTTPClient replaces the default client, which has a 30 second timeout.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// New returns a client for the service at baseURL, e.g.
+// "http://localhost:8083".
+func New(baseURL string, options ...Option) (*Client, error) {
+	parsed, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base URL %q: scheme must be http or https", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    parsed,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		userAgent:  defaultUserAgent,
+	}
+	for _, option := range options {
+		option(c)
+	}
+
+	return c, nil
+}
+
+// Error is a response the server answered with a non-2xx status, decoded
+// from its RFC 7807 problem details.
+type Error struct {
+	StatusCode int
+	// Code is the server's stable error code, e.g. "todo_not_found".
+	Code string
+	// Message is the problem title and Details what went wrong.
+	Message    string
+	Details    string
+	Violations []Violation
+	RequestID  string
+	// RetryAfter is set when the server asked the client to back off.
+	RetryAfter time.Duration
+}
+
+// Violation is one problem the server found with a request.
+type Violation struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	message := e.Message
+	if message == "" {
+		message = http.StatusText(e.StatusCode)
+	}
+	if e.Details != "" {
+		message += ": " + e.Details
+	}
+	return fmt.Sprintf("%d %s", e.StatusCode, message)
+}
+
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsVersionMismatch reports whether a conditional write failed because the
+// todo changed since its version was read.
+func IsVersionMismatch(err error) bool {
+	return hasStatus(err, http.StatusPreconditionFailed)
+}
+
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+func hasStatus(err error, status int) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+// request describes one API call; out receives the "data" member of the
+// response envelope.
+type request struct {
+	method  string
+	path    string
+	query   url.Values
+	body    interface{}
+	header  http.Header
+	out     interface{}
+	rawBody io.Reader
+}
+
+func (c *Client) do(ctx context.Context, r request) error {
+	resp, err := c.send(ctx, r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if r.out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	envelope := struct {
+		Data interface{} `json:"data"`
+	}{Data: r.out}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("failed to decode %s %s response: %w", r.method, r.path, err)
+	}
+
+	return nil
+}
+
+// send performs the request and returns the response with its body open.
+// Non-2xx responses are turned into *Error.
+func (c *Client) send(ctx context.Context, r request) (*http.Response, error) {
+	endpoint := *c.baseURL
+	endpoint.Path += apiPrefix + r.path
+	endpoint.RawQuery = r.query.Encode()
+
+	body := r.rawBody
+	if r.body != nil {
+		encoded, err := json.Marshal(r.body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.method, endpoint.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range r.header {
+		req.Header[name] = values
+	}
+	if r.body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if c.authHeader != "" {
+		req.Header.Set("Authorization", c.authHeader)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+
+	defer resp.Body.Close()
+	return nil, decodeError(resp)
+}
+
+func decodeError(resp *http.Response) error {
+	apiErr := &Error{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-ID"),
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+
+	var body struct {
+		Title     string      `json:"title"`
+		Detail    string      `json:"detail"`
+		Code      string      `json:"code"`
+		RequestID string      `json:"request_id"`
+		Errors    []Violation `json:"errors"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&body); err == nil {
+		apiErr.Code = body.Code
+		apiErr.Message = body.Title
+		apiErr.Details = body.Detail
+		apiErr.Violations = body.Errors
+		if apiErr.RequestID == "" {
+			apiErr.RequestID = body.RequestID
+		}
+	}
+
+	return apiErr
+}
+
+func ifMatch(version *int) http.Header {
+	if version == nil {
+		return nil
+	}
+	return http.Header{"If-Match": {`"` + strconv.Itoa(*version) + `"`}}
+}
+
+func pathID(id string) string {
+	return "/" + url.PathEscape(id)
+}
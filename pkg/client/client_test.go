@@ -0,0 +1,168 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc, options ...Option) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := New(server.URL, options...)
+	require.NoError(t, err)
+	return c
+}
+
+func writeData(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+}
+
+func TestNew_RejectsInvalidBaseURL(t *testing.T) {
+	_, err := New("localhost:8083")
+	assert.ErrorContains(t, err, "scheme must be http or https")
+}
+
+func TestCreateTodo(t *testing.T) {
+	due := time.Date(2030, 1, 2, 15, 0, 0, 0, time.UTC)
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/todo", r.URL.Path)
+		assert.Equal(t, "Bearer secret-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "retry-1", r.Header.Get("Idempotency-Key"))
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{"description": "Ship it", "due_date": "2030-01-02T15:00:00Z"}, body)
+
+		writeData(w, http.StatusCreated, map[string]interface{}{
+			"id": "8a2f", "description": "Ship it", "due_date": due, "completed_at": nil, "version": 1,
+		})
+	}, WithToken("secret-token"))
+
+	todo, err := c.CreateTodo(context.Background(), CreateTodoRequest{Description: "Ship it", DueDate: due, IdempotencyKey: "retry-1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "8a2f", todo.ID)
+	assert.Equal(t, due, todo.DueDate)
+	assert.Equal(t, 1, todo.Version)
+	assert.False(t, todo.Completed())
+}
+
+func TestUpdateTodo_VersionMismatch(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, `"3"`, r.Header.Get("If-Match"))
+		assert.Equal(t, "ApiKey tdk_123", r.Header.Get("Authorization"))
+
+		w.Header().Set("X-Request-ID", "req-42")
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusPreconditionFailed)
+		w.Write([]byte(`{"type":"urn:todo-service:problem:version_mismatch","title":"Precondition Failed","status":412,` +
+			`"detail":"todo version does not match","code":"version_mismatch","request_id":"req-42"}`))
+	}, WithAPIKey("tdk_123"))
+
+	version := 3
+	_, err := c.UpdateTodo(context.Background(), "8a2f", UpdateTodoRequest{Description: "Edited", IfVersion: &version})
+
+	require.Error(t, err)
+	assert.True(t, IsVersionMismatch(err))
+	assert.False(t, IsNotFound(err))
+
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "req-42", apiErr.RequestID)
+	assert.Equal(t, "version_mismatch", apiErr.Code)
+	assert.Equal(t, "412 Precondition Failed: todo version does not match", err.Error())
+}
+
+func TestCreateTodo_ValidationProblem(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"type":"urn:todo-service:problem:validation_failed","title":"Bad Request","status":400,` +
+			`"detail":"validation failed: description: is required","code":"validation_failed",` +
+			`"errors":[{"field":"description","code":"required","message":"is required"}]}`))
+	})
+
+	_, err := c.CreateTodo(context.Background(), CreateTodoRequest{DueDate: time.Now()})
+
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "validation_failed", apiErr.Code)
+	assert.Equal(t, []Violation{{Field: "description", Code: "required", Message: "is required"}}, apiErr.Violations)
+}
+
+func TestListTodos_EncodesPaging(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "limit=10&offset=20", r.URL.RawQuery)
+		writeData(w, http.StatusOK, []map[string]interface{}{{"id": "a"}, {"id": "b"}})
+	})
+
+	todos, err := c.ListTodos(context.Background(), ListTodosOptions{Limit: 10, Offset: 20})
+
+	require.NoError(t, err)
+	require.Len(t, todos, 2)
+	assert.Equal(t, "b", todos[1].ID)
+}
+
+func TestRateLimitedError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	err := c.DeleteTodo(context.Background(), "8a2f", nil)
+
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.True(t, IsRateLimited(err))
+	assert.Equal(t, 7*time.Second, apiErr.RetryAfter)
+	assert.Equal(t, "429 Too Many Requests", err.Error())
+}
+
+func TestUploadAndDownloadFile(t *testing.T) {
+	stored := map[string][]byte{}
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			file, header, err := r.FormFile("file")
+			require.NoError(t, err)
+			assert.Equal(t, "notes.txt", header.Filename)
+			assert.Equal(t, "text/plain; charset=utf-8", header.Header.Get("Content-Type"))
+
+			data, _ := io.ReadAll(file)
+			stored["f-1"] = data
+			writeData(w, http.StatusCreated, map[string]string{"file_id": "f-1"})
+
+		case http.MethodGet:
+			assert.Equal(t, "/api/v1/files/f-1", r.URL.Path)
+			w.Header().Set("Content-Type", "text/plain")
+			w.Header().Set("Content-Disposition", `attachment; filename="notes.txt"`)
+			w.Write(stored["f-1"])
+		}
+	})
+
+	uploaded, err := c.UploadFile(context.Background(), UploadFileRequest{FileName: "notes.txt", Data: strings.NewReader("hello world")})
+	require.NoError(t, err)
+	assert.Equal(t, "f-1", uploaded.FileID)
+
+	var out bytes.Buffer
+	info, err := c.DownloadFile(context.Background(), uploaded.FileID, &out)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", out.String())
+	assert.Equal(t, &FileInfo{FileName: "notes.txt", ContentType: "text/plain", Size: 11}, info)
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todo-service/internal/interfaces/http/openapi"
+)
+
+// jsonFields maps the JSON names of t's fields, including embedded ones, to
+// their types.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous {
+			for name, typ := range jsonFields(field.Type) {
+				fields[name] = typ
+			}
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// jsonType is the JSON Schema type a value of t encodes to.
+func jsonType(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return "string"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// TestTypesMatchSpec keeps the client's wire types in step with the
+// schemas the server documents: the same properties, of the same types.
+func TestTypesMatchSpec(t *testing.T) {
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+
+	tests := []struct {
+		schema string
+		value  interface{}
+	}{
+		{"Todo", Todo{}},
+		{"CreateTodoRequest", CreateTodoRequest{}},
+		{"UpdateTodoRequest", UpdateTodoRequest{}},
+		{"TodoGrant", Share{}},
+		{"UploadFileResponse", UploadedFile{}},
+		{"StorageUsage", StorageUsage{}},
+		{"SyncChange", SyncChange{}},
+		{"SyncPullResponse", SyncPullResponse{}},
+		{"SyncPushItem", SyncPushItem{}},
+		{"SyncPushResult", SyncPushResult{}},
+		{"Webhook", Webhook{}},
+		{"CreateWebhookRequest", CreateWebhookRequest{}},
+		{"CreateWebhookResponse", CreatedWebhook{}},
+		{"WebhookDelivery", WebhookDelivery{}},
+		{"APIKey", APIKey{}},
+		{"CreateAPIKeyRequest", CreateAPIKeyRequest{}},
+		{"CreateAPIKeyResponse", CreatedAPIKey{}},
+		{"FieldViolation", Violation{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.schema, func(t *testing.T) {
+			properties := spec.Properties(tt.schema)
+			require.NotNil(t, properties, "schema %s does not exist", tt.schema)
+			fields := jsonFields(reflect.TypeOf(tt.value))
+
+			assert.Equal(t, sortedKeys(properties), sortedKeys(fields))
+			for name, field := range fields {
+				property, ok := properties[name]
+				if !ok || len(property.Type) == 0 {
+					continue
+				}
+				assert.Contains(t, property.Type, jsonType(field), "%s.%s", tt.schema, name)
+			}
+		})
+	}
+}
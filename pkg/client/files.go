@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"path/filepath"
+)
+
+type UploadFileRequest struct {
+	FileName string
+	// ContentType defaults to the type registered for the file extension.
+	ContentType    string
+	Data           io.Reader
+	IdempotencyKey string
+}
+
+type UploadedFile struct {
+	FileID string `json:"file_id"`
+}
+
+// FileInfo describes a downloaded file, taken from the response headers.
+type FileInfo struct {
+	FileName    string
+	ContentType string
+	Size        int64
+}
+
+type StorageUsage struct {
+	Bytes          int64  `json:"bytes"`
+	Files          int64  `json:"files"`
+	MaxBytes       *int64 `json:"max_bytes"`
+	MaxFiles       *int64 `json:"max_files"`
+	RemainingBytes *int64 `json:"remaining_bytes"`
+	RemainingFiles *int64 `json:"remaining_files"`
+}
+
+// UploadFile streams req.Data to the server as a multipart form without
+// buffering it in memory. Attach the returned ID to a todo via FileID.
+func (c *Client) UploadFile(ctx context.Context, req UploadFileRequest) (*UploadedFile, error) {
+	contentType := req.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(req.FileName))
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	body, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+	go func() {
+		part, err := form.CreatePart(textproto.MIMEHeader{
+			"Content-Disposition": {mime.FormatMediaType("form-data", map[string]string{"name": "file", "filename": req.FileName})},
+			"Content-Type":        {contentType},
+		})
+		if err == nil {
+			_, err = io.Copy(part, req.Data)
+		}
+		if err == nil {
+			err = form.Close()
+		}
+		writer.CloseWithError(err)
+	}()
+
+	header := http.Header{"Content-Type": {form.FormDataContentType()}}
+	if req.IdempotencyKey != "" {
+		header.Set("Idempotency-Key", req.IdempotencyKey)
+	}
+
+	var uploaded UploadedFile
+	err := c.do(ctx, request{method: http.MethodPost, path: "/upload", rawBody: body, header: header, out: &uploaded})
+	body.Close()
+	if err != nil {
+		return nil, err
+	}
+	return &uploaded, nil
+}
+
+// DownloadFile copies the contents of one of the caller's files to w.
+func (c *Client) DownloadFile(ctx context.Context, id string, w io.Writer) (*FileInfo, error) {
+	resp, err := c.send(ctx, request{method: http.MethodGet, path: "/files" + pathID(id)})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	info := &FileInfo{ContentType: resp.Header.Get("Content-Type"), Size: resp.ContentLength}
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		info.FileName = params["filename"]
+	}
+
+	written, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+	if info.Size >= 0 && written != info.Size {
+		return nil, fmt.Errorf("failed to download file: got %d of %d bytes", written, info.Size)
+	}
+	info.Size = written
+
+	return info, nil
+}
+
+func (c *Client) DeleteFile(ctx context.Context, id string) error {
+	err := c.do(ctx, request{method: http.MethodDelete, path: "/files" + pathID(id)})
+	return err
+}
+
+// GetUsage reports the caller's storage use against their quota; limits
+// are nil when unlimited.
+func (c *Client) GetUsage(ctx context.Context) (*StorageUsage, error) {
+	var usage StorageUsage
+	err := c.do(ctx, request{method: http.MethodGet, path: "/me/usage", out: &usage})
+	if err != nil {
+		return nil, err
+	}
+	return &usage, nil
+}
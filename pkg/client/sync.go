@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type SyncChange struct {
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+	Todo    *Todo  `json:"todo,omitempty"`
+}
+
+type SyncPullResponse struct {
+	Changes   []SyncChange `json:"changes"`
+	NextToken string       `json:"next_token"`
+	HasMore   bool         `json:"has_more"`
+}
+
+const (
+	SyncOpCreate = "create"
+	SyncOpUpdate = "update"
+	SyncOpDelete = "delete"
+)
+
+type SyncPushItem struct {
+	Op            string     `json:"op"`
+	ID            string     `json:"id"`
+	Description   string     `json:"description,omitempty"`
+	DueDate       time.Time  `json:"due_date,omitempty"`
+	FileID        *string    `json:"file_id,omitempty"`
+	BaseUpdatedAt *time.Time `json:"base_updated_at,omitempty"`
+	BaseVersion   *int       `json:"base_version,omitempty"`
+}
+
+type SyncPushResult struct {
+	ID     string `json:"id"`
+	Op     string `json:"op"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	// Errors lists every violation when Status is "invalid".
+	Errors []Violation `json:"errors,omitempty"`
+	Todo   *Todo       `json:"todo,omitempty"`
+}
+
+// SyncPull returns the changes after since, an empty string meaning from
+// the beginning. Keep calling with NextToken while HasMore is set.
+func (c *Client) SyncPull(ctx context.Context, since string, limit int) (*SyncPullResponse, error) {
+	query := url.Values{}
+	if since != "" {
+		query.Set("since", since)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var response SyncPullResponse
+	err := c.do(ctx, request{method: http.MethodGet, path: "/sync", query: query, out: &response})
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// SyncPush applies a batch of offline changes; each item gets its own
+// result, so one conflict does not fail the batch.
+func (c *Client) SyncPush(ctx context.Context, changes []SyncPushItem) ([]SyncPushResult, error) {
+	body := map[string][]SyncPushItem{"changes": changes}
+
+	var response struct {
+		Results []SyncPushResult `json:"results"`
+	}
+	err := c.do(ctx, request{method: http.MethodPost, path: "/sync", body: body, out: &response})
+	if err != nil {
+		return nil, err
+	}
+	return response.Results, nil
+}
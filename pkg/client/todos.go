@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type Todo struct {
+	ID          string     `json:"id"`
+	TenantID    string     `json:"tenant_id"`
+	OwnerID     string     `json:"owner_id"`
+	Description string     `json:"description"`
+	DueDate     time.Time  `json:"due_date"`
+	FileID      *string    `json:"file_id,omitempty"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	// Version is the todo's ETag; pass it as IfVersion to make a write fail
+	// when someone else changed the todo in the meantime.
+	Version int `json:"version"`
+}
+
+func (t *Todo) Completed() bool {
+	return t.CompletedAt != nil
+}
+
+type CreateTodoRequest struct {
+	Description string    `json:"description"`
+	DueDate     time.Time `json:"due_date"`
+	FileID      *string   `json:"file_id,omitempty"`
+	// IdempotencyKey makes retries of the same create return the first
+	// result instead of creating a duplicate.
+	IdempotencyKey string `json:"-"`
+}
+
+// UpdateTodoRequest replaces the todo's editable fields.
+type UpdateTodoRequest struct {
+	Description string    `json:"description"`
+	DueDate     time.Time `json:"due_date"`
+	FileID      *string   `json:"file_id,omitempty"`
+	IfVersion   *int      `json:"-"`
+}
+
+type ListTodosOptions struct {
+	// Limit defaults to 50 on the server and is capped at 200.
+	Limit  int
+	Offset int
+}
+
+// Share is a grant of access to a todo for another user of the tenant.
+type Share struct {
+	TodoID    string    `json:"todo_id"`
+	TenantID  string    `json:"tenant_id"`
+	UserID    string    `json:"user_id"`
+	Role      string    `json:"role"`
+	GrantedBy string    `json:"granted_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+const (
+	RoleViewer = "viewer"
+	RoleEditor = "editor"
+)
+
+func (c *Client) CreateTodo(ctx context.Context, req CreateTodoRequest) (*Todo, error) {
+	var header http.Header
+	if req.IdempotencyKey != "" {
+		header = http.Header{"Idempotency-Key": {req.IdempotencyKey}}
+	}
+
+	var todo Todo
+	err := c.do(ctx, request{method: http.MethodPost, path: "/todo", body: req, header: header, out: &todo})
+	if err != nil {
+		return nil, err
+	}
+	return &todo, nil
+}
+
+// ListTodos returns one page of the todos the caller owns or has been
+// shared, newest first.
+func (c *Client) ListTodos(ctx context.Context, options ListTodosOptions) ([]*Todo, error) {
+	query := url.Values{}
+	if options.Limit > 0 {
+		query.Set("limit", strconv.Itoa(options.Limit))
+	}
+	if options.Offset > 0 {
+		query.Set("offset", strconv.Itoa(options.Offset))
+	}
+
+	var todos []*Todo
+	err := c.do(ctx, request{method: http.MethodGet, path: "/todo", query: query, out: &todos})
+	return todos, err
+}
+
+func (c *Client) GetTodo(ctx context.Context, id string) (*Todo, error) {
+	var todo Todo
+	err := c.do(ctx, request{method: http.MethodGet, path: "/todo" + pathID(id), out: &todo})
+	if err != nil {
+		return nil, err
+	}
+	return &todo, nil
+}
+
+func (c *Client) UpdateTodo(ctx context.Context, id string, req UpdateTodoRequest) (*Todo, error) {
+	var todo Todo
+	err := c.do(ctx, request{method: http.MethodPut, path: "/todo" + pathID(id), body: req, header: ifMatch(req.IfVersion), out: &todo})
+	if err != nil {
+		return nil, err
+	}
+	return &todo, nil
+}
+
+// CompleteTodo marks a todo completed; completing it again is a no-op.
+func (c *Client) CompleteTodo(ctx context.Context, id string, ifVersion *int) (*Todo, error) {
+	return c.setCompleted(ctx, id, "/complete", ifVersion)
+}
+
+func (c *Client) ReopenTodo(ctx context.Context, id string, ifVersion *int) (*Todo, error) {
+	return c.setCompleted(ctx, id, "/reopen", ifVersion)
+}
+
+func (c *Client) setCompleted(ctx context.Context, id, action string, ifVersion *int) (*Todo, error) {
+	var todo Todo
+	err := c.do(ctx, request{method: http.MethodPost, path: "/todo" + pathID(id) + action, header: ifMatch(ifVersion), out: &todo})
+	if err != nil {
+		return nil, err
+	}
+	return &todo, nil
+}
+
+func (c *Client) DeleteTodo(ctx context.Context, id string, ifVersion *int) error {
+	err := c.do(ctx, request{method: http.MethodDelete, path: "/todo" + pathID(id), header: ifMatch(ifVersion)})
+	return err
+}
+
+func (c *Client) ListShares(ctx context.Context, todoID string) ([]*Share, error) {
+	var shares []*Share
+	err := c.do(ctx, request{method: http.MethodGet, path: "/todo" + pathID(todoID) + "/shares", out: &shares})
+	return shares, err
+}
+
+// ShareTodo gives userID the role (RoleViewer or RoleEditor) on a todo, or
+// changes the role they already have.
+func (c *Client) ShareTodo(ctx context.Context, todoID, userID, role string) (*Share, error) {
+	body := map[string]string{"user_id": userID, "role": role}
+
+	var share Share
+	err := c.do(ctx, request{method: http.MethodPost, path: "/todo" + pathID(todoID) + "/shares", body: body, out: &share})
+	if err != nil {
+		return nil, err
+	}
+	return &share, nil
+}
+
+func (c *Client) UnshareTodo(ctx context.Context, todoID, userID string) error {
+	err := c.do(ctx, request{method: http.MethodDelete, path: "/todo" + pathID(todoID) + "/shares" + pathID(userID)})
+	return err
+}
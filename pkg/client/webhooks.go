@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type Webhook struct {
+	ID                  string     `json:"id"`
+	TenantID            string     `json:"tenant_id"`
+	OwnerID             string     `json:"owner_id"`
+	URL                 string     `json:"url"`
+	EventTypes          []string   `json:"event_types"`
+	Active              bool       `json:"active"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+type CreateWebhookRequest struct {
+	URL string `json:"url"`
+	// EventTypes defaults to every event type.
+	EventTypes []string `json:"event_types,omitempty"`
+	// Secret signs deliveries; the server generates one when empty.
+	Secret string `json:"secret,omitempty"`
+}
+
+// CreatedWebhook carries the signing secret, which is only returned once.
+type CreatedWebhook struct {
+	Webhook
+	Secret string `json:"secret"`
+}
+
+type WebhookDelivery struct {
+	ID         string    `json:"id"`
+	WebhookID  string    `json:"webhook_id"`
+	EventID    string    `json:"event_id"`
+	EventType  string    `json:"event_type"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Success    bool      `json:"success"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+	// NextAttemptAt is when a failed attempt will be retried.
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+}
+
+func (c *Client) CreateWebhook(ctx context.Context, req CreateWebhookRequest) (*CreatedWebhook, error) {
+	var webhook CreatedWebhook
+	err := c.do(ctx, request{method: http.MethodPost, path: "/webhooks", body: req, out: &webhook})
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (c *Client) ListWebhooks(ctx context.Context) ([]*Webhook, error) {
+	var webhooks []*Webhook
+	err := c.do(ctx, request{method: http.MethodGet, path: "/webhooks", out: &webhooks})
+	return webhooks, err
+}
+
+func (c *Client) GetWebhook(ctx context.Context, id string) (*Webhook, error) {
+	var webhook Webhook
+	err := c.do(ctx, request{method: http.MethodGet, path: "/webhooks" + pathID(id), out: &webhook})
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, id string) error {
+	err := c.do(ctx, request{method: http.MethodDelete, path: "/webhooks" + pathID(id)})
+	return err
+}
+
+// EnableWebhook re-enables a webhook disabled after repeated failures.
+func (c *Client) EnableWebhook(ctx context.Context, id string) (*Webhook, error) {
+	var webhook Webhook
+	err := c.do(ctx, request{method: http.MethodPost, path: "/webhooks" + pathID(id) + "/enable", out: &webhook})
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (c *Client) ListWebhookDeliveries(ctx context.Context, id string, limit int) ([]*WebhookDelivery, error) {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var deliveries []*WebhookDelivery
+	err := c.do(ctx, request{method: http.MethodGet, path: "/webhooks" + pathID(id) + "/deliveries", query: query, out: &deliveries})
+	return deliveries, err
+}